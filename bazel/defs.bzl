@@ -0,0 +1,167 @@
+"""Bazel rules generating HTTP server interface code for proto_library targets.
+
+go_http_server_interface_library generates the code of a proto_library and
+compiles it into one Go package with the go_proto_library of the same protos:
+
+    load("@protoc-gen-go-http-server-interface//bazel:defs.bzl", "go_http_server_interface_library")
+
+    go_http_server_interface_library(
+        name = "tasks",
+        proto = ":tasks_proto",
+        go_proto = ":tasks_go_proto",
+        importpath = "example.com/tasks/v1",
+        options = ["binding=true"],
+    )
+
+go_http_server_interface_aspect generates the code of the proto_library
+targets it is applied to from the command line, with the options of the
+//bazel:opt flag:
+
+    bazel build //proto/... \\
+        --aspects=@protoc-gen-go-http-server-interface//bazel:defs.bzl%go_http_server_interface_aspect \\
+        --output_groups=go_http_server_interface \\
+        --@protoc-gen-go-http-server-interface//bazel:opt=binding=true
+
+Both run genhttp on the descriptor sets of the proto_library, never on the
+.proto files, so the output depends only on those sets and the options.
+"""
+
+load("@protobuf//bazel/common:proto_info.bzl", "ProtoInfo")
+load("@rules_go//go:def.bzl", "go_library")
+
+GoHttpServerInterfaceInfo = provider(
+    doc = "The HTTP server interface code generated for a proto_library.",
+    fields = {"srcs": "list of the generated _http.pb.go files, one per .proto source"},
+)
+
+OptInfo = provider(
+    doc = "Plugin options set with an opt_flag.",
+    fields = {"opts": "list of plugin options, such as binding=true"},
+)
+
+# The generated code imports these packages depending on the options.
+_RUNTIME_DEPS = [
+    Label("@org_golang_google_protobuf//encoding/protojson"),
+    Label("@org_golang_google_protobuf//proto"),
+    Label("@org_golang_google_protobuf//reflect/protoreflect"),
+]
+
+def _import_name(src, proto_info):
+    """Returns the name protoc gives src: its path below the proto source root."""
+    root = proto_info.proto_source_root
+    if root == ".":
+        root = src.root.path
+    elif src.root.path and not root.startswith(src.root.path):
+        root = src.root.path + "/" + root
+    prefix = root + "/" if root else ""
+    if src.path.startswith(prefix):
+        return src.path[len(prefix):]
+    return src.path
+
+def _generate(actions, name, proto_info, opts, genhttp):
+    """Declares the action generating the code of proto_info's sources.
+
+    Every source gets a file, empty but for the package clause when it
+    declares no HTTP routes, so the outputs are known before the action runs.
+    """
+    names = []
+    srcs = []
+    for src in proto_info.direct_sources:
+        import_name = _import_name(src, proto_info)
+        names.append(import_name)
+        srcs.append(actions.declare_file("{}_http/{}_http.pb.go".format(name, import_name.removesuffix(".proto"))))
+    if not srcs:
+        return []
+
+    # The files land at their import names below the output directory
+    out = srcs[0].path.removesuffix(names[0].removesuffix(".proto") + "_http.pb.go")
+
+    args = actions.args()
+    args.add_all(proto_info.transitive_descriptor_sets, before_each = "-descriptor_set_in")
+    args.add("-opt", ",".join(["paths=source_relative", "empty_files=true"] + opts))
+    args.add("-out", out)
+    args.add_all(names)
+    actions.run(
+        executable = genhttp,
+        arguments = [args],
+        inputs = proto_info.transitive_descriptor_sets,
+        outputs = srcs,
+        mnemonic = "GoHttpServerInterface",
+        progress_message = "Generating HTTP server interface code for %{label}",
+    )
+    return srcs
+
+def _opt_flag_impl(ctx):
+    return [OptInfo(opts = ctx.build_setting_value)]
+
+opt_flag = rule(
+    implementation = _opt_flag_impl,
+    build_setting = config.string_list(flag = True),
+    doc = "A command line flag holding plugin options, split at commas.",
+)
+
+def _aspect_impl(target, ctx):
+    srcs = _generate(ctx.actions, target.label.name, target[ProtoInfo], ctx.attr._opt[OptInfo].opts, ctx.executable._genhttp)
+    return [
+        GoHttpServerInterfaceInfo(srcs = srcs),
+        OutputGroupInfo(go_http_server_interface = depset(srcs)),
+    ]
+
+go_http_server_interface_aspect = aspect(
+    implementation = _aspect_impl,
+    required_providers = [ProtoInfo],
+    provides = [GoHttpServerInterfaceInfo],
+    attrs = {
+        "_genhttp": attr.label(default = Label("//cmd/genhttp"), executable = True, cfg = "exec"),
+        "_opt": attr.label(default = Label("//bazel:opt"), providers = [OptInfo]),
+    },
+    doc = "Generates the HTTP server interface code of proto_library targets.",
+)
+
+def _go_http_server_interface_srcs_impl(ctx):
+    srcs = _generate(ctx.actions, ctx.label.name, ctx.attr.proto[ProtoInfo], ctx.attr.options, ctx.executable._genhttp)
+    return [
+        DefaultInfo(files = depset(srcs)),
+        GoHttpServerInterfaceInfo(srcs = srcs),
+    ]
+
+go_http_server_interface_srcs = rule(
+    implementation = _go_http_server_interface_srcs_impl,
+    attrs = {
+        "proto": attr.label(mandatory = True, providers = [ProtoInfo], doc = "The proto_library to generate code for."),
+        "options": attr.string_list(doc = "Plugin options, such as binding=true."),
+        "_genhttp": attr.label(default = Label("//cmd/genhttp"), executable = True, cfg = "exec"),
+    },
+    doc = "Generates the HTTP server interface code of a proto_library.",
+)
+
+def go_http_server_interface_library(name, proto, go_proto, importpath, options = [], deps = [], **kwargs):
+    """Compiles the HTTP server interface code of proto with its messages.
+
+    Args:
+      name: the name of the go_library.
+      proto: the proto_library to generate code for.
+      go_proto: the go_proto_library of proto, embedded in the package.
+      importpath: the import path of the package, that of go_proto.
+      options: plugin options, such as binding=true; paths and empty_files
+        are set by the rule.
+      deps: further dependencies of the generated code, such as the router
+        of the adapters option.
+      **kwargs: passed to the go_library.
+    """
+    go_http_server_interface_srcs(
+        name = name + "_srcs",
+        proto = proto,
+        options = options,
+        tags = kwargs.get("tags"),
+        visibility = ["//visibility:private"],
+    )
+    listed = [str(native.package_relative_label(dep)) for dep in deps]
+    go_library(
+        name = name,
+        srcs = [":" + name + "_srcs"],
+        embed = [go_proto],
+        importpath = importpath,
+        deps = deps + [dep for dep in _RUNTIME_DEPS if str(dep) not in listed],
+        **kwargs
+    )
@@ -0,0 +1,222 @@
+// Command genhttp generates the HTTP server interface code of .proto files
+// without a protoc plugin setup, so small projects can regenerate it with
+// go generate:
+//
+//	//go:generate go run github.com/farhaan/protoc-gen-go-http-server-interface/cmd/genhttp -I proto -proto proto/tasks/v1/tasks.proto -out pb
+//
+// It runs protoc only to parse the files into a descriptor set, or reads the
+// sets given with -descriptor_set_in, such as the output of buf build -o or
+// the descriptor sets of Bazel proto_library targets, and generates the code
+// in-process, so protoc-gen-go-http-server-interface need
+// not be installed. The message types still come from protoc-gen-go.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpinterface"
+)
+
+// stringList collects repeated flags.
+type stringList []string
+
+func (l *stringList) String() string     { return strings.Join(*l, ",") }
+func (l *stringList) Set(v string) error { *l = append(*l, v); return nil }
+
+func main() {
+	if err := run(os.Args[1:], os.Stderr); err != nil {
+		fmt.Fprintf(os.Stderr, "genhttp: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run generates the code for the command line args, writing protoc's
+// diagnostics and the generator's warnings to stderr.
+func run(args []string, stderr io.Writer) error {
+	flags := flag.NewFlagSet("genhttp", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var includes, protos stringList
+	flags.Var(&includes, "I", "proto import path passed to protoc (repeatable, default .)")
+	flags.Var(&protos, "proto", ".proto file to generate code for (repeatable); further files may follow the flags")
+	var descriptorSets stringList
+	flags.Var(&descriptorSets, "descriptor_set_in", "read the files from these FileDescriptorSets instead of running protoc (repeatable, or a list like protoc's)")
+	out := flags.String("out", ".", "directory the generated files are written to")
+	opt := flags.String("opt", "paths=source_relative", "plugin options, as given to --go-http-server-interface_opt")
+	protoc := flags.String("protoc", "protoc", "protoc executable used to parse the .proto files")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	protos = append(protos, flags.Args()...)
+
+	var set *descriptor.FileDescriptorSet
+	var names []string
+	var err error
+	if len(descriptorSets) > 0 {
+		if set, err = readDescriptorSets(descriptorSets); err != nil {
+			return err
+		}
+		names = protos
+	} else {
+		if len(protos) == 0 {
+			return errors.New("no .proto files given; pass them with -proto or -descriptor_set_in")
+		}
+		if len(includes) == 0 {
+			includes = stringList{"."}
+		}
+		if names, err = protoNames(includes, protos); err != nil {
+			return err
+		}
+		if set, err = runProtoc(*protoc, includes, protos, stderr); err != nil {
+			return err
+		}
+	}
+
+	req, err := newRequest(set, names, *opt)
+	if err != nil {
+		return err
+	}
+	g := httpinterface.New()
+	g.Warnings = stderr
+	resp := g.Generate(req)
+	if resp.Error != nil {
+		return errors.New(resp.GetError())
+	}
+	return writeFiles(*out, resp.GetFile())
+}
+
+// protoNames returns the names protoc gives the files at paths: their paths
+// relative to the first import path containing them, with forward slashes.
+func protoNames(includes, paths []string) ([]string, error) {
+	names := make([]string, 0, len(paths))
+	for _, path := range paths {
+		name := ""
+		for _, include := range includes {
+			rel, err := filepath.Rel(include, path)
+			if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				name = filepath.ToSlash(rel)
+				break
+			}
+		}
+		if name == "" {
+			return nil, fmt.Errorf("%s is not under any import path given with -I", path)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// readDescriptorSets reads and merges the FileDescriptorSets in the files at
+// paths, each of which may be a list separated by os.PathListSeparator. The
+// files keep the order they first appear in, so the same sets always yield
+// the same request; a file found again must be identical.
+func readDescriptorSets(paths []string) (*descriptor.FileDescriptorSet, error) {
+	merged := &descriptor.FileDescriptorSet{}
+	seen := make(map[string]*descriptor.FileDescriptorProto)
+	for _, list := range paths {
+		for _, path := range filepath.SplitList(list) {
+			set, err := readDescriptorSet(path)
+			if err != nil {
+				return nil, err
+			}
+			for _, file := range set.GetFile() {
+				if prev, ok := seen[file.GetName()]; ok {
+					if !proto.Equal(prev, file) {
+						return nil, fmt.Errorf("%s differs between the descriptor sets", file.GetName())
+					}
+					continue
+				}
+				seen[file.GetName()] = file
+				merged.File = append(merged.File, file)
+			}
+		}
+	}
+	return merged, nil
+}
+
+// runProtoc parses the files at paths with protoc into a descriptor set
+// holding them, their imports and their comments.
+func runProtoc(protoc string, includes, paths []string, stderr io.Writer) (*descriptor.FileDescriptorSet, error) {
+	tmp, err := os.CreateTemp("", "genhttp-*.pb")
+	if err != nil {
+		return nil, err
+	}
+	_ = tmp.Close()
+	defer os.Remove(tmp.Name())
+
+	args := make([]string, 0, len(includes)+len(paths)+3)
+	for _, include := range includes {
+		args = append(args, "-I"+include)
+	}
+	args = append(args, "--include_imports", "--include_source_info", "--descriptor_set_out="+tmp.Name())
+	args = append(args, paths...)
+	cmd := exec.Command(protoc, args...)
+	cmd.Stdout, cmd.Stderr = stderr, stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s failed: %w", protoc, err)
+	}
+	return readDescriptorSet(tmp.Name())
+}
+
+// readDescriptorSet reads the FileDescriptorSet in the file at path.
+func readDescriptorSet(path string) (*descriptor.FileDescriptorSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	set := &descriptor.FileDescriptorSet{}
+	if err := proto.Unmarshal(data, set); err != nil {
+		return nil, fmt.Errorf("parsing descriptor set %s: %w", path, err)
+	}
+	return set, nil
+}
+
+// newRequest returns the plugin request generating the files of set named
+// names, or all of them when names is empty, with the plugin options opt.
+func newRequest(set *descriptor.FileDescriptorSet, names []string, opt string) (*plugin.CodeGeneratorRequest, error) {
+	inSet := make([]string, 0, len(set.GetFile()))
+	for _, file := range set.GetFile() {
+		inSet = append(inSet, file.GetName())
+	}
+	if len(names) == 0 {
+		names = inSet
+	}
+	for _, name := range names {
+		if !slices.Contains(inSet, name) {
+			return nil, fmt.Errorf("%s is not in the descriptor set", name)
+		}
+	}
+	return &plugin.CodeGeneratorRequest{
+		FileToGenerate: names,
+		Parameter:      proto.String(opt),
+		ProtoFile:      set.GetFile(),
+	}, nil
+}
+
+// writeFiles writes the generated files under dir.
+func writeFiles(dir string, files []*plugin.CodeGeneratorResponse_File) error {
+	for _, file := range files {
+		if file.GetInsertionPoint() != "" {
+			return fmt.Errorf("%s: insertion points are not supported", file.GetName())
+		}
+		path := filepath.Join(dir, filepath.FromSlash(file.GetName()))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(file.GetContent()), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
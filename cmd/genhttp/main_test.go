@@ -0,0 +1,215 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	options "google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+)
+
+// writeDescriptorSet writes a descriptor set holding items/v1/items.proto,
+// whose ItemService gets items at GET /items/{id}, to a file under dir and
+// returns its path.
+func writeDescriptorSet(t *testing.T, dir string) string {
+	t.Helper()
+
+	opts := &descriptor.MethodOptions{}
+	proto.SetExtension(opts, options.E_Http, &options.HttpRule{Pattern: &options.HttpRule_Get{Get: "/items/{id}"}})
+	set := &descriptor.FileDescriptorSet{
+		File: []*descriptor.FileDescriptorProto{{
+			Name:        proto.String("items/v1/items.proto"),
+			Package:     proto.String("items.v1"),
+			Syntax:      proto.String("proto3"),
+			MessageType: []*descriptor.DescriptorProto{{Name: proto.String("Item")}},
+			Service: []*descriptor.ServiceDescriptorProto{{
+				Name: proto.String("ItemService"),
+				Method: []*descriptor.MethodDescriptorProto{
+					{Name: proto.String("GetItem"), InputType: proto.String(".items.v1.Item"), OutputType: proto.String(".items.v1.Item"), Options: opts},
+				},
+			}},
+			Options: &descriptor.FileOptions{GoPackage: proto.String("example.com/items/v1;itemsv1")},
+		}},
+	}
+	data, err := proto.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "items.pb")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRunDescriptorSet(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "all files", args: nil},
+		{name: "named file", args: []string{"-proto", "items/v1/items.proto"}},
+		{name: "positional file", args: []string{"items/v1/items.proto"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			out := filepath.Join(dir, "gen")
+			args := append([]string{"-descriptor_set_in", writeDescriptorSet(t, dir), "-out", out}, tt.args...)
+			if err := run(args, &bytes.Buffer{}); err != nil {
+				t.Fatalf("run() error = %v", err)
+			}
+			code, err := os.ReadFile(filepath.Join(out, "items", "v1", "items_http.pb.go"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range []string{"package itemsv1", "func RegisterItemServiceRoutes("} {
+				if !strings.Contains(string(code), want) {
+					t.Errorf("generated code missing %q", want)
+				}
+			}
+		})
+	}
+}
+
+func TestRunMergesDescriptorSets(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	set := writeDescriptorSet(t, dir)
+	// A second set holding a file without routes, as the descriptor set of
+	// another proto_library would.
+	other := filepath.Join(dir, "other.pb")
+	data, err := proto.Marshal(&descriptor.FileDescriptorSet{File: []*descriptor.FileDescriptorProto{{
+		Name:    proto.String("items/v1/common.proto"),
+		Package: proto.String("items.v1"),
+		Syntax:  proto.String("proto3"),
+		Options: &descriptor.FileOptions{GoPackage: proto.String("example.com/items/v1;itemsv1")},
+	}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(other, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out := filepath.Join(dir, "gen")
+	args := []string{
+		"-descriptor_set_in", set + string(os.PathListSeparator) + other,
+		"-descriptor_set_in", set,
+		"-opt", "paths=source_relative,empty_files=true",
+		"-out", out,
+	}
+	if err := run(args, &bytes.Buffer{}); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	for _, name := range []string{"items_http.pb.go", "common_http.pb.go"} {
+		if _, err := os.Stat(filepath.Join(out, "items", "v1", name)); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestRunProtoc(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake protoc is a shell script")
+	}
+
+	dir := t.TempDir()
+	set := writeDescriptorSet(t, dir)
+	// The fake protoc logs its arguments and writes the prepared set to
+	// the --descriptor_set_out path.
+	protoc := filepath.Join(dir, "protoc")
+	script := "#!/bin/sh\necho \"$@\" > " + filepath.Join(dir, "args") + "\n" +
+		"for a in \"$@\"; do case $a in --descriptor_set_out=*) cp " + set + " \"${a#--descriptor_set_out=}\";; esac; done\n"
+	if err := os.WriteFile(protoc, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	out := filepath.Join(dir, "gen")
+	protoPath := filepath.Join("proto", "items", "v1", "items.proto")
+	if err := run([]string{"-protoc", protoc, "-I", "proto", "-proto", protoPath, "-out", out}, &bytes.Buffer{}); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	args, err := os.ReadFile(filepath.Join(dir, "args"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"-Iproto", "--include_imports", "--include_source_info", protoPath} {
+		if !strings.Contains(string(args), want) {
+			t.Errorf("protoc args %q missing %q", args, want)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(out, "items", "v1", "items_http.pb.go")); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	set := writeDescriptorSet(t, dir)
+	conflicting := filepath.Join(dir, "conflicting.pb")
+	data, err := proto.Marshal(&descriptor.FileDescriptorSet{File: []*descriptor.FileDescriptorProto{{
+		Name:    proto.String("items/v1/items.proto"),
+		Package: proto.String("items.v2"),
+	}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(conflicting, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "no files",
+			args: nil,
+			want: "no .proto files given",
+		},
+		{
+			name: "file outside import paths",
+			args: []string{"-I", "proto", "other/items.proto"},
+			want: "other/items.proto is not under any import path",
+		},
+		{
+			name: "file missing from descriptor set",
+			args: []string{"-descriptor_set_in", set, "items/v1/other.proto"},
+			want: "items/v1/other.proto is not in the descriptor set",
+		},
+		{
+			name: "conflicting descriptor sets",
+			args: []string{"-descriptor_set_in", set, "-descriptor_set_in", conflicting},
+			want: "items/v1/items.proto differs between the descriptor sets",
+		},
+		{
+			name: "invalid option",
+			args: []string{"-descriptor_set_in", set, "-opt", "bogus=true", "-out", filepath.Join(dir, "gen")},
+			want: "unknown option",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := run(tt.args, &bytes.Buffer{})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("run() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
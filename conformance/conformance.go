@@ -0,0 +1,320 @@
+// Package conformance checks generated servers against the gRPC transcoding
+// rules of google.api.http: how routes match request paths, how the request
+// body and query string bind into the request message, and which requests
+// are rejected.
+//
+// The cases run against the ConformanceService of
+// proto/conformance/v1/conformance.proto, whose methods all answer with the
+// message their request bound to. To check a server generated with other
+// options, generate that file with them, serve an implementation returning
+// each request unchanged, and run Cases against it with a Runner:
+//
+//	router := pb.NewRouter(nil)
+//	pb.MustRegisterConformanceServiceRoutes(router, pb.NewConformanceServiceHandler(echo{}))
+//	conformance.Runner{Handler: router}.Run(t, conformance.Cases())
+package conformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// Case is a request to the ConformanceService and the response the
+// transcoding rules require for it.
+type Case struct {
+	// Name identifies the case as area/behavior, such as "path/percent-decoded".
+	Name string
+	// Method is the request method.
+	Method string
+	// Target is the request path and query string.
+	Target string
+	// Body is the JSON request body, sent with Content-Type application/json
+	// when not empty.
+	Body string
+	// WantStatus is the expected response status code.
+	WantStatus int
+	// WantJSON is the expected JSON response body, compared by value. It is
+	// only checked when WantStatus is 200 OK.
+	WantJSON string
+}
+
+// Runner sends conformance cases to a handler serving the ConformanceService.
+type Runner struct {
+	// Handler serves the ConformanceService routes.
+	Handler http.Handler
+	// PathPrefix is prepended to each case target, for servers generated
+	// with path_prefix or registered under a Group.
+	PathPrefix string
+	// Skip lists the names of cases not to run, for servers generated with
+	// options that deliberately change a behavior.
+	Skip []string
+}
+
+// Run runs each case as a subtest of t, skipping those named in r.Skip.
+func (r Runner) Run(t *testing.T, cases []Case) {
+	t.Helper()
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if slices.Contains(r.Skip, c.Name) {
+				t.Skip("skipped by Runner.Skip")
+			}
+			if err := r.Check(c); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// Check sends the request of c to r.Handler and reports how the response
+// differs from the one c expects.
+func (r Runner) Check(c Case) error {
+	var body io.Reader
+	if c.Body != "" {
+		body = strings.NewReader(c.Body)
+	}
+	req := httptest.NewRequest(c.Method, r.PathPrefix+c.Target, body)
+	if c.Body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	rec := httptest.NewRecorder()
+	r.Handler.ServeHTTP(rec, req)
+
+	if rec.Code != c.WantStatus {
+		return fmt.Errorf("%s %s: status = %d, want %d (body %q)",
+			c.Method, c.Target, rec.Code, c.WantStatus, strings.TrimSpace(rec.Body.String()))
+	}
+	if c.WantStatus != http.StatusOK || c.WantJSON == "" {
+		return nil
+	}
+
+	var got, want any
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		return fmt.Errorf("%s %s: decoding response %q: %v", c.Method, c.Target, rec.Body.String(), err)
+	}
+	if err := json.Unmarshal([]byte(c.WantJSON), &want); err != nil {
+		return fmt.Errorf("%s %s: decoding WantJSON: %v", c.Method, c.Target, err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		return fmt.Errorf("%s %s: response = %s, want %s", c.Method, c.Target, rec.Body.String(), c.WantJSON)
+	}
+	return nil
+}
+
+// Cases returns the canonical conformance cases, grouped by the area of the
+// transcoding rules they cover.
+func Cases() []Case {
+	return slices.Concat(pathCases, bodyCases, queryCases, errorCases)
+}
+
+// pathCases cover matching request paths to routes and binding path parameters.
+var pathCases = []Case{
+	{
+		Name: "path/single-segment", Method: http.MethodGet, Target: "/v1/echoes/abc",
+		WantStatus: http.StatusOK, WantJSON: `{"id":"abc"}`,
+	},
+	{
+		Name: "path/percent-decoded", Method: http.MethodGet, Target: "/v1/echoes/a%20b%C3%A9",
+		WantStatus: http.StatusOK, WantJSON: `{"id":"a bé"}`,
+	},
+	{
+		Name: "path/escaped-slash-in-segment", Method: http.MethodGet, Target: "/v1/echoes/a%2Fb",
+		WantStatus: http.StatusOK, WantJSON: `{"id":"a/b"}`,
+	},
+	{
+		Name: "path/multi-segment", Method: http.MethodGet, Target: "/v1/files/docs/2024/report.txt",
+		WantStatus: http.StatusOK, WantJSON: `{"path":"docs/2024/report.txt"}`,
+	},
+	{
+		Name: "path/template-variable", Method: http.MethodGet, Target: "/v1/folders/f1/files/report.txt",
+		WantStatus: http.StatusOK, WantJSON: `{"path":"folders/f1/files/report.txt"}`,
+	},
+	{
+		Name: "path/custom-verb", Method: http.MethodPost, Target: "/v1/echoes:search", Body: `{"id":"abc"}`,
+		WantStatus: http.StatusOK, WantJSON: `{"id":"abc"}`,
+	},
+	{
+		Name: "path/custom-verb-method-not-allowed", Method: http.MethodGet, Target: "/v1/echoes:search",
+		WantStatus: http.StatusMethodNotAllowed,
+	},
+	{
+		Name: "path/additional-binding", Method: http.MethodPatch, Target: "/v1/echoes/abc/nested", Body: `{"value":"v"}`,
+		WantStatus: http.StatusOK, WantJSON: `{"id":"abc","nested":{"value":"v"}}`,
+	},
+	{
+		Name: "path/not-found", Method: http.MethodGet, Target: "/v1/unknown",
+		WantStatus: http.StatusNotFound,
+	},
+	{
+		Name: "path/extra-segment-not-found", Method: http.MethodGet, Target: "/v1/echoes/abc/def",
+		WantStatus: http.StatusNotFound,
+	},
+	{
+		Name: "path/method-not-allowed", Method: http.MethodDelete, Target: "/v1/echoes/abc",
+		WantStatus: http.StatusMethodNotAllowed,
+	},
+}
+
+// bodyCases cover binding the request body to the whole message or to a field.
+var bodyCases = []Case{
+	{
+		Name: "body/star-all-fields", Method: http.MethodPost, Target: "/v1/echoes",
+		Body: `{"id":"abc","displayName":"Abc","number":7,"flag":true,"tags":["x","y"],` +
+			`"nested":{"value":"v","count":2},"kind":"KIND_SMALL","path":"a/b","labels":{"env":"prod"}}`,
+		WantStatus: http.StatusOK,
+		WantJSON: `{"id":"abc","displayName":"Abc","number":7,"flag":true,"tags":["x","y"],` +
+			`"nested":{"value":"v","count":2},"kind":"KIND_SMALL","path":"a/b","labels":{"env":"prod"}}`,
+	},
+	{
+		Name: "body/star-proto-names", Method: http.MethodPost, Target: "/v1/echoes", Body: `{"display_name":"Abc"}`,
+		WantStatus: http.StatusOK, WantJSON: `{"displayName":"Abc"}`,
+	},
+	{
+		Name: "body/star-enum-number", Method: http.MethodPost, Target: "/v1/echoes", Body: `{"kind":2}`,
+		WantStatus: http.StatusOK, WantJSON: `{"kind":"KIND_LARGE"}`,
+	},
+	{
+		Name: "body/star-ignores-query", Method: http.MethodPost, Target: "/v1/echoes?number=5&displayName=q", Body: `{"id":"abc"}`,
+		WantStatus: http.StatusOK, WantJSON: `{"id":"abc"}`,
+	},
+	{
+		Name: "body/star-empty", Method: http.MethodPost, Target: "/v1/echoes",
+		WantStatus: http.StatusOK, WantJSON: `{}`,
+	},
+	{
+		Name: "body/field", Method: http.MethodPut, Target: "/v1/echoes/abc/nested", Body: `{"value":"v","count":2}`,
+		WantStatus: http.StatusOK, WantJSON: `{"id":"abc","nested":{"value":"v","count":2}}`,
+	},
+	{
+		Name: "body/field-with-query", Method: http.MethodPut, Target: "/v1/echoes/abc/nested?displayName=Abc&number=7",
+		Body: `{"value":"v"}`, WantStatus: http.StatusOK, WantJSON: `{"id":"abc","displayName":"Abc","number":7,"nested":{"value":"v"}}`,
+	},
+	{
+		Name: "body/field-not-set-by-query", Method: http.MethodPut, Target: "/v1/echoes/abc/nested?nested.value=q&nested.count=3",
+		Body: `{"value":"v"}`, WantStatus: http.StatusOK, WantJSON: `{"id":"abc","nested":{"value":"v"}}`,
+	},
+	{
+		Name: "body/field-sibling-injection", Method: http.MethodPut, Target: "/v1/echoes/abc/nested",
+		Body: `{"value":"v"},"displayName":"evil"`, WantStatus: http.StatusBadRequest,
+	},
+	{
+		Name: "body/repeated-field", Method: http.MethodPut, Target: "/v1/echoes/abc/tags?number=7", Body: `["x","y,z"]`,
+		WantStatus: http.StatusOK, WantJSON: `{"id":"abc","number":7,"tags":["x","y,z"]}`,
+	},
+	{
+		Name: "body/repeated-field-sibling-injection", Method: http.MethodPut, Target: "/v1/echoes/abc/tags",
+		Body: `["x"],"displayName":"evil"`, WantStatus: http.StatusBadRequest,
+	},
+	{
+		Name: "body/scalar-field", Method: http.MethodPatch, Target: "/v1/echoes/abc/display-name", Body: `"Abc"`,
+		WantStatus: http.StatusOK, WantJSON: `{"id":"abc","displayName":"Abc"}`,
+	},
+	{
+		Name: "body/scalar-field-sibling-injection", Method: http.MethodPatch, Target: "/v1/echoes/abc/display-name",
+		Body: `"Abc","number":7`, WantStatus: http.StatusBadRequest,
+	},
+}
+
+// queryCases cover binding the query string to the fields not bound by the
+// path or the body.
+var queryCases = []Case{
+	{
+		Name: "query/scalars", Method: http.MethodGet, Target: "/v1/echoes?id=abc&number=7&flag=true",
+		WantStatus: http.StatusOK, WantJSON: `{"id":"abc","number":7,"flag":true}`,
+	},
+	{
+		Name: "query/json-name", Method: http.MethodGet, Target: "/v1/echoes?displayName=Abc",
+		WantStatus: http.StatusOK, WantJSON: `{"displayName":"Abc"}`,
+	},
+	{
+		Name: "query/proto-name", Method: http.MethodGet, Target: "/v1/echoes?display_name=Abc",
+		WantStatus: http.StatusOK, WantJSON: `{"displayName":"Abc"}`,
+	},
+	{
+		Name: "query/percent-decoded", Method: http.MethodGet, Target: "/v1/echoes?displayName=a%20b%26c",
+		WantStatus: http.StatusOK, WantJSON: `{"displayName":"a b&c"}`,
+	},
+	{
+		Name: "query/repeated-keys", Method: http.MethodGet, Target: "/v1/echoes?tags=x&tags=y",
+		WantStatus: http.StatusOK, WantJSON: `{"tags":["x","y"]}`,
+	},
+	{
+		Name: "query/repeated-comma-separated", Method: http.MethodGet, Target: "/v1/echoes?tags=x,y&tags=z",
+		WantStatus: http.StatusOK, WantJSON: `{"tags":["x","y","z"]}`,
+	},
+	{
+		Name: "query/map-entry", Method: http.MethodGet, Target: "/v1/echoes?labels[env]=prod&labels[tier]=web",
+		WantStatus: http.StatusOK, WantJSON: `{"labels":{"env":"prod","tier":"web"}}`,
+	},
+	{
+		Name: "query/nested-message", Method: http.MethodGet, Target: "/v1/echoes?nested.value=v&nested.count=2",
+		WantStatus: http.StatusOK, WantJSON: `{"nested":{"value":"v","count":2}}`,
+	},
+	{
+		Name: "query/enum-name", Method: http.MethodGet, Target: "/v1/echoes?kind=KIND_LARGE",
+		WantStatus: http.StatusOK, WantJSON: `{"kind":"KIND_LARGE"}`,
+	},
+	{
+		Name: "query/enum-number", Method: http.MethodGet, Target: "/v1/echoes?kind=1",
+		WantStatus: http.StatusOK, WantJSON: `{"kind":"KIND_SMALL"}`,
+	},
+	{
+		Name: "query/with-path-parameter", Method: http.MethodGet, Target: "/v1/echoes/abc?displayName=Abc",
+		WantStatus: http.StatusOK, WantJSON: `{"id":"abc","displayName":"Abc"}`,
+	},
+	{
+		Name: "query/path-parameter-wins", Method: http.MethodGet, Target: "/v1/echoes/abc?id=def",
+		WantStatus: http.StatusOK, WantJSON: `{"id":"abc"}`,
+	},
+	{
+		Name: "query/oneof-member", Method: http.MethodGet, Target: "/v1/echoes?email=a@example.com",
+		WantStatus: http.StatusOK, WantJSON: `{"email":"a@example.com"}`,
+	},
+}
+
+// errorCases cover requests the server rejects, and the unknown input it
+// must ignore rather than reject.
+var errorCases = []Case{
+	{
+		Name: "error/malformed-body", Method: http.MethodPost, Target: "/v1/echoes", Body: `{"id":`,
+		WantStatus: http.StatusBadRequest,
+	},
+	{
+		Name: "error/body-wrong-type", Method: http.MethodPost, Target: "/v1/echoes", Body: `{"number":"seven"}`,
+		WantStatus: http.StatusBadRequest,
+	},
+	{
+		Name: "error/body-field-wrong-type", Method: http.MethodPut, Target: "/v1/echoes/abc/nested", Body: `"v"`,
+		WantStatus: http.StatusBadRequest,
+	},
+	{
+		Name: "error/query-wrong-type", Method: http.MethodGet, Target: "/v1/echoes?number=seven",
+		WantStatus: http.StatusBadRequest,
+	},
+	{
+		Name: "error/query-path-through-scalar", Method: http.MethodGet, Target: "/v1/echoes?number.value=7",
+		WantStatus: http.StatusBadRequest,
+	},
+	{
+		Name: "error/query-unknown-enum", Method: http.MethodGet, Target: "/v1/echoes?kind=KIND_HUGE",
+		WantStatus: http.StatusBadRequest,
+	},
+	{
+		Name: "error/query-oneof-conflict", Method: http.MethodGet, Target: "/v1/echoes?email=a@example.com&phone=555",
+		WantStatus: http.StatusBadRequest,
+	},
+	{
+		Name: "error/unknown-body-field-ignored", Method: http.MethodPost, Target: "/v1/echoes", Body: `{"id":"abc","color":"red"}`,
+		WantStatus: http.StatusOK, WantJSON: `{"id":"abc"}`,
+	},
+	{
+		Name: "error/unknown-query-parameter-ignored", Method: http.MethodGet, Target: "/v1/echoes?id=abc&color=red",
+		WantStatus: http.StatusOK, WantJSON: `{"id":"abc"}`,
+	},
+}
@@ -0,0 +1,81 @@
+package conformance_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/conformance"
+	pb "github.com/farhaan/protoc-gen-go-http-server-interface/conformance/pb/conformance/v1"
+)
+
+// echo implements ConformanceService by answering with each request.
+type echo struct{}
+
+func (echo) GetEcho(_ context.Context, req *pb.EchoMessage) (*pb.EchoMessage, error) { return req, nil }
+
+func (echo) ListEchoes(_ context.Context, req *pb.EchoMessage) (*pb.EchoMessage, error) {
+	return req, nil
+}
+
+func (echo) CreateEcho(_ context.Context, req *pb.EchoMessage) (*pb.EchoMessage, error) {
+	return req, nil
+}
+
+func (echo) UpdateEchoNested(_ context.Context, req *pb.EchoMessage) (*pb.EchoMessage, error) {
+	return req, nil
+}
+
+func (echo) SetEchoField(_ context.Context, req *pb.EchoMessage) (*pb.EchoMessage, error) {
+	return req, nil
+}
+
+func (echo) SearchEchoes(_ context.Context, req *pb.EchoMessage) (*pb.EchoMessage, error) {
+	return req, nil
+}
+
+func (echo) GetFile(_ context.Context, req *pb.EchoMessage) (*pb.EchoMessage, error) { return req, nil }
+
+func (echo) GetFolderFile(_ context.Context, req *pb.EchoMessage) (*pb.EchoMessage, error) {
+	return req, nil
+}
+
+// TestConformance runs the canonical cases against the generated server.
+func TestConformance(t *testing.T) {
+	t.Parallel()
+
+	router := pb.NewRouter(nil)
+	pb.MustRegisterConformanceServiceRoutes(router, pb.NewConformanceServiceHandler(echo{}))
+	conformance.Runner{Handler: router}.Run(t, conformance.Cases())
+}
+
+// TestConformance_PathPrefix runs the cases against routes registered under a group.
+func TestConformance_PathPrefix(t *testing.T) {
+	t.Parallel()
+
+	router := pb.NewRouter(nil)
+	pb.MustRegisterConformanceServiceRoutes(router.Group("/api"), pb.NewConformanceServiceHandler(echo{}))
+	conformance.Runner{Handler: router, PathPrefix: "/api"}.Run(t, conformance.Cases())
+}
+
+// TestHandlerFor_PathTemplate serves a path template route through its
+// HandlerFor handler the way another framework would, setting the template
+// variable whole from its own route parameters.
+func TestHandlerFor_PathTemplate(t *testing.T) {
+	t.Parallel()
+
+	h := pb.HandlerForGetFolderFile(pb.NewConformanceServiceHandler(echo{}))
+	req := httptest.NewRequest(http.MethodGet, "/folders/f1/files/report.txt", nil)
+	req.SetPathValue("path", "folders/f1/files/report.txt")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+	if want := `{"path":"folders/f1/files/report.txt"}`; strings.TrimSpace(rec.Body.String()) != want {
+		t.Errorf("body = %s, want %s", rec.Body, want)
+	}
+}
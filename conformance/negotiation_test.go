@@ -0,0 +1,46 @@
+package conformance_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	pb "github.com/farhaan/protoc-gen-go-http-server-interface/conformance/pb/conformance/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestBinaryBodyOfWhitespaceBytes checks that a binary body made only of
+// bytes that are whitespace in JSON is decoded rather than dropped as empty.
+func TestBinaryBodyOfWhitespaceBytes(t *testing.T) {
+	t.Parallel()
+
+	// A 32-byte id encodes as the tag '\n', the length ' ' and the id.
+	id := strings.Repeat(" ", 32)
+	data, err := proto.Marshal(&pb.EchoMessage{Id: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(string(data)) != "" {
+		t.Fatalf("encoding %q is not all whitespace", data)
+	}
+
+	router := pb.NewRouter(nil, pb.WithCodecs(pb.Codecs{"application/x-protobuf": pb.ProtoMarshaler{}}))
+	pb.MustRegisterConformanceServiceRoutes(router, pb.NewConformanceServiceHandler(echo{}))
+	req := httptest.NewRequest(http.MethodPost, "/v1/echoes", strings.NewReader(string(data)))
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Accept", "application/x-protobuf")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+	got := &pb.EchoMessage{}
+	if err := proto.Unmarshal(rec.Body.Bytes(), got); err != nil {
+		t.Fatalf("decoding the response: %v", err)
+	}
+	if got.GetId() != id {
+		t.Errorf("id = %q, want %q", got.GetId(), id)
+	}
+}
@@ -0,0 +1,402 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: conformance/v1/conformance.proto
+
+package conformancev1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Kind is an enum bound by name or number.
+type Kind int32
+
+const (
+	Kind_KIND_UNSPECIFIED Kind = 0
+	Kind_KIND_SMALL       Kind = 1
+	Kind_KIND_LARGE       Kind = 2
+)
+
+// Enum value maps for Kind.
+var (
+	Kind_name = map[int32]string{
+		0: "KIND_UNSPECIFIED",
+		1: "KIND_SMALL",
+		2: "KIND_LARGE",
+	}
+	Kind_value = map[string]int32{
+		"KIND_UNSPECIFIED": 0,
+		"KIND_SMALL":       1,
+		"KIND_LARGE":       2,
+	}
+)
+
+func (x Kind) Enum() *Kind {
+	p := new(Kind)
+	*p = x
+	return p
+}
+
+func (x Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_conformance_v1_conformance_proto_enumTypes[0].Descriptor()
+}
+
+func (Kind) Type() protoreflect.EnumType {
+	return &file_conformance_v1_conformance_proto_enumTypes[0]
+}
+
+func (x Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Kind.Descriptor instead.
+func (Kind) EnumDescriptor() ([]byte, []int) {
+	return file_conformance_v1_conformance_proto_rawDescGZIP(), []int{0}
+}
+
+// Nested is a message field of EchoMessage.
+type Nested struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Nested) Reset() {
+	*x = Nested{}
+	mi := &file_conformance_v1_conformance_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Nested) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Nested) ProtoMessage() {}
+
+func (x *Nested) ProtoReflect() protoreflect.Message {
+	mi := &file_conformance_v1_conformance_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Nested.ProtoReflect.Descriptor instead.
+func (*Nested) Descriptor() ([]byte, []int) {
+	return file_conformance_v1_conformance_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Nested) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Nested) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// EchoMessage has a field of each kind the transcoding rules bind.
+type EchoMessage struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DisplayName string                 `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Number      int32                  `protobuf:"varint,3,opt,name=number,proto3" json:"number,omitempty"`
+	Flag        bool                   `protobuf:"varint,4,opt,name=flag,proto3" json:"flag,omitempty"`
+	Tags        []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	Nested      *Nested                `protobuf:"bytes,6,opt,name=nested,proto3" json:"nested,omitempty"`
+	Kind        Kind                   `protobuf:"varint,7,opt,name=kind,proto3,enum=conformance.v1.Kind" json:"kind,omitempty"`
+	Path        string                 `protobuf:"bytes,8,opt,name=path,proto3" json:"path,omitempty"`
+	Labels      map[string]string      `protobuf:"bytes,9,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Types that are valid to be assigned to Contact:
+	//
+	//	*EchoMessage_Email
+	//	*EchoMessage_Phone
+	Contact       isEchoMessage_Contact `protobuf_oneof:"contact"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EchoMessage) Reset() {
+	*x = EchoMessage{}
+	mi := &file_conformance_v1_conformance_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EchoMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoMessage) ProtoMessage() {}
+
+func (x *EchoMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_conformance_v1_conformance_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EchoMessage.ProtoReflect.Descriptor instead.
+func (*EchoMessage) Descriptor() ([]byte, []int) {
+	return file_conformance_v1_conformance_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EchoMessage) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *EchoMessage) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *EchoMessage) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *EchoMessage) GetFlag() bool {
+	if x != nil {
+		return x.Flag
+	}
+	return false
+}
+
+func (x *EchoMessage) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *EchoMessage) GetNested() *Nested {
+	if x != nil {
+		return x.Nested
+	}
+	return nil
+}
+
+func (x *EchoMessage) GetKind() Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return Kind_KIND_UNSPECIFIED
+}
+
+func (x *EchoMessage) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *EchoMessage) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *EchoMessage) GetContact() isEchoMessage_Contact {
+	if x != nil {
+		return x.Contact
+	}
+	return nil
+}
+
+func (x *EchoMessage) GetEmail() string {
+	if x != nil {
+		if x, ok := x.Contact.(*EchoMessage_Email); ok {
+			return x.Email
+		}
+	}
+	return ""
+}
+
+func (x *EchoMessage) GetPhone() string {
+	if x != nil {
+		if x, ok := x.Contact.(*EchoMessage_Phone); ok {
+			return x.Phone
+		}
+	}
+	return ""
+}
+
+type isEchoMessage_Contact interface {
+	isEchoMessage_Contact()
+}
+
+type EchoMessage_Email struct {
+	Email string `protobuf:"bytes,10,opt,name=email,proto3,oneof"`
+}
+
+type EchoMessage_Phone struct {
+	Phone string `protobuf:"bytes,11,opt,name=phone,proto3,oneof"`
+}
+
+func (*EchoMessage_Email) isEchoMessage_Contact() {}
+
+func (*EchoMessage_Phone) isEchoMessage_Contact() {}
+
+var File_conformance_v1_conformance_proto protoreflect.FileDescriptor
+
+const file_conformance_v1_conformance_proto_rawDesc = "" +
+	"\n" +
+	" conformance/v1/conformance.proto\x12\x0econformance.v1\x1a\x1cgoogle/api/annotations.proto\"4\n" +
+	"\x06Nested\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"\xa5\x03\n" +
+	"\vEchoMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\fdisplay_name\x18\x02 \x01(\tR\vdisplayName\x12\x16\n" +
+	"\x06number\x18\x03 \x01(\x05R\x06number\x12\x12\n" +
+	"\x04flag\x18\x04 \x01(\bR\x04flag\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12.\n" +
+	"\x06nested\x18\x06 \x01(\v2\x16.conformance.v1.NestedR\x06nested\x12(\n" +
+	"\x04kind\x18\a \x01(\x0e2\x14.conformance.v1.KindR\x04kind\x12\x12\n" +
+	"\x04path\x18\b \x01(\tR\x04path\x12?\n" +
+	"\x06labels\x18\t \x03(\v2'.conformance.v1.EchoMessage.LabelsEntryR\x06labels\x12\x16\n" +
+	"\x05email\x18\n" +
+	" \x01(\tH\x00R\x05email\x12\x16\n" +
+	"\x05phone\x18\v \x01(\tH\x00R\x05phone\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\t\n" +
+	"\acontact*<\n" +
+	"\x04Kind\x12\x14\n" +
+	"\x10KIND_UNSPECIFIED\x10\x00\x12\x0e\n" +
+	"\n" +
+	"KIND_SMALL\x10\x01\x12\x0e\n" +
+	"\n" +
+	"KIND_LARGE\x10\x022\x9e\a\n" +
+	"\x12ConformanceService\x12\\\n" +
+	"\aGetEcho\x12\x1b.conformance.v1.EchoMessage\x1a\x1b.conformance.v1.EchoMessage\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/v1/echoes/{id}\x12Z\n" +
+	"\n" +
+	"ListEchoes\x12\x1b.conformance.v1.EchoMessage\x1a\x1b.conformance.v1.EchoMessage\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
+	"/v1/echoes\x12]\n" +
+	"\n" +
+	"CreateEcho\x12\x1b.conformance.v1.EchoMessage\x1a\x1b.conformance.v1.EchoMessage\"\x15\x82\xd3\xe4\x93\x02\x0f:\x01*\"\n" +
+	"/v1/echoes\x12\x96\x01\n" +
+	"\x10UpdateEchoNested\x12\x1b.conformance.v1.EchoMessage\x1a\x1b.conformance.v1.EchoMessage\"H\x82\xd3\xe4\x93\x02B:\x06nestedZ :\x06nested2\x16/v1/echoes/{id}/nested\x1a\x16/v1/echoes/{id}/nested\x12\x9a\x01\n" +
+	"\fSetEchoField\x12\x1b.conformance.v1.EchoMessage\x1a\x1b.conformance.v1.EchoMessage\"P\x82\xd3\xe4\x93\x02J:\x04tagsZ,:\fdisplay_name2\x1c/v1/echoes/{id}/display-name\x1a\x14/v1/echoes/{id}/tags\x12f\n" +
+	"\fSearchEchoes\x12\x1b.conformance.v1.EchoMessage\x1a\x1b.conformance.v1.EchoMessage\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/echoes:search\x12`\n" +
+	"\aGetFile\x12\x1b.conformance.v1.EchoMessage\x1a\x1b.conformance.v1.EchoMessage\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/files/{path...}\x12o\n" +
+	"\rGetFolderFile\x12\x1b.conformance.v1.EchoMessage\x1a\x1b.conformance.v1.EchoMessage\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/v1/{path=folders/*/files/*}BdZbgithub.com/farhaan/protoc-gen-go-http-server-interface/conformance/pb/conformance/v1;conformancev1b\x06proto3"
+
+var (
+	file_conformance_v1_conformance_proto_rawDescOnce sync.Once
+	file_conformance_v1_conformance_proto_rawDescData []byte
+)
+
+func file_conformance_v1_conformance_proto_rawDescGZIP() []byte {
+	file_conformance_v1_conformance_proto_rawDescOnce.Do(func() {
+		file_conformance_v1_conformance_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_conformance_v1_conformance_proto_rawDesc), len(file_conformance_v1_conformance_proto_rawDesc)))
+	})
+	return file_conformance_v1_conformance_proto_rawDescData
+}
+
+var file_conformance_v1_conformance_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_conformance_v1_conformance_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_conformance_v1_conformance_proto_goTypes = []any{
+	(Kind)(0),           // 0: conformance.v1.Kind
+	(*Nested)(nil),      // 1: conformance.v1.Nested
+	(*EchoMessage)(nil), // 2: conformance.v1.EchoMessage
+	nil,                 // 3: conformance.v1.EchoMessage.LabelsEntry
+}
+var file_conformance_v1_conformance_proto_depIdxs = []int32{
+	1,  // 0: conformance.v1.EchoMessage.nested:type_name -> conformance.v1.Nested
+	0,  // 1: conformance.v1.EchoMessage.kind:type_name -> conformance.v1.Kind
+	3,  // 2: conformance.v1.EchoMessage.labels:type_name -> conformance.v1.EchoMessage.LabelsEntry
+	2,  // 3: conformance.v1.ConformanceService.GetEcho:input_type -> conformance.v1.EchoMessage
+	2,  // 4: conformance.v1.ConformanceService.ListEchoes:input_type -> conformance.v1.EchoMessage
+	2,  // 5: conformance.v1.ConformanceService.CreateEcho:input_type -> conformance.v1.EchoMessage
+	2,  // 6: conformance.v1.ConformanceService.UpdateEchoNested:input_type -> conformance.v1.EchoMessage
+	2,  // 7: conformance.v1.ConformanceService.SetEchoField:input_type -> conformance.v1.EchoMessage
+	2,  // 8: conformance.v1.ConformanceService.SearchEchoes:input_type -> conformance.v1.EchoMessage
+	2,  // 9: conformance.v1.ConformanceService.GetFile:input_type -> conformance.v1.EchoMessage
+	2,  // 10: conformance.v1.ConformanceService.GetFolderFile:input_type -> conformance.v1.EchoMessage
+	2,  // 11: conformance.v1.ConformanceService.GetEcho:output_type -> conformance.v1.EchoMessage
+	2,  // 12: conformance.v1.ConformanceService.ListEchoes:output_type -> conformance.v1.EchoMessage
+	2,  // 13: conformance.v1.ConformanceService.CreateEcho:output_type -> conformance.v1.EchoMessage
+	2,  // 14: conformance.v1.ConformanceService.UpdateEchoNested:output_type -> conformance.v1.EchoMessage
+	2,  // 15: conformance.v1.ConformanceService.SetEchoField:output_type -> conformance.v1.EchoMessage
+	2,  // 16: conformance.v1.ConformanceService.SearchEchoes:output_type -> conformance.v1.EchoMessage
+	2,  // 17: conformance.v1.ConformanceService.GetFile:output_type -> conformance.v1.EchoMessage
+	2,  // 18: conformance.v1.ConformanceService.GetFolderFile:output_type -> conformance.v1.EchoMessage
+	11, // [11:19] is the sub-list for method output_type
+	3,  // [3:11] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_conformance_v1_conformance_proto_init() }
+func file_conformance_v1_conformance_proto_init() {
+	if File_conformance_v1_conformance_proto != nil {
+		return
+	}
+	file_conformance_v1_conformance_proto_msgTypes[1].OneofWrappers = []any{
+		(*EchoMessage_Email)(nil),
+		(*EchoMessage_Phone)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_conformance_v1_conformance_proto_rawDesc), len(file_conformance_v1_conformance_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_conformance_v1_conformance_proto_goTypes,
+		DependencyIndexes: file_conformance_v1_conformance_proto_depIdxs,
+		EnumInfos:         file_conformance_v1_conformance_proto_enumTypes,
+		MessageInfos:      file_conformance_v1_conformance_proto_msgTypes,
+	}.Build()
+	File_conformance_v1_conformance_proto = out.File
+	file_conformance_v1_conformance_proto_goTypes = nil
+	file_conformance_v1_conformance_proto_depIdxs = nil
+}
@@ -0,0 +1,1877 @@
+// Code generated by protoc-gen-go-http-server-interface. DO NOT EDIT.
+package conformancev1
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// GeneratorVersion is the version of protoc-gen-go-http-server-interface that
+// generated this file.
+const GeneratorVersion = "dev"
+
+// GeneratorParameters is the plugin parameter string this file was generated
+// with, listing the options that shaped its HTTP layer.
+const GeneratorParameters = "paths=source_relative,binding=true,content_negotiation=true"
+
+// GeneratorInfo describes the generator run that produced this file.
+type GeneratorInfo struct {
+	// Version is the generator version, GeneratorVersion.
+	Version string
+	// Parameters is the plugin parameter string, GeneratorParameters.
+	Parameters string
+	// Source is the proto file the code was generated from.
+	Source string
+}
+
+// GenerationInfo reports which generator version and options produced this
+// package's HTTP layer, for deployed binaries to expose in build or health
+// endpoints.
+func GenerationInfo() GeneratorInfo {
+	return GeneratorInfo{Version: GeneratorVersion, Parameters: GeneratorParameters, Source: "conformance/v1/conformance.proto"}
+}
+
+// Middleware represents a middleware function that wraps an http.Handler.
+type Middleware func(http.Handler) http.Handler
+
+// Routes defines the minimal interface for route registration.
+// This interface is intentionally minimal to maximize compatibility with
+// standard library and third-party routers (chi, gorilla/mux, etc.).
+type Routes interface {
+	// HandleFunc registers a handler function for the given method and pattern.
+	HandleFunc(method, pattern string, handler http.HandlerFunc)
+}
+
+// Router extends Routes with grouping and middleware support.
+type Router interface {
+	Routes
+	// Group creates a sub-router with the given prefix.
+	Group(prefix string, middlewares ...Middleware) Router
+	// Use appends middlewares to the chain.
+	Use(middlewares ...Middleware) Router
+}
+
+// RouteGroup implements Router using http.ServeMux.
+type RouteGroup struct {
+	mux         *http.ServeMux
+	prefix      string
+	middlewares []Middleware
+	routes      []string
+	registry    *routeRegistry
+	onConflict  ConflictPolicy
+	codecs      Codecs
+}
+
+// routeRegistry records the routes and mounts of a router and all its groups.
+type routeRegistry struct {
+	routes  []string
+	mounts  []string
+	options map[string]*atomic.Pointer[sharedOptions]
+}
+
+// RouterOption configures a router created by NewRouter.
+type RouterOption func(*RouteGroup)
+
+// NewRouter creates a new router with an optional mux.
+// If mux is nil, a new http.ServeMux will be created.
+// Options such as WithCodecs apply to the router and all its groups.
+func NewRouter(mux *http.ServeMux, opts ...RouterOption) *RouteGroup {
+	if mux == nil {
+		mux = http.NewServeMux()
+	}
+	g := &RouteGroup{
+		mux:         mux,
+		prefix:      "",
+		middlewares: nil,
+		routes:      []string{},
+		registry:    &routeRegistry{},
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// Mux returns the underlying http.ServeMux.
+func (g *RouteGroup) Mux() *http.ServeMux {
+	return g.mux
+}
+
+// joinPath safely joins URL path segments.
+func joinPath(base, path string) string {
+	if path == "" || path == "/" {
+		return base
+	}
+	if base == "" || base == "/" {
+		return path
+	}
+	return strings.TrimSuffix(base, "/") + "/" + strings.TrimPrefix(path, "/")
+}
+
+// Group creates a new RouteGroup with the given prefix and optional middlewares.
+func (g *RouteGroup) Group(prefix string, middlewares ...Middleware) Router {
+	// Ensure prefix starts with /
+	if prefix != "" && !strings.HasPrefix(prefix, "/") {
+		prefix = "/" + prefix
+	}
+
+	return &RouteGroup{
+		mux:         g.mux,
+		prefix:      joinPath(g.prefix, prefix),
+		middlewares: appendMiddlewares(g.middlewares, middlewares),
+		routes:      []string{},
+		registry:    g.registry,
+		onConflict:  g.onConflict,
+		codecs:      g.codecs,
+	}
+}
+
+// Use appends middlewares to all routes registered after this call.
+func (g *RouteGroup) Use(middlewares ...Middleware) Router {
+	g.middlewares = appendMiddlewares(g.middlewares, middlewares)
+	return g
+}
+
+// HandleFunc registers a handler function for the given method and pattern.
+// Group middlewares are automatically applied to the handler.
+// It panics with *MountConflictError if the route falls under a mounted prefix.
+func (g *RouteGroup) HandleFunc(method, pattern string, handler http.HandlerFunc) {
+	fullPattern := joinPath(g.prefix, pattern)
+	if g.codecs != nil {
+		handler = withCodecs(g.codecs, handler)
+	}
+	finalHandler := applyMiddlewares(handler, g.middlewares)
+	routeKey := method + " " + fullPattern
+	if g.registry != nil {
+		for _, mount := range g.registry.mounts {
+			if underMount(fullPattern, mount) {
+				panic(&MountConflictError{Prefix: mount, Route: routeKey})
+			}
+		}
+		g.registry.routes = append(g.registry.routes, routeKey)
+	}
+	if g.handle(routeKey, finalHandler) {
+		g.routes = append(g.routes, routeKey)
+	}
+}
+
+// sharedOptions is what the OPTIONS route of a path answers with: the methods
+// the path's routes accept and the details of the HandleOptions calls for it.
+type sharedOptions struct {
+	allow   []string
+	details []any
+}
+
+// HandleOptions registers respond as the OPTIONS route of pattern, whose other
+// routes accept the methods in allow, sorted, and are described by detail.
+// The services with routes on a path share its OPTIONS route: a call for a
+// path the router or any of its groups already has one for adds allow and
+// detail to those respond is called with, instead of registering the route
+// again, which the ServeMux would panic on.
+func (g *RouteGroup) HandleOptions(pattern string, allow []string, detail any, respond func(w http.ResponseWriter, r *http.Request, allow []string, details []any)) {
+	key := patternShape(http.MethodOptions + " " + joinPath(g.prefix, pattern))
+	if g.registry != nil {
+		if shared, ok := g.registry.options[key]; ok {
+			current := shared.Load()
+			details := append(make([]any, 0, len(current.details)+1), current.details...)
+			shared.Store(&sharedOptions{allow: mergeMethods(current.allow, allow), details: append(details, detail)})
+			return
+		}
+	}
+	shared := &atomic.Pointer[sharedOptions]{}
+	shared.Store(&sharedOptions{allow: allow, details: []any{detail}})
+	g.HandleFunc(http.MethodOptions, pattern, func(w http.ResponseWriter, r *http.Request) {
+		current := shared.Load()
+		respond(w, r, current.allow, current.details)
+	})
+	if g.registry != nil {
+		if g.registry.options == nil {
+			g.registry.options = map[string]*atomic.Pointer[sharedOptions]{}
+		}
+		g.registry.options[key] = shared
+	}
+}
+
+// mergeMethods returns the sorted union of the sorted method lists a and b.
+func mergeMethods(a, b []string) []string {
+	merged := make([]string, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		switch {
+		case a[0] < b[0]:
+			merged, a = append(merged, a[0]), a[1:]
+		case a[0] > b[0]:
+			merged, b = append(merged, b[0]), b[1:]
+		default:
+			merged, a, b = append(merged, a[0]), a[1:], b[1:]
+		}
+	}
+	return append(append(merged, a...), b...)
+}
+
+// ConflictPolicy selects what a router does when a route is registered for a
+// method and pattern that its ServeMux already serves, such as a /liveness
+// route of two services sharing the mux.
+type ConflictPolicy int
+
+const (
+	// ConflictError panics with *RouteConflictError.
+	ConflictError ConflictPolicy = iota
+	// ConflictSkip keeps the route registered first.
+	ConflictSkip
+	// ConflictReplace serves the route registered last. Only routes registered
+	// under ConflictReplace by a generated router can be replaced; others
+	// panic as with ConflictError.
+	ConflictReplace
+)
+
+// OnConflict sets the ConflictPolicy of the routes registered after this
+// call, on the group and groups created from it afterwards. It is
+// ConflictError by default.
+func (g *RouteGroup) OnConflict(policy ConflictPolicy) Router {
+	g.onConflict = policy
+	return g
+}
+
+// RouteConflictError reports a route registered for a method and pattern that
+// the ServeMux already serves.
+type RouteConflictError struct {
+	// Route is the conflicting route, as "METHOD /path".
+	Route string
+	// Existing is the route already registered, which differs from Route only
+	// in the names of its wildcards.
+	Existing string
+}
+
+func (e *RouteConflictError) Error() string {
+	return "protogen: route " + e.Route + " conflicts with registered route " + e.Existing
+}
+
+// handle registers h for routeKey on the mux, resolving a conflict with a
+// route already registered for the same method and pattern by the group's
+// ConflictPolicy. It reports whether h serves the route. Routes that only
+// overlap routeKey are left for the mux to reject. Under ConflictError, the
+// default, the mux is only searched for the existing route once registering
+// h panics.
+func (g *RouteGroup) handle(routeKey string, h http.Handler) bool {
+	if g.onConflict == ConflictError {
+		defer func() {
+			if p := recover(); p != nil {
+				if _, pattern := registeredRoute(g.mux, routeKey); pattern != "" && patternShape(pattern) == patternShape(routeKey) {
+					panic(&RouteConflictError{Route: routeKey, Existing: pattern})
+				}
+				panic(p)
+			}
+		}()
+		g.mux.Handle(routeKey, h)
+		return true
+	}
+	if existing, pattern := registeredRoute(g.mux, routeKey); pattern != "" && patternShape(pattern) == patternShape(routeKey) {
+		if g.onConflict == ConflictSkip {
+			return false
+		}
+		if r, ok := existing.(interface{ ReplaceHandler(http.Handler) }); ok {
+			r.ReplaceHandler(h)
+			return true
+		}
+		panic(&RouteConflictError{Route: routeKey, Existing: pattern})
+	}
+	if g.onConflict == ConflictReplace {
+		rh := &replaceableHandler{}
+		rh.ReplaceHandler(h)
+		h = rh
+	}
+	g.mux.Handle(routeKey, h)
+	return true
+}
+
+// replaceableHandler is the handler of a route registered under
+// ConflictReplace, which a router of any generated package can replace under
+// ConflictReplace too.
+type replaceableHandler struct {
+	handler atomic.Pointer[http.Handler]
+}
+
+func (h *replaceableHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	(*h.handler.Load()).ServeHTTP(w, r)
+}
+
+// ReplaceHandler serves the route with handler from now on.
+func (h *replaceableHandler) ReplaceHandler(handler http.Handler) {
+	h.handler.Store(&handler)
+}
+
+// registeredRoute returns the handler and pattern of the route of mux that
+// serves the route pattern routeKey, "METHOD /path", with a placeholder value
+// for each wildcard, or a pattern of "" if no route serves it.
+func registeredRoute(mux *http.ServeMux, routeKey string) (http.Handler, string) {
+	method, path, _ := strings.Cut(routeKey, " ")
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if segment == "{$}" {
+			segments[i] = ""
+		} else if strings.HasPrefix(segment, "{") {
+			segments[i] = "_"
+		}
+	}
+	r, err := http.NewRequest(method, strings.Join(segments, "/"), nil)
+	if err != nil {
+		return nil, ""
+	}
+	return mux.Handler(r)
+}
+
+// patternShape returns the route pattern "METHOD /path" with its wildcards
+// unnamed, so patterns differing only in wildcard names compare equal.
+func patternShape(routeKey string) string {
+	segments := strings.Split(routeKey, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") && segment != "{$}" {
+			if strings.HasSuffix(segment, "...}") {
+				segments[i] = "{...}"
+			} else {
+				segments[i] = "{}"
+			}
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// Mount serves h for every request under prefix, relative to the group, with
+// the prefix stripped from the request path, so third-party handlers such as a
+// GraphQL endpoint or websocket hub can live under the generated router. Group
+// middlewares are applied to h. Mounting at the root serves h for requests no
+// other route matches.
+//
+// Mount returns *MountConflictError if a route registered on the router or any
+// of its groups falls under prefix; routes registered under prefix afterwards
+// panic with the same error.
+func (g *RouteGroup) Mount(prefix string, h http.Handler) error {
+	if h == nil {
+		return ErrNilHandler
+	}
+	if prefix != "" && !strings.HasPrefix(prefix, "/") {
+		prefix = "/" + prefix
+	}
+	mount := strings.TrimSuffix(joinPath(g.prefix, prefix), "/")
+
+	if g.registry != nil {
+		for _, route := range g.registry.routes {
+			_, path, _ := strings.Cut(route, " ")
+			if underMount(path, mount) {
+				return &MountConflictError{Prefix: mount, Route: route}
+			}
+		}
+		g.registry.mounts = append(g.registry.mounts, mount)
+	}
+
+	handler := applyMiddlewares(stripMountPrefix(mount, h), g.middlewares)
+	if mount != "" {
+		g.mux.Handle(mount, handler)
+	}
+	g.mux.Handle(mount+"/", handler)
+	return nil
+}
+
+// MountConflictError reports a mounted prefix that overlaps a registered route.
+type MountConflictError struct {
+	// Prefix is the mounted path prefix.
+	Prefix string
+	// Route is the conflicting route, as "METHOD /path".
+	Route string
+}
+
+// Error returns the mounted prefix and the route it overlaps.
+func (e *MountConflictError) Error() string {
+	return "protogen: mount " + e.Prefix + "/ overlaps route " + e.Route
+}
+
+// underMount reports whether requests to the route pattern path can fall
+// under the mounted prefix mount, comparing them segment by segment: a
+// wildcard such as {id} matches any segment of mount, and one such as
+// {path...} all the rest. Nothing conflicts with a mount at the root, which
+// only receives unmatched requests.
+func underMount(path, mount string) bool {
+	if mount == "" {
+		return false
+	}
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for i, want := range strings.Split(strings.TrimPrefix(mount, "/"), "/") {
+		if i == len(segments) {
+			return false
+		}
+		segment := segments[i]
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "...}") {
+			return true
+		}
+		if segment != want && (!strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") || segment == "{$}") {
+			return false
+		}
+	}
+	return true
+}
+
+// stripMountPrefix serves h with prefix removed from the request path; a
+// request for the prefix itself is served as "/".
+func stripMountPrefix(prefix string, h http.Handler) http.Handler {
+	if prefix == "" {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r2 := r.Clone(r.Context())
+		r2.URL.Path = strings.TrimPrefix(r.URL.Path, prefix)
+		if r2.URL.Path == "" {
+			r2.URL.Path = "/"
+		}
+		if r.URL.RawPath != "" {
+			r2.URL.RawPath = strings.TrimPrefix(r.URL.RawPath, prefix)
+			if r2.URL.RawPath == "" {
+				r2.URL.RawPath = "/"
+			}
+		}
+		h.ServeHTTP(w, r2)
+	})
+}
+
+// GetRoutes returns all registered routes for this group.
+func (g *RouteGroup) GetRoutes() []string {
+	return g.routes
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (g *RouteGroup) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mux.ServeHTTP(w, r)
+}
+
+// appendMiddlewares combines parent and new middlewares, filtering out nils.
+func appendMiddlewares(parent, additional []Middleware) []Middleware {
+	result := make([]Middleware, 0, len(parent)+len(additional))
+	for _, mw := range parent {
+		if mw != nil {
+			result = append(result, mw)
+		}
+	}
+	for _, mw := range additional {
+		if mw != nil {
+			result = append(result, mw)
+		}
+	}
+	return result
+}
+
+// applyMiddlewares wraps handler with the given middlewares (outermost first).
+func applyMiddlewares(handler http.Handler, middlewares []Middleware) http.Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		if middlewares[i] != nil {
+			handler = middlewares[i](handler)
+		}
+	}
+	return handler
+}
+
+// ErrNilRouter is returned when a nil router is passed to a register function.
+var ErrNilRouter = errors.New("protogen: router is nil")
+
+// ErrNilHandler is returned when a nil handler is passed to a register function.
+var ErrNilHandler = errors.New("protogen: handler is nil")
+
+// DefaultRouter creates a new router with a new ServeMux.
+//
+// Deprecated: Use NewRouter(nil) instead.
+func DefaultRouter() *RouteGroup {
+	return NewRouter(nil)
+}
+
+// pathTemplateValues wraps h so each path template variable named by a key of
+// variables is available under its field path, set to the path segments its
+// ServeMux wildcards matched: "name" reads as "projects/p1/locations/l1" for
+// "projects/{name_1}/locations/{name_2}", as the google.api template
+// {name=projects/*/locations/*} would bind it.
+func pathTemplateValues(h http.HandlerFunc, variables map[string]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		for name, segments := range variables {
+			var value strings.Builder
+			for {
+				literal, rest, found := strings.Cut(segments, "{")
+				value.WriteString(literal)
+				if !found {
+					break
+				}
+				var wildcard string
+				wildcard, segments, _ = strings.Cut(rest, "}")
+				value.WriteString(r.PathValue(strings.TrimSuffix(wildcard, "...")))
+			}
+			r.SetPathValue(name, value.String())
+		}
+		h(w, r)
+	}
+}
+
+// BindRequest binds r into msg following the google.api.http mapping rules.
+// The body is decoded first according to the rule's body selector ("*" for the
+// whole message, a top-level field name, or "" for no body), then the named
+// path parameters are applied, then the query string fills the remaining
+// fields unless the body selector is "*".
+//
+// Setting more than one member of a oneof across these sources fails with
+// *OneofConflictError. All errors describe invalid client input and should be
+// reported with 400 Bad Request.
+func BindRequest(r *http.Request, msg proto.Message, body string, pathParams ...string) error {
+	if msg == nil {
+		return nil
+	}
+	if err := bindBody(r, msg, body); err != nil {
+		return err
+	}
+
+	b := newFieldBinder()
+	m := msg.ProtoReflect()
+	b.source = "path parameter"
+	for _, name := range pathParams {
+		if value := r.PathValue(name); value != "" {
+			if err := b.populate(m, name, []string{value}); err != nil {
+				return err
+			}
+		}
+	}
+
+	if body != "*" {
+		filter := slices.Clip(pathParams)
+		if body != "" {
+			filter = append(filter, body)
+		}
+		b.source = "query parameter"
+		if err := b.populateQuery(m, r.URL.Query(), filter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bindBody decodes the request body into msg, or into the top-level field
+// named by body when it is not "*", with the Marshaler of its Content-Type.
+func bindBody(r *http.Request, msg proto.Message, body string) error {
+	if body == "" || r.Body == nil || r.Body == http.NoBody {
+		return nil
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return fmt.Errorf("reading request body: %w", err)
+	}
+	// Whitespace is an empty JSON body, but meaningful bytes of a binary one.
+	mediaType, marshaler := requestMarshaler(r)
+	_, isJSON := marshaler.(JSONMarshaler)
+	if len(data) == 0 || isJSON && len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+
+	if body != "*" {
+		fd := lookupQueryField(msg.ProtoReflect().Descriptor(), body)
+		if fd == nil {
+			return fmt.Errorf("body field %q not found in %s", body, msg.ProtoReflect().Descriptor().FullName())
+		}
+		if fd.Message() == nil || fd.Cardinality() == protoreflect.Repeated {
+			if !isJSON {
+				return fmt.Errorf("body field %q cannot be decoded from %s", body, mediaType)
+			}
+			b := newFieldBinder()
+			b.source = "body field"
+			if err := b.setJSONField(msg.ProtoReflect(), fd, body, data); err != nil {
+				return fmt.Errorf("invalid request body: %w", err)
+			}
+			return nil
+		}
+		msg = msg.ProtoReflect().Mutable(fd).Message().Interface()
+	}
+	if err := marshaler.Unmarshal(data, msg); err != nil {
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+	return nil
+}
+
+// Marshaler decodes request bodies of one media type into proto messages and
+// encodes messages in it.
+type Marshaler interface {
+	Unmarshal(data []byte, msg proto.Message) error
+	Marshal(msg proto.Message) ([]byte, error)
+}
+
+// JSONMarshaler is the Marshaler of the proto JSON mapping. Unknown request
+// fields are ignored, and messages are encoded by DefaultResponseEncoder.
+type JSONMarshaler struct{}
+
+// Unmarshal decodes the JSON data into msg.
+func (JSONMarshaler) Unmarshal(data []byte, msg proto.Message) error {
+	return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, msg)
+}
+
+// Marshal encodes msg as JSON.
+func (JSONMarshaler) Marshal(msg proto.Message) ([]byte, error) {
+	return DefaultResponseEncoder.Marshal(msg)
+}
+
+// Marshalers maps the media types request bodies are accepted in to their
+// Marshaler. Add media types, such as "application/msgpack", before
+// serving, or per router with WithCodecs.
+// Bodies without a Content-Type, or of a type not listed, are decoded as JSON.
+var Marshalers = map[string]Marshaler{"application/json": JSONMarshaler{}, "application/x-protobuf": ProtoMarshaler{}}
+
+// requestMarshaler returns the media type of r's body and its Marshaler,
+// falling back to JSON for bodies without a registered Content-Type.
+func requestMarshaler(r *http.Request) (string, Marshaler) {
+	if mediaType, marshaler, ok := lookupMarshaler(r); ok {
+		return mediaType, marshaler
+	}
+	return "application/json", JSONMarshaler{}
+}
+
+// lookupMarshaler returns the media type of r's Content-Type and its Marshaler
+// in the Codecs of its router or Marshalers, reporting whether one is registered.
+func lookupMarshaler(r *http.Request) (string, Marshaler, bool) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return "", nil, false
+	}
+	marshaler, ok := routeMarshalers(r)[mediaType]
+	return mediaType, marshaler, ok
+}
+
+// ErrNotAcceptable is returned by WriteNegotiatedResponse when the request
+// accepts none of the media types of the response.
+var ErrNotAcceptable = errors.New("not acceptable")
+
+// NegotiateMediaType returns the media type to write msg in for r and its
+// Marshaler, choosing the accepted type of highest quality among the
+// types in the Codecs of r's router and Marshalers. Requests without
+// an Accept header, or accepting */* or application/*, get application/json.
+// It reports false when r accepts none of them.
+func NegotiateMediaType(r *http.Request, msg proto.Message) (string, Marshaler, bool) {
+	if r.Header.Get("Accept") == "" {
+		return "application/json", JSONMarshaler{}, true
+	}
+	type mediaRange struct {
+		name string
+		q    float64
+	}
+	var ranges []mediaRange
+	for _, value := range r.Header.Values("Accept") {
+		for _, part := range strings.Split(value, ",") {
+			name, params, err := mime.ParseMediaType(part)
+			if err != nil {
+				continue
+			}
+			q := 1.0
+			if v, ok := params["q"]; ok {
+				if q, err = strconv.ParseFloat(v, 64); err != nil {
+					continue
+				}
+			}
+			if q > 0 {
+				ranges = append(ranges, mediaRange{name, q})
+			}
+		}
+	}
+	slices.SortStableFunc(ranges, func(a, b mediaRange) int { return cmp.Compare(b.q, a.q) })
+	marshalers := routeMarshalers(r)
+	for _, rng := range ranges {
+		if marshaler, ok := marshalers[rng.name]; ok {
+			return rng.name, marshaler, true
+		}
+		if rng.name == "*/*" || rng.name == "application/*" {
+			return "application/json", JSONMarshaler{}, true
+		}
+	}
+	return "", nil, false
+}
+
+// WriteNegotiatedResponse writes msg to w with the given status code in the
+// media type NegotiateMediaType chooses for r. Requests accepting none of the
+// media types of msg are answered with 406 Not Acceptable listing them, and
+// ErrNotAcceptable is returned.
+func WriteNegotiatedResponse(w http.ResponseWriter, r *http.Request, status int, msg proto.Message) error {
+	w.Header().Add("Vary", "Accept")
+	mediaType, marshaler, ok := NegotiateMediaType(r, msg)
+	if !ok {
+		available := slices.Sorted(maps.Keys(routeMarshalers(r)))
+		http.Error(w, "not acceptable: available types are "+strings.Join(available, ", "), http.StatusNotAcceptable)
+		return ErrNotAcceptable
+	}
+
+	data, err := marshaler.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", mediaType)
+	w.WriteHeader(status)
+	_, err = w.Write(data)
+	return err
+}
+
+// ApplyDefaults sets every unset field of msg that declares an explicit default
+// value, such as proto2 [default = ...], recursing into message fields that are
+// set. Only fields with explicit presence (proto2 optional, or editions fields
+// resolving to features.field_presence = EXPLICIT) can be unset, so fields with
+// implicit presence keep their zero value. Oneof members are never selected.
+func ApplyDefaults(msg proto.Message) {
+	if msg == nil {
+		return
+	}
+	applyDefaults(msg.ProtoReflect())
+}
+
+// applyDefaults implements ApplyDefaults for m and the messages it contains.
+func applyDefaults(m protoreflect.Message) {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		switch {
+		case fd.HasDefault() && fd.HasPresence() && fd.ContainingOneof() == nil:
+			if !m.Has(fd) {
+				m.Set(fd, fd.Default())
+			}
+		case fd.Message() == nil || !m.Has(fd):
+			continue
+		case fd.IsList():
+			list := m.Mutable(fd).List()
+			for j := 0; j < list.Len(); j++ {
+				applyDefaults(list.Get(j).Message())
+			}
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				m.Mutable(fd).Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+					applyDefaults(v.Message())
+					return true
+				})
+			}
+		default:
+			applyDefaults(m.Mutable(fd).Message())
+		}
+	}
+}
+
+// PresentFields returns the sorted proto field paths populated on msg, such as
+// the fields a client sent in a PATCH request. A field with explicit presence,
+// like a proto3 optional field, is reported whenever it was set, even to its
+// zero value, so ?done=false is told apart from an absent done; fields with
+// implicit presence are reported only when non-zero. A set message field is
+// reported by the paths of its populated fields, or by its own path when it is
+// empty or a well-known type. Repeated and map fields are reported as a whole.
+func PresentFields(msg proto.Message) []string {
+	if msg == nil {
+		return nil
+	}
+	paths := presentFields(msg.ProtoReflect(), "", nil)
+	sort.Strings(paths)
+	return paths
+}
+
+// presentFields appends the populated field paths of m, prefixed by prefix, to paths.
+func presentFields(m protoreflect.Message, prefix string, paths []string) []string {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.IsExtension() {
+			return true
+		}
+		path := prefix + string(fd.Name())
+		if fd.Message() != nil && !fd.IsList() && !fd.IsMap() && !isWellKnownType(fd.Message()) {
+			n := len(paths)
+			if paths = presentFields(v.Message(), path+".", paths); len(paths) > n {
+				return true
+			}
+		}
+		paths = append(paths, path)
+		return true
+	})
+	return paths
+}
+
+// isWellKnownType reports whether md is one of the google.protobuf types with a
+// special JSON mapping.
+func isWellKnownType(md protoreflect.MessageDescriptor) bool {
+	return md.ParentFile().Package() == "google.protobuf"
+}
+
+// OneofConflictError reports request data that sets more than one member of a oneof.
+type OneofConflictError struct {
+	// Oneof is the name of the oneof.
+	Oneof string
+	// Field and Source identify the member that was set first.
+	Field, Source string
+	// ConflictingField and ConflictingSource identify the member that conflicts with it.
+	ConflictingField, ConflictingSource string
+}
+
+// Error implements the error interface.
+func (e *OneofConflictError) Error() string {
+	return fmt.Sprintf("oneof %s accepts only one field: %s is set by %s and %s is set by %s",
+		e.Oneof, e.Field, e.Source, e.ConflictingField, e.ConflictingSource)
+}
+
+// fieldBinder assigns string values to message fields while tracking which
+// source set each oneof, so conflicting members are rejected rather than
+// silently overwritten.
+type fieldBinder struct {
+	// source describes where values currently being bound come from.
+	source string
+	// oneofs maps a oneof to the description of the value that set it.
+	oneofs map[protoreflect.FullName]string
+}
+
+// newFieldBinder creates a fieldBinder with no oneofs claimed.
+func newFieldBinder() *fieldBinder {
+	return &fieldBinder{oneofs: make(map[protoreflect.FullName]string)}
+}
+
+// claim records that key sets fd of m, failing if a different member of the
+// same oneof is already set. Members already set when binding starts came
+// from the request body.
+func (b *fieldBinder) claim(m protoreflect.Message, fd protoreflect.FieldDescriptor, key string) error {
+	od := fd.ContainingOneof()
+	if od == nil || od.IsSynthetic() {
+		return nil
+	}
+	setBy, claimed := b.oneofs[od.FullName()]
+	if current := m.WhichOneof(od); current != nil && current.Number() != fd.Number() {
+		if !claimed {
+			setBy = "the request body"
+		}
+		return &OneofConflictError{
+			Oneof:             string(od.Name()),
+			Field:             string(current.Name()),
+			Source:            setBy,
+			ConflictingField:  string(fd.Name()),
+			ConflictingSource: fmt.Sprintf("%s %q", b.source, key),
+		}
+	}
+	if !claimed {
+		b.oneofs[od.FullName()] = fmt.Sprintf("%s %q", b.source, key)
+	}
+	return nil
+}
+
+// PopulateQueryParameters sets fields of msg from URL query values, following
+// the grpc-gateway query parameter conventions.
+//
+// Keys name fields by their proto or JSON name. Nested message fields are
+// addressed with dotted keys (filter.status=ACTIVE), map entries with brackets
+// (labels[env]=prod), and repeated fields accept repeated keys (tag=a&tag=b)
+// or a comma-separated list (tag=a,b). Keys that do not name a field are
+// ignored, as are keys equal to or nested under a field path in filter.
+// Setting more than one member of a oneof fails with *OneofConflictError.
+func PopulateQueryParameters(msg proto.Message, values url.Values, filter ...string) error {
+	if msg == nil {
+		return nil
+	}
+	b := newFieldBinder()
+	b.source = "query parameter"
+	return b.populateQuery(msg.ProtoReflect(), values, filter)
+}
+
+// populateQuery assigns each query value to the field its key addresses, in key order.
+func (b *fieldBinder) populateQuery(m protoreflect.Message, values url.Values, filter []string) error {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if len(values[key]) == 0 || isFilteredQueryKey(key, filter) {
+			continue
+		}
+		if err := b.populate(m, key, values[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isFilteredQueryKey reports whether key equals or is nested under a field path in filter.
+func isFilteredQueryKey(key string, filter []string) bool {
+	if i := strings.IndexByte(key, '['); i >= 0 {
+		key = key[:i]
+	}
+	for _, path := range filter {
+		if key == path || strings.HasPrefix(key, path+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// populate resolves a dotted key against m and assigns vals to the addressed field.
+func (b *fieldBinder) populate(m protoreflect.Message, key string, vals []string) error {
+	path := strings.Split(key, ".")
+	for i, name := range path {
+		mapKey, hasMapKey := "", false
+		if open := strings.IndexByte(name, '['); open > 0 && strings.HasSuffix(name, "]") {
+			name, mapKey, hasMapKey = name[:open], name[open+1:len(name)-1], true
+		}
+
+		fd := lookupQueryField(m.Descriptor(), name)
+		if fd == nil {
+			return nil
+		}
+		if err := b.claim(m, fd, key); err != nil {
+			return err
+		}
+
+		last := i == len(path)-1
+		switch {
+		case hasMapKey:
+			if !last || !fd.IsMap() {
+				return fmt.Errorf("%s %q: %s is not a map field", b.source, key, name)
+			}
+			return b.setMapEntry(m, fd, key, mapKey, vals[len(vals)-1])
+		case last:
+			return b.setField(m, fd, key, vals)
+		case fd.Message() == nil || fd.IsList() || fd.IsMap():
+			return fmt.Errorf("%s %q: %s is not a singular message field", b.source, key, name)
+		default:
+			m = m.Mutable(fd).Message()
+		}
+	}
+	return nil
+}
+
+// lookupQueryField finds a field of md by its proto name or JSON name.
+func lookupQueryField(md protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+	fields := md.Fields()
+	if fd := fields.ByName(protoreflect.Name(name)); fd != nil {
+		return fd
+	}
+	return fields.ByJSONName(name)
+}
+
+// setField assigns vals to a singular or repeated field of m.
+func (b *fieldBinder) setField(m protoreflect.Message, fd protoreflect.FieldDescriptor, key string, vals []string) error {
+	switch {
+	case fd.IsMap():
+		return fmt.Errorf("%s %q: map fields are set with %s[key]=value", b.source, key, key)
+	case fd.IsList():
+		list := m.Mutable(fd).List()
+		for _, raw := range vals {
+			for _, part := range strings.Split(raw, ",") {
+				var v protoreflect.Value
+				if fd.Message() != nil {
+					v = list.NewElement()
+					if err := parseQueryMessage(v.Message(), part); err != nil {
+						return fmt.Errorf("%s %q: %v", b.source, key, err)
+					}
+				} else {
+					var err error
+					if v, err = parseQueryScalar(fd, part); err != nil {
+						return fmt.Errorf("%s %q: %v", b.source, key, err)
+					}
+				}
+				list.Append(v)
+			}
+		}
+		return nil
+	case fd.Message() != nil:
+		msg := m.NewField(fd).Message()
+		if err := parseQueryMessage(msg, vals[len(vals)-1]); err != nil {
+			return fmt.Errorf("%s %q: %v", b.source, key, err)
+		}
+		m.Set(fd, protoreflect.ValueOfMessage(msg))
+		return nil
+	default:
+		v, err := parseQueryScalar(fd, vals[len(vals)-1])
+		if err != nil {
+			return fmt.Errorf("%s %q: %v", b.source, key, err)
+		}
+		m.Set(fd, v)
+		return nil
+	}
+}
+
+// setJSONField assigns the JSON value data to the scalar, repeated or map
+// field fd of m, decoding message elements as JSONMarshaler does.
+func (b *fieldBinder) setJSONField(m protoreflect.Message, fd protoreflect.FieldDescriptor, key string, data []byte) error {
+	switch {
+	case fd.IsMap():
+		var entries map[string]json.RawMessage
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return fmt.Errorf("%s %q: %v", b.source, key, err)
+		}
+		mapKeys := make([]string, 0, len(entries))
+		for mapKey := range entries {
+			mapKeys = append(mapKeys, mapKey)
+		}
+		slices.Sort(mapKeys)
+		for _, mapKey := range mapKeys {
+			v, err := b.jsonValue(fd.MapValue(), m.Mutable(fd).Map().NewValue, key, entries[mapKey])
+			if err != nil {
+				return err
+			}
+			k, err := parseQueryScalar(fd.MapKey(), mapKey)
+			if err != nil {
+				return fmt.Errorf("%s %q: invalid map key: %v", b.source, key, err)
+			}
+			m.Mutable(fd).Map().Set(k.MapKey(), v)
+		}
+		return nil
+	case fd.IsList():
+		var elems []json.RawMessage
+		if err := json.Unmarshal(data, &elems); err != nil {
+			return fmt.Errorf("%s %q: %v", b.source, key, err)
+		}
+		list := m.Mutable(fd).List()
+		for _, elem := range elems {
+			v, err := b.jsonValue(fd, list.NewElement, key, elem)
+			if err != nil {
+				return err
+			}
+			list.Append(v)
+		}
+		return nil
+	default:
+		v, err := b.jsonValue(fd, nil, key, data)
+		if err != nil || !v.IsValid() {
+			return err
+		}
+		m.Set(fd, v)
+		return nil
+	}
+}
+
+// jsonValue converts the JSON value data to a value of the kind of fd. Message
+// values are decoded into a value from newMessage. A null scalar returns the
+// invalid Value.
+func (b *fieldBinder) jsonValue(fd protoreflect.FieldDescriptor, newMessage func() protoreflect.Value, key string, data json.RawMessage) (protoreflect.Value, error) {
+	if fd.Message() != nil {
+		v := newMessage()
+		if err := (JSONMarshaler{}).Unmarshal(data, v.Message().Interface()); err != nil {
+			return protoreflect.Value{}, fmt.Errorf("%s %q: %v", b.source, key, err)
+		}
+		return v, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var raw any
+	if err := dec.Decode(&raw); err != nil {
+		return protoreflect.Value{}, fmt.Errorf("%s %q: %v", b.source, key, err)
+	}
+	if dec.More() {
+		return protoreflect.Value{}, fmt.Errorf("%s %q: invalid JSON value", b.source, key)
+	}
+	var s string
+	switch raw := raw.(type) {
+	case nil:
+		return protoreflect.Value{}, nil
+	case string:
+		s = raw
+	case json.Number:
+		s = raw.String()
+	case bool:
+		s = strconv.FormatBool(raw)
+	default:
+		return protoreflect.Value{}, fmt.Errorf("%s %q: %s is not a %v value", b.source, key, data, fd.Kind())
+	}
+	v, err := parseQueryScalar(fd, s)
+	if err != nil {
+		return protoreflect.Value{}, fmt.Errorf("%s %q: %v", b.source, key, err)
+	}
+	return v, nil
+}
+
+// setMapEntry assigns raw to the entry of map field fd identified by mapKey.
+func (b *fieldBinder) setMapEntry(m protoreflect.Message, fd protoreflect.FieldDescriptor, key, mapKey, raw string) error {
+	k, err := parseQueryScalar(fd.MapKey(), mapKey)
+	if err != nil {
+		return fmt.Errorf("%s %q: invalid map key: %v", b.source, key, err)
+	}
+
+	entries := m.Mutable(fd).Map()
+	var v protoreflect.Value
+	if fd.MapValue().Message() != nil {
+		v = entries.NewValue()
+		if err := parseQueryMessage(v.Message(), raw); err != nil {
+			return fmt.Errorf("%s %q: %v", b.source, key, err)
+		}
+	} else if v, err = parseQueryScalar(fd.MapValue(), raw); err != nil {
+		return fmt.Errorf("%s %q: %v", b.source, key, err)
+	}
+	entries.Set(k.MapKey(), v)
+	return nil
+}
+
+// parseQueryMessage fills a well-known message type from its query string form.
+func parseQueryMessage(msg protoreflect.Message, raw string) error {
+	md := msg.Descriptor()
+	switch md.FullName() {
+	case "google.protobuf.Timestamp", "google.protobuf.Duration", "google.protobuf.FieldMask":
+		return protojson.Unmarshal([]byte(strconv.Quote(raw)), msg.Interface())
+	case "google.protobuf.DoubleValue", "google.protobuf.FloatValue",
+		"google.protobuf.Int64Value", "google.protobuf.UInt64Value",
+		"google.protobuf.Int32Value", "google.protobuf.UInt32Value",
+		"google.protobuf.BoolValue", "google.protobuf.StringValue", "google.protobuf.BytesValue":
+		fd := md.Fields().ByName("value")
+		v, err := parseQueryScalar(fd, raw)
+		if err != nil {
+			return err
+		}
+		msg.Set(fd, v)
+		return nil
+	default:
+		return fmt.Errorf("message type %s cannot be set from a query parameter", md.FullName())
+	}
+}
+
+// parseQueryScalar converts raw to a value of the scalar or enum kind of fd.
+func parseQueryScalar(fd protoreflect.FieldDescriptor, raw string) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(raw)
+		return protoreflect.ValueOfBool(b), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		n, err := strconv.ParseInt(raw, 10, 32)
+		return protoreflect.ValueOfInt32(int32(n)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		return protoreflect.ValueOfInt64(n), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		n, err := strconv.ParseUint(raw, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(n)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := strconv.ParseUint(raw, 10, 64)
+		return protoreflect.ValueOfUint64(n), err
+	case protoreflect.FloatKind:
+		f, err := strconv.ParseFloat(raw, 32)
+		return protoreflect.ValueOfFloat32(float32(f)), err
+	case protoreflect.DoubleKind:
+		f, err := strconv.ParseFloat(raw, 64)
+		return protoreflect.ValueOfFloat64(f), err
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(raw), nil
+	case protoreflect.BytesKind:
+		for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.URLEncoding, base64.RawStdEncoding, base64.RawURLEncoding} {
+			if b, err := enc.DecodeString(raw); err == nil {
+				return protoreflect.ValueOfBytes(b), nil
+			}
+		}
+		return protoreflect.Value{}, fmt.Errorf("invalid base64 value %q", raw)
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByName(protoreflect.Name(raw)); ev != nil {
+			return protoreflect.ValueOfEnum(ev.Number()), nil
+		}
+		n, err := strconv.ParseInt(raw, 10, 32)
+		if err != nil || fd.Enum().Values().ByNumber(protoreflect.EnumNumber(n)) == nil {
+			return protoreflect.Value{}, fmt.Errorf("invalid value %q for enum %s", raw, fd.Enum().FullName())
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n)), nil
+	default:
+		return protoreflect.Value{}, fmt.Errorf("unsupported field kind %v", fd.Kind())
+	}
+}
+
+// ResponseEncoder writes proto messages as JSON response bodies.
+type ResponseEncoder struct {
+	// EmitUnsetOptionals writes unset fields with explicit presence, such as
+	// proto3 optional and message fields, as null instead of omitting them, so
+	// clients can rely on every optional field appearing in the response.
+	EmitUnsetOptionals bool
+}
+
+// DefaultResponseEncoder is the encoder used by WriteResponse.
+var DefaultResponseEncoder = ResponseEncoder{EmitUnsetOptionals: false}
+
+// WriteResponse writes msg to w as JSON with the given status code using DefaultResponseEncoder.
+func WriteResponse(w http.ResponseWriter, status int, msg proto.Message) error {
+	return DefaultResponseEncoder.Write(w, status, msg)
+}
+
+// Write writes msg to w as JSON with the given status code.
+func (e ResponseEncoder) Write(w http.ResponseWriter, status int, msg proto.Message) error {
+	data, err := e.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, err = w.Write(data)
+	return err
+}
+
+// Marshal encodes msg as JSON.
+func (e ResponseEncoder) Marshal(msg proto.Message) ([]byte, error) {
+	data, err := protojson.Marshal(msg)
+	if err != nil || !e.EmitUnsetOptionals {
+		return data, err
+	}
+	return addUnsetFields(msg.ProtoReflect(), data)
+}
+
+// addUnsetFields adds a null member to the JSON object data for every unset
+// field of m with explicit presence outside a real oneof, recursing into the
+// populated message fields of m.
+func addUnsetFields(m protoreflect.Message, data []byte) ([]byte, error) {
+	if isWellKnownType(m.Descriptor()) {
+		return data, nil
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		name := fd.JSONName()
+		var err error
+		switch {
+		case !m.Has(fd):
+			if od := fd.ContainingOneof(); fd.HasPresence() && (od == nil || od.IsSynthetic()) {
+				obj[name] = json.RawMessage("null")
+			}
+		case fd.IsList():
+			if fd.Message() == nil {
+				continue
+			}
+			var items []json.RawMessage
+			if err = json.Unmarshal(obj[name], &items); err != nil {
+				return nil, err
+			}
+			list := m.Get(fd).List()
+			for j := range items {
+				if items[j], err = addUnsetFields(list.Get(j).Message(), items[j]); err != nil {
+					return nil, err
+				}
+			}
+			obj[name], err = json.Marshal(items)
+		case fd.IsMap():
+			if fd.MapValue().Message() == nil {
+				continue
+			}
+			var entries map[string]json.RawMessage
+			if err = json.Unmarshal(obj[name], &entries); err != nil {
+				return nil, err
+			}
+			m.Get(fd).Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+				key := k.String()
+				entries[key], err = addUnsetFields(v.Message(), entries[key])
+				return err == nil
+			})
+			if err == nil {
+				obj[name], err = json.Marshal(entries)
+			}
+		case fd.Message() != nil:
+			obj[name], err = addUnsetFields(m.Get(fd).Message(), obj[name])
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(obj)
+}
+
+// ProtoMarshaler is the Marshaler of the protobuf binary wire format,
+// registered in Marshalers for application/x-protobuf.
+type ProtoMarshaler struct{}
+
+// Unmarshal decodes the protobuf wire-format data into msg.
+func (ProtoMarshaler) Unmarshal(data []byte, msg proto.Message) error {
+	return proto.Unmarshal(data, msg)
+}
+
+// Marshal encodes msg in the protobuf wire format.
+func (ProtoMarshaler) Marshal(msg proto.Message) ([]byte, error) {
+	return proto.Marshal(msg)
+}
+
+// Codecs maps media types to their Marshaler, for the encodings a router
+// speaks besides those in Marshalers, such as msgpack or CBOR.
+type Codecs map[string]Marshaler
+
+// WithCodecs makes the routes of the router and all its groups decode request
+// bodies and negotiate responses in the media types of codecs too. A media
+// type in both codecs and Marshalers uses the Marshaler in codecs.
+func WithCodecs(codecs Codecs) RouterOption {
+	return func(g *RouteGroup) {
+		g.codecs = codecs
+	}
+}
+
+// codecsKey is the context key of the Codecs of a route.
+type codecsKey struct{}
+
+// withCodecs makes codecs the Codecs of the requests h serves.
+func withCodecs(codecs Codecs, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h(w, r.WithContext(context.WithValue(r.Context(), codecsKey{}, codecs)))
+	}
+}
+
+// routeMarshalers returns the Marshalers of the media types r's route speaks:
+// those in Marshalers and in the Codecs of its router.
+func routeMarshalers(r *http.Request) map[string]Marshaler {
+	codecs, _ := r.Context().Value(codecsKey{}).(Codecs)
+	if len(codecs) == 0 {
+		return Marshalers
+	}
+	marshalers := maps.Clone(Marshalers)
+	maps.Copy(marshalers, codecs)
+	return marshalers
+}
+
+// UnaryHandler handles a decoded request message and returns the response
+// message to encode.
+type UnaryHandler func(ctx context.Context, req proto.Message) (proto.Message, error)
+
+// UnaryInterceptor intercepts a typed handler call after the request has been
+// decoded and before the response is encoded, like a gRPC unary server
+// interceptor. rpc is the full method name, such as "/tasks.v1.TaskService/GetTask".
+// An interceptor calls next to continue the chain, or returns without calling
+// it to short-circuit the call, so validation, caching and authorization can
+// work with typed requests instead of HTTP requests.
+type UnaryInterceptor func(ctx context.Context, rpc string, req proto.Message, next UnaryHandler) (proto.Message, error)
+
+// ChainUnaryInterceptors returns an interceptor that runs interceptors in
+// order, the first being the outermost. Nil interceptors are skipped.
+func ChainUnaryInterceptors(interceptors ...UnaryInterceptor) UnaryInterceptor {
+	return func(ctx context.Context, rpc string, req proto.Message, next UnaryHandler) (proto.Message, error) {
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			if interceptor := interceptors[i]; interceptor != nil {
+				inner := next
+				next = func(ctx context.Context, req proto.Message) (proto.Message, error) {
+					return interceptor(ctx, rpc, req, inner)
+				}
+			}
+		}
+		return next(ctx, req)
+	}
+}
+
+// serveUnary binds r into req, calls handler through interceptor, and writes
+// the response with WriteNegotiatedResponse. Binding errors are reported with
+// 400 Bad Request and handler errors by writeUnaryError.
+func serveUnary(w http.ResponseWriter, r *http.Request, rpc string, req proto.Message, body string, pathParams []string,
+	interceptor UnaryInterceptor, handler UnaryHandler) {
+	if err := BindRequest(r, req, body, pathParams...); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := interceptor(r.Context(), rpc, req, handler)
+	if err != nil {
+		writeUnaryError(w, err)
+		return
+	}
+	_ = WriteNegotiatedResponse(w, r, http.StatusOK, resp)
+}
+
+// writeUnaryError reports a handler error with the status returned by an
+// HTTPStatus() int method on err, or 500 Internal Server Error, and the
+// Retry-After header set by setRetryAfter. The error text is only sent for
+// statuses below 500.
+func writeUnaryError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var statusErr interface{ HTTPStatus() int }
+	if errors.As(err, &statusErr) {
+		status = statusErr.HTTPStatus()
+	}
+	setRetryAfter(w, err)
+	if status >= http.StatusInternalServerError {
+		http.Error(w, http.StatusText(status), status)
+	} else {
+		http.Error(w, err.Error(), status)
+	}
+}
+
+// setRetryAfter sets the Retry-After header to the delay returned by a
+// RetryAfter() time.Duration method on err, such as that of a throttled or
+// unavailable backend, rounded up to whole seconds. It returns the delay, or
+// zero when err carries none.
+func setRetryAfter(w http.ResponseWriter, err error) time.Duration {
+	var retryErr interface{ RetryAfter() time.Duration }
+	if !errors.As(err, &retryErr) || retryErr.RetryAfter() <= 0 {
+		return 0
+	}
+	delay := retryErr.RetryAfter()
+	w.Header().Set("Retry-After", strconv.FormatInt(int64((delay+time.Second-1)/time.Second), 10))
+	return delay
+}
+
+// ConformanceServiceHandler is the interface for ConformanceService HTTP handlers.
+type ConformanceServiceHandler interface {
+	// GetEcho binds a path parameter and the query string.
+	HandleGetEcho(w http.ResponseWriter, r *http.Request)
+	// ListEchoes binds the query string only.
+	HandleListEchoes(w http.ResponseWriter, r *http.Request)
+	// CreateEcho binds the whole body, ignoring the query string.
+	HandleCreateEcho(w http.ResponseWriter, r *http.Request)
+	// UpdateEchoNested binds the body to the nested field, and the remaining
+	// fields from a path parameter and the query string.
+	HandleUpdateEchoNested(w http.ResponseWriter, r *http.Request)
+	// SetEchoField binds the body to a repeated or a scalar field, and the
+	// remaining fields from a path parameter and the query string.
+	HandleSetEchoField(w http.ResponseWriter, r *http.Request)
+	// SearchEchoes is a custom method on the collection.
+	HandleSearchEchoes(w http.ResponseWriter, r *http.Request)
+	// GetFile binds a multi-segment path parameter.
+	HandleGetFile(w http.ResponseWriter, r *http.Request)
+	// GetFolderFile binds a path template variable spanning several segments.
+	HandleGetFolderFile(w http.ResponseWriter, r *http.Request)
+}
+
+// RegisterConformanceServiceRoutes registers HTTP routes for ConformanceService.
+// Returns an error if router or handler is nil.
+func RegisterConformanceServiceRoutes(r Routes, handler ConformanceServiceHandler) error {
+	if r == nil {
+		return ErrNilRouter
+	}
+	if handler == nil {
+		return ErrNilHandler
+	}
+	r.HandleFunc(http.MethodGet, "/v1/echoes/{id}", handler.HandleGetEcho)
+	r.HandleFunc(http.MethodGet, "/v1/echoes", handler.HandleListEchoes)
+	r.HandleFunc(http.MethodPost, "/v1/echoes", handler.HandleCreateEcho)
+	r.HandleFunc(http.MethodPut, "/v1/echoes/{id}/nested", handler.HandleUpdateEchoNested)
+	r.HandleFunc(http.MethodPatch, "/v1/echoes/{id}/nested", handler.HandleUpdateEchoNested)
+	r.HandleFunc(http.MethodPut, "/v1/echoes/{id}/tags", handler.HandleSetEchoField)
+	r.HandleFunc(http.MethodPatch, "/v1/echoes/{id}/display-name", handler.HandleSetEchoField)
+	r.HandleFunc(http.MethodPost, "/v1/echoes:search", handler.HandleSearchEchoes)
+	r.HandleFunc(http.MethodGet, "/v1/files/{path...}", handler.HandleGetFile)
+	r.HandleFunc(http.MethodGet, "/v1/folders/{path_1}/files/{path_2}", pathTemplateValues(handler.HandleGetFolderFile, map[string]string{"path": "folders/{path_1}/files/{path_2}"}))
+	return nil
+}
+
+// ConformanceServiceBasePath is the base path of the ConformanceService routes, from its proto
+// package or its (http_server.base_path) option, for RegisterConformanceServiceRoutesAt.
+const ConformanceServiceBasePath = "/conformance.v1"
+
+// RegisterConformanceServiceRoutesAt registers the routes of RegisterConformanceServiceRoutes in a
+// group of r under base, usually ConformanceServiceBasePath, so every service is mounted
+// the same way:
+//
+//	RegisterConformanceServiceRoutesAt(router, ConformanceServiceBasePath, handler)
+//
+// Returns an error if router or handler is nil.
+func RegisterConformanceServiceRoutesAt(r Router, base string, handler ConformanceServiceHandler) error {
+	if r == nil {
+		return ErrNilRouter
+	}
+	return RegisterConformanceServiceRoutes(r.Group(base), handler)
+}
+
+// MustRegisterConformanceServiceRoutes registers HTTP routes for ConformanceService.
+// Panics if router or handler is nil.
+func MustRegisterConformanceServiceRoutes(r Routes, handler ConformanceServiceHandler) {
+	if err := RegisterConformanceServiceRoutes(r, handler); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterConformanceServiceRoutes is a convenience method on RouteGroup.
+//
+// Deprecated: Use RegisterConformanceServiceRoutes(router, handler) instead.
+// This method does not return errors and will not work with Router interface from Group().
+func (g *RouteGroup) RegisterConformanceServiceRoutes(handler ConformanceServiceHandler) {
+	_ = RegisterConformanceServiceRoutes(g, handler)
+}
+
+// RegisterGetEchoRoute registers the GetEcho handler.
+// This registers all HTTP bindings for this method (1 binding(s)).
+// Returns an error if router or handler is nil.
+//
+// GetEcho binds a path parameter and the query string.
+func RegisterGetEchoRoute(r Routes, handler ConformanceServiceHandler, middlewares ...Middleware) error {
+	if r == nil {
+		return ErrNilRouter
+	}
+	if handler == nil {
+		return ErrNilHandler
+	}
+	h := applyMiddlewares(http.HandlerFunc(handler.HandleGetEcho), middlewares)
+	r.HandleFunc(http.MethodGet, "/v1/echoes/{id}", h.ServeHTTP)
+	return nil
+}
+
+// HandlerForGetEcho returns the GetEcho handler wrapped in middlewares
+// and served like its "GET /v1/echoes/{id}" route, for frameworks with their
+// own routing, such as echo, gin or fiber, to register under their syntax for
+// the pattern. Handlers reading path values with r.PathValue need them set
+// from the framework's parameters with r.SetPathValue first.
+func HandlerForGetEcho(handler ConformanceServiceHandler, middlewares ...Middleware) http.Handler {
+	return applyMiddlewares(http.HandlerFunc(handler.HandleGetEcho), middlewares)
+}
+
+// RegisterGetEcho is a convenience method on RouteGroup.
+//
+// Deprecated: Use RegisterGetEchoRoute(router, handler, middlewares...) instead.
+// This method does not return errors and will not work with Router interface from Group().
+func (g *RouteGroup) RegisterGetEcho(handler ConformanceServiceHandler, middlewares ...Middleware) {
+	_ = RegisterGetEchoRoute(g, handler, middlewares...)
+}
+
+// RegisterListEchoesRoute registers the ListEchoes handler.
+// This registers all HTTP bindings for this method (1 binding(s)).
+// Returns an error if router or handler is nil.
+//
+// ListEchoes binds the query string only.
+func RegisterListEchoesRoute(r Routes, handler ConformanceServiceHandler, middlewares ...Middleware) error {
+	if r == nil {
+		return ErrNilRouter
+	}
+	if handler == nil {
+		return ErrNilHandler
+	}
+	h := applyMiddlewares(http.HandlerFunc(handler.HandleListEchoes), middlewares)
+	r.HandleFunc(http.MethodGet, "/v1/echoes", h.ServeHTTP)
+	return nil
+}
+
+// HandlerForListEchoes returns the ListEchoes handler wrapped in middlewares
+// and served like its "GET /v1/echoes" route, for frameworks with their
+// own routing, such as echo, gin or fiber, to register under their syntax for
+// the pattern. Handlers reading path values with r.PathValue need them set
+// from the framework's parameters with r.SetPathValue first.
+func HandlerForListEchoes(handler ConformanceServiceHandler, middlewares ...Middleware) http.Handler {
+	return applyMiddlewares(http.HandlerFunc(handler.HandleListEchoes), middlewares)
+}
+
+// RegisterListEchoes is a convenience method on RouteGroup.
+//
+// Deprecated: Use RegisterListEchoesRoute(router, handler, middlewares...) instead.
+// This method does not return errors and will not work with Router interface from Group().
+func (g *RouteGroup) RegisterListEchoes(handler ConformanceServiceHandler, middlewares ...Middleware) {
+	_ = RegisterListEchoesRoute(g, handler, middlewares...)
+}
+
+// RegisterCreateEchoRoute registers the CreateEcho handler.
+// This registers all HTTP bindings for this method (1 binding(s)).
+// Returns an error if router or handler is nil.
+//
+// CreateEcho binds the whole body, ignoring the query string.
+func RegisterCreateEchoRoute(r Routes, handler ConformanceServiceHandler, middlewares ...Middleware) error {
+	if r == nil {
+		return ErrNilRouter
+	}
+	if handler == nil {
+		return ErrNilHandler
+	}
+	h := applyMiddlewares(http.HandlerFunc(handler.HandleCreateEcho), middlewares)
+	r.HandleFunc(http.MethodPost, "/v1/echoes", h.ServeHTTP)
+	return nil
+}
+
+// HandlerForCreateEcho returns the CreateEcho handler wrapped in middlewares
+// and served like its "POST /v1/echoes" route, for frameworks with their
+// own routing, such as echo, gin or fiber, to register under their syntax for
+// the pattern. Handlers reading path values with r.PathValue need them set
+// from the framework's parameters with r.SetPathValue first.
+func HandlerForCreateEcho(handler ConformanceServiceHandler, middlewares ...Middleware) http.Handler {
+	return applyMiddlewares(http.HandlerFunc(handler.HandleCreateEcho), middlewares)
+}
+
+// RegisterCreateEcho is a convenience method on RouteGroup.
+//
+// Deprecated: Use RegisterCreateEchoRoute(router, handler, middlewares...) instead.
+// This method does not return errors and will not work with Router interface from Group().
+func (g *RouteGroup) RegisterCreateEcho(handler ConformanceServiceHandler, middlewares ...Middleware) {
+	_ = RegisterCreateEchoRoute(g, handler, middlewares...)
+}
+
+// RegisterUpdateEchoNestedRoute registers the UpdateEchoNested handler.
+// This registers all HTTP bindings for this method (2 binding(s)).
+// Returns an error if router or handler is nil.
+//
+// UpdateEchoNested binds the body to the nested field, and the remaining
+// fields from a path parameter and the query string.
+func RegisterUpdateEchoNestedRoute(r Routes, handler ConformanceServiceHandler, middlewares ...Middleware) error {
+	if r == nil {
+		return ErrNilRouter
+	}
+	if handler == nil {
+		return ErrNilHandler
+	}
+	h := applyMiddlewares(http.HandlerFunc(handler.HandleUpdateEchoNested), middlewares)
+	r.HandleFunc(http.MethodPut, "/v1/echoes/{id}/nested", h.ServeHTTP)
+	r.HandleFunc(http.MethodPatch, "/v1/echoes/{id}/nested", h.ServeHTTP)
+	return nil
+}
+
+// HandlerForUpdateEchoNested returns the UpdateEchoNested handler wrapped in middlewares
+// and served like its "PUT /v1/echoes/{id}/nested" route, for frameworks with their
+// own routing, such as echo, gin or fiber, to register under their syntax for
+// the pattern. Handlers reading path values with r.PathValue need them set
+// from the framework's parameters with r.SetPathValue first.
+func HandlerForUpdateEchoNested(handler ConformanceServiceHandler, middlewares ...Middleware) http.Handler {
+	return applyMiddlewares(http.HandlerFunc(handler.HandleUpdateEchoNested), middlewares)
+}
+
+// RegisterUpdateEchoNested is a convenience method on RouteGroup.
+//
+// Deprecated: Use RegisterUpdateEchoNestedRoute(router, handler, middlewares...) instead.
+// This method does not return errors and will not work with Router interface from Group().
+func (g *RouteGroup) RegisterUpdateEchoNested(handler ConformanceServiceHandler, middlewares ...Middleware) {
+	_ = RegisterUpdateEchoNestedRoute(g, handler, middlewares...)
+}
+
+// RegisterSetEchoFieldRoute registers the SetEchoField handler.
+// This registers all HTTP bindings for this method (2 binding(s)).
+// Returns an error if router or handler is nil.
+//
+// SetEchoField binds the body to a repeated or a scalar field, and the
+// remaining fields from a path parameter and the query string.
+func RegisterSetEchoFieldRoute(r Routes, handler ConformanceServiceHandler, middlewares ...Middleware) error {
+	if r == nil {
+		return ErrNilRouter
+	}
+	if handler == nil {
+		return ErrNilHandler
+	}
+	h := applyMiddlewares(http.HandlerFunc(handler.HandleSetEchoField), middlewares)
+	r.HandleFunc(http.MethodPut, "/v1/echoes/{id}/tags", h.ServeHTTP)
+	r.HandleFunc(http.MethodPatch, "/v1/echoes/{id}/display-name", h.ServeHTTP)
+	return nil
+}
+
+// HandlerForSetEchoField returns the SetEchoField handler wrapped in middlewares
+// and served like its "PUT /v1/echoes/{id}/tags" route, for frameworks with their
+// own routing, such as echo, gin or fiber, to register under their syntax for
+// the pattern. Handlers reading path values with r.PathValue need them set
+// from the framework's parameters with r.SetPathValue first.
+func HandlerForSetEchoField(handler ConformanceServiceHandler, middlewares ...Middleware) http.Handler {
+	return applyMiddlewares(http.HandlerFunc(handler.HandleSetEchoField), middlewares)
+}
+
+// RegisterSetEchoField is a convenience method on RouteGroup.
+//
+// Deprecated: Use RegisterSetEchoFieldRoute(router, handler, middlewares...) instead.
+// This method does not return errors and will not work with Router interface from Group().
+func (g *RouteGroup) RegisterSetEchoField(handler ConformanceServiceHandler, middlewares ...Middleware) {
+	_ = RegisterSetEchoFieldRoute(g, handler, middlewares...)
+}
+
+// RegisterSearchEchoesRoute registers the SearchEchoes handler.
+// This registers all HTTP bindings for this method (1 binding(s)).
+// Returns an error if router or handler is nil.
+//
+// SearchEchoes is a custom method on the collection.
+func RegisterSearchEchoesRoute(r Routes, handler ConformanceServiceHandler, middlewares ...Middleware) error {
+	if r == nil {
+		return ErrNilRouter
+	}
+	if handler == nil {
+		return ErrNilHandler
+	}
+	h := applyMiddlewares(http.HandlerFunc(handler.HandleSearchEchoes), middlewares)
+	r.HandleFunc(http.MethodPost, "/v1/echoes:search", h.ServeHTTP)
+	return nil
+}
+
+// HandlerForSearchEchoes returns the SearchEchoes handler wrapped in middlewares
+// and served like its "POST /v1/echoes:search" route, for frameworks with their
+// own routing, such as echo, gin or fiber, to register under their syntax for
+// the pattern. Handlers reading path values with r.PathValue need them set
+// from the framework's parameters with r.SetPathValue first.
+func HandlerForSearchEchoes(handler ConformanceServiceHandler, middlewares ...Middleware) http.Handler {
+	return applyMiddlewares(http.HandlerFunc(handler.HandleSearchEchoes), middlewares)
+}
+
+// RegisterSearchEchoes is a convenience method on RouteGroup.
+//
+// Deprecated: Use RegisterSearchEchoesRoute(router, handler, middlewares...) instead.
+// This method does not return errors and will not work with Router interface from Group().
+func (g *RouteGroup) RegisterSearchEchoes(handler ConformanceServiceHandler, middlewares ...Middleware) {
+	_ = RegisterSearchEchoesRoute(g, handler, middlewares...)
+}
+
+// RegisterGetFileRoute registers the GetFile handler.
+// This registers all HTTP bindings for this method (1 binding(s)).
+// Returns an error if router or handler is nil.
+//
+// GetFile binds a multi-segment path parameter.
+func RegisterGetFileRoute(r Routes, handler ConformanceServiceHandler, middlewares ...Middleware) error {
+	if r == nil {
+		return ErrNilRouter
+	}
+	if handler == nil {
+		return ErrNilHandler
+	}
+	h := applyMiddlewares(http.HandlerFunc(handler.HandleGetFile), middlewares)
+	r.HandleFunc(http.MethodGet, "/v1/files/{path...}", h.ServeHTTP)
+	return nil
+}
+
+// HandlerForGetFile returns the GetFile handler wrapped in middlewares
+// and served like its "GET /v1/files/{path...}" route, for frameworks with their
+// own routing, such as echo, gin or fiber, to register under their syntax for
+// the pattern. Handlers reading path values with r.PathValue need them set
+// from the framework's parameters with r.SetPathValue first.
+func HandlerForGetFile(handler ConformanceServiceHandler, middlewares ...Middleware) http.Handler {
+	return applyMiddlewares(http.HandlerFunc(handler.HandleGetFile), middlewares)
+}
+
+// RegisterGetFile is a convenience method on RouteGroup.
+//
+// Deprecated: Use RegisterGetFileRoute(router, handler, middlewares...) instead.
+// This method does not return errors and will not work with Router interface from Group().
+func (g *RouteGroup) RegisterGetFile(handler ConformanceServiceHandler, middlewares ...Middleware) {
+	_ = RegisterGetFileRoute(g, handler, middlewares...)
+}
+
+// RegisterGetFolderFileRoute registers the GetFolderFile handler.
+// This registers all HTTP bindings for this method (1 binding(s)).
+// Returns an error if router or handler is nil.
+//
+// GetFolderFile binds a path template variable spanning several segments.
+func RegisterGetFolderFileRoute(r Routes, handler ConformanceServiceHandler, middlewares ...Middleware) error {
+	if r == nil {
+		return ErrNilRouter
+	}
+	if handler == nil {
+		return ErrNilHandler
+	}
+	h := applyMiddlewares(http.HandlerFunc(handler.HandleGetFolderFile), middlewares)
+	r.HandleFunc(http.MethodGet, "/v1/folders/{path_1}/files/{path_2}", pathTemplateValues(h.ServeHTTP, map[string]string{"path": "folders/{path_1}/files/{path_2}"}))
+	return nil
+}
+
+// HandlerForGetFolderFile returns the GetFolderFile handler wrapped in middlewares
+// and served like its "GET /v1/folders/{path_1}/files/{path_2}" route, for frameworks with their
+// own routing, such as echo, gin or fiber, to register under their syntax for
+// the pattern. Handlers reading path values with r.PathValue need them set
+// from the framework's parameters with r.SetPathValue first.
+// Path template variables are set whole under their field path: "path",
+// not the wildcards of "folders/{path_1}/files/{path_2}".
+func HandlerForGetFolderFile(handler ConformanceServiceHandler, middlewares ...Middleware) http.Handler {
+	return applyMiddlewares(http.HandlerFunc(handler.HandleGetFolderFile), middlewares)
+}
+
+// RegisterGetFolderFile is a convenience method on RouteGroup.
+//
+// Deprecated: Use RegisterGetFolderFileRoute(router, handler, middlewares...) instead.
+// This method does not return errors and will not work with Router interface from Group().
+func (g *RouteGroup) RegisterGetFolderFile(handler ConformanceServiceHandler, middlewares ...Middleware) {
+	_ = RegisterGetFolderFileRoute(g, handler, middlewares...)
+}
+
+// ConformanceServiceTypedHandler is the typed form of ConformanceServiceHandler: each method
+// receives the decoded request message and returns the response message, with
+// the same signatures as a gRPC server for ConformanceService. Use NewConformanceServiceHandler
+// to serve it over HTTP.
+type ConformanceServiceTypedHandler interface {
+	// GetEcho binds a path parameter and the query string.
+	GetEcho(ctx context.Context, req *EchoMessage) (*EchoMessage, error)
+	// ListEchoes binds the query string only.
+	ListEchoes(ctx context.Context, req *EchoMessage) (*EchoMessage, error)
+	// CreateEcho binds the whole body, ignoring the query string.
+	CreateEcho(ctx context.Context, req *EchoMessage) (*EchoMessage, error)
+	// UpdateEchoNested binds the body to the nested field, and the remaining
+	// fields from a path parameter and the query string.
+	UpdateEchoNested(ctx context.Context, req *EchoMessage) (*EchoMessage, error)
+	// SetEchoField binds the body to a repeated or a scalar field, and the
+	// remaining fields from a path parameter and the query string.
+	SetEchoField(ctx context.Context, req *EchoMessage) (*EchoMessage, error)
+	// SearchEchoes is a custom method on the collection.
+	SearchEchoes(ctx context.Context, req *EchoMessage) (*EchoMessage, error)
+	// GetFile binds a multi-segment path parameter.
+	GetFile(ctx context.Context, req *EchoMessage) (*EchoMessage, error)
+	// GetFolderFile binds a path template variable spanning several segments.
+	GetFolderFile(ctx context.Context, req *EchoMessage) (*EchoMessage, error)
+}
+
+// NewConformanceServiceHandler adapts srv to ConformanceServiceHandler. Each route binds the
+// HTTP request into the method's request message, calls srv through the
+// interceptors, and writes the response message as JSON.
+func NewConformanceServiceHandler(srv ConformanceServiceTypedHandler, interceptors ...UnaryInterceptor) ConformanceServiceHandler {
+	return &conformanceServiceTypedAdapter{srv: srv, interceptor: ChainUnaryInterceptors(interceptors...)}
+}
+
+// conformanceServiceTypedAdapter implements ConformanceServiceHandler on top of a ConformanceServiceTypedHandler.
+type conformanceServiceTypedAdapter struct {
+	srv         ConformanceServiceTypedHandler
+	interceptor UnaryInterceptor
+}
+
+// HandleGetEcho serves GetEcho through the typed handler.
+func (a *conformanceServiceTypedAdapter) HandleGetEcho(w http.ResponseWriter, r *http.Request) {
+	serveUnary(w, r, "/conformance.v1.ConformanceService/GetEcho", &EchoMessage{}, "", []string{"id"}, a.interceptor,
+		func(ctx context.Context, req proto.Message) (proto.Message, error) {
+			return a.srv.GetEcho(ctx, req.(*EchoMessage))
+		})
+}
+
+// HandleListEchoes serves ListEchoes through the typed handler.
+func (a *conformanceServiceTypedAdapter) HandleListEchoes(w http.ResponseWriter, r *http.Request) {
+	serveUnary(w, r, "/conformance.v1.ConformanceService/ListEchoes", &EchoMessage{}, "", []string{}, a.interceptor,
+		func(ctx context.Context, req proto.Message) (proto.Message, error) {
+			return a.srv.ListEchoes(ctx, req.(*EchoMessage))
+		})
+}
+
+// HandleCreateEcho serves CreateEcho through the typed handler.
+func (a *conformanceServiceTypedAdapter) HandleCreateEcho(w http.ResponseWriter, r *http.Request) {
+	serveUnary(w, r, "/conformance.v1.ConformanceService/CreateEcho", &EchoMessage{}, "*", []string{}, a.interceptor,
+		func(ctx context.Context, req proto.Message) (proto.Message, error) {
+			return a.srv.CreateEcho(ctx, req.(*EchoMessage))
+		})
+}
+
+// HandleUpdateEchoNested serves UpdateEchoNested through the typed handler.
+func (a *conformanceServiceTypedAdapter) HandleUpdateEchoNested(w http.ResponseWriter, r *http.Request) {
+	serveUnary(w, r, "/conformance.v1.ConformanceService/UpdateEchoNested", &EchoMessage{}, "nested", []string{"id"}, a.interceptor,
+		func(ctx context.Context, req proto.Message) (proto.Message, error) {
+			return a.srv.UpdateEchoNested(ctx, req.(*EchoMessage))
+		})
+}
+
+// HandleSetEchoField serves SetEchoField through the typed handler.
+func (a *conformanceServiceTypedAdapter) HandleSetEchoField(w http.ResponseWriter, r *http.Request) {
+	body := ""
+	switch r.Method {
+	case http.MethodPut:
+		body = "tags"
+	case http.MethodPatch:
+		body = "display_name"
+	}
+	serveUnary(w, r, "/conformance.v1.ConformanceService/SetEchoField", &EchoMessage{}, body, []string{"id"}, a.interceptor,
+		func(ctx context.Context, req proto.Message) (proto.Message, error) {
+			return a.srv.SetEchoField(ctx, req.(*EchoMessage))
+		})
+}
+
+// HandleSearchEchoes serves SearchEchoes through the typed handler.
+func (a *conformanceServiceTypedAdapter) HandleSearchEchoes(w http.ResponseWriter, r *http.Request) {
+	serveUnary(w, r, "/conformance.v1.ConformanceService/SearchEchoes", &EchoMessage{}, "*", []string{}, a.interceptor,
+		func(ctx context.Context, req proto.Message) (proto.Message, error) {
+			return a.srv.SearchEchoes(ctx, req.(*EchoMessage))
+		})
+}
+
+// HandleGetFile serves GetFile through the typed handler.
+func (a *conformanceServiceTypedAdapter) HandleGetFile(w http.ResponseWriter, r *http.Request) {
+	serveUnary(w, r, "/conformance.v1.ConformanceService/GetFile", &EchoMessage{}, "", []string{"path"}, a.interceptor,
+		func(ctx context.Context, req proto.Message) (proto.Message, error) {
+			return a.srv.GetFile(ctx, req.(*EchoMessage))
+		})
+}
+
+// HandleGetFolderFile serves GetFolderFile through the typed handler.
+func (a *conformanceServiceTypedAdapter) HandleGetFolderFile(w http.ResponseWriter, r *http.Request) {
+	serveUnary(w, r, "/conformance.v1.ConformanceService/GetFolderFile", &EchoMessage{}, "", []string{"path"}, a.interceptor,
+		func(ctx context.Context, req proto.Message) (proto.Message, error) {
+			return a.srv.GetFolderFile(ctx, req.(*EchoMessage))
+		})
+}
@@ -0,0 +1,500 @@
+// Package doctor diagnoses the setup protoc-gen-go-http-server-interface runs
+// in: the protoc that invokes it, the googleapis protos declaring the HTTP
+// annotations, the Go module the generated code builds in, the buf
+// configuration and the proto files themselves. Most reports of the plugin
+// producing no output come down to one of these.
+package doctor
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Status is the outcome of a check.
+type Status int
+
+const (
+	// OK means the check found nothing wrong.
+	OK Status = iota
+	// Warn means generation works but may not do what is expected.
+	Warn
+	// Fail means generation fails or produces code that does not build or route.
+	Fail
+)
+
+// String returns the label Report prints for s.
+func (s Status) String() string {
+	switch s {
+	case OK:
+		return "ok"
+	case Warn:
+		return "warn"
+	default:
+		return "fail"
+	}
+}
+
+// Result is the outcome of one check.
+type Result struct {
+	// Check names what was checked, such as "protoc".
+	Check string
+	// Status is the outcome.
+	Status Status
+	// Message describes what was found.
+	Message string
+	// Fix describes how to resolve a warning or failure.
+	Fix string
+}
+
+// Doctor runs the checks against a project directory.
+type Doctor struct {
+	// Dir is the project directory, usually the working directory.
+	Dir string
+	// ImportPaths are the proto import paths passed to protoc with -I,
+	// searched for the googleapis protos besides Dir and protoc's own include
+	// directory.
+	ImportPaths []string
+	// Files are the proto files checked. When empty, the .proto files under
+	// Dir are.
+	Files []string
+	// LookPath finds an executable; nil means exec.LookPath.
+	LookPath func(file string) (string, error)
+	// Output runs a command and returns its standard output; nil means
+	// exec.Command(name, args...).Output.
+	Output func(name string, args ...string) ([]byte, error)
+}
+
+// Run runs every check and returns their results in order.
+func (d *Doctor) Run() []Result {
+	files := d.protoFiles()
+	protoc, protocResult := d.checkProtoc(files)
+	return []Result{
+		protocResult,
+		d.checkGoogleapis(protoc),
+		d.checkGo(),
+		d.checkGoModule(),
+		d.checkBufGen(),
+		checkProtoFiles(files),
+	}
+}
+
+// Report writes results to w with their fixes, and reports whether none failed.
+func Report(w io.Writer, results []Result) bool {
+	ok := true
+	for _, r := range results {
+		fmt.Fprintf(w, "%-4s  %s: %s\n", r.Status, r.Check, r.Message)
+		if r.Status != OK && r.Fix != "" {
+			fmt.Fprintf(w, "      fix: %s\n", r.Fix)
+		}
+		if r.Status == Fail {
+			ok = false
+		}
+	}
+	return ok
+}
+
+func (d *Doctor) lookPath(file string) (string, error) {
+	if d.LookPath != nil {
+		return d.LookPath(file)
+	}
+	return exec.LookPath(file)
+}
+
+func (d *Doctor) output(name string, args ...string) ([]byte, error) {
+	if d.Output != nil {
+		return d.Output(name, args...)
+	}
+	return exec.Command(name, args...).Output()
+}
+
+// protocVersionRegex matches the version printed by protoc --version.
+var protocVersionRegex = regexp.MustCompile(`libprotoc (\d+)\.(\d+)`)
+
+// checkProtoc finds protoc and checks that it supports proto3 optional
+// fields and, when files use them, editions. It returns the path of protoc,
+// or "" when it is not installed.
+func (d *Doctor) checkProtoc(files []protoFile) (string, Result) {
+	result := Result{Check: "protoc"}
+	path, err := d.lookPath("protoc")
+	if err != nil {
+		if buf, err := d.lookPath("buf"); err == nil {
+			result.Message = "not found, but buf is (" + buf + "), which compiles protos itself"
+			return "", result
+		}
+		result.Status = Fail
+		result.Message = "neither protoc nor buf is on PATH"
+		result.Fix = "install buf (https://buf.build/docs/installation) or protoc (https://protobuf.dev/installation/)"
+		return "", result
+	}
+
+	out, err := d.output(path, "--version")
+	m := protocVersionRegex.FindStringSubmatch(string(out))
+	if err != nil || m == nil {
+		result.Status = Warn
+		result.Message = fmt.Sprintf("%s: cannot read its version", path)
+		result.Fix = "check that " + path + " --version prints libprotoc <version>"
+		return path, result
+	}
+	major, _ := strconv.Atoi(m[1])
+	minor, _ := strconv.Atoi(m[2])
+	// Releases after 3.20 are numbered 21, 22 and so on.
+	release := major
+	if major == 3 {
+		release = minor
+	}
+
+	result.Message = fmt.Sprintf("libprotoc %s.%s (%s)", m[1], m[2], path)
+	switch {
+	case release < 15:
+		result.Status = Warn
+		result.Message += " does not support proto3 optional fields"
+		result.Fix = "upgrade protoc to 3.15 or newer"
+	case release < 27 && usesEditions(files):
+		result.Status = Fail
+		result.Message += " does not support editions, which the proto files use"
+		result.Fix = "upgrade protoc to 27.0 or newer, or generate with buf"
+	}
+	return path, result
+}
+
+// checkGoogleapis looks for google/api/annotations.proto and http.proto in
+// the import paths, and for the googleapis dependency in buf.yaml.
+func (d *Doctor) checkGoogleapis(protoc string) Result {
+	result := Result{Check: "googleapis"}
+	if data, err := os.ReadFile(filepath.Join(d.Dir, "buf.yaml")); err == nil &&
+		strings.Contains(string(data), "buf.build/googleapis/googleapis") {
+		result.Message = "buf.yaml depends on buf.build/googleapis/googleapis"
+		return result
+	}
+
+	dirs := append([]string{d.Dir}, d.ImportPaths...)
+	if protoc != "" {
+		dirs = append(dirs, filepath.Join(filepath.Dir(filepath.Dir(protoc)), "include"))
+	}
+	for _, dir := range dirs {
+		annotations := filepath.Join(dir, "google", "api", "annotations.proto")
+		if !fileExists(annotations) {
+			continue
+		}
+		if !fileExists(filepath.Join(dir, "google", "api", "http.proto")) {
+			result.Status = Fail
+			result.Message = "found " + annotations + " but not the http.proto it imports"
+			result.Fix = "copy google/api/http.proto from https://github.com/googleapis/googleapis next to annotations.proto"
+			return result
+		}
+		result.Message = "found " + annotations
+		return result
+	}
+
+	result.Status = Fail
+	result.Message = "google/api/annotations.proto is not in " + strings.Join(dirs, ", ")
+	result.Fix = "add buf.build/googleapis/googleapis to the deps of buf.yaml, or copy google/api/annotations.proto " +
+		"and http.proto from https://github.com/googleapis/googleapis into a directory passed with -I"
+	return result
+}
+
+// goVersionRegex matches a Go version such as go1.23.4 or a go.mod go directive.
+var goVersionRegex = regexp.MustCompile(`(?:go|^)1\.(\d+)`)
+
+// minGoMinor is the minor version of the oldest Go release the generated
+// code supports; it needs the method and wildcard patterns of http.ServeMux.
+const minGoMinor = 22
+
+// checkGo checks that the installed Go toolchain builds the generated code.
+func (d *Doctor) checkGo() Result {
+	result := Result{Check: "go"}
+	out, err := d.output("go", "env", "GOVERSION")
+	if err != nil {
+		result.Status = Fail
+		result.Message = "cannot run go env GOVERSION: " + err.Error()
+		result.Fix = "install Go 1.22 or newer (https://go.dev/dl/)"
+		return result
+	}
+	version := strings.TrimSpace(string(out))
+	result.Message = version
+	if m := goVersionRegex.FindStringSubmatch(version); m != nil {
+		if minor, _ := strconv.Atoi(m[1]); minor < minGoMinor {
+			result.Status = Fail
+			result.Message += " cannot build the generated code, which routes with Go 1.22 http.ServeMux patterns"
+			result.Fix = "install Go 1.22 or newer (https://go.dev/dl/)"
+		}
+	}
+	return result
+}
+
+// checkGoModule checks the go.mod of the module containing Dir: ServeMux
+// treats patterns as literal paths in modules declaring a go version below
+// 1.22, and the generated .pb.go files need google.golang.org/protobuf.
+func (d *Doctor) checkGoModule() Result {
+	result := Result{Check: "go.mod"}
+	path := findGoMod(d.Dir)
+	if path == "" {
+		result.Status = Warn
+		result.Message = "no go.mod in " + d.Dir + " or its parents"
+		result.Fix = "run go mod init <module path> where the generated code is built"
+		return result
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		result.Status = Warn
+		result.Message = err.Error()
+		return result
+	}
+
+	result.Message = path
+	for _, line := range strings.Split(string(data), "\n") {
+		directive, ok := strings.CutPrefix(strings.TrimSpace(line), "go ")
+		if !ok {
+			continue
+		}
+		m := goVersionRegex.FindStringSubmatch(strings.TrimSpace(directive))
+		if m == nil {
+			continue
+		}
+		if minor, _ := strconv.Atoi(m[1]); minor < minGoMinor {
+			result.Status = Fail
+			result.Message += " declares go " + strings.TrimSpace(directive) +
+				", so http.ServeMux matches patterns such as GET /v1/{id} as literal paths and every route answers 404"
+			result.Fix = "run go mod edit -go=1.22 (or newer)"
+			return result
+		}
+	}
+	if !strings.Contains(string(data), "google.golang.org/protobuf") {
+		result.Status = Warn
+		result.Message += " does not require google.golang.org/protobuf, which the generated .pb.go files import"
+		result.Fix = "run go get google.golang.org/protobuf"
+	}
+	return result
+}
+
+// findGoMod returns the go.mod of the module containing dir, or "".
+func findGoMod(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		if path := filepath.Join(dir, "go.mod"); fileExists(path) {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// bufPlugin is a plugin entry of buf.gen.yaml, in version v1 or v2.
+type bufPlugin struct {
+	Remote string `json:"remote"`
+	Local  any    `json:"local"`
+	Name   string `json:"name"`
+	Plugin string `json:"plugin"`
+	Out    string `json:"out"`
+	Opt    any    `json:"opt"`
+}
+
+// id returns the remote, local or named plugin p runs.
+func (p bufPlugin) id() string {
+	switch local := p.Local.(type) {
+	case string:
+		return local
+	case []any:
+		if len(local) > 0 {
+			return fmt.Sprint(local[0])
+		}
+	}
+	return p.Remote + p.Name + p.Plugin
+}
+
+// paths returns the paths option of p, or "import" when unset.
+func (p bufPlugin) paths() string {
+	var opts []string
+	switch opt := p.Opt.(type) {
+	case string:
+		opts = strings.Split(opt, ",")
+	case []any:
+		for _, o := range opt {
+			opts = append(opts, strings.Split(fmt.Sprint(o), ",")...)
+		}
+	}
+	for _, o := range opts {
+		if v, ok := strings.CutPrefix(strings.TrimSpace(o), "paths="); ok {
+			return v
+		}
+	}
+	return "import"
+}
+
+// checkBufGen checks that buf.gen.yaml, when present, runs the plugin next
+// to protoc-gen-go with the same out directory and paths option, so the
+// generated files land in the same Go package.
+func (d *Doctor) checkBufGen() Result {
+	result := Result{Check: "buf.gen.yaml"}
+	data, err := os.ReadFile(filepath.Join(d.Dir, "buf.gen.yaml"))
+	if err != nil {
+		result.Message = "not used"
+		return result
+	}
+	var config struct {
+		Plugins []bufPlugin `json:"plugins"`
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		result.Status = Fail
+		result.Message = err.Error()
+		result.Fix = "fix the YAML syntax of buf.gen.yaml"
+		return result
+	}
+
+	var gen, goGen *bufPlugin
+	for i, p := range config.Plugins {
+		id := p.id()
+		switch {
+		case strings.Contains(id, "go-http-server-interface"):
+			gen = &config.Plugins[i]
+		case id == "go" || strings.HasSuffix(id, "protoc-gen-go") || strings.HasSuffix(id, "protocolbuffers/go"):
+			goGen = &config.Plugins[i]
+		}
+	}
+	switch {
+	case gen == nil:
+		result.Status = Warn
+		result.Message = "does not run protoc-gen-go-http-server-interface"
+		result.Fix = "add a plugin with local: protoc-gen-go-http-server-interface and the out and opt of protoc-gen-go"
+	case goGen == nil:
+		result.Status = Warn
+		result.Message = "does not run protoc-gen-go, whose message types the generated code uses"
+		result.Fix = "add a plugin with remote: buf.build/protocolbuffers/go"
+	case gen.Out != goGen.Out || gen.paths() != goGen.paths():
+		result.Status = Fail
+		result.Message = fmt.Sprintf("protoc-gen-go writes to %s with paths=%s but protoc-gen-go-http-server-interface "+
+			"writes to %s with paths=%s, so their files land in different packages",
+			goGen.Out, goGen.paths(), gen.Out, gen.paths())
+		result.Fix = "give both plugins the same out and paths option"
+	default:
+		result.Message = "runs protoc-gen-go-http-server-interface next to protoc-gen-go"
+	}
+	return result
+}
+
+// protoFile is a proto file read for checking.
+type protoFile struct {
+	path string
+	// source is the file without comments.
+	source string
+}
+
+// commentRegex matches proto comments.
+var commentRegex = regexp.MustCompile(`(?s)//[^\n]*|/\*.*?\*/`)
+
+// protoFiles reads d.Files, or the .proto files under d.Dir outside hidden
+// directories and copies of the googleapis and well-known protos.
+func (d *Doctor) protoFiles() []protoFile {
+	paths := d.Files
+	if len(paths) == 0 {
+		_ = filepath.WalkDir(d.Dir, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			name := entry.Name()
+			if entry.IsDir() {
+				if path != d.Dir && (strings.HasPrefix(name, ".") || name == "node_modules" || name == "vendor") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			slashed := filepath.ToSlash(path)
+			if strings.HasSuffix(name, ".proto") &&
+				!strings.Contains(slashed, "google/api/") && !strings.Contains(slashed, "google/protobuf/") {
+				if rel, err := filepath.Rel(d.Dir, path); err == nil {
+					path = rel
+				}
+				paths = append(paths, path)
+			}
+			return nil
+		})
+	}
+
+	files := make([]protoFile, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil && !filepath.IsAbs(path) {
+			data, err = os.ReadFile(filepath.Join(d.Dir, path))
+		}
+		if err != nil {
+			continue
+		}
+		files = append(files, protoFile{path: path, source: commentRegex.ReplaceAllString(string(data), "")})
+	}
+	return files
+}
+
+// usesEditions reports whether any of files declares an edition.
+func usesEditions(files []protoFile) bool {
+	for _, f := range files {
+		if editionRegex.MatchString(f.source) {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	editionRegex     = regexp.MustCompile(`(?m)^\s*edition\s*=`)
+	serviceRegex     = regexp.MustCompile(`(?m)^\s*service\s+\w+`)
+	annotationsRegex = regexp.MustCompile(`import\s+(?:public\s+)?"google/api/annotations\.proto"`)
+)
+
+// checkProtoFiles checks that files using the google.api.http option import
+// it, and notes files declaring services without HTTP bindings, for which
+// the plugin generates nothing.
+func checkProtoFiles(files []protoFile) Result {
+	result := Result{Check: "proto files"}
+	if len(files) == 0 {
+		result.Status = Warn
+		result.Message = "no .proto files found"
+		result.Fix = "run --doctor from the directory holding your protos, or pass them as arguments"
+		return result
+	}
+
+	var missingImport, unannotated []string
+	for _, f := range files {
+		annotated := strings.Contains(f.source, "google.api.http")
+		switch {
+		case annotated && !annotationsRegex.MatchString(f.source):
+			missingImport = append(missingImport, f.path)
+		case !annotated && serviceRegex.MatchString(f.source):
+			unannotated = append(unannotated, f.path)
+		}
+	}
+	switch {
+	case len(missingImport) > 0:
+		result.Status = Fail
+		result.Message = "use option (google.api.http) without importing google/api/annotations.proto: " +
+			strings.Join(missingImport, ", ")
+		result.Fix = `add import "google/api/annotations.proto"; to each of them`
+	case len(unannotated) > 0:
+		result.Status = Warn
+		result.Message = "declare services without google.api.http bindings, so no _http.pb.go is generated for them: " +
+			strings.Join(unannotated, ", ")
+		result.Fix = `annotate their rpcs with option (google.api.http) = {get: "/v1/..."}, or bind them with grpc_api_configuration`
+	default:
+		result.Message = fmt.Sprintf("%d checked", len(files))
+	}
+	return result
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,282 @@
+package doctor
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFiles writes files, keyed by slash-separated path, under dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// fakeDoctor returns a Doctor for dir whose PATH holds the executables in
+// bins and whose commands print the outputs keyed by command line.
+func fakeDoctor(dir string, bins []string, outputs map[string]string) *Doctor {
+	return &Doctor{
+		Dir: dir,
+		LookPath: func(file string) (string, error) {
+			for _, bin := range bins {
+				if bin == file {
+					return "/opt/protobuf/bin/" + file, nil
+				}
+			}
+			return "", errors.New("not found")
+		},
+		Output: func(name string, args ...string) ([]byte, error) {
+			out, ok := outputs[strings.Join(append([]string{filepath.Base(name)}, args...), " ")]
+			if !ok {
+				return nil, errors.New("exit status 1")
+			}
+			return []byte(out), nil
+		},
+	}
+}
+
+const (
+	annotatedProto = `syntax = "proto3";
+import "google/api/annotations.proto";
+service Tasks {
+  rpc Get(Req) returns (Req) { option (google.api.http) = {get: "/v1/{id}"}; }
+}
+`
+	goMod = "module example.com/tasks\n\ngo 1.23\n\nrequire google.golang.org/protobuf v1.36.8\n"
+)
+
+func TestDoctor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		bins    []string
+		outputs map[string]string
+		files   map[string]string
+		check   string
+		status  Status
+		message string
+	}{
+		{
+			name:    "protoc found",
+			bins:    []string{"protoc"},
+			outputs: map[string]string{"protoc --version": "libprotoc 25.1\n"},
+			check:   "protoc",
+			status:  OK,
+			message: "libprotoc 25.1 (/opt/protobuf/bin/protoc)",
+		},
+		{
+			name:    "buf instead of protoc",
+			bins:    []string{"buf"},
+			check:   "protoc",
+			status:  OK,
+			message: "not found, but buf is",
+		},
+		{
+			name:    "no compiler",
+			check:   "protoc",
+			status:  Fail,
+			message: "neither protoc nor buf",
+		},
+		{
+			name:    "protoc without proto3 optional",
+			bins:    []string{"protoc"},
+			outputs: map[string]string{"protoc --version": "libprotoc 3.12.4\n"},
+			check:   "protoc",
+			status:  Warn,
+			message: "does not support proto3 optional fields",
+		},
+		{
+			name:    "protoc without editions",
+			bins:    []string{"protoc"},
+			outputs: map[string]string{"protoc --version": "libprotoc 3.21.12\n"},
+			files:   map[string]string{"proto/tasks.proto": "edition = \"2023\";\n"},
+			check:   "protoc",
+			status:  Fail,
+			message: "does not support editions",
+		},
+		{
+			name:    "googleapis missing",
+			bins:    []string{"protoc"},
+			outputs: map[string]string{"protoc --version": "libprotoc 25.1\n"},
+			check:   "googleapis",
+			status:  Fail,
+			message: "is not in",
+		},
+		{
+			name:    "googleapis from buf",
+			files:   map[string]string{"buf.yaml": "version: v2\ndeps:\n  - buf.build/googleapis/googleapis\n"},
+			check:   "googleapis",
+			status:  OK,
+			message: "buf.yaml depends on",
+		},
+		{
+			name:    "googleapis copy without http.proto",
+			files:   map[string]string{"google/api/annotations.proto": ""},
+			check:   "googleapis",
+			status:  Fail,
+			message: "but not the http.proto it imports",
+		},
+		{
+			name:    "old go",
+			outputs: map[string]string{"go env GOVERSION": "go1.21.6\n"},
+			check:   "go",
+			status:  Fail,
+			message: "go1.21.6 cannot build the generated code",
+		},
+		{
+			name:    "current go",
+			outputs: map[string]string{"go env GOVERSION": "go1.23.4\n"},
+			check:   "go",
+			status:  OK,
+			message: "go1.23.4",
+		},
+		{
+			name:    "module before ServeMux patterns",
+			files:   map[string]string{"go.mod": "module example.com/tasks\n\ngo 1.21\n"},
+			check:   "go.mod",
+			status:  Fail,
+			message: "declares go 1.21, so http.ServeMux matches patterns",
+		},
+		{
+			name:    "module without protobuf",
+			files:   map[string]string{"go.mod": "module example.com/tasks\n\ngo 1.22.0\n"},
+			check:   "go.mod",
+			status:  Warn,
+			message: "does not require google.golang.org/protobuf",
+		},
+		{
+			name:    "module",
+			files:   map[string]string{"go.mod": goMod},
+			check:   "go.mod",
+			status:  OK,
+		},
+		{
+			name: "buf.gen.yaml paths mismatch",
+			files: map[string]string{"buf.gen.yaml": `version: v2
+plugins:
+  - remote: buf.build/protocolbuffers/go
+    out: pb
+    opt: paths=source_relative
+  - local: protoc-gen-go-http-server-interface
+    out: pb
+`},
+			check:   "buf.gen.yaml",
+			status:  Fail,
+			message: "protoc-gen-go writes to pb with paths=source_relative but protoc-gen-go-http-server-interface writes to pb with paths=import",
+		},
+		{
+			name: "buf.gen.yaml v1",
+			files: map[string]string{"buf.gen.yaml": `version: v1
+plugins:
+  - plugin: go
+    out: pb
+    opt: paths=source_relative
+  - plugin: go-http-server-interface
+    out: pb
+    opt:
+      - paths=source_relative
+      - binding=true
+`},
+			check:   "buf.gen.yaml",
+			status:  OK,
+			message: "runs protoc-gen-go-http-server-interface next to protoc-gen-go",
+		},
+		{
+			name: "buf.gen.yaml without the plugin",
+			files: map[string]string{"buf.gen.yaml": `version: v2
+plugins:
+  - remote: buf.build/protocolbuffers/go
+    out: pb
+`},
+			check:   "buf.gen.yaml",
+			status:  Warn,
+			message: "does not run protoc-gen-go-http-server-interface",
+		},
+		{
+			name: "missing annotations import",
+			files: map[string]string{
+				"proto/tasks.proto": strings.Replace(annotatedProto, `import "google/api/annotations.proto";`, "", 1),
+				"proto/users.proto": annotatedProto,
+			},
+			check:   "proto files",
+			status:  Fail,
+			message: "without importing google/api/annotations.proto: " + filepath.Join("proto", "tasks.proto"),
+		},
+		{
+			name: "unannotated service",
+			files: map[string]string{
+				"proto/tasks.proto": "syntax = \"proto3\";\n// option (google.api.http)\nservice Tasks {}\n",
+			},
+			check:   "proto files",
+			status:  Warn,
+			message: "declare services without google.api.http bindings",
+		},
+		{
+			name: "annotated protos",
+			files: map[string]string{
+				"proto/tasks.proto":                    annotatedProto,
+				"third_party/google/api/http.proto":    "service Ignored {}\n",
+				".cache/proto/unannotated/users.proto": "service Ignored {}\n",
+			},
+			check:   "proto files",
+			status:  OK,
+			message: "1 checked",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+			var found bool
+			for _, r := range fakeDoctor(dir, tt.bins, tt.outputs).Run() {
+				if r.Check != tt.check {
+					continue
+				}
+				found = true
+				if r.Status != tt.status || !strings.Contains(r.Message, tt.message) {
+					t.Errorf("%s = %s %q, want %s containing %q", r.Check, r.Status, r.Message, tt.status, tt.message)
+				}
+				if r.Status != OK && r.Fix == "" {
+					t.Errorf("%s: no fix for %s result", r.Check, r.Status)
+				}
+			}
+			if !found {
+				t.Fatalf("no %s result", tt.check)
+			}
+		})
+	}
+}
+
+func TestReport(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	ok := Report(&out, []Result{
+		{Check: "go", Status: OK, Message: "go1.23.4", Fix: "unused"},
+		{Check: "go.mod", Status: Fail, Message: "declares go 1.21", Fix: "run go mod edit -go=1.22"},
+	})
+	if ok {
+		t.Error("Report() = true with a failed check")
+	}
+	want := "ok    go: go1.23.4\nfail  go.mod: declares go 1.21\n      fix: run go mod edit -go=1.22\n"
+	if out.String() != want {
+		t.Errorf("Report() wrote %q, want %q", out.String(), want)
+	}
+	if !Report(&out, []Result{{Check: "go", Status: Warn}}) {
+		t.Error("Report() = false with warnings only")
+	}
+}
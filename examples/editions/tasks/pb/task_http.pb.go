@@ -5,8 +5,34 @@ import (
 	"errors"
 	"net/http"
 	"strings"
+	"sync/atomic"
 )
 
+// GeneratorVersion is the version of protoc-gen-go-http-server-interface that
+// generated this file.
+const GeneratorVersion = "dev"
+
+// GeneratorParameters is the plugin parameter string this file was generated
+// with, listing the options that shaped its HTTP layer.
+const GeneratorParameters = "paths=source_relative,editions=true"
+
+// GeneratorInfo describes the generator run that produced this file.
+type GeneratorInfo struct {
+	// Version is the generator version, GeneratorVersion.
+	Version string
+	// Parameters is the plugin parameter string, GeneratorParameters.
+	Parameters string
+	// Source is the proto file the code was generated from.
+	Source string
+}
+
+// GenerationInfo reports which generator version and options produced this
+// package's HTTP layer, for deployed binaries to expose in build or health
+// endpoints.
+func GenerationInfo() GeneratorInfo {
+	return GeneratorInfo{Version: GeneratorVersion, Parameters: GeneratorParameters, Source: "task.proto"}
+}
+
 // Middleware represents a middleware function that wraps an http.Handler.
 type Middleware func(http.Handler) http.Handler
 
@@ -33,6 +59,15 @@ type RouteGroup struct {
 	prefix      string
 	middlewares []Middleware
 	routes      []string
+	registry    *routeRegistry
+	onConflict  ConflictPolicy
+}
+
+// routeRegistry records the routes and mounts of a router and all its groups.
+type routeRegistry struct {
+	routes  []string
+	mounts  []string
+	options map[string]*atomic.Pointer[sharedOptions]
 }
 
 // NewRouter creates a new router with an optional mux.
@@ -46,6 +81,7 @@ func NewRouter(mux *http.ServeMux) *RouteGroup {
 		prefix:      "",
 		middlewares: nil,
 		routes:      []string{},
+		registry:    &routeRegistry{},
 	}
 }
 
@@ -77,6 +113,8 @@ func (g *RouteGroup) Group(prefix string, middlewares ...Middleware) Router {
 		prefix:      joinPath(g.prefix, prefix),
 		middlewares: appendMiddlewares(g.middlewares, middlewares),
 		routes:      []string{},
+		registry:    g.registry,
+		onConflict:  g.onConflict,
 	}
 }
 
@@ -88,12 +126,299 @@ func (g *RouteGroup) Use(middlewares ...Middleware) Router {
 
 // HandleFunc registers a handler function for the given method and pattern.
 // Group middlewares are automatically applied to the handler.
+// It panics with *MountConflictError if the route falls under a mounted prefix.
 func (g *RouteGroup) HandleFunc(method, pattern string, handler http.HandlerFunc) {
 	fullPattern := joinPath(g.prefix, pattern)
 	finalHandler := applyMiddlewares(handler, g.middlewares)
 	routeKey := method + " " + fullPattern
-	g.mux.Handle(routeKey, finalHandler)
-	g.routes = append(g.routes, routeKey)
+	if g.registry != nil {
+		for _, mount := range g.registry.mounts {
+			if underMount(fullPattern, mount) {
+				panic(&MountConflictError{Prefix: mount, Route: routeKey})
+			}
+		}
+		g.registry.routes = append(g.registry.routes, routeKey)
+	}
+	if g.handle(routeKey, finalHandler) {
+		g.routes = append(g.routes, routeKey)
+	}
+}
+
+// sharedOptions is what the OPTIONS route of a path answers with: the methods
+// the path's routes accept and the details of the HandleOptions calls for it.
+type sharedOptions struct {
+	allow   []string
+	details []any
+}
+
+// HandleOptions registers respond as the OPTIONS route of pattern, whose other
+// routes accept the methods in allow, sorted, and are described by detail.
+// The services with routes on a path share its OPTIONS route: a call for a
+// path the router or any of its groups already has one for adds allow and
+// detail to those respond is called with, instead of registering the route
+// again, which the ServeMux would panic on.
+func (g *RouteGroup) HandleOptions(pattern string, allow []string, detail any, respond func(w http.ResponseWriter, r *http.Request, allow []string, details []any)) {
+	key := patternShape(http.MethodOptions + " " + joinPath(g.prefix, pattern))
+	if g.registry != nil {
+		if shared, ok := g.registry.options[key]; ok {
+			current := shared.Load()
+			details := append(make([]any, 0, len(current.details)+1), current.details...)
+			shared.Store(&sharedOptions{allow: mergeMethods(current.allow, allow), details: append(details, detail)})
+			return
+		}
+	}
+	shared := &atomic.Pointer[sharedOptions]{}
+	shared.Store(&sharedOptions{allow: allow, details: []any{detail}})
+	g.HandleFunc(http.MethodOptions, pattern, func(w http.ResponseWriter, r *http.Request) {
+		current := shared.Load()
+		respond(w, r, current.allow, current.details)
+	})
+	if g.registry != nil {
+		if g.registry.options == nil {
+			g.registry.options = map[string]*atomic.Pointer[sharedOptions]{}
+		}
+		g.registry.options[key] = shared
+	}
+}
+
+// mergeMethods returns the sorted union of the sorted method lists a and b.
+func mergeMethods(a, b []string) []string {
+	merged := make([]string, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		switch {
+		case a[0] < b[0]:
+			merged, a = append(merged, a[0]), a[1:]
+		case a[0] > b[0]:
+			merged, b = append(merged, b[0]), b[1:]
+		default:
+			merged, a, b = append(merged, a[0]), a[1:], b[1:]
+		}
+	}
+	return append(append(merged, a...), b...)
+}
+
+// ConflictPolicy selects what a router does when a route is registered for a
+// method and pattern that its ServeMux already serves, such as a /liveness
+// route of two services sharing the mux.
+type ConflictPolicy int
+
+const (
+	// ConflictError panics with *RouteConflictError.
+	ConflictError ConflictPolicy = iota
+	// ConflictSkip keeps the route registered first.
+	ConflictSkip
+	// ConflictReplace serves the route registered last. Only routes registered
+	// under ConflictReplace by a generated router can be replaced; others
+	// panic as with ConflictError.
+	ConflictReplace
+)
+
+// OnConflict sets the ConflictPolicy of the routes registered after this
+// call, on the group and groups created from it afterwards. It is
+// ConflictError by default.
+func (g *RouteGroup) OnConflict(policy ConflictPolicy) Router {
+	g.onConflict = policy
+	return g
+}
+
+// RouteConflictError reports a route registered for a method and pattern that
+// the ServeMux already serves.
+type RouteConflictError struct {
+	// Route is the conflicting route, as "METHOD /path".
+	Route string
+	// Existing is the route already registered, which differs from Route only
+	// in the names of its wildcards.
+	Existing string
+}
+
+func (e *RouteConflictError) Error() string {
+	return "protogen: route " + e.Route + " conflicts with registered route " + e.Existing
+}
+
+// handle registers h for routeKey on the mux, resolving a conflict with a
+// route already registered for the same method and pattern by the group's
+// ConflictPolicy. It reports whether h serves the route. Routes that only
+// overlap routeKey are left for the mux to reject. Under ConflictError, the
+// default, the mux is only searched for the existing route once registering
+// h panics.
+func (g *RouteGroup) handle(routeKey string, h http.Handler) bool {
+	if g.onConflict == ConflictError {
+		defer func() {
+			if p := recover(); p != nil {
+				if _, pattern := registeredRoute(g.mux, routeKey); pattern != "" && patternShape(pattern) == patternShape(routeKey) {
+					panic(&RouteConflictError{Route: routeKey, Existing: pattern})
+				}
+				panic(p)
+			}
+		}()
+		g.mux.Handle(routeKey, h)
+		return true
+	}
+	if existing, pattern := registeredRoute(g.mux, routeKey); pattern != "" && patternShape(pattern) == patternShape(routeKey) {
+		if g.onConflict == ConflictSkip {
+			return false
+		}
+		if r, ok := existing.(interface{ ReplaceHandler(http.Handler) }); ok {
+			r.ReplaceHandler(h)
+			return true
+		}
+		panic(&RouteConflictError{Route: routeKey, Existing: pattern})
+	}
+	if g.onConflict == ConflictReplace {
+		rh := &replaceableHandler{}
+		rh.ReplaceHandler(h)
+		h = rh
+	}
+	g.mux.Handle(routeKey, h)
+	return true
+}
+
+// replaceableHandler is the handler of a route registered under
+// ConflictReplace, which a router of any generated package can replace under
+// ConflictReplace too.
+type replaceableHandler struct {
+	handler atomic.Pointer[http.Handler]
+}
+
+func (h *replaceableHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	(*h.handler.Load()).ServeHTTP(w, r)
+}
+
+// ReplaceHandler serves the route with handler from now on.
+func (h *replaceableHandler) ReplaceHandler(handler http.Handler) {
+	h.handler.Store(&handler)
+}
+
+// registeredRoute returns the handler and pattern of the route of mux that
+// serves the route pattern routeKey, "METHOD /path", with a placeholder value
+// for each wildcard, or a pattern of "" if no route serves it.
+func registeredRoute(mux *http.ServeMux, routeKey string) (http.Handler, string) {
+	method, path, _ := strings.Cut(routeKey, " ")
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if segment == "{$}" {
+			segments[i] = ""
+		} else if strings.HasPrefix(segment, "{") {
+			segments[i] = "_"
+		}
+	}
+	r, err := http.NewRequest(method, strings.Join(segments, "/"), nil)
+	if err != nil {
+		return nil, ""
+	}
+	return mux.Handler(r)
+}
+
+// patternShape returns the route pattern "METHOD /path" with its wildcards
+// unnamed, so patterns differing only in wildcard names compare equal.
+func patternShape(routeKey string) string {
+	segments := strings.Split(routeKey, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") && segment != "{$}" {
+			if strings.HasSuffix(segment, "...}") {
+				segments[i] = "{...}"
+			} else {
+				segments[i] = "{}"
+			}
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// Mount serves h for every request under prefix, relative to the group, with
+// the prefix stripped from the request path, so third-party handlers such as a
+// GraphQL endpoint or websocket hub can live under the generated router. Group
+// middlewares are applied to h. Mounting at the root serves h for requests no
+// other route matches.
+//
+// Mount returns *MountConflictError if a route registered on the router or any
+// of its groups falls under prefix; routes registered under prefix afterwards
+// panic with the same error.
+func (g *RouteGroup) Mount(prefix string, h http.Handler) error {
+	if h == nil {
+		return ErrNilHandler
+	}
+	if prefix != "" && !strings.HasPrefix(prefix, "/") {
+		prefix = "/" + prefix
+	}
+	mount := strings.TrimSuffix(joinPath(g.prefix, prefix), "/")
+
+	if g.registry != nil {
+		for _, route := range g.registry.routes {
+			_, path, _ := strings.Cut(route, " ")
+			if underMount(path, mount) {
+				return &MountConflictError{Prefix: mount, Route: route}
+			}
+		}
+		g.registry.mounts = append(g.registry.mounts, mount)
+	}
+
+	handler := applyMiddlewares(stripMountPrefix(mount, h), g.middlewares)
+	if mount != "" {
+		g.mux.Handle(mount, handler)
+	}
+	g.mux.Handle(mount+"/", handler)
+	return nil
+}
+
+// MountConflictError reports a mounted prefix that overlaps a registered route.
+type MountConflictError struct {
+	// Prefix is the mounted path prefix.
+	Prefix string
+	// Route is the conflicting route, as "METHOD /path".
+	Route string
+}
+
+// Error returns the mounted prefix and the route it overlaps.
+func (e *MountConflictError) Error() string {
+	return "protogen: mount " + e.Prefix + "/ overlaps route " + e.Route
+}
+
+// underMount reports whether requests to the route pattern path can fall
+// under the mounted prefix mount, comparing them segment by segment: a
+// wildcard such as {id} matches any segment of mount, and one such as
+// {path...} all the rest. Nothing conflicts with a mount at the root, which
+// only receives unmatched requests.
+func underMount(path, mount string) bool {
+	if mount == "" {
+		return false
+	}
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for i, want := range strings.Split(strings.TrimPrefix(mount, "/"), "/") {
+		if i == len(segments) {
+			return false
+		}
+		segment := segments[i]
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "...}") {
+			return true
+		}
+		if segment != want && (!strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") || segment == "{$}") {
+			return false
+		}
+	}
+	return true
+}
+
+// stripMountPrefix serves h with prefix removed from the request path; a
+// request for the prefix itself is served as "/".
+func stripMountPrefix(prefix string, h http.Handler) http.Handler {
+	if prefix == "" {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r2 := r.Clone(r.Context())
+		r2.URL.Path = strings.TrimPrefix(r.URL.Path, prefix)
+		if r2.URL.Path == "" {
+			r2.URL.Path = "/"
+		}
+		if r.URL.RawPath != "" {
+			r2.URL.RawPath = strings.TrimPrefix(r.URL.RawPath, prefix)
+			if r2.URL.RawPath == "" {
+				r2.URL.RawPath = "/"
+			}
+		}
+		h.ServeHTTP(w, r2)
+	})
 }
 
 // GetRoutes returns all registered routes for this group.
@@ -147,13 +472,21 @@ func DefaultRouter() *RouteGroup {
 
 // TaskServiceHandler is the interface for TaskService HTTP handlers.
 type TaskServiceHandler interface {
+	// CreateTask creates a new task
 	HandleCreateTask(w http.ResponseWriter, r *http.Request)
+	// GetTask retrieves a task by ID
 	HandleGetTask(w http.ResponseWriter, r *http.Request)
+	// UpdateTask updates an existing task (supports both PUT and PATCH)
 	HandleUpdateTask(w http.ResponseWriter, r *http.Request)
+	// DeleteTask deletes a task
 	HandleDeleteTask(w http.ResponseWriter, r *http.Request)
+	// ListTasks lists all tasks with optional filtering
 	HandleListTasks(w http.ResponseWriter, r *http.Request)
+	// CompleteTask marks a task as complete (custom action)
 	HandleCompleteTask(w http.ResponseWriter, r *http.Request)
+	// GetTasksByProject retrieves all tasks for a project (nested path params)
 	HandleGetTasksByProject(w http.ResponseWriter, r *http.Request)
+	// AssignTask assigns a task to a user (multiple path params)
 	HandleAssignTask(w http.ResponseWriter, r *http.Request)
 }
 
@@ -178,6 +511,24 @@ func RegisterTaskServiceRoutes(r Routes, handler TaskServiceHandler) error {
 	return nil
 }
 
+// TaskServiceBasePath is the base path of the TaskService routes, from its proto
+// package or its (http_server.base_path) option, for RegisterTaskServiceRoutesAt.
+const TaskServiceBasePath = "/taskservice.v1"
+
+// RegisterTaskServiceRoutesAt registers the routes of RegisterTaskServiceRoutes in a
+// group of r under base, usually TaskServiceBasePath, so every service is mounted
+// the same way:
+//
+//	RegisterTaskServiceRoutesAt(router, TaskServiceBasePath, handler)
+//
+// Returns an error if router or handler is nil.
+func RegisterTaskServiceRoutesAt(r Router, base string, handler TaskServiceHandler) error {
+	if r == nil {
+		return ErrNilRouter
+	}
+	return RegisterTaskServiceRoutes(r.Group(base), handler)
+}
+
 // MustRegisterTaskServiceRoutes registers HTTP routes for TaskService.
 // Panics if router or handler is nil.
 func MustRegisterTaskServiceRoutes(r Routes, handler TaskServiceHandler) {
@@ -197,6 +548,8 @@ func (g *RouteGroup) RegisterTaskServiceRoutes(handler TaskServiceHandler) {
 // RegisterCreateTaskRoute registers the CreateTask handler.
 // This registers all HTTP bindings for this method (1 binding(s)).
 // Returns an error if router or handler is nil.
+//
+// CreateTask creates a new task
 func RegisterCreateTaskRoute(r Routes, handler TaskServiceHandler, middlewares ...Middleware) error {
 	if r == nil {
 		return ErrNilRouter
@@ -209,6 +562,15 @@ func RegisterCreateTaskRoute(r Routes, handler TaskServiceHandler, middlewares .
 	return nil
 }
 
+// HandlerForCreateTask returns the CreateTask handler wrapped in middlewares
+// and served like its "POST /api/v1/tasks" route, for frameworks with their
+// own routing, such as echo, gin or fiber, to register under their syntax for
+// the pattern. Handlers reading path values with r.PathValue need them set
+// from the framework's parameters with r.SetPathValue first.
+func HandlerForCreateTask(handler TaskServiceHandler, middlewares ...Middleware) http.Handler {
+	return applyMiddlewares(http.HandlerFunc(handler.HandleCreateTask), middlewares)
+}
+
 // RegisterCreateTask is a convenience method on RouteGroup.
 //
 // Deprecated: Use RegisterCreateTaskRoute(router, handler, middlewares...) instead.
@@ -220,6 +582,8 @@ func (g *RouteGroup) RegisterCreateTask(handler TaskServiceHandler, middlewares
 // RegisterGetTaskRoute registers the GetTask handler.
 // This registers all HTTP bindings for this method (1 binding(s)).
 // Returns an error if router or handler is nil.
+//
+// GetTask retrieves a task by ID
 func RegisterGetTaskRoute(r Routes, handler TaskServiceHandler, middlewares ...Middleware) error {
 	if r == nil {
 		return ErrNilRouter
@@ -232,6 +596,15 @@ func RegisterGetTaskRoute(r Routes, handler TaskServiceHandler, middlewares ...M
 	return nil
 }
 
+// HandlerForGetTask returns the GetTask handler wrapped in middlewares
+// and served like its "GET /api/v1/tasks/{task_id}" route, for frameworks with their
+// own routing, such as echo, gin or fiber, to register under their syntax for
+// the pattern. Handlers reading path values with r.PathValue need them set
+// from the framework's parameters with r.SetPathValue first.
+func HandlerForGetTask(handler TaskServiceHandler, middlewares ...Middleware) http.Handler {
+	return applyMiddlewares(http.HandlerFunc(handler.HandleGetTask), middlewares)
+}
+
 // RegisterGetTask is a convenience method on RouteGroup.
 //
 // Deprecated: Use RegisterGetTaskRoute(router, handler, middlewares...) instead.
@@ -243,6 +616,8 @@ func (g *RouteGroup) RegisterGetTask(handler TaskServiceHandler, middlewares ...
 // RegisterUpdateTaskRoute registers the UpdateTask handler.
 // This registers all HTTP bindings for this method (2 binding(s)).
 // Returns an error if router or handler is nil.
+//
+// UpdateTask updates an existing task (supports both PUT and PATCH)
 func RegisterUpdateTaskRoute(r Routes, handler TaskServiceHandler, middlewares ...Middleware) error {
 	if r == nil {
 		return ErrNilRouter
@@ -256,6 +631,15 @@ func RegisterUpdateTaskRoute(r Routes, handler TaskServiceHandler, middlewares .
 	return nil
 }
 
+// HandlerForUpdateTask returns the UpdateTask handler wrapped in middlewares
+// and served like its "PUT /api/v1/tasks/{task_id}" route, for frameworks with their
+// own routing, such as echo, gin or fiber, to register under their syntax for
+// the pattern. Handlers reading path values with r.PathValue need them set
+// from the framework's parameters with r.SetPathValue first.
+func HandlerForUpdateTask(handler TaskServiceHandler, middlewares ...Middleware) http.Handler {
+	return applyMiddlewares(http.HandlerFunc(handler.HandleUpdateTask), middlewares)
+}
+
 // RegisterUpdateTask is a convenience method on RouteGroup.
 //
 // Deprecated: Use RegisterUpdateTaskRoute(router, handler, middlewares...) instead.
@@ -267,6 +651,8 @@ func (g *RouteGroup) RegisterUpdateTask(handler TaskServiceHandler, middlewares
 // RegisterDeleteTaskRoute registers the DeleteTask handler.
 // This registers all HTTP bindings for this method (1 binding(s)).
 // Returns an error if router or handler is nil.
+//
+// DeleteTask deletes a task
 func RegisterDeleteTaskRoute(r Routes, handler TaskServiceHandler, middlewares ...Middleware) error {
 	if r == nil {
 		return ErrNilRouter
@@ -279,6 +665,15 @@ func RegisterDeleteTaskRoute(r Routes, handler TaskServiceHandler, middlewares .
 	return nil
 }
 
+// HandlerForDeleteTask returns the DeleteTask handler wrapped in middlewares
+// and served like its "DELETE /api/v1/tasks/{task_id}" route, for frameworks with their
+// own routing, such as echo, gin or fiber, to register under their syntax for
+// the pattern. Handlers reading path values with r.PathValue need them set
+// from the framework's parameters with r.SetPathValue first.
+func HandlerForDeleteTask(handler TaskServiceHandler, middlewares ...Middleware) http.Handler {
+	return applyMiddlewares(http.HandlerFunc(handler.HandleDeleteTask), middlewares)
+}
+
 // RegisterDeleteTask is a convenience method on RouteGroup.
 //
 // Deprecated: Use RegisterDeleteTaskRoute(router, handler, middlewares...) instead.
@@ -290,6 +685,8 @@ func (g *RouteGroup) RegisterDeleteTask(handler TaskServiceHandler, middlewares
 // RegisterListTasksRoute registers the ListTasks handler.
 // This registers all HTTP bindings for this method (1 binding(s)).
 // Returns an error if router or handler is nil.
+//
+// ListTasks lists all tasks with optional filtering
 func RegisterListTasksRoute(r Routes, handler TaskServiceHandler, middlewares ...Middleware) error {
 	if r == nil {
 		return ErrNilRouter
@@ -302,6 +699,15 @@ func RegisterListTasksRoute(r Routes, handler TaskServiceHandler, middlewares ..
 	return nil
 }
 
+// HandlerForListTasks returns the ListTasks handler wrapped in middlewares
+// and served like its "GET /api/v1/tasks" route, for frameworks with their
+// own routing, such as echo, gin or fiber, to register under their syntax for
+// the pattern. Handlers reading path values with r.PathValue need them set
+// from the framework's parameters with r.SetPathValue first.
+func HandlerForListTasks(handler TaskServiceHandler, middlewares ...Middleware) http.Handler {
+	return applyMiddlewares(http.HandlerFunc(handler.HandleListTasks), middlewares)
+}
+
 // RegisterListTasks is a convenience method on RouteGroup.
 //
 // Deprecated: Use RegisterListTasksRoute(router, handler, middlewares...) instead.
@@ -313,6 +719,8 @@ func (g *RouteGroup) RegisterListTasks(handler TaskServiceHandler, middlewares .
 // RegisterCompleteTaskRoute registers the CompleteTask handler.
 // This registers all HTTP bindings for this method (1 binding(s)).
 // Returns an error if router or handler is nil.
+//
+// CompleteTask marks a task as complete (custom action)
 func RegisterCompleteTaskRoute(r Routes, handler TaskServiceHandler, middlewares ...Middleware) error {
 	if r == nil {
 		return ErrNilRouter
@@ -325,6 +733,15 @@ func RegisterCompleteTaskRoute(r Routes, handler TaskServiceHandler, middlewares
 	return nil
 }
 
+// HandlerForCompleteTask returns the CompleteTask handler wrapped in middlewares
+// and served like its "POST /api/v1/tasks/{task_id}/complete" route, for frameworks with their
+// own routing, such as echo, gin or fiber, to register under their syntax for
+// the pattern. Handlers reading path values with r.PathValue need them set
+// from the framework's parameters with r.SetPathValue first.
+func HandlerForCompleteTask(handler TaskServiceHandler, middlewares ...Middleware) http.Handler {
+	return applyMiddlewares(http.HandlerFunc(handler.HandleCompleteTask), middlewares)
+}
+
 // RegisterCompleteTask is a convenience method on RouteGroup.
 //
 // Deprecated: Use RegisterCompleteTaskRoute(router, handler, middlewares...) instead.
@@ -336,6 +753,8 @@ func (g *RouteGroup) RegisterCompleteTask(handler TaskServiceHandler, middleware
 // RegisterGetTasksByProjectRoute registers the GetTasksByProject handler.
 // This registers all HTTP bindings for this method (1 binding(s)).
 // Returns an error if router or handler is nil.
+//
+// GetTasksByProject retrieves all tasks for a project (nested path params)
 func RegisterGetTasksByProjectRoute(r Routes, handler TaskServiceHandler, middlewares ...Middleware) error {
 	if r == nil {
 		return ErrNilRouter
@@ -348,6 +767,15 @@ func RegisterGetTasksByProjectRoute(r Routes, handler TaskServiceHandler, middle
 	return nil
 }
 
+// HandlerForGetTasksByProject returns the GetTasksByProject handler wrapped in middlewares
+// and served like its "GET /api/v1/projects/{project_id}/tasks" route, for frameworks with their
+// own routing, such as echo, gin or fiber, to register under their syntax for
+// the pattern. Handlers reading path values with r.PathValue need them set
+// from the framework's parameters with r.SetPathValue first.
+func HandlerForGetTasksByProject(handler TaskServiceHandler, middlewares ...Middleware) http.Handler {
+	return applyMiddlewares(http.HandlerFunc(handler.HandleGetTasksByProject), middlewares)
+}
+
 // RegisterGetTasksByProject is a convenience method on RouteGroup.
 //
 // Deprecated: Use RegisterGetTasksByProjectRoute(router, handler, middlewares...) instead.
@@ -359,6 +787,8 @@ func (g *RouteGroup) RegisterGetTasksByProject(handler TaskServiceHandler, middl
 // RegisterAssignTaskRoute registers the AssignTask handler.
 // This registers all HTTP bindings for this method (1 binding(s)).
 // Returns an error if router or handler is nil.
+//
+// AssignTask assigns a task to a user (multiple path params)
 func RegisterAssignTaskRoute(r Routes, handler TaskServiceHandler, middlewares ...Middleware) error {
 	if r == nil {
 		return ErrNilRouter
@@ -371,6 +801,15 @@ func RegisterAssignTaskRoute(r Routes, handler TaskServiceHandler, middlewares .
 	return nil
 }
 
+// HandlerForAssignTask returns the AssignTask handler wrapped in middlewares
+// and served like its "POST /api/v1/projects/{project_id}/tasks/{task_id}/assign/{user_id}" route, for frameworks with their
+// own routing, such as echo, gin or fiber, to register under their syntax for
+// the pattern. Handlers reading path values with r.PathValue need them set
+// from the framework's parameters with r.SetPathValue first.
+func HandlerForAssignTask(handler TaskServiceHandler, middlewares ...Middleware) http.Handler {
+	return applyMiddlewares(http.HandlerFunc(handler.HandleAssignTask), middlewares)
+}
+
 // RegisterAssignTask is a convenience method on RouteGroup.
 //
 // Deprecated: Use RegisterAssignTaskRoute(router, handler, middlewares...) instead.
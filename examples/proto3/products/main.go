@@ -73,12 +73,9 @@ func main() {
 	userHandler := userHandler.NewUserHandler(userService)
 
 	// OPTION 1: Register routes at the root level
-	// This might cause conflicts since both services have a "/liveness" endpoint
-	// Uncomment these lines to see what happens (likely a panic due to route conflicts)
-	/*
-		productRouter.RegisterProductServiceRoutes(productHandler)
-		userRouter.RegisterUserServiceRoutes(userHandler)
-	*/
+	// Both services have a "/liveness" endpoint, which the product service
+	// registered above; skip the user service's instead of panicking
+	_ = userPb.RegisterUserServiceRoutes(userRouter.OnConflict(userPb.ConflictSkip), userHandler)
 
 	// OPTION 2: Add path prefixes to completely avoid conflicts
 	// Create service-specific groups with different prefixes
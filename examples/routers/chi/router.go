@@ -4,7 +4,11 @@
 // how to create a minimal wrapper to use chi with generated code.
 //
 // Note: Chi uses chi.URLParam(r, "param") for path parameters, not r.PathValue().
-// Your handler implementations need to use chi.URLParam accordingly.
+// Your handler implementations need to use chi.URLParam accordingly,
+// or generate with pathvalue_source=chi and read them with pb.PathValue(r, "param").
+//
+// Generating with adapters=chi emits this wrapper as pb.ChiRoutes, which also
+// sets path parameters on the request for r.PathValue.
 package chi
 
 import (
@@ -4,7 +4,11 @@
 // how to create a minimal wrapper to use gorilla/mux with generated code.
 //
 // Note: Gorilla uses mux.Vars(r)["param"] for path parameters, not r.PathValue().
-// Your handler implementations need to use mux.Vars accordingly.
+// Your handler implementations need to use mux.Vars accordingly,
+// or generate with pathvalue_source=gorilla and read them with pb.PathValue(r, "param").
+//
+// Generating with adapters=gorilla emits this wrapper as pb.GorillaRoutes, which
+// also sets path parameters on the request for r.PathValue.
 package gorilla
 
 import (
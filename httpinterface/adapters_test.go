@@ -0,0 +1,166 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateCodeAdapters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		adapters   []string
+		expected   []string
+		unexpected []string
+	}{
+		{
+			name:     "gin",
+			adapters: []string{AdapterGin},
+			expected: []string{
+				"\t\"github.com/gin-gonic/gin\"\n",
+				"func adaptPattern(pattern string, escapeColons bool, wildcard func(name string, rest bool) (segment, key string)) (string, []adapterParam) {",
+				"func NewGinRoutes(r gin.IRoutes) GinRoutes {",
+				"func (g GinRoutes) HandleFunc(method, pattern string, handler http.HandlerFunc) {",
+				"\t\t\tc.Request.SetPathValue(p.name, value)\n",
+			},
+			unexpected: []string{"gofiber", "FiberRoutes", "ChiRoutes"},
+		},
+		{
+			name:     "fiber",
+			adapters: []string{AdapterFiber},
+			expected: []string{
+				"\t\"net/url\"\n",
+				"\t\"github.com/gofiber/fiber/v2\"\n\t\"github.com/gofiber/fiber/v2/middleware/adaptor\"\n",
+				"func NewFiberRoutes(r fiber.Router) FiberRoutes {",
+				"\t\t\tvalues[i] = strings.Clone(value)\n",
+				"\t\treturn adaptor.HTTPHandlerFunc(func(w http.ResponseWriter, r *http.Request) {\n",
+			},
+			unexpected: []string{"gin-gonic", "GinRoutes", "ChiRoutes"},
+		},
+		{
+			name:     "chi",
+			adapters: []string{AdapterChi},
+			expected: []string{
+				"\t\"net/url\"\n",
+				"\t\"github.com/go-chi/chi/v5\"\n",
+				"func NewChiRoutes(r chi.Router) ChiRoutes {",
+				"\tpath, params := adaptPattern(pattern, false, func(name string, rest bool) (string, string) {\n",
+				"\t\treturn \"{\" + name + \"}\", name\n",
+				"\t\t\tr.SetPathValue(p.name, value)\n",
+			},
+			unexpected: []string{"gin-gonic", "gofiber", "GinRoutes", "FiberRoutes"},
+		},
+		{
+			name:     "gorilla",
+			adapters: []string{AdapterGorilla},
+			expected: []string{
+				"\t\"github.com/gorilla/mux\"\n",
+				"func NewGorillaRoutes(r *mux.Router) GorillaRoutes {",
+				"\t\t\treturn \"{\" + name + \":.*}\", name\n",
+				"\t}).Methods(method)\n",
+			},
+			unexpected: []string{"gin-gonic", "gofiber", "go-chi", "ChiRoutes"},
+		},
+		{
+			name:       "none",
+			unexpected: []string{"adaptPattern", "GinRoutes", "FiberRoutes", "ChiRoutes", "GorillaRoutes"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			code, err := New().GenerateCode(newServiceData("api", Options{Adapters: tt.adapters}))
+			if err != nil {
+				t.Fatalf("GenerateCode() error = %v", err)
+			}
+			for _, expected := range tt.expected {
+				if !strings.Contains(code, expected) {
+					t.Errorf("Generated code doesn't contain %q", expected)
+				}
+			}
+			for _, unexpected := range tt.unexpected {
+				if strings.Contains(code, unexpected) {
+					t.Errorf("Generated code contains %q", unexpected)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateRouterAdapterFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		router     string
+		file       string
+		expected   []string
+		unexpected []string
+	}{
+		{
+			router: RouterGorilla,
+			file:   "items_http_gorilla_adapter.pb.go",
+			expected: []string{
+				"// Code generated by protoc-gen-go-http-server-interface. DO NOT EDIT.\n",
+				"\t\"github.com/gorilla/mux\"\n",
+				"\treturn mux.Vars(r)[name]\n",
+				"func NewGorillaRoutes(r *mux.Router) GorillaRoutes {",
+			},
+			unexpected: []string{"go-chi", "ChiRoutes", "type ItemServiceHandler interface"},
+		},
+		{
+			router: RouterChi,
+			file:   "items_http_chi_adapter.pb.go",
+			expected: []string{
+				"\t\"net/url\"\n",
+				"\t\"github.com/go-chi/chi/v5\"\n",
+				"\treturn chi.URLParam(r, name)\n",
+				"func NewChiRoutes(r chi.Router) ChiRoutes {",
+			},
+			unexpected: []string{"gorilla", "GorillaRoutes", "type ItemServiceHandler interface"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.router, func(t *testing.T) {
+			t.Parallel()
+			resp := New().Generate(graphqlRequest(t, "binding=true,router="+tt.router))
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+			files := map[string]string{}
+			for _, f := range resp.File {
+				files[f.GetName()] = f.GetContent()
+			}
+			if len(files) != 2 {
+				t.Errorf("generated %d files, want items_http.pb.go and %s", len(files), tt.file)
+			}
+
+			adapter, ok := files[tt.file]
+			if !ok {
+				t.Fatalf("missing %s in %d generated files", tt.file, len(resp.File))
+			}
+			for _, expected := range tt.expected {
+				if !strings.Contains(adapter, expected) {
+					t.Errorf("%s doesn't contain %q", tt.file, expected)
+				}
+			}
+			for _, unexpected := range tt.unexpected {
+				if strings.Contains(adapter, unexpected) {
+					t.Errorf("%s contains %q", tt.file, unexpected)
+				}
+			}
+
+			code := files["items_http.pb.go"]
+			if !strings.Contains(code, "PathValue(r, name)") {
+				t.Error("items_http.pb.go doesn't read path values with PathValue")
+			}
+			for _, unexpected := range []string{"func PathValue(", "Routes struct", "\"github.com/gorilla/mux\"", "\"github.com/go-chi/chi/v5\""} {
+				if strings.Contains(code, unexpected) {
+					t.Errorf("items_http.pb.go contains %q", unexpected)
+				}
+			}
+		})
+	}
+}
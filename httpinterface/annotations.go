@@ -1,7 +1,12 @@
 package httpinterface
 
 import (
+	"net/http"
+	"slices"
+	"strings"
+
 	"github.com/farhaan/protoc-gen-go-http-server-interface/httpinterface/parser"
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpserver"
 	options "google.golang.org/genproto/googleapis/api/annotations"
 	"google.golang.org/protobuf/proto"
 	descriptor "google.golang.org/protobuf/types/descriptorpb"
@@ -41,14 +46,190 @@ func extractHTTPRules(method *descriptor.MethodDescriptorProto) []parser.HTTPRul
 	return rules
 }
 
+// methodStreamArray reports whether method sets the (http_server.stream_array) option.
+func methodStreamArray(method *descriptor.MethodDescriptorProto) bool {
+	if method.Options == nil {
+		return false
+	}
+	v, _ := proto.GetExtension(method.Options, httpserver.E_StreamArray).(bool)
+	return v
+}
+
+// methodInternal reports whether method sets (http_server.visibility) = INTERNAL.
+func methodInternal(method *descriptor.MethodDescriptorProto) bool {
+	if method.Options == nil {
+		return false
+	}
+	v, _ := proto.GetExtension(method.Options, httpserver.E_Visibility).(httpserver.Visibility)
+	return v == httpserver.Visibility_INTERNAL
+}
+
+// methodCacheTTL returns the ttl_seconds of the method's (http_server.cache)
+// option, or zero if the method does not set it.
+func methodCacheTTL(method *descriptor.MethodDescriptorProto) uint32 {
+	if method.Options == nil {
+		return 0
+	}
+	v, _ := proto.GetExtension(method.Options, httpserver.E_Cache).(*httpserver.Cache)
+	return v.GetTtlSeconds()
+}
+
+// methodDedupeWindow returns the window_seconds of the method's
+// (http_server.dedupe) option, or zero if the method does not set it.
+func methodDedupeWindow(method *descriptor.MethodDescriptorProto) uint32 {
+	if method.Options == nil {
+		return 0
+	}
+	v, _ := proto.GetExtension(method.Options, httpserver.E_Dedupe).(*httpserver.Dedupe)
+	return v.GetWindowSeconds()
+}
+
+// methodTimeouts returns the read_ms and write_ms of the method's
+// (http_server.timeouts) option, or zeros if the method does not set it.
+func methodTimeouts(method *descriptor.MethodDescriptorProto) (read, write uint32) {
+	if method.Options == nil {
+		return 0, 0
+	}
+	v, _ := proto.GetExtension(method.Options, httpserver.E_Timeouts).(*httpserver.Timeouts)
+	return v.GetReadMs(), v.GetWriteMs()
+}
+
+// methodTags returns the tags of method's routes: the (http_server.service_tags)
+// of service followed by the (http_server.tags) of method, without duplicates.
+func methodTags(service *descriptor.ServiceDescriptorProto, method *descriptor.MethodDescriptorProto) []string {
+	var tags []string
+	if service.Options != nil {
+		v, _ := proto.GetExtension(service.Options, httpserver.E_ServiceTags).([]string)
+		tags = append(tags, v...)
+	}
+	if method.Options != nil {
+		v, _ := proto.GetExtension(method.Options, httpserver.E_Tags).([]string)
+		for _, tag := range v {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
+
+// methodScopes returns the authorization scopes of method's routes: the
+// (http_server.service_scopes) of service followed by the
+// (http_server.scopes) of method, without duplicates.
+func methodScopes(service *descriptor.ServiceDescriptorProto, method *descriptor.MethodDescriptorProto) []string {
+	var scopes []string
+	if service.Options != nil {
+		v, _ := proto.GetExtension(service.Options, httpserver.E_ServiceScopes).([]string)
+		scopes = append(scopes, v...)
+	}
+	if method.Options != nil {
+		v, _ := proto.GetExtension(method.Options, httpserver.E_Scopes).([]string)
+		for _, scope := range v {
+			if !slices.Contains(scopes, scope) {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	return scopes
+}
+
+// serviceBasePath returns the (http_server.base_path) of service, or "/"
+// followed by the proto package of file when the service does not set it.
+func serviceBasePath(file *descriptor.FileDescriptorProto, service *descriptor.ServiceDescriptorProto) string {
+	if service.Options != nil {
+		if v, _ := proto.GetExtension(service.Options, httpserver.E_BasePath).(string); v != "" {
+			return v
+		}
+	}
+	if file.GetPackage() == "" {
+		return ""
+	}
+	return "/" + file.GetPackage()
+}
+
+// serviceForwardHeaders returns the (http_server.forward_headers) of service
+// in canonical form, such as "X-Request-Id", without duplicates.
+func serviceForwardHeaders(service *descriptor.ServiceDescriptorProto) []string {
+	if service.Options == nil {
+		return nil
+	}
+	v, _ := proto.GetExtension(service.Options, httpserver.E_ForwardHeaders).([]string)
+	var headers []string
+	for _, name := range v {
+		name = http.CanonicalHeaderKey(strings.TrimSpace(name))
+		if !slices.Contains(headers, name) {
+			headers = append(headers, name)
+		}
+	}
+	return headers
+}
+
+// methodAsync reports whether method sets the (http_server.async) option.
+func methodAsync(method *descriptor.MethodDescriptorProto) bool {
+	if method.Options == nil {
+		return false
+	}
+	v, _ := proto.GetExtension(method.Options, httpserver.E_Async).(bool)
+	return v
+}
+
+// methodStreamBody reports whether method sets the (http_server.stream_body) option.
+func methodStreamBody(method *descriptor.MethodDescriptorProto) bool {
+	if method.Options == nil {
+		return false
+	}
+	v, _ := proto.GetExtension(method.Options, httpserver.E_StreamBody).(bool)
+	return v
+}
+
+// methodLongPollMs returns the method's (http_server.long_poll_ms) option, or
+// zero if the method does not set it.
+func methodLongPollMs(method *descriptor.MethodDescriptorProto) uint32 {
+	if method.Options == nil {
+		return 0
+	}
+	v, _ := proto.GetExtension(method.Options, httpserver.E_LongPollMs).(uint32)
+	return v
+}
+
+// methodSkipUnitOfWork reports whether method sets (http_server.unit_of_work) = false.
+func methodSkipUnitOfWork(method *descriptor.MethodDescriptorProto) bool {
+	if method.Options == nil || !proto.HasExtension(method.Options, httpserver.E_UnitOfWork) {
+		return false
+	}
+	v, _ := proto.GetExtension(method.Options, httpserver.E_UnitOfWork).(bool)
+	return !v
+}
+
+// methodIdempotent reports whether method's idempotency_level marks it
+// IDEMPOTENT or NO_SIDE_EFFECTS.
+func methodIdempotent(method *descriptor.MethodDescriptorProto) bool {
+	switch method.GetOptions().GetIdempotencyLevel() {
+	case descriptor.MethodOptions_IDEMPOTENT, descriptor.MethodOptions_NO_SIDE_EFFECTS:
+		return true
+	}
+	return false
+}
+
+// methodSLO returns the method's (http_server.slo) option, or nil if the
+// method does not set it.
+func methodSLO(method *descriptor.MethodDescriptorProto) *httpserver.Slo {
+	if method.Options == nil {
+		return nil
+	}
+	v, _ := proto.GetExtension(method.Options, httpserver.E_Slo).(*httpserver.Slo)
+	return v
+}
+
 // extractPathParams extracts path parameters from a URL pattern.
 func extractPathParams(pattern string) []string {
 	return parser.PathParams(pattern)
 }
 
-// convertPathPattern converts a path pattern to Go format
+// convertPathPattern converts a google.api path template to the http.ServeMux
+// pattern syntax
 func convertPathPattern(pattern string) string {
-	return pattern
+	return parser.ConvertPathTemplate(pattern)
 }
 
 // CreateHTTPRuleExtractorForFile creates an HTTP rule extractor for a specific file
@@ -81,3 +262,23 @@ func CreatePathPatternConverterForFile(file *descriptor.FileDescriptorProto) Pat
 		return p.ConvertPathPattern(pattern)
 	}
 }
+
+// enumValueHTTPStatus returns the value's (http_server.http_status) option, or
+// zero if the value does not set it.
+func enumValueHTTPStatus(value *descriptor.EnumValueDescriptorProto) int32 {
+	if value.Options == nil {
+		return 0
+	}
+	v, _ := proto.GetExtension(value.Options, httpserver.E_HttpStatus).(int32)
+	return v
+}
+
+// fieldNormalize returns the field's (http_server.normalize) option, the
+// normalizations of its value in order, or nil if the field does not set it.
+func fieldNormalize(field *descriptor.FieldDescriptorProto) []httpserver.Normalization {
+	if field.Options == nil {
+		return nil
+	}
+	v, _ := proto.GetExtension(field.Options, httpserver.E_Normalize).([]httpserver.Normalization)
+	return v
+}
@@ -51,20 +51,7 @@ func TestCreateHTTPRuleExtractorForFile(t *testing.T) {
 		},
 		{
 			name: "editions_file_with_http_rule",
-			file: &descriptor.FileDescriptorProto{
-				Options: &descriptor.FileOptions{
-					UninterpretedOption: []*descriptor.UninterpretedOption{
-						{
-							Name: []*descriptor.UninterpretedOption_NamePart{
-								{
-									NamePart:    proto.String("edition"),
-									IsExtension: proto.Bool(false),
-								},
-							},
-						},
-					},
-				},
-			},
+			file: editionsFixture(t, "tasks.proto"),
 			method: func() *descriptor.MethodDescriptorProto {
 				method := &descriptor.MethodDescriptorProto{
 					Name:    proto.String("UpdateUser"),
@@ -133,21 +120,8 @@ func TestCreatePathParamExtractorForFile(t *testing.T) {
 			expected: []string{"id", "post_id"},
 		},
 		{
-			name: "editions_file",
-			file: &descriptor.FileDescriptorProto{
-				Options: &descriptor.FileOptions{
-					UninterpretedOption: []*descriptor.UninterpretedOption{
-						{
-							Name: []*descriptor.UninterpretedOption_NamePart{
-								{
-									NamePart:    proto.String("edition"),
-									IsExtension: proto.Bool(false),
-								},
-							},
-						},
-					},
-				},
-			},
+			name:     "editions_file",
+			file:     editionsFixture(t, "tasks.proto"),
 			pattern:  "/v1/organizations/{org_id}/users/{user_id}",
 			expected: []string{"org_id", "user_id"},
 		},
@@ -190,21 +164,8 @@ func TestCreatePathPatternConverterForFile(t *testing.T) {
 			expected: "/v1/users/{id}",
 		},
 		{
-			name: "editions_file",
-			file: &descriptor.FileDescriptorProto{
-				Options: &descriptor.FileOptions{
-					UninterpretedOption: []*descriptor.UninterpretedOption{
-						{
-							Name: []*descriptor.UninterpretedOption_NamePart{
-								{
-									NamePart:    proto.String("edition"),
-									IsExtension: proto.Bool(false),
-								},
-							},
-						},
-					},
-				},
-			},
+			name:     "editions_file",
+			file:     editionsFixture(t, "tasks.proto"),
 			pattern:  "/v1/organizations/{org_id}/users/{user_id}",
 			expected: "/v1/organizations/{org_id}/users/{user_id}",
 		},
@@ -467,6 +428,11 @@ func TestConvertPathPattern_Legacy(t *testing.T) {
 			pattern:  "/api/test",
 			expected: "/api/test",
 		},
+		{
+			name:     "path_template",
+			pattern:  "/v1/{name=projects/*}/files/{path=**}",
+			expected: "/v1/projects/{name_1}/files/{path...}",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,72 @@
+package httpinterface
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpinterface/parser"
+	options "google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/genproto/googleapis/api/serviceconfig"
+	"google.golang.org/protobuf/encoding/protojson"
+	"sigs.k8s.io/yaml"
+)
+
+// APIConfigRules maps fully-qualified method names ("pkg.Service.Method") to the
+// HTTP rules declared for them in a gateway-style API configuration file.
+type APIConfigRules map[string][]parser.HTTPRule
+
+// LoadAPIConfig reads a google.api.Service configuration in YAML or JSON, as
+// used by grpc-gateway's grpc_api_configuration option, and returns its HTTP
+// rules keyed by selector. Fields other than http.rules are ignored.
+func LoadAPIConfig(path string) (APIConfigRules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading API configuration: %w", err)
+	}
+	return ParseAPIConfig(data)
+}
+
+// ParseAPIConfig parses the contents of an API configuration file. See LoadAPIConfig.
+func ParseAPIConfig(data []byte) (APIConfigRules, error) {
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing API configuration: %w", err)
+	}
+
+	service := &serviceconfig.Service{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(jsonData, service); err != nil {
+		return nil, fmt.Errorf("parsing API configuration: %w", err)
+	}
+
+	rules := APIConfigRules{}
+	for _, httpRule := range service.GetHttp().GetRules() {
+		selector := strings.TrimPrefix(httpRule.GetSelector(), ".")
+		if selector == "" {
+			return nil, fmt.Errorf("API configuration: http rule without a selector")
+		}
+
+		for _, r := range append([]*options.HttpRule{httpRule}, httpRule.GetAdditionalBindings()...) {
+			rule := parser.ExtractHTTPRule(r)
+			if rule.Method == "" || rule.Pattern == "" {
+				return nil, fmt.Errorf("API configuration: http rule for %s has no method and path", selector)
+			}
+			rules[selector] = append(rules[selector], rule)
+		}
+	}
+	return rules, nil
+}
+
+// merge appends the configured rules for the method named fullName to rules,
+// skipping bindings whose method and pattern are already present.
+func (c APIConfigRules) merge(fullName string, rules []parser.HTTPRule) []parser.HTTPRule {
+	for _, extra := range c[fullName] {
+		if !slices.ContainsFunc(rules, func(r parser.HTTPRule) bool {
+			return r.Method == extra.Method && r.Pattern == extra.Pattern
+		}) {
+			rules = append(rules, extra)
+		}
+	}
+	return rules
+}
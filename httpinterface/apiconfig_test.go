@@ -0,0 +1,231 @@
+package httpinterface
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpinterface/parser"
+	options "google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+const testAPIConfigYAML = `type: google.api.Service
+config_version: 3
+name: tasks.example.com
+
+http:
+  rules:
+    - selector: tasks.v1.TaskService.GetTask
+      get: /v1/tasks/{id}
+    - selector: tasks.v1.TaskService.UpdateTask
+      patch: /v1/tasks/{task.id}
+      body: task
+      additional_bindings:
+        - put: /v1/tasks/{task.id}
+          body: task
+    - selector: tasks.v1.TaskService.ListTasks
+      get: /v1/tasks
+`
+
+func TestParseAPIConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		data    string
+		want    APIConfigRules
+		wantErr string
+	}{
+		{
+			name: "yaml rules with additional bindings",
+			data: testAPIConfigYAML,
+			want: APIConfigRules{
+				"tasks.v1.TaskService.GetTask": {
+					{Method: "GET", Pattern: "/v1/tasks/{id}", PathParams: []string{"id"}},
+				},
+				"tasks.v1.TaskService.UpdateTask": {
+					{Method: "PATCH", Pattern: "/v1/tasks/{task.id}", Body: "task", PathParams: []string{"task.id"}},
+					{Method: "PUT", Pattern: "/v1/tasks/{task.id}", Body: "task", PathParams: []string{"task.id"}},
+				},
+				"tasks.v1.TaskService.ListTasks": {
+					{Method: "GET", Pattern: "/v1/tasks", PathParams: []string{}},
+				},
+			},
+		},
+		{
+			name: "json with custom method",
+			data: `{"http": {"rules": [{"selector": ".tasks.v1.TaskService.Merge", "custom": {"kind": "MERGE", "path": "/v1/tasks:merge"}, "body": "*"}]}}`,
+			want: APIConfigRules{
+				"tasks.v1.TaskService.Merge": {
+					{Method: "MERGE", Pattern: "/v1/tasks:merge", Body: "*", PathParams: []string{}},
+				},
+			},
+		},
+		{
+			name: "no http section",
+			data: "type: google.api.Service\nconfig_version: 3\n",
+			want: APIConfigRules{},
+		},
+		{
+			name:    "rule without selector",
+			data:    "http:\n  rules:\n    - get: /v1/tasks\n",
+			wantErr: "http rule without a selector",
+		},
+		{
+			name:    "rule without pattern",
+			data:    "http:\n  rules:\n    - selector: tasks.v1.TaskService.GetTask\n",
+			wantErr: "http rule for tasks.v1.TaskService.GetTask has no method and path",
+		},
+		{
+			name:    "invalid yaml",
+			data:    "http: [",
+			wantErr: "parsing API configuration",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseAPIConfig([]byte(tt.data))
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseAPIConfig() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseAPIConfig() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAPIConfig() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateWithAPIConfiguration(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "tasks_api.yaml")
+	if err := os.WriteFile(path, []byte(testAPIConfigYAML), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	annotated := func(rule *options.HttpRule) *descriptor.MethodOptions {
+		opts := &descriptor.MethodOptions{}
+		proto.SetExtension(opts, options.E_Http, rule)
+		return opts
+	}
+
+	file := &descriptor.FileDescriptorProto{
+		Name:    proto.String("tasks.proto"),
+		Package: proto.String("tasks.v1"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptor.DescriptorProto{
+			{Name: proto.String("Task")},
+		},
+		Service: []*descriptor.ServiceDescriptorProto{
+			{
+				Name: proto.String("TaskService"),
+				Method: []*descriptor.MethodDescriptorProto{
+					// Bound only by the configuration
+					{Name: proto.String("GetTask"), InputType: proto.String(".tasks.v1.Task"), OutputType: proto.String(".tasks.v1.Task")},
+					// Annotated with the same PATCH binding; the configuration adds PUT
+					{
+						Name:       proto.String("UpdateTask"),
+						InputType:  proto.String(".tasks.v1.Task"),
+						OutputType: proto.String(".tasks.v1.Task"),
+						Options: annotated(&options.HttpRule{
+							Pattern: &options.HttpRule_Patch{Patch: "/v1/tasks/{task.id}"},
+							Body:    "task",
+						}),
+					},
+					// Annotated with a different binding; both are kept
+					{
+						Name:       proto.String("ListTasks"),
+						InputType:  proto.String(".tasks.v1.Task"),
+						OutputType: proto.String(".tasks.v1.Task"),
+						Options: annotated(&options.HttpRule{
+							Pattern: &options.HttpRule_Get{Get: "/v1/tasks:list"},
+						}),
+					},
+					// Not mentioned anywhere
+					{Name: proto.String("DeleteTask"), InputType: proto.String(".tasks.v1.Task"), OutputType: proto.String(".tasks.v1.Task")},
+				},
+			},
+		},
+	}
+
+	resp := New().Generate(&plugin.CodeGeneratorRequest{
+		Parameter:      proto.String("grpc_api_configuration=" + path + ",raw_patterns=true"),
+		ProtoFile:      []*descriptor.FileDescriptorProto{file},
+		FileToGenerate: []string{"tasks.proto"},
+	})
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	if len(resp.File) != 1 {
+		t.Fatalf("len(resp.File) = %d, want 1", len(resp.File))
+	}
+	code := resp.File[0].GetContent()
+
+	for _, expected := range []string{
+		`r.HandleFunc(http.MethodGet, "/v1/tasks/{id}", handler.HandleGetTask)`,
+		`r.HandleFunc(http.MethodPatch, "/v1/tasks/{task.id}", handler.HandleUpdateTask)`,
+		`r.HandleFunc(http.MethodPut, "/v1/tasks/{task.id}", handler.HandleUpdateTask)`,
+		`r.HandleFunc(http.MethodGet, "/v1/tasks:list", handler.HandleListTasks)`,
+		`r.HandleFunc(http.MethodGet, "/v1/tasks", handler.HandleListTasks)`,
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+
+	if n := strings.Count(code, `r.HandleFunc(http.MethodPatch, "/v1/tasks/{task.id}", handler.HandleUpdateTask)`); n != 1 {
+		t.Errorf("PATCH binding registered %d times, want 1", n)
+	}
+	if strings.Contains(code, "HandleDeleteTask") {
+		t.Error("Generated code contains unbound method DeleteTask")
+	}
+}
+
+func TestGenerateWithMissingAPIConfiguration(t *testing.T) {
+	t.Parallel()
+
+	resp := New().Generate(&plugin.CodeGeneratorRequest{
+		Parameter: proto.String("grpc_api_configuration=" + filepath.Join(t.TempDir(), "missing.yaml")),
+	})
+	if !strings.Contains(resp.GetError(), "reading API configuration") {
+		t.Errorf("Generate() error = %q, want reading API configuration error", resp.GetError())
+	}
+}
+
+func TestAPIConfigRulesMerge(t *testing.T) {
+	t.Parallel()
+
+	rules := APIConfigRules{
+		"pkg.Svc.Get": {
+			{Method: "GET", Pattern: "/v1/things/{id}"},
+			{Method: "GET", Pattern: "/v1/things/{id}:view"},
+		},
+	}
+
+	got := rules.merge("pkg.Svc.Get", []parser.HTTPRule{{Method: "GET", Pattern: "/v1/things/{id}"}})
+	want := []parser.HTTPRule{
+		{Method: "GET", Pattern: "/v1/things/{id}"},
+		{Method: "GET", Pattern: "/v1/things/{id}:view"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("merge() = %v, want %v", got, want)
+	}
+
+	if got := rules.merge("pkg.Svc.Other", nil); len(got) != 0 {
+		t.Errorf("merge() for unknown selector = %v, want none", got)
+	}
+}
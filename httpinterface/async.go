@@ -0,0 +1,43 @@
+package httpinterface
+
+import "fmt"
+
+// HasAsync reports whether any method in d sets the (http_server.async)
+// option, so the generated file needs the Enqueuer helpers.
+func (d *ServiceData) HasAsync() bool {
+	for _, service := range d.Services {
+		if service.HasAsyncMethods() {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAsyncMethods reports whether any method of s sets the (http_server.async)
+// option, so its typed handler must also implement Enqueuer.
+func (s ServiceInfo) HasAsyncMethods() bool {
+	for _, method := range s.Methods {
+		if method.Async {
+			return true
+		}
+	}
+	return false
+}
+
+// checkAsync reports an error if a method in data sets the (http_server.async)
+// option without binding=true, which decodes the requests handed to the
+// Enqueuer.
+func checkAsync(data *ServiceData) error {
+	if data.Options.Binding {
+		return nil
+	}
+	for _, service := range data.Services {
+		for _, method := range service.Methods {
+			if method.Async {
+				return fmt.Errorf("%s.%s sets (http_server.async), which requires binding=true",
+					service.Name, method.Name)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpserver"
+)
+
+func TestGenerateAsync(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		parameter      string
+		wantErr        string
+		wantContain    []string
+		wantNotContain []string
+	}{
+		{
+			name:      "with binding",
+			parameter: "binding=true",
+			wantContain: []string{
+				"type Enqueuer interface",
+				"type OperationRef struct",
+				"type ItemServiceTypedHandler interface {\n\tEnqueuer\n\tGetItem(ctx context.Context, req *Item) (*Item, error)\n}",
+				`serveAsync(w, r, "/items.v1.ItemService/ExportItems", &Item{}, "*", []string{}, a.interceptor, a.srv)`,
+				"func AcceptExportItems(w http.ResponseWriter, r *http.Request, enq Enqueuer, interceptors ...UnaryInterceptor)",
+				`serveUnary(w, r, "/items.v1.ItemService/GetItem", &Item{}, "", []string{"id"}, a.interceptor,`,
+			},
+			wantNotContain: []string{"ExportItems(ctx context.Context"},
+		},
+		{
+			name:      "without binding",
+			parameter: "",
+			wantErr:   "items.proto: ItemService.ExportItems sets (http_server.async), which requires binding=true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp := New().Generate(itemsRequest(t, tt.parameter,
+				itemMethod("ExportItems", postRule("/items:export", "*"), withExtension(httpserver.E_Async, true)),
+				itemMethod("GetItem", getRule("/items/{id}")),
+			))
+
+			if tt.wantErr != "" {
+				if !strings.Contains(resp.GetError(), tt.wantErr) {
+					t.Fatalf("Generate() error = %q, want error containing %q", resp.GetError(), tt.wantErr)
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+			code := resp.File[0].GetContent()
+			for _, expected := range tt.wantContain {
+				if !strings.Contains(code, expected) {
+					t.Errorf("Generated code doesn't contain %q", expected)
+				}
+			}
+			for _, unexpected := range tt.wantNotContain {
+				if strings.Contains(code, unexpected) {
+					t.Errorf("Generated code contains %q", unexpected)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateCodeWithoutAsync(t *testing.T) {
+	t.Parallel()
+
+	code, err := New().GenerateCode(newServiceData("api", Options{Binding: true}, newService("ItemService",
+		newMethod("GetItem", "Item", "Item"),
+	)))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	for _, unexpected := range []string{"Enqueuer", "serveAsync", "OperationRef"} {
+		if strings.Contains(code, unexpected) {
+			t.Errorf("Generated code contains %q without an async method", unexpected)
+		}
+	}
+}
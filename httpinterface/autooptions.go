@@ -0,0 +1,125 @@
+package httpinterface
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// wildcardRegex matches a path wildcard such as {id} or {name...}, but not
+// {$}, capturing the "..." of a multi-segment one.
+var wildcardRegex = regexp.MustCompile(`\{[^/{}$]*?(\.\.\.)?\}`)
+
+// pathKey returns pattern with its wildcards unnamed, as {} or {...}, so
+// patterns http.ServeMux serves alike compare equal. {$} is kept, as are the
+// "..." of multi-segment wildcards, which match other paths than {}.
+func pathKey(pattern string) string {
+	return wildcardRegex.ReplaceAllString(pattern, "{$1}")
+}
+
+// DefaultDiscoveryURL is the discovery document OPTIONS responses link to
+// with self_description when discovery_url is not set.
+const DefaultDiscoveryURL = "/openapi.json"
+
+// OptionsRoute is an OPTIONS responder synthesized with auto_options for one
+// path of a service.
+type OptionsRoute struct {
+	// Pattern is the path as written by the first route registered on it.
+	Pattern string
+	// Allow is the sorted, comma-separated list of methods the path accepts.
+	Allow string
+}
+
+// OptionsRoutes returns one OptionsRoute per distinct path of the service's
+// routes, in the order the paths are first registered. Paths that differ only
+// in wildcard names are the same path to http.ServeMux and share a responder;
+// see pathKey.
+// GET routes also accept HEAD. Paths with an explicit OPTIONS binding are
+// skipped so the method's own handler answers them.
+func (s ServiceInfo) OptionsRoutes() []OptionsRoute {
+	var (
+		order    []string
+		patterns = map[string]string{}
+		methods  = map[string][]string{}
+	)
+	for _, method := range s.Methods {
+		for _, rule := range method.HTTPRules {
+			key := pathKey(rule.Pattern)
+			if _, ok := patterns[key]; !ok {
+				order = append(order, key)
+				patterns[key] = rule.Pattern
+			}
+			methods[key] = append(methods[key], strings.ToUpper(rule.Method))
+			if strings.EqualFold(rule.Method, http.MethodGet) {
+				methods[key] = append(methods[key], http.MethodHead)
+			}
+		}
+	}
+
+	routes := make([]OptionsRoute, 0, len(order))
+	for _, key := range order {
+		allow := methods[key]
+		if slices.Contains(allow, http.MethodOptions) {
+			continue
+		}
+		allow = append(allow, http.MethodOptions)
+		slices.Sort(allow)
+		routes = append(routes, OptionsRoute{
+			Pattern: patterns[key],
+			Allow:   strings.Join(slices.Compact(allow), ", "),
+		})
+	}
+	return routes
+}
+
+// Methods returns the methods of Allow.
+func (r OptionsRoute) Methods() []string {
+	return strings.Split(r.Allow, ", ")
+}
+
+// RouteMethod is a route sharing the path of an OptionsRoute, described by its
+// OPTIONS response with self_description.
+type RouteMethod struct {
+	Method string
+	// RPC is the full name of the route's method, e.g. "/tasks.v1.TaskService/GetTask".
+	RPC string
+	// Body is set when the route's rule takes a request body.
+	Body bool
+	// Tags are the tags of the route's method.
+	Tags []string
+}
+
+// RouteMethods returns the routes of the service on the path of route, in
+// registration order.
+func (s ServiceInfo) RouteMethods(route OptionsRoute) []RouteMethod {
+	key := pathKey(route.Pattern)
+	var methods []RouteMethod
+	for _, method := range s.Methods {
+		for _, rule := range method.HTTPRules {
+			if pathKey(rule.Pattern) == key {
+				methods = append(methods, RouteMethod{
+					Method: strings.ToUpper(rule.Method),
+					RPC:    s.RPCName(method),
+					Body:   rule.Body != "",
+					Tags:   method.Tags,
+				})
+			}
+		}
+	}
+	return methods
+}
+
+// SchemaLink returns the link to the path of r in the OpenAPI discovery
+// document at discoveryURL, or DefaultDiscoveryURL when empty: a JSON pointer
+// fragment such as "/openapi.json#/paths/~1v1~1tasks~1%7Btask_id%7D".
+func (r OptionsRoute) SchemaLink(discoveryURL string) string {
+	if discoveryURL == "" {
+		discoveryURL = DefaultDiscoveryURL
+	}
+	// OpenAPI paths name multi-segment wildcards like single-segment ones.
+	path := strings.ReplaceAll(r.Pattern, "...}", "}")
+	pointer := "/paths/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(path)
+	return discoveryURL + "#" + (&url.URL{Fragment: pointer}).EscapedFragment()
+}
@@ -0,0 +1,162 @@
+package httpinterface
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpinterface/parser"
+)
+
+func TestOptionsRoutes(t *testing.T) {
+	t.Parallel()
+
+	method := func(name string, rules ...parser.HTTPRule) MethodInfo {
+		return MethodInfo{Name: name, HTTPRules: rules}
+	}
+
+	tests := []struct {
+		name    string
+		service ServiceInfo
+		want    []OptionsRoute
+	}{
+		{
+			name: "sibling routes share a path",
+			service: ServiceInfo{Methods: []MethodInfo{
+				method("ListItems", parser.HTTPRule{Method: "GET", Pattern: "/items"}),
+				method("CreateItem", parser.HTTPRule{Method: "POST", Pattern: "/items"}),
+				method("UpdateItem",
+					parser.HTTPRule{Method: "PUT", Pattern: "/items/{id}"},
+					parser.HTTPRule{Method: "PATCH", Pattern: "/items/{item_id}"},
+				),
+				method("DeleteItem", parser.HTTPRule{Method: "DELETE", Pattern: "/items/{id}"}),
+			}},
+			want: []OptionsRoute{
+				{Pattern: "/items", Allow: "GET, HEAD, OPTIONS, POST"},
+				{Pattern: "/items/{id}", Allow: "DELETE, OPTIONS, PATCH, PUT"},
+			},
+		},
+		{
+			name: "custom method",
+			service: ServiceInfo{Methods: []MethodInfo{
+				method("MergeItems", parser.HTTPRule{Method: "MERGE", Pattern: "/items:merge"}),
+			}},
+			want: []OptionsRoute{{Pattern: "/items:merge", Allow: "MERGE, OPTIONS"}},
+		},
+		{
+			name: "multi-segment and end wildcards are distinct paths",
+			service: ServiceInfo{Methods: []MethodInfo{
+				method("GetItem", parser.HTTPRule{Method: "GET", Pattern: "/items/{id}"}),
+				method("GetFile", parser.HTTPRule{Method: "GET", Pattern: "/items/{name...}"}),
+				method("ListItems", parser.HTTPRule{Method: "GET", Pattern: "/items/{$}"}),
+			}},
+			want: []OptionsRoute{
+				{Pattern: "/items/{id}", Allow: "GET, HEAD, OPTIONS"},
+				{Pattern: "/items/{name...}", Allow: "GET, HEAD, OPTIONS"},
+				{Pattern: "/items/{$}", Allow: "GET, HEAD, OPTIONS"},
+			},
+		},
+		{
+			name: "explicit options binding",
+			service: ServiceInfo{Methods: []MethodInfo{
+				method("GetItem", parser.HTTPRule{Method: "GET", Pattern: "/items/{id}"}),
+				method("DescribeItem", parser.HTTPRule{Method: "OPTIONS", Pattern: "/items/{id}"}),
+			}},
+			want: []OptionsRoute{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.service.OptionsRoutes(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("OptionsRoutes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateCodeAutoOptions(t *testing.T) {
+	t.Parallel()
+	g := New()
+
+	data := func(autoOptions bool) *ServiceData {
+		return newServiceData("api", Options{AutoOptions: autoOptions}, newService("ItemService",
+			newMethod("GetItem", "Item", "Item", newRule("GET", "/items/{id}", "")),
+			newMethod("DeleteItem", "Item", "Item", newRule("DELETE", "/items/{id}", "")),
+		))
+	}
+
+	code, err := g.GenerateCode(data(true))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	for _, expected := range []string{
+		"func allowMethods(w http.ResponseWriter, r *http.Request, allow []string, _ []any) {",
+		"func handleOptions(r Routes, pattern string, allow []string, detail any, respond func(w http.ResponseWriter, r *http.Request, allow []string, details []any)) {",
+		`handleOptions(r, "/items/{id}", []string{"DELETE", "GET", "HEAD", "OPTIONS"}, nil, allowMethods)`,
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+	if n := strings.Count(code, "handleOptions(r, "); n != 1 {
+		t.Errorf("OPTIONS route registered %d times, want 1", n)
+	}
+
+	code, err = g.GenerateCode(data(false))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	if strings.Contains(code, "handleOptions") {
+		t.Error("Generated code contains handleOptions without auto_options")
+	}
+}
+
+func TestGenerateCodeSelfDescription(t *testing.T) {
+	t.Parallel()
+	g := New()
+
+	code, err := g.GenerateCode(newServiceData("api",
+		Options{AutoOptions: true, SelfDescription: true, DiscoveryURL: "https://items.example.com/openapi.yaml"},
+		newService("items.v1.ItemService",
+			newMethod("GetItem", "Item", "Item", newRule("GET", "/items/{id}", "")),
+			newMethod("UpdateItem", "Item", "Item", newRule("PATCH", "/items/{item_id}", "item")),
+		),
+	))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	for _, expected := range []string{
+		"func describeRoute(schema string) func(w http.ResponseWriter, r *http.Request, allow []string, details []any) {",
+		"\t\taccepted := []string{\"application/json\"}\n",
+		`handleOptions(r, "/items/{id}", []string{"GET", "HEAD", "OPTIONS", "PATCH"}, []RouteMethod{` + "\n" +
+			"\t\t{Method: \"GET\", RPC: \"/items.v1.ItemService/GetItem\"},\n" +
+			"\t\t{Method: \"PATCH\", RPC: \"/items.v1.ItemService/UpdateItem\", Body: true},\n" +
+			"\t}, describeRoute(\"https://items.example.com/openapi.yaml#/paths/~1items~1%7Bid%7D\"))\n",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+	if strings.Contains(code, "allowMethods") {
+		t.Error("Generated code contains allowMethods with self_description")
+	}
+}
+
+func TestOptionsRouteSchemaLink(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern, discoveryURL, want string
+	}{
+		{"/v1/tasks", "", "/openapi.json#/paths/~1v1~1tasks"},
+		{"/v1/{name...}", "/api/openapi.yaml", "/api/openapi.yaml#/paths/~1v1~1%7Bname%7D"},
+		{"/v1/tasks/{task_id}:complete", "https://tasks.example.com/openapi.json", "https://tasks.example.com/openapi.json#/paths/~1v1~1tasks~1%7Btask_id%7D:complete"},
+	}
+	for _, tt := range tests {
+		if got := (OptionsRoute{Pattern: tt.pattern}).SchemaLink(tt.discoveryURL); got != tt.want {
+			t.Errorf("SchemaLink(%q) for %q = %q, want %q", tt.discoveryURL, tt.pattern, got, tt.want)
+		}
+	}
+}
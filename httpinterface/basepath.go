@@ -0,0 +1,20 @@
+package httpinterface
+
+import (
+	"fmt"
+	"strings"
+)
+
+// checkBasePath reports services whose (http_server.base_path) option is not
+// a literal path starting with "/". Base paths taken from the proto package
+// always are.
+func checkBasePath(data *ServiceData) error {
+	for _, service := range data.Services {
+		base := service.BasePath
+		if base != "" && (!strings.HasPrefix(base, "/") || strings.ContainsAny(base, "{}*?# \t")) {
+			return fmt.Errorf("%s sets (http_server.base_path) %q, which must be a literal path starting with /",
+				service.Name, base)
+		}
+	}
+	return nil
+}
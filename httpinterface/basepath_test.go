@@ -0,0 +1,82 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpserver"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGenerateBasePath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		noPackage   bool
+		base        string
+		wantErr     string
+		wantContain []string
+	}{
+		{
+			name: "from package",
+			wantContain: []string{
+				`const ItemServiceBasePath = "/items.v1"`,
+				"func RegisterItemServiceRoutesAt(r Router, base string, handler ItemServiceHandler) error {",
+				"\treturn RegisterItemServiceRoutes(r.Group(base), handler)\n",
+			},
+		},
+		{
+			name:        "option",
+			base:        "/api/items",
+			wantContain: []string{`const ItemServiceBasePath = "/api/items"`},
+		},
+		{
+			name:        "no package",
+			noPackage:   true,
+			wantContain: []string{`const ItemServiceBasePath = ""`},
+		},
+		{
+			name:    "relative option",
+			base:    "api/items",
+			wantErr: `items.proto: ItemService sets (http_server.base_path) "api/items", which must be a literal path starting with /`,
+		},
+		{
+			name:    "pattern option",
+			base:    "/tenants/{tenant}",
+			wantErr: "which must be a literal path starting with /",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			file := itemsFile(itemMethod("GetItem", getRule("/items/{id}")))
+			if tt.noPackage {
+				file.Package = nil
+				method := file.Service[0].Method[0]
+				method.InputType, method.OutputType = proto.String(".Item"), proto.String(".Item")
+			}
+			if tt.base != "" {
+				setServiceExtension(file, httpserver.E_BasePath, tt.base)
+			}
+			resp := New().Generate(pluginRequest(t, "", file))
+
+			if tt.wantErr != "" {
+				if !strings.Contains(resp.GetError(), tt.wantErr) {
+					t.Fatalf("Generate() error = %q, want error containing %q", resp.GetError(), tt.wantErr)
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+			code := resp.File[0].GetContent()
+			for _, expected := range tt.wantContain {
+				if !strings.Contains(code, expected) {
+					t.Errorf("Generated code doesn't contain %q", expected)
+				}
+			}
+		})
+	}
+}
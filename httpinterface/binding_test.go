@@ -0,0 +1,178 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestGenerateCodeBindingDisabled ensures the binding runtime and its imports are opt-in
+func TestGenerateCodeBindingDisabled(t *testing.T) {
+	t.Parallel()
+	g := New()
+
+	code, err := g.GenerateCode(taskServiceData(Options{}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+
+	for _, unexpected := range []string{"PopulateQueryParameters", "google.golang.org/protobuf", `"net/url"`} {
+		if strings.Contains(code, unexpected) {
+			t.Errorf("Generated code should not contain %q without binding=true", unexpected)
+		}
+	}
+}
+
+// TestGenerateCodeBindingQueryParameters tests the generated query parameter binding runtime
+func TestGenerateCodeBindingQueryParameters(t *testing.T) {
+	t.Parallel()
+	g := New()
+
+	code, err := g.GenerateCode(taskServiceData(Options{Binding: true}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+
+	expectedContents := []string{
+		// Imports are grouped: standard library first, then protobuf
+		"\t\"time\"\n\n\t\"google.golang.org/protobuf/encoding/protojson\"",
+		`"google.golang.org/protobuf/proto"`,
+		`"google.golang.org/protobuf/reflect/protoreflect"`,
+		`"net/url"`,
+		"func PopulateQueryParameters(msg proto.Message, values url.Values, filter ...string) error",
+		// Dotted keys for nested messages
+		`strings.Split(key, ".")`,
+		// Bracketed keys for map entries
+		"func (b *fieldBinder) setMapEntry(",
+		// Repeated keys and comma-separated lists
+		`strings.Split(raw, ",")`,
+		// Proto and JSON field names
+		"fields.ByJSONName(name)",
+		// Well-known types
+		`"google.protobuf.Timestamp"`,
+		`"google.protobuf.StringValue"`,
+		// Enums by name or number
+		"fd.Enum().Values().ByName(protoreflect.Name(raw))",
+	}
+
+	for _, expected := range expectedContents {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+}
+
+// TestGenerateCodeBindingOneofConflicts tests that generated binding rejects conflicting oneof members
+func TestGenerateCodeBindingOneofConflicts(t *testing.T) {
+	t.Parallel()
+	g := New()
+
+	code, err := g.GenerateCode(taskServiceData(Options{Binding: true}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+
+	expectedContents := []string{
+		"func BindRequest(r *http.Request, msg proto.Message, body string, pathParams ...string) error",
+		"type OneofConflictError struct",
+		"func (e *OneofConflictError) Error() string",
+		// Every assignment claims its oneof before setting the field
+		"if err := b.claim(m, fd, key); err != nil",
+		// Members set before path and query binding came from the body
+		`setBy = "the request body"`,
+		`b.source = "path parameter"`,
+		`b.source = "query parameter"`,
+	}
+
+	for _, expected := range expectedContents {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+}
+
+// TestGenerateCodeBindingDefaults tests generation of presence-aware default value injection
+func TestGenerateCodeBindingDefaults(t *testing.T) {
+	t.Parallel()
+	g := New()
+
+	tests := []struct {
+		name        string
+		opts        Options
+		wantApplied bool
+	}{
+		{name: "binding only", opts: Options{Binding: true}, wantApplied: false},
+		{name: "binding with apply_defaults", opts: Options{Binding: true, ApplyDefaults: true}, wantApplied: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			code, err := g.GenerateCode(taskServiceData(tt.opts))
+			if err != nil {
+				t.Fatalf("GenerateCode() error = %v", err)
+			}
+
+			// ApplyDefaults is always available to handlers once binding is enabled
+			for _, expected := range []string{
+				"func ApplyDefaults(msg proto.Message)",
+				"fd.HasDefault() && fd.HasPresence() && fd.ContainingOneof() == nil",
+			} {
+				if !strings.Contains(code, expected) {
+					t.Errorf("Generated code doesn't contain %q", expected)
+				}
+			}
+
+			if got := strings.Contains(code, "\tapplyDefaults(m)\n\treturn nil"); got != tt.wantApplied {
+				t.Errorf("BindRequest applies defaults = %v, want %v", got, tt.wantApplied)
+			}
+		})
+	}
+}
+
+// TestGenerateCodeBindingPresence tests generation of presence-aware field reporting and response encoding
+func TestGenerateCodeBindingPresence(t *testing.T) {
+	t.Parallel()
+	g := New()
+
+	tests := []struct {
+		name        string
+		opts        Options
+		wantDefault string
+	}{
+		{
+			name:        "unset optionals omitted by default",
+			opts:        Options{Binding: true},
+			wantDefault: "var DefaultResponseEncoder = ResponseEncoder{EmitUnsetOptionals: false}",
+		},
+		{
+			name:        "emit_unset_optionals",
+			opts:        Options{Binding: true, EmitUnsetOptionals: true},
+			wantDefault: "var DefaultResponseEncoder = ResponseEncoder{EmitUnsetOptionals: true}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			code, err := g.GenerateCode(taskServiceData(tt.opts))
+			if err != nil {
+				t.Fatalf("GenerateCode() error = %v", err)
+			}
+
+			for _, expected := range []string{
+				`"encoding/json"`,
+				"func PresentFields(msg proto.Message) []string",
+				"type ResponseEncoder struct",
+				"func WriteResponse(w http.ResponseWriter, status int, msg proto.Message) error",
+				"func (e ResponseEncoder) Marshal(msg proto.Message) ([]byte, error)",
+				// Proto3 optional fields live in synthetic oneofs but are still written as null
+				"fd.HasPresence() && (od == nil || od.IsSynthetic())",
+				tt.wantDefault,
+			} {
+				if !strings.Contains(code, expected) {
+					t.Errorf("Generated code doesn't contain %q", expected)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package httpinterface
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpinterface/parser"
+)
+
+// HasCache reports whether any method in d sets the (http_server.cache)
+// option, so the generated file needs the ResponseCache helpers.
+func (d *ServiceData) HasCache() bool {
+	for _, service := range d.Services {
+		for _, method := range service.Methods {
+			if method.CacheTTLSeconds > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CachesRule reports whether rule is served through the response cache: the
+// method sets (http_server.cache) and rule is a GET binding.
+func (m MethodInfo) CachesRule(rule parser.HTTPRule) bool {
+	return m.CacheTTLSeconds > 0 && rule.Method == "GET"
+}
+
+// CachesEveryRule reports whether every binding of m is served through the
+// response cache, so its routes never use the handler without it.
+func (m MethodInfo) CachesEveryRule() bool {
+	return !slices.ContainsFunc(m.HTTPRules, func(rule parser.HTTPRule) bool { return !m.CachesRule(rule) })
+}
+
+// checkCache reports an error if a method in data sets the (http_server.cache)
+// option without a GET binding, as only GET responses are cached.
+func checkCache(data *ServiceData) error {
+	for _, service := range data.Services {
+		for _, method := range service.Methods {
+			if method.CacheTTLSeconds > 0 && !slices.ContainsFunc(method.HTTPRules, method.CachesRule) {
+				return fmt.Errorf("%s.%s sets (http_server.cache) but has no GET binding",
+					service.Name, method.Name)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpserver"
+	options "google.golang.org/genproto/googleapis/api/annotations"
+)
+
+func TestGenerateCache(t *testing.T) {
+	t.Parallel()
+
+	// Each case caches GetItem, bound to rule, next to the uncached ListItems.
+	cache := withExtension(httpserver.E_Cache, &httpserver.Cache{TtlSeconds: 30})
+	tests := []struct {
+		name        string
+		rule        *options.HttpRule
+		wantErr     string
+		wantContain []string
+	}{
+		{
+			name: "get binding",
+			rule: &options.HttpRule{
+				Pattern: &options.HttpRule_Get{Get: "/items/{id}"},
+				AdditionalBindings: []*options.HttpRule{
+					{Pattern: &options.HttpRule_Post{Post: "/items/{id}/get"}, Body: "*"},
+				},
+			},
+			wantContain: []string{
+				"\t\"sync/atomic\"\n",
+				"type Cache interface",
+				"type ResponseCache struct",
+				"\tcache       *ResponseCache\n",
+				"func WithResponseCache(c *ResponseCache) RouterOption {",
+				"func NewRouter(mux *http.ServeMux, opts ...RouterOption) *RouteGroup {",
+				"\tif g.cache != nil {\n\t\thandler = withResponseCache(g.cache, handler)\n\t}\n",
+				"func (c *ResponseCache) Stats() CacheStats",
+				`r.HandleFunc(http.MethodGet, "/items/{id}", cacheResponse("/items/{id}", 30, handler.HandleGetItem))`,
+				`r.HandleFunc(http.MethodGet, "/items/{id}", applyMiddlewares(cacheResponse("/items/{id}", 30, handler.HandleGetItem), middlewares).ServeHTTP)`,
+				`r.HandleFunc(http.MethodPost, "/items/{id}/get", h.ServeHTTP)`,
+				`return applyMiddlewares(cacheResponse("/items/{id}", 30, handler.HandleGetItem), middlewares).ServeHTTP`,
+				`r.HandleFunc(http.MethodPost, "/items/{id}/get", handler.HandleGetItem)`,
+				`r.HandleFunc(http.MethodGet, "/items", handler.HandleListItems)`,
+			},
+		},
+		{
+			name:    "no get binding",
+			rule:    &options.HttpRule{Pattern: &options.HttpRule_Post{Post: "/items/{id}/get"}, Body: "*"},
+			wantErr: "items.proto: ItemService.GetItem sets (http_server.cache) but has no GET binding",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp := New().Generate(itemsRequest(t, "",
+				itemMethod("GetItem", tt.rule, cache),
+				itemMethod("ListItems", getRule("/items")),
+			))
+
+			if tt.wantErr != "" {
+				if !strings.Contains(resp.GetError(), tt.wantErr) {
+					t.Fatalf("Generate() error = %q, want error containing %q", resp.GetError(), tt.wantErr)
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+			code := resp.File[0].GetContent()
+			for _, expected := range tt.wantContain {
+				if !strings.Contains(code, expected) {
+					t.Errorf("Generated code doesn't contain %q", expected)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateCodeWithoutCache(t *testing.T) {
+	t.Parallel()
+
+	code, err := New().GenerateCode(newServiceData("api", Options{}, newService("ItemService",
+		newMethod("GetItem", "Item", "Item"),
+	)))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	for _, unexpected := range []string{"ResponseCache", "cacheResponse", "\t\"time\"\n"} {
+		if strings.Contains(code, unexpected) {
+			t.Errorf("Generated code contains %q without a cached method", unexpected)
+		}
+	}
+}
+
+func TestGenerateCacheInsideRouteMiddlewares(t *testing.T) {
+	t.Parallel()
+
+	resp := New().Generate(itemsRequest(t, "route_config=true",
+		itemMethod("GetItem", getRule("/items/{id}"), withExtension(httpserver.E_Cache, &httpserver.Cache{TtlSeconds: 30})),
+	))
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	code := resp.File[0].GetContent()
+	for _, expected := range []string{
+		`c.handler(0, 0, applyMiddlewares(cacheResponse("/items/{id}", 30, handler.HandleGetItem), c.Middlewares).ServeHTTP)`,
+		`r.HandleFunc(http.MethodGet, "/items/{id}", applyMiddlewares(cacheResponse("/items/{id}", 30, handler.HandleGetItem), middlewares).ServeHTTP)`,
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+	// Every route of GetItem is cached, so none uses the handler without the cache.
+	if unexpected := "applyMiddlewares(http.HandlerFunc(handler.HandleGetItem)"; strings.Contains(code, unexpected) {
+		t.Errorf("Generated code contains %q", unexpected)
+	}
+}
+
+func TestGenerateCacheRuntimeModule(t *testing.T) {
+	t.Parallel()
+
+	resp := New().Generate(itemsRequest(t, "runtime_module=example.com/items/httpserverts",
+		itemMethod("GetItem", getRule("/items/{id}"), withExtension(httpserver.E_Cache, &httpserver.Cache{TtlSeconds: 30})),
+	))
+	want := "items.proto: runtime_module cannot serve the cache of ItemService.GetItem"
+	if !strings.HasPrefix(resp.GetError(), want) {
+		t.Errorf("Generate() error = %q, want prefix %q", resp.GetError(), want)
+	}
+}
@@ -0,0 +1,47 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateCapture(t *testing.T) {
+	t.Parallel()
+
+	code, err := New().GenerateCode(uploadServiceData(Options{Capture: true}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+
+	for _, expected := range []string{
+		"var captureRoutes = []struct{ method, pattern, rpc string }{\n" +
+			"\t{\"GET\", \"/uploads/{id}\", \"/UploadService/GetUpload\"},\n" +
+			"\t{\"POST\", \"/uploads\", \"/UploadService/CreateUpload\"},\n}",
+		"func NewCapture(size int) *Capture {",
+		"func (c *Capture) EnableCapture(rpc string, n int) {",
+		"func (c *Capture) Middleware() Middleware {",
+		"rpc := captureRPC(r.Pattern)",
+		"func (c *Capture) Handler(authorize func(*http.Request) bool) http.Handler {",
+		"func RegisterCaptureRoutes(r Routes, c *Capture, authorize func(*http.Request) bool) error {",
+		`"strconv"`,
+		`"sync"`,
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+}
+
+func TestGenerateWithoutCapture(t *testing.T) {
+	t.Parallel()
+
+	code, err := New().GenerateCode(uploadServiceData(Options{}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	for _, unexpected := range []string{"captureRoutes", "CapturedExchange", `"strconv"`} {
+		if strings.Contains(code, unexpected) {
+			t.Errorf("Generated code contains %q without capture=true", unexpected)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateChaos(t *testing.T) {
+	t.Parallel()
+
+	code, err := New().GenerateCode(uploadServiceData(Options{Chaos: true}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+
+	for _, expected := range []string{
+		"type ChaosRule struct",
+		"func NewChaos() *Chaos {",
+		"func (c *Chaos) Set(route string, rule ChaosRule) {",
+		"func (c *Chaos) Middleware() Middleware {",
+		"rule, ok := c.rule(r.Pattern)",
+		`rule, ok := c.rules["*"]`,
+		"type truncatingResponseWriter struct",
+		`"math/rand"`,
+		`"sync"`,
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+}
+
+func TestGenerateWithoutChaos(t *testing.T) {
+	t.Parallel()
+
+	code, err := New().GenerateCode(uploadServiceData(Options{}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	for _, unexpected := range []string{"ChaosRule", "truncatingResponseWriter", `"math/rand"`} {
+		if strings.Contains(code, unexpected) {
+			t.Errorf("Generated code contains %q without chaos=true", unexpected)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package httpinterface
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+// cliReservedFlags are the flags of the generated CLIs themselves. Request
+// fields with these names can only be set through --data.
+var cliReservedFlags = []string{"base-url", "data", "help"}
+
+// cliQueryMessages are the well-known types a flag can set, as
+// PopulateQueryParameters parses them from a single string.
+var cliQueryMessages = []string{
+	".google.protobuf.Timestamp", ".google.protobuf.Duration", ".google.protobuf.FieldMask",
+	".google.protobuf.DoubleValue", ".google.protobuf.FloatValue",
+	".google.protobuf.Int64Value", ".google.protobuf.UInt64Value",
+	".google.protobuf.Int32Value", ".google.protobuf.UInt32Value",
+	".google.protobuf.BoolValue", ".google.protobuf.StringValue", ".google.protobuf.BytesValue",
+}
+
+// cliTemplateData is the data passed to the cli-main template for one service.
+type cliTemplateData struct {
+	Source  string
+	Import  GoImport
+	Service ServiceInfo
+	// MessageImports are the other packages holding the request types of
+	// Commands, the only messages the CLI constructs.
+	MessageImports []GoImport
+	// Command is the name of the CLI, e.g. "taskservicecli".
+	Command  string
+	Commands []cliCommand
+}
+
+// cliCommand is the subcommand calling one RPC.
+type cliCommand struct {
+	Method MethodInfo
+	// Use is the subcommand name, e.g. "get-task".
+	Use string
+	// InputType is the Go type of the request message, qualified with its package.
+	InputType string
+	Flags     []cliFlag
+}
+
+// cliFlag is a flag setting a request field.
+type cliFlag struct {
+	// Name is the flag name, e.g. "task-id" or "task.title".
+	Name string
+	// Field is the dotted proto field path, e.g. "task_id" or "task.title".
+	Field string
+	// Usage describes the field type.
+	Usage    string
+	Repeated bool
+}
+
+// generateCLIFiles returns a cmd/<service>cli/main.go for each service of
+// file, next to its generated code: a cobra command with a subcommand per RPC
+// that sets the request from flags and calls the service through the
+// generated client. Methods marked (http_server.stream_body) are left out.
+func (g *Generator) generateCLIFiles(file *descriptor.FileDescriptorProto, data *ServiceData) ([]*plugin.CodeGeneratorResponse_File, error) {
+	if data.GoImport.Path == "" {
+		return nil, fmt.Errorf("%s: cli needs the Go import path of the generated code; set go_package or import_alias", file.GetName())
+	}
+
+	outputFile := &plugin.CodeGeneratorResponse_File{Name: proto.String(g.getOutputFilename(file.GetName()))}
+	g.applySourceRelativePath(outputFile, file.GetName())
+	dir := path.Dir(outputFile.GetName())
+
+	files := make([]*plugin.CodeGeneratorResponse_File, 0, len(data.Services))
+	for _, service := range data.Services {
+		cli := cliTemplateData{
+			Source:  file.GetName(),
+			Import:  data.GoImport,
+			Service: service,
+			Command: strings.ToLower(service.Name) + "cli",
+		}
+		for _, method := range service.Methods {
+			if method.StreamBody {
+				continue
+			}
+			rpc := findMethod(file, service.Name, method.Name)
+			inputType := method.InputGoType
+			if !strings.Contains(inputType, ".") {
+				inputType = data.GoImport.Name + "." + inputType
+			}
+			cli.Commands = append(cli.Commands, cliCommand{
+				Method:    method,
+				Use:       strings.ReplaceAll(snakeCase(method.Name), "_", "-"),
+				InputType: inputType,
+				Flags:     g.cliFlags(rpc.GetInputType(), "", map[string]bool{}),
+			})
+			if imp := method.InputImport; imp.Path != "" && imp.Path != data.GoImport.Path {
+				cli.MessageImports = append(cli.MessageImports, imp)
+			}
+		}
+		sortImports(cli.MessageImports)
+		cli.MessageImports = slices.Compact(cli.MessageImports)
+
+		name := path.Join(dir, "cmd", cli.Command, "main.go")
+		content, err := g.renderScaffold(name, "cli-main", cli)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate %s: %v", name, err)
+		}
+		files = append(files, &plugin.CodeGeneratorResponse_File{
+			Name:    proto.String(name),
+			Content: proto.String(content),
+		})
+	}
+	return files, nil
+}
+
+// cliFlags returns the flags for the fields of the message typeName that a
+// single string value can set, prefixing their paths with prefix and
+// descending into message fields not already on the path in visiting. Maps and
+// repeated messages can only be set through --data.
+func (g *Generator) cliFlags(typeName, prefix string, visiting map[string]bool) []cliFlag {
+	msg := g.protoTypes.messages[typeName]
+	if msg == nil || visiting[typeName] {
+		return nil
+	}
+	visiting[typeName] = true
+	defer delete(visiting, typeName)
+
+	var flags []cliFlag
+	for _, field := range msg.GetField() {
+		fieldPath := prefix + field.GetName()
+		repeated := field.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED
+		usage := strings.ToLower(strings.TrimPrefix(field.GetType().String(), "TYPE_"))
+
+		switch field.GetType() {
+		case descriptor.FieldDescriptorProto_TYPE_MESSAGE, descriptor.FieldDescriptorProto_TYPE_GROUP:
+			if !slices.Contains(cliQueryMessages, field.GetTypeName()) {
+				if !repeated {
+					flags = append(flags, g.cliFlags(field.GetTypeName(), fieldPath+".", visiting)...)
+				}
+				continue
+			}
+			usage = strings.TrimPrefix(field.GetTypeName(), ".")
+		case descriptor.FieldDescriptorProto_TYPE_ENUM:
+			usage = g.cliEnumUsage(field.GetTypeName())
+		}
+		if repeated {
+			usage += ", repeatable"
+		}
+
+		name := strings.ReplaceAll(fieldPath, "_", "-")
+		if slices.Contains(cliReservedFlags, name) {
+			continue
+		}
+		flags = append(flags, cliFlag{Name: name, Field: fieldPath, Usage: usage, Repeated: repeated})
+	}
+	return flags
+}
+
+// cliEnumUsage lists the values of the enum typeName for a flag's usage.
+func (g *Generator) cliEnumUsage(typeName string) string {
+	enum := g.protoTypes.enums[typeName]
+	if enum == nil {
+		return "enum"
+	}
+	names := make([]string, len(enum.GetValue()))
+	for i, value := range enum.GetValue() {
+		names[i] = value.GetName()
+	}
+	return "one of " + strings.Join(names, ", ")
+}
@@ -0,0 +1,113 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+
+	options "google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestGenerateCLI(t *testing.T) {
+	t.Parallel()
+
+	req := graphqlRequest(t, "binding=true,client=true,cli=true")
+	req.ProtoFile[0].Options = &descriptor.FileOptions{GoPackage: proto.String("example.com/items/pb;itemspb")}
+	resp := New().Generate(req)
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	files := map[string]string{}
+	for _, f := range resp.File {
+		files[f.GetName()] = f.GetContent()
+	}
+
+	main, ok := files["cmd/itemservicecli/main.go"]
+	if !ok {
+		t.Fatalf("missing cmd/itemservicecli/main.go in %d generated files", len(resp.File))
+	}
+	for _, expected := range []string{
+		"// Code generated by protoc-gen-go-http-server-interface. DO NOT EDIT.\n// source: items.proto\n",
+		"package main",
+		`itemspb "example.com/items/pb"`,
+		`os.Getenv("ITEMSERVICE_URL")`,
+		"return itemspb.NewItemServiceClient(baseURL, nil)",
+		`use:   "get-item",`,
+		`short: "Call GetItem: GET /v1/items/{item_id}",`,
+		`{name: "item-id", field: "item_id", usage: "string"},`,
+		`{name: "state", field: "state", usage: "one of STATE_UNSPECIFIED, STATE_ACTIVE"},`,
+		`{name: "tags", field: "tags", usage: "string, repeatable", repeated: true},`,
+		`{name: "owner.name", field: "owner.name", usage: "string"},`,
+		"return &itemspb.ExportRequest{}",
+		"return client().SaveItem(ctx, req.(*itemspb.Item))",
+	} {
+		if !strings.Contains(main, expected) {
+			t.Errorf("Generated CLI doesn't contain %q", expected)
+		}
+	}
+	if strings.Contains(main, `name: "labels"`) {
+		t.Error("Generated CLI has a flag for a map field")
+	}
+}
+
+func TestGenerateCLIImportsRequestPackagesOnly(t *testing.T) {
+	t.Parallel()
+
+	req := graphqlRequest(t, "binding=true,client=true,cli=true")
+	file := req.ProtoFile[0]
+	file.Options = &descriptor.FileOptions{GoPackage: proto.String("example.com/items/pb;itemspb")}
+	file.Dependency = append(file.Dependency, "google/protobuf/empty.proto")
+	deleteOpts := &descriptor.MethodOptions{}
+	proto.SetExtension(deleteOpts, options.E_Http, &options.HttpRule{Pattern: &options.HttpRule_Delete{Delete: "/v1/items/{item_id}"}})
+	file.Service[0].Method = append(file.Service[0].Method, &descriptor.MethodDescriptorProto{
+		Name: proto.String("DeleteItem"), InputType: proto.String(".items.v1.GetItemRequest"), OutputType: proto.String(".google.protobuf.Empty"),
+		Options: deleteOpts,
+	})
+	req.ProtoFile = append([]*descriptor.FileDescriptorProto{protodesc.ToFileDescriptorProto(emptypb.File_google_protobuf_empty_proto)}, req.ProtoFile...)
+
+	resp := New().Generate(req)
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	for _, f := range resp.File {
+		if f.GetName() != "cmd/itemservicecli/main.go" {
+			continue
+		}
+		if strings.Contains(f.GetContent(), "emptypb") {
+			t.Errorf("Generated CLI imports the package of a response type:\n%s", f.GetContent())
+		}
+		if !strings.Contains(f.GetContent(), "return client().DeleteItem(ctx, req.(*itemspb.GetItemRequest))") {
+			t.Error("Generated CLI has no delete-item command")
+		}
+		return
+	}
+	t.Fatal("missing cmd/itemservicecli/main.go")
+}
+
+func TestGenerateCLIWithoutImportPath(t *testing.T) {
+	t.Parallel()
+
+	resp := New().Generate(graphqlRequest(t, "binding=true,client=true,cli=true"))
+	if !strings.Contains(resp.GetError(), "items.proto: cli needs the Go import path") {
+		t.Errorf("Generate() error = %q, want missing import path", resp.GetError())
+	}
+}
+
+func TestGenerateWithoutCLI(t *testing.T) {
+	t.Parallel()
+
+	req := graphqlRequest(t, "binding=true,client=true")
+	req.ProtoFile[0].Options = &descriptor.FileOptions{GoPackage: proto.String("example.com/items/pb;itemspb")}
+	resp := New().Generate(req)
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	for _, f := range resp.File {
+		if strings.Contains(f.GetName(), "/cmd/") {
+			t.Errorf("Generated %s without cli=true", f.GetName())
+		}
+	}
+}
@@ -0,0 +1,11 @@
+package httpinterface
+
+import "github.com/farhaan/protoc-gen-go-http-server-interface/httpinterface/parser"
+
+// PrimaryRule returns m's primary binding, the one generated clients call.
+func (m MethodInfo) PrimaryRule() parser.HTTPRule {
+	if len(m.HTTPRules) == 0 {
+		return parser.HTTPRule{}
+	}
+	return m.HTTPRules[0]
+}
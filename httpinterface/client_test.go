@@ -0,0 +1,117 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestGenerateClient(t *testing.T) {
+	t.Parallel()
+
+	code, err := New().GenerateCode(itemServiceData(Options{Binding: true, Client: true}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+
+	for _, expected := range []string{
+		"type ClientError struct",
+		"func (c clientConn) invoke(ctx context.Context, rpc, method, pattern, body string, req proto.Message, resp any, opts ...ClientOption) error",
+		"func NewItemServiceClient(baseURL string, httpClient *http.Client, opts ...ClientOption) *ItemServiceClient",
+		"// GetItem calls GET /v1/items/{id}.\nfunc (c *ItemServiceClient) GetItem(ctx context.Context, req *GetItemRequest, opts ...ClientOption) (*Item, error) {",
+		`c.conn.invoke(ctx, "/items.v1.ItemService/GetItem", http.MethodGet, "/v1/items/{id}", "", req, resp, opts...)`,
+		`c.conn.invoke(ctx, "/items.v1.ItemService/UpdateItem", http.MethodPatch, "/v1/items/{item.id}", "item", req, resp, opts...)`,
+		"func (c *ItemServiceClient) ListItems(ctx context.Context, req *ListItemsRequest, opts ...ClientOption) ([]*Item, error) {",
+		`c.conn.invoke(ctx, "/items.v1.ItemService/ListItems", http.MethodGet, "/v1/items", "", req, &items, opts...)`,
+		"func (e *ClientError) RetryAfter() time.Duration {",
+		"\tStatus  string\n\tMessage string\n\tDetails []StatusDetail\n",
+		"var ErrUnexpectedContentType = errors.New(\"unexpected response content type\")",
+		"\t\tif isJSONContentType(contentType) {\n\t\t\tclientErr.decodeStatus()\n\t\t}\n",
+		"if len(bytes.TrimSpace(data)) > 0 && !isJSONContentType(contentType) {",
+		"RetryDelay: parseRetryAfter(httpResp.Header.Get(\"Retry-After\"))",
+		"if isClientErr && attempt < retryAfterAttempts && retryLater(ctx, clientErr) {",
+		"func WithRetryPolicy(policy RetryPolicy) ClientOption {",
+		"type HedgingPolicy struct {",
+		"func WithHedgingPolicy(policy HedgingPolicy, rpcs ...string) ClientOption {",
+		"data, err = c.hedge(ctx, policy, rpc, method, target, reqData)",
+		"\t\"maps\"\n",
+		"func WithHTTPClient(httpClient *http.Client) ClientOption {",
+		"func WithBaseURL(baseURL string) ClientOption {",
+		"func WithHeader(key, value string) ClientOption {",
+		"func WithClientInterceptor(interceptors ...ClientInterceptor) ClientOption {",
+		"type ClientInterceptor func(rpc string, req *http.Request, next ClientInvoker) (*http.Response, error)",
+		"httpResp, err := ChainClientInterceptors(c.interceptors...)(rpc, httpReq, c.httpClient.Do)",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+	if strings.Contains(code, "/v1/things/{id}\", \"\", req") {
+		t.Error("Generated client calls an additional binding")
+	}
+}
+
+func TestMethodIdempotent(t *testing.T) {
+	t.Parallel()
+
+	withLevel := func(level descriptor.MethodOptions_IdempotencyLevel) *descriptor.MethodDescriptorProto {
+		return &descriptor.MethodDescriptorProto{Options: &descriptor.MethodOptions{IdempotencyLevel: level.Enum()}}
+	}
+
+	tests := []struct {
+		name   string
+		method *descriptor.MethodDescriptorProto
+		want   bool
+	}{
+		{name: "no options", method: &descriptor.MethodDescriptorProto{}},
+		{name: "unknown", method: withLevel(descriptor.MethodOptions_IDEMPOTENCY_UNKNOWN)},
+		{name: "idempotent", method: withLevel(descriptor.MethodOptions_IDEMPOTENT), want: true},
+		{name: "no side effects", method: withLevel(descriptor.MethodOptions_NO_SIDE_EFFECTS), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := methodIdempotent(tt.method); got != tt.want {
+				t.Errorf("methodIdempotent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateClientIdempotent(t *testing.T) {
+	t.Parallel()
+
+	data := itemServiceData(Options{Binding: true, Client: true})
+	data.Services[0].Methods[0].Idempotent = true
+	code, err := New().GenerateCode(data)
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+
+	for _, expected := range []string{
+		"// GetItem calls GET /v1/items/{id}.\n// The method is idempotent, so failed calls are retried following the\n// client's RetryPolicy, or hedged following its HedgingPolicy.\nfunc (c *ItemServiceClient) GetItem(",
+		`c.conn.idempotentCall().invoke(ctx, "/items.v1.ItemService/GetItem", http.MethodGet, "/v1/items/{id}", "", req, resp, opts...)`,
+		`c.conn.invoke(ctx, "/items.v1.ItemService/UpdateItem", http.MethodPatch, "/v1/items/{item.id}", "item", req, resp, opts...)`,
+		"\t\"math/rand\"\n",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+}
+
+func TestGenerateWithoutClient(t *testing.T) {
+	t.Parallel()
+
+	code, err := New().GenerateCode(itemServiceData(Options{Binding: true}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	for _, unexpected := range []string{"ClientError", "clientConn", "ItemServiceClient", "ItemServiceResolver"} {
+		if strings.Contains(code, unexpected) {
+			t.Errorf("Generated code contains %q without client=true", unexpected)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package httpinterface
+
+import (
+	"slices"
+	"strings"
+
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+)
+
+// directivePrefix starts the comment lines read as generator directives, such
+// as "http: deprecated".
+const directivePrefix = "http:"
+
+// Field numbers of the source code info paths locating a method: the service
+// field of FileDescriptorProto and the method field of ServiceDescriptorProto.
+const (
+	fileServiceField   = 6
+	serviceMethodField = 2
+)
+
+// methodPath returns the source code info path of the method at methodIndex
+// of the service at serviceIndex.
+func methodPath(serviceIndex, methodIndex int) []int32 {
+	return []int32{fileServiceField, int32(serviceIndex), serviceMethodField, int32(methodIndex)}
+}
+
+// sourceLocation returns the location of path in the source code info of
+// file, or nil if the file has none for it.
+func sourceLocation(file *descriptor.FileDescriptorProto, path []int32) *descriptor.SourceCodeInfo_Location {
+	for _, location := range file.GetSourceCodeInfo().GetLocation() {
+		if slices.Equal(location.GetPath(), path) {
+			return location
+		}
+	}
+	return nil
+}
+
+// methodComment returns the leading comments of the method at methodIndex of
+// the service at serviceIndex in file, one line per comment line without the
+// comment markers, or "" if the file has no source code info for it. Lines
+// holding "http:" directives are left out.
+func methodComment(file *descriptor.FileDescriptorProto, serviceIndex, methodIndex int) string {
+	comment := cleanComment(sourceLocation(file, methodPath(serviceIndex, methodIndex)).GetLeadingComments())
+	lines := slices.DeleteFunc(strings.Split(comment, "\n"), func(line string) bool {
+		return strings.HasPrefix(line, directivePrefix)
+	})
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+// cleanComment trims a comment as protoc records it, " Gets a task.\n", to
+// its text: the space after each comment marker, trailing spaces and leading
+// and trailing blank lines are dropped.
+func cleanComment(comment string) string {
+	lines := strings.Split(comment, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(strings.TrimPrefix(line, " "), " \t")
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+// goComment returns text as // comment lines indented by indent, with "//"
+// alone for blank lines.
+func goComment(text, indent string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = indent + "//"
+		} else {
+			lines[i] = indent + "// " + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
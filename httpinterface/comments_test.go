@@ -0,0 +1,89 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+
+	options "google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+func TestGenerateMethodComments(t *testing.T) {
+	t.Parallel()
+
+	file := docTestFile("docs/v1/tasks.proto", "TaskService",
+		docTestMethod{"GetTask", &options.HttpRule{Pattern: &options.HttpRule_Get{Get: "/v1/tasks/{task_id}"}}},
+		docTestMethod{"DeleteTask", &options.HttpRule{Pattern: &options.HttpRule_Delete{Delete: "/v1/tasks/{task_id}"}}},
+	)
+	file.SourceCodeInfo = &descriptor.SourceCodeInfo{Location: []*descriptor.SourceCodeInfo_Location{
+		{Path: []int32{6, 0}, LeadingComments: proto.String(" TaskService manages tasks.\n")},
+		{Path: []int32{6, 0, 2, 0}, LeadingComments: proto.String(" GetTask returns a task.\n\n Missing tasks are answered with 404.  \n")},
+		{Path: []int32{6, 0, 2, 0, 4}, LeadingComments: proto.String(" Not the method's comment.\n")},
+	}}
+
+	resp := New().Generate(&plugin.CodeGeneratorRequest{
+		Parameter:      proto.String("paths=source_relative,binding=true,gateway_openapi=gcp,gateway_backend=https://tasks.example.com"),
+		ProtoFile:      []*descriptor.FileDescriptorProto{file},
+		FileToGenerate: []string{"docs/v1/tasks.proto"},
+	})
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	files := map[string]string{}
+	for _, f := range resp.File {
+		files[f.GetName()] = f.GetContent()
+	}
+
+	for name, expected := range map[string][]string{
+		"docs/v1/tasks_http.pb.go": {
+			"type TaskServiceHandler interface {\n" +
+				"\t// GetTask returns a task.\n\t//\n\t// Missing tasks are answered with 404.\n" +
+				"\tHandleGetTask(w http.ResponseWriter, r *http.Request)\n" +
+				"\tHandleDeleteTask(w http.ResponseWriter, r *http.Request)\n}",
+			"\t// GetTask returns a task.\n\t//\n\t// Missing tasks are answered with 404.\n" +
+				"\tGetTask(ctx context.Context, req *Request) (*Response, error)\n" +
+				"\tDeleteTask(ctx context.Context, req *Request) (*Response, error)\n",
+			"// Returns an error if router or handler is nil.\n//\n" +
+				"// GetTask returns a task.\n//\n// Missing tasks are answered with 404.\n" +
+				"func RegisterGetTaskRoute(",
+			"// Returns an error if router or handler is nil.\nfunc RegisterDeleteTaskRoute(",
+		},
+		"docs/v1/tasks_http_gateway.swagger.yaml": {
+			"      description: |-\n        GetTask returns a task.\n\n        Missing tasks are answered with 404.\n      operationId: TaskService_GetTask\n",
+		},
+	} {
+		content, ok := files[name]
+		if !ok {
+			t.Errorf("missing %s in %d generated files", name, len(resp.File))
+			continue
+		}
+		for _, e := range expected {
+			if !strings.Contains(content, e) {
+				t.Errorf("%s doesn't contain %q", name, e)
+			}
+		}
+	}
+	if strings.Contains(files["docs/v1/tasks_http.pb.go"], "Not the method's comment") {
+		t.Errorf("generated code contains the comment of another element")
+	}
+}
+
+func TestCleanComment(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		comment, want string
+	}{
+		{"", ""},
+		{" Gets a task.\n", "Gets a task."},
+		{" Gets a task.\n More.\n", "Gets a task.\nMore."},
+		{"\n Block comment.\n\n Indented:\n   code\n", "Block comment.\n\nIndented:\n  code"},
+	}
+	for _, tt := range tests {
+		if got := cleanComment(tt.comment); got != tt.want {
+			t.Errorf("cleanComment(%q) = %q, want %q", tt.comment, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateCodeConditionalGet(t *testing.T) {
+	t.Parallel()
+	g := New()
+
+	data := func(conditionalGet bool) *ServiceData {
+		get := newMethod("GetItem", "Item", "Item", newRule("GET", "/items/{id}", ""))
+		get.CacheTTLSeconds = 60
+		return newServiceData("api", Options{ConditionalGet: conditionalGet}, newService("ItemService",
+			get,
+			newMethod("DeleteItem", "Item", "Item", newRule("DELETE", "/items/{id}", "")),
+		))
+	}
+
+	code, err := g.GenerateCode(data(true))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	for _, expected := range []string{
+		"\t\"context\"\n",
+		"type ResponseMeta struct",
+		"func SetLastModified(ctx context.Context, t time.Time)",
+		"func SetETag(ctx context.Context, etag string)",
+		"func conditionalGET(h http.HandlerFunc) http.HandlerFunc",
+		`r.HandleFunc(http.MethodGet, "/items/{id}", conditionalGET(cacheResponse("/items/{id}", 60, handler.HandleGetItem)))`,
+		`r.HandleFunc(http.MethodGet, "/items/{id}", conditionalGET(applyMiddlewares(cacheResponse("/items/{id}", 60, handler.HandleGetItem), middlewares).ServeHTTP))`,
+		`r.HandleFunc(http.MethodDelete, "/items/{id}", handler.HandleDeleteItem)`,
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+
+	code, err = g.GenerateCode(data(false))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	for _, unexpected := range []string{"ResponseMeta", "conditionalGET"} {
+		if strings.Contains(code, unexpected) {
+			t.Errorf("Generated code contains %q without conditional_get", unexpected)
+		}
+	}
+}
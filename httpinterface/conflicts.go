@@ -0,0 +1,66 @@
+package httpinterface
+
+import (
+	"fmt"
+
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+// routeOwner is the method binding a route, for reporting route conflicts.
+type routeOwner struct {
+	// Service is the fully-qualified name of the service, e.g. "tasks.v1.TaskService".
+	Service string
+	Method  string
+	// File is the proto file that declares the service.
+	File string
+}
+
+// routeConflicts returns a warning for every route bound by methods of more
+// than one service among the files to generate, such as a GET /liveness probe
+// declared by each service. Routers registering both on one ServeMux panic,
+// so the warnings suggest remedies. Patterns differing only in wildcard names
+// conflict too; public and internal routes, registered by different
+// functions, do not conflict with each other.
+func (g *Generator) routeConflicts(req *plugin.CodeGeneratorRequest) []string {
+	owners := map[string]routeOwner{}
+	var warnings []string
+
+	for _, file := range req.ProtoFile {
+		if !g.shouldGenerate(file.GetName(), req.FileToGenerate) || !g.hasHTTPRules(file) {
+			continue
+		}
+		for _, service := range g.buildServiceData(file).Services {
+			for _, method := range service.Methods {
+				owner := routeOwner{Service: service.FullName, Method: method.Name, File: file.GetName()}
+				for _, rule := range method.HTTPRules {
+					route := rule.Method + " " + rule.Pattern
+					key := rule.Method + " " + pathKey(rule.Pattern)
+					if method.Internal {
+						key += " internal"
+					}
+					first, ok := owners[key]
+					if !ok {
+						owners[key] = owner
+						continue
+					}
+					if first.Service == owner.Service {
+						continue
+					}
+					warnings = append(warnings, fmt.Sprintf("%s is bound by both %s.%s (%s) and %s.%s (%s); "+
+						"registering both services on one ServeMux panics. Register them under distinct "+
+						"prefixes with Group, call OnConflict(ConflictSkip) on the router registered second, "+
+						"or serve the route from one service only",
+						route, first.Service, first.Method, first.File, owner.Service, owner.Method, owner.File))
+				}
+			}
+		}
+	}
+	return warnings
+}
+
+// warn writes a generation warning to g.Warnings, if set.
+func (g *Generator) warn(warning string) {
+	if g.Warnings != nil {
+		fmt.Fprintln(g.Warnings, "protoc-gen-go-http-server-interface: warning: "+warning)
+	}
+}
@@ -0,0 +1,132 @@
+package httpinterface
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpserver"
+	options "google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+// conflictFile returns a file with service in package pkg, with a method for
+// each of rules keyed by method name. A Purge method is internal.
+func conflictFile(name, pkg, service string, rules map[string]*options.HttpRule) *descriptor.FileDescriptorProto {
+	file := &descriptor.FileDescriptorProto{
+		Name:        proto.String(name),
+		Package:     proto.String(pkg),
+		Syntax:      proto.String("proto3"),
+		MessageType: []*descriptor.DescriptorProto{{Name: proto.String("Empty")}},
+	}
+	svc := &descriptor.ServiceDescriptorProto{Name: proto.String(service)}
+	for _, method := range []string{"Liveness", "Readiness", "GetItem", "Purge"} {
+		rule, ok := rules[method]
+		if !ok {
+			continue
+		}
+		opts := &descriptor.MethodOptions{}
+		proto.SetExtension(opts, options.E_Http, rule)
+		if method == "Purge" {
+			proto.SetExtension(opts, httpserver.E_Visibility, httpserver.Visibility_INTERNAL)
+		}
+		empty := "." + pkg + ".Empty"
+		svc.Method = append(svc.Method, &descriptor.MethodDescriptorProto{
+			Name: proto.String(method), InputType: proto.String(empty), OutputType: proto.String(empty), Options: opts,
+		})
+	}
+	file.Service = []*descriptor.ServiceDescriptorProto{svc}
+	return file
+}
+
+func TestGenerateRouteConflictWarnings(t *testing.T) {
+	t.Parallel()
+
+	get := func(path string) *options.HttpRule {
+		return &options.HttpRule{Pattern: &options.HttpRule_Get{Get: path}}
+	}
+	post := func(path string) *options.HttpRule {
+		return &options.HttpRule{Pattern: &options.HttpRule_Post{Post: path}}
+	}
+
+	tests := []struct {
+		name     string
+		items    map[string]*options.HttpRule
+		users    map[string]*options.HttpRule
+		expected []string
+	}{
+		{
+			name:  "shared probe",
+			items: map[string]*options.HttpRule{"Liveness": get("/liveness"), "Readiness": get("/readiness")},
+			users: map[string]*options.HttpRule{"Liveness": get("/liveness")},
+			expected: []string{
+				"protoc-gen-go-http-server-interface: warning: GET /liveness is bound by both " +
+					"items.v1.ItemService.Liveness (items.proto) and users.v1.UserService.Liveness (users.proto); " +
+					"registering both services on one ServeMux panics. Register them under distinct prefixes with Group, " +
+					"call OnConflict(ConflictSkip) on the router registered second, or serve the route from one service only\n",
+			},
+		},
+		{
+			name:     "wildcard names",
+			items:    map[string]*options.HttpRule{"GetItem": get("/v1/{id}")},
+			users:    map[string]*options.HttpRule{"GetItem": get("/v1/{name}")},
+			expected: []string{"warning: GET /v1/{name} is bound by both items.v1.ItemService.GetItem (items.proto) and users.v1.UserService.GetItem (users.proto)"},
+		},
+		{
+			name:  "multi-segment wildcard",
+			items: map[string]*options.HttpRule{"GetItem": get("/v1/{id}")},
+			users: map[string]*options.HttpRule{"GetItem": get("/v1/{name...}")},
+		},
+		{
+			name:  "public and internal",
+			items: map[string]*options.HttpRule{"Liveness": post("/purge")},
+			users: map[string]*options.HttpRule{"Purge": post("/purge")},
+		},
+		{
+			name:  "distinct routes",
+			items: map[string]*options.HttpRule{"Liveness": get("/items/liveness")},
+			users: map[string]*options.HttpRule{"Liveness": get("/users/liveness"), "Readiness": post("/liveness")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := &plugin.CodeGeneratorRequest{
+				FileToGenerate: []string{"items.proto", "users.proto"},
+				ProtoFile: []*descriptor.FileDescriptorProto{
+					conflictFile("items.proto", "items.v1", "ItemService", tt.items),
+					conflictFile("users.proto", "users.v1", "UserService", tt.users),
+				},
+			}
+			var warnings bytes.Buffer
+			g := New()
+			g.Warnings = &warnings
+			resp := g.Generate(req)
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+			if len(resp.File) != 2 {
+				t.Errorf("len(resp.File) = %d, want 2", len(resp.File))
+			}
+			for _, e := range tt.expected {
+				if !strings.Contains(warnings.String(), e) {
+					t.Errorf("warnings = %q, want %q", warnings.String(), e)
+				}
+			}
+			if len(tt.expected) == 0 && warnings.Len() != 0 {
+				t.Errorf("unexpected warnings %q", warnings.String())
+			}
+			if n := strings.Count(warnings.String(), "\n"); n != len(tt.expected) {
+				t.Errorf("got %d warnings, want %d", n, len(tt.expected))
+			}
+
+			// Warnings are discarded without a writer
+			if resp := New().Generate(req); resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateStrictContentType(t *testing.T) {
+	t.Parallel()
+
+	code, err := New().GenerateCode(uploadServiceData(Options{Binding: true, StrictContentType: true}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+
+	for _, expected := range []string{
+		`r.HandleFunc(http.MethodGet, "/uploads/{id}", requireContentType(handler.HandleGetUpload, false))`,
+		`r.HandleFunc(http.MethodPost, "/uploads", requireContentType(handler.HandleCreateUpload, true))`,
+		"func requireContentType(h http.HandlerFunc, hasBody bool) http.HandlerFunc {",
+		"return slices.Sorted(maps.Keys(Marshalers))",
+		"// Routes answer bodies of other types with 415 Unsupported Media Type.\n",
+		`"maps"`,
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+}
+
+func TestGenerateMarshalersWithoutStrictContentType(t *testing.T) {
+	t.Parallel()
+
+	code, err := New().GenerateCode(uploadServiceData(Options{Binding: true}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	for _, expected := range []string{
+		"var Marshalers = map[string]Marshaler{\"application/json\": JSONMarshaler{}}",
+		"mediaType, marshaler := requestMarshaler(r)",
+		"// Bodies without a Content-Type, or of a type not listed, are decoded as JSON.\n",
+		`"mime"`,
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+	for _, unexpected := range []string{"requireContentType", `"maps"`} {
+		if strings.Contains(code, unexpected) {
+			t.Errorf("Generated code contains %q without strict_content_type=true", unexpected)
+		}
+	}
+}
+
+func TestGenerateStrictQuery(t *testing.T) {
+	t.Parallel()
+
+	code, err := New().GenerateCode(uploadServiceData(Options{Binding: true, StrictQuery: true}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	for _, expected := range []string{
+		"\t\tb.strictQuery = true\n",
+		"return fmt.Errorf(\"query parameter %q: the request message is bound from the body\", key)",
+		"return fmt.Errorf(\"%s %q: the field is bound from the path or the body\", b.source, key)",
+		"return fmt.Errorf(\"%s %q: %s has no field %s\", b.source, key, m.Descriptor().FullName(), name)",
+		`"maps"`,
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+
+	code, err = New().GenerateCode(uploadServiceData(Options{Binding: true}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	if strings.Contains(code, "strictQuery") {
+		t.Error("Generated code rejects unknown query parameters without strict_query=true")
+	}
+}
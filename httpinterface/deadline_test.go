@@ -0,0 +1,49 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateCodePropagateDeadline(t *testing.T) {
+	t.Parallel()
+	g := New()
+
+	code, err := g.GenerateCode(itemServiceData(Options{Binding: true, Client: true, PropagateDeadline: true}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	for _, expected := range []string{
+		"const DeadlineHeader = \"X-Request-Timeout\"",
+		"func PropagateDeadline(limit time.Duration) Middleware {",
+		"ms, err := strconv.ParseInt(r.Header.Get(DeadlineHeader), 10, 32)",
+		"ctx, cancel := context.WithTimeout(r.Context(), timeout)",
+		"httpReq.Header.Set(DeadlineHeader, strconv.FormatInt(max(time.Until(deadline).Milliseconds(), 1), 10))",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+
+	// The middleware alone needs none of the binding imports
+	code, err = g.GenerateCode(newServiceData("api", Options{PropagateDeadline: true}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	for _, expected := range []string{"\t\"context\"\n", "\t\"strconv\"\n", "\t\"time\"\n", "func PropagateDeadline("} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code without binding doesn't contain %q", expected)
+		}
+	}
+
+	code, err = g.GenerateCode(itemServiceData(Options{Binding: true, Client: true}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	if strings.Contains(code, "DeadlineHeader") {
+		t.Error("Generated code uses DeadlineHeader without propagate_deadline=true")
+	}
+	if !strings.Contains(code, "func WithTimeout(timeout time.Duration) ClientOption {") {
+		t.Error("Generated client has no WithTimeout option")
+	}
+}
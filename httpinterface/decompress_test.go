@@ -0,0 +1,70 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestGenerateCodeDecompressDisabled ensures no decompression code is emitted by default
+func TestGenerateCodeDecompressDisabled(t *testing.T) {
+	t.Parallel()
+	g := New()
+
+	code, err := g.GenerateCode(uploadServiceData(Options{}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+
+	for _, unexpected := range []string{"DecompressRequest", "decompressBody", `"compress/gzip"`} {
+		if strings.Contains(code, unexpected) {
+			t.Errorf("Generated code should not contain %q without decompress=true", unexpected)
+		}
+	}
+}
+
+// TestGenerateCodeDecompress tests the generated request decompression support
+func TestGenerateCodeDecompress(t *testing.T) {
+	t.Parallel()
+	g := New()
+
+	code, err := g.GenerateCode(uploadServiceData(Options{Decompress: true}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+
+	expectedContents := []string{
+		`"compress/gzip"`,
+		`"compress/zlib"`,
+		`"io"`,
+		"const MaxDecompressedBodySize = 10 << 20",
+		"func DecompressRequest(maxBytes int64) Middleware",
+		"http.MaxBytesReader(w, body, maxBytes)",
+		"http.StatusUnsupportedMediaType",
+		`r.HandleFunc(http.MethodPost, "/uploads", decompressBody(handler.HandleCreateUpload))`,
+		`r.HandleFunc(http.MethodPost, "/uploads", decompressBody(h.ServeHTTP))`,
+		// Routes without a body are registered unwrapped
+		`r.HandleFunc(http.MethodGet, "/uploads/{id}", handler.HandleGetUpload)`,
+		`r.HandleFunc(http.MethodGet, "/uploads/{id}", h.ServeHTTP)`,
+	}
+
+	for _, expected := range expectedContents {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+}
+
+// TestGenerateCodeDecompressMaxBytes tests that decompress_max_bytes sets the generated limit
+func TestGenerateCodeDecompressMaxBytes(t *testing.T) {
+	t.Parallel()
+	g := New()
+
+	code, err := g.GenerateCode(uploadServiceData(Options{Decompress: true, DecompressMaxBytes: 4096}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+
+	if !strings.Contains(code, "const MaxDecompressedBodySize = 4096") {
+		t.Error("Generated code doesn't use the configured decompress_max_bytes limit")
+	}
+}
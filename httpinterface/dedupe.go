@@ -0,0 +1,14 @@
+package httpinterface
+
+// HasDedupe reports whether any method in d sets the (http_server.dedupe)
+// option, so the generated file needs the RequestDeduper helpers.
+func (d *ServiceData) HasDedupe() bool {
+	for _, service := range d.Services {
+		for _, method := range service.Methods {
+			if method.DedupeWindowSeconds > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
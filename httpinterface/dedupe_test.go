@@ -0,0 +1,53 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpserver"
+)
+
+func TestGenerateDedupe(t *testing.T) {
+	t.Parallel()
+
+	resp := New().Generate(itemsRequest(t, "strict_content_type=true,binding=true",
+		itemMethod("CreateItem", postRule("/items", "*"), withExtension(httpserver.E_Dedupe, &httpserver.Dedupe{WindowSeconds: 5})),
+		itemMethod("GetItem", getRule("/items/{id}")),
+	))
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	code := resp.File[0].GetContent()
+	for _, expected := range []string{
+		"type RequestDeduper struct {",
+		"var DefaultRequestDeduper = &RequestDeduper{}",
+		"func (d *RequestDeduper) Fingerprint(route string, r *http.Request, body []byte) string {",
+		"func (d *RequestDeduper) Handler(route string, window time.Duration, h http.HandlerFunc) http.HandlerFunc {",
+		"\t\"crypto/sha256\"\n",
+		"\t\"encoding/hex\"\n",
+		`r.HandleFunc(http.MethodPost, "/items", requireContentType(dedupeRequest("POST /items", 5, handler.HandleCreateItem), true))`,
+		`r.HandleFunc(http.MethodGet, "/items/{id}", requireContentType(handler.HandleGetItem, false))`,
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+}
+
+func TestGenerateDedupeDisabled(t *testing.T) {
+	t.Parallel()
+
+	resp := New().Generate(itemsRequest(t, "",
+		itemMethod("CreateItem", postRule("/items", "*"), withExtension(httpserver.E_Dedupe, &httpserver.Dedupe{WindowSeconds: 0})),
+		itemMethod("GetItem", getRule("/items/{id}")),
+	))
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	code := resp.File[0].GetContent()
+	for _, unexpected := range []string{"RequestDeduper", "dedupeRequest", "crypto/sha256"} {
+		if strings.Contains(code, unexpected) {
+			t.Errorf("Generated code contains %q with a zero window", unexpected)
+		}
+	}
+}
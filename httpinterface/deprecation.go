@@ -0,0 +1,107 @@
+package httpinterface
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpinterface/parser"
+	options "google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+)
+
+// deprecatedDirective is the comment directive marking an HTTP binding
+// deprecated.
+const deprecatedDirective = directivePrefix + " deprecated"
+
+// Field numbers of the source code info path locating an additional binding
+// within a method: the options field of MethodDescriptorProto and the
+// additional_bindings field of google.api.HttpRule.
+const (
+	methodOptionsField      = 4
+	httpRuleAdditionalField = 11
+)
+
+// methodDeprecatedBindings returns the bindings of the method at methodIndex
+// of the service at serviceIndex in file marked deprecated, as "METHOD
+// pattern" with prefix applied to the pattern. A binding is marked by a line
+// "http: deprecated GET /v1/legacy/{id}" in the rpc's leading comment, or by a
+// line "http: deprecated" in the comments of the additional binding itself,
+// which only compilers locating elements inside option values record.
+func methodDeprecatedBindings(file *descriptor.FileDescriptorProto, serviceIndex, methodIndex int, prefix string) []string {
+	path := methodPath(serviceIndex, methodIndex)
+	method := file.Service[serviceIndex].Method[methodIndex]
+
+	var bindings []string
+	comment := cleanComment(sourceLocation(file, path).GetLeadingComments())
+	for _, line := range strings.Split(comment, "\n") {
+		if binding, ok := strings.CutPrefix(line, deprecatedDirective+" "); ok {
+			if verb, pattern, ok := strings.Cut(strings.TrimSpace(binding), " "); ok {
+				bindings = append(bindings, verb+" "+prefix+strings.TrimSpace(pattern))
+			}
+		}
+	}
+
+	if method.Options == nil {
+		return bindings
+	}
+	httpRule, _ := proto.GetExtension(method.Options, options.E_Http).(*options.HttpRule)
+	for i, binding := range httpRule.GetAdditionalBindings() {
+		bindingPath := append(slices.Clone(path), methodOptionsField, int32(options.E_Http.Field), httpRuleAdditionalField, int32(i))
+		location := sourceLocation(file, bindingPath)
+		if location == nil {
+			continue
+		}
+		lines := strings.Split(cleanComment(location.GetLeadingComments())+"\n"+cleanComment(location.GetTrailingComments()), "\n")
+		if rule := parser.ExtractHTTPRule(binding); rule.Method != "" && slices.Contains(lines, deprecatedDirective) {
+			bindings = append(bindings, rule.Method+" "+prefix+rule.Pattern)
+		}
+	}
+	return bindings
+}
+
+// HasDeprecatedBindings reports whether any method in d has a binding marked
+// deprecated, so the generated file needs deprecatedRoute.
+func (d *ServiceData) HasDeprecatedBindings() bool {
+	for _, service := range d.Services {
+		for _, method := range service.Methods {
+			if len(method.DeprecatedBindings) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Deprecates reports whether rule is marked deprecated with an
+// "http: deprecated" comment directive; its route sets the Deprecation header.
+func (m MethodInfo) Deprecates(rule parser.HTTPRule) bool {
+	return slices.ContainsFunc(m.DeprecatedBindings, func(binding string) bool {
+		return bindingNames(binding, rule)
+	})
+}
+
+// bindingNames reports whether binding, as written in an "http: deprecated"
+// directive, names rule, by its path template or by its ServeMux pattern.
+func bindingNames(binding string, rule parser.HTTPRule) bool {
+	return binding == rule.Method+" "+rule.PathTemplate() || binding == rule.Method+" "+rule.Pattern
+}
+
+// checkDeprecatedBindings reports an error if an "http: deprecated" directive
+// in data names a binding its method does not have.
+func checkDeprecatedBindings(data *ServiceData) error {
+	for _, service := range data.Services {
+		for _, method := range service.Methods {
+			for _, binding := range method.DeprecatedBindings {
+				if !slices.ContainsFunc(method.HTTPRules, func(rule parser.HTTPRule) bool {
+					return bindingNames(binding, rule)
+				}) {
+					return fmt.Errorf("%s.%s: %q marks %s deprecated, but the method has no such binding",
+						service.Name, method.Name, deprecatedDirective, binding)
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+
+	options "google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+// deprecationRequest returns a request for a file whose GetTask has a legacy
+// additional binding and RenameTask a legacy :rename binding, with comments
+// from locations.
+func deprecationRequest(parameter string, locations ...*descriptor.SourceCodeInfo_Location) *plugin.CodeGeneratorRequest {
+	file := docTestFile("docs/v1/tasks.proto", "TaskService",
+		docTestMethod{"GetTask", &options.HttpRule{
+			Pattern:            &options.HttpRule_Get{Get: "/v2/tasks/{task_id}"},
+			AdditionalBindings: []*options.HttpRule{{Pattern: &options.HttpRule_Get{Get: "/v1/tasks/{task_id}"}}},
+		}},
+		docTestMethod{"RenameTask", &options.HttpRule{
+			Pattern:            &options.HttpRule_Post{Post: "/v2/tasks/{task_id}/name"},
+			Body:               "*",
+			AdditionalBindings: []*options.HttpRule{{Pattern: &options.HttpRule_Post{Post: "/v1/tasks/{task_id}/rename"}, Body: "*"}},
+		}},
+	)
+	file.SourceCodeInfo = &descriptor.SourceCodeInfo{Location: locations}
+	return &plugin.CodeGeneratorRequest{
+		Parameter:      proto.String(strings.TrimSuffix("paths=source_relative,doc=true,gateway_openapi=gcp,gateway_backend=https://tasks.example.com,"+parameter, ",")),
+		ProtoFile:      []*descriptor.FileDescriptorProto{file},
+		FileToGenerate: []string{"docs/v1/tasks.proto"},
+	}
+}
+
+func TestGenerateDeprecatedBindings(t *testing.T) {
+	t.Parallel()
+
+	locations := []*descriptor.SourceCodeInfo_Location{
+		// On the rpc, as protoc records it
+		{Path: []int32{6, 0, 2, 0}, LeadingComments: proto.String(" GetTask returns a task.\n http: deprecated GET /v1/tasks/{task_id}\n")},
+		// On the binding, as compilers locating option values record it
+		{Path: []int32{6, 0, 2, 1, 4, 72295728, 11, 0}, TrailingComments: proto.String(" http: deprecated\n")},
+	}
+
+	tests := []struct {
+		name       string
+		parameter  string
+		expected   map[string][]string
+		unexpected map[string][]string
+	}{
+		{
+			name: "left out of docs",
+			expected: map[string][]string{
+				"docs/v1/tasks_http.pb.go": {
+					"func deprecatedRoute(h http.HandlerFunc) http.HandlerFunc {",
+					"\t\tw.Header().Set(\"Deprecation\", \"true\")\n",
+					`r.HandleFunc(http.MethodGet, "/v2/tasks/{task_id}", handler.HandleGetTask)`,
+					`r.HandleFunc(http.MethodGet, "/v1/tasks/{task_id}", deprecatedRoute(handler.HandleGetTask))`,
+					`r.HandleFunc(http.MethodPost, "/v2/tasks/{task_id}/name", handler.HandleRenameTask)`,
+					`r.HandleFunc(http.MethodPost, "/v1/tasks/{task_id}/rename", deprecatedRoute(handler.HandleRenameTask))`,
+					`r.HandleFunc(http.MethodGet, "/v1/tasks/{task_id}", deprecatedRoute(h.ServeHTTP))`,
+					"\t// GetTask returns a task.\n\tHandleGetTask(",
+				},
+				"docs/v1/doc.go": {
+					"//	GET  /v2/tasks/{task_id}       HandleGetTask\n",
+					"//	POST /v2/tasks/{task_id}/name  HandleRenameTask\n//\n",
+				},
+				"docs/v1/tasks_http_gateway.swagger.yaml": {
+					"  /v1/tasks/{task_id}:\n    get:\n      deprecated: true\n",
+					"  /v1/tasks/{task_id}/rename:\n    post:\n      deprecated: true\n",
+				},
+			},
+			unexpected: map[string][]string{
+				"docs/v1/tasks_http.pb.go": {"http: deprecated GET"},
+				"docs/v1/doc.go":           {"/v1/tasks/{"},
+			},
+		},
+		{
+			name:      "listed in docs",
+			parameter: "doc_deprecated=true",
+			expected: map[string][]string{
+				"docs/v1/doc.go": {
+					"//	GET  /v1/tasks/{task_id}         HandleGetTask (deprecated)\n",
+					"//	POST /v1/tasks/{task_id}/rename  HandleRenameTask (deprecated)\n",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := New().Generate(deprecationRequest(tt.parameter, locations...))
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+			files := map[string]string{}
+			for _, f := range resp.File {
+				files[f.GetName()] = f.GetContent()
+			}
+			for name, expected := range tt.expected {
+				for _, e := range expected {
+					if !strings.Contains(files[name], e) {
+						t.Errorf("%s doesn't contain %q", name, e)
+					}
+				}
+			}
+			for name, unexpected := range tt.unexpected {
+				for _, u := range unexpected {
+					if strings.Contains(files[name], u) {
+						t.Errorf("%s contains %q", name, u)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateWithoutDeprecatedBindings(t *testing.T) {
+	t.Parallel()
+
+	resp := New().Generate(deprecationRequest(""))
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	for _, f := range resp.File {
+		for _, unexpected := range []string{"deprecatedRoute", "Deprecation", "deprecated"} {
+			if strings.Contains(f.GetContent(), unexpected) {
+				t.Errorf("%s contains %q without deprecated bindings", f.GetName(), unexpected)
+			}
+		}
+	}
+}
+
+func TestGenerateDeprecatedUnknownBinding(t *testing.T) {
+	t.Parallel()
+
+	resp := New().Generate(deprecationRequest("", &descriptor.SourceCodeInfo_Location{
+		Path:            []int32{6, 0, 2, 0},
+		LeadingComments: proto.String(" http: deprecated GET /v1/task/{task_id}\n"),
+	}))
+	want := `docs/v1/tasks.proto: TaskService.GetTask: "http: deprecated" marks GET /v1/task/{task_id} deprecated, but the method has no such binding`
+	if resp.GetError() != want {
+		t.Errorf("Generate() error = %q, want %q", resp.GetError(), want)
+	}
+}
@@ -0,0 +1,139 @@
+package httpinterface
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpinterface/parser"
+	"google.golang.org/protobuf/proto"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+// docTemplateData is the data passed to the doc template for one output directory.
+type docTemplateData struct {
+	PackageName string
+	// Summary names the services of the package, e.g. "TaskService and UserService".
+	Summary  string
+	Services []docService
+	Options  Options
+}
+
+// docService describes one service in the generated package documentation.
+type docService struct {
+	Name string
+	// File is the proto file that declares the service.
+	File   string
+	Routes []docRoute
+}
+
+// docRoute is one line of the route summary, padded so the columns align.
+type docRoute struct {
+	Method  string
+	Pattern string
+	RPC     string
+	// Internal marks routes registered by Register<Service>InternalRoutes.
+	Internal bool
+	// Deprecated marks bindings with an "http: deprecated" directive, listed
+	// with doc_deprecated only.
+	Deprecated bool
+	// Tags lists the tags of the route's method, e.g. "tasks, admin".
+	Tags string
+}
+
+// generateDocFiles returns a doc.go for every output directory that received
+// generated code, documenting all services generated into that directory.
+func (g *Generator) generateDocFiles(req *plugin.CodeGeneratorRequest) ([]*plugin.CodeGeneratorResponse_File, error) {
+	var dirs []string
+	packages := map[string]*docTemplateData{}
+
+	for _, file := range req.ProtoFile {
+		if !g.shouldGenerate(file.GetName(), req.FileToGenerate) || !g.hasHTTPRules(file) {
+			continue
+		}
+		data := g.buildServiceData(file)
+		if len(data.Services) == 0 {
+			continue
+		}
+
+		outputFile := &plugin.CodeGeneratorResponse_File{Name: proto.String(g.getOutputFilename(file.GetName()))}
+		g.applySourceRelativePath(outputFile, file.GetName())
+		dir := path.Dir(outputFile.GetName())
+
+		pkg, ok := packages[dir]
+		if !ok {
+			pkg = &docTemplateData{PackageName: data.PackageName, Options: data.Options}
+			packages[dir] = pkg
+			dirs = append(dirs, dir)
+		}
+		for _, service := range data.Services {
+			pkg.Services = append(pkg.Services, newDocService(service, file.GetName(), g.Options.DocDeprecated))
+		}
+	}
+
+	files := make([]*plugin.CodeGeneratorResponse_File, 0, len(dirs))
+	for _, dir := range dirs {
+		pkg := packages[dir]
+		pkg.Summary = joinNames(pkg.Services)
+
+		var buf bytes.Buffer
+		if err := g.ParsedTemplates.ExecuteTemplate(&buf, "doc", pkg); err != nil {
+			return nil, fmt.Errorf("failed to execute doc template for %s: %v", dir, err)
+		}
+		files = append(files, &plugin.CodeGeneratorResponse_File{
+			Name:    proto.String(path.Join(dir, "doc.go")),
+			Content: proto.String(buf.String()),
+		})
+	}
+	return files, nil
+}
+
+// newDocService builds the route summary of service, aligning the method and
+// pattern columns. Deprecated bindings are left out unless withDeprecated is
+// set.
+func newDocService(service ServiceInfo, file string, withDeprecated bool) docService {
+	doc := docService{Name: service.Name, File: file}
+
+	listed := func(method MethodInfo, rule parser.HTTPRule) bool {
+		return withDeprecated || !method.Deprecates(rule)
+	}
+	methodWidth, patternWidth := 0, 0
+	for _, method := range service.Methods {
+		for _, rule := range method.HTTPRules {
+			if listed(method, rule) {
+				methodWidth = max(methodWidth, len(rule.Method))
+				patternWidth = max(patternWidth, len(rule.Pattern))
+			}
+		}
+	}
+	for _, method := range service.Methods {
+		for _, rule := range method.HTTPRules {
+			if !listed(method, rule) {
+				continue
+			}
+			doc.Routes = append(doc.Routes, docRoute{
+				Method:  fmt.Sprintf("%-*s", methodWidth, rule.Method),
+				Pattern: fmt.Sprintf("%-*s", patternWidth, rule.Pattern),
+				RPC:     method.Name,
+
+				Internal:   method.Internal,
+				Deprecated: method.Deprecates(rule),
+				Tags:       strings.Join(method.Tags, ", "),
+			})
+		}
+	}
+	return doc
+}
+
+// joinNames joins service names as English prose: "A", "A and B", "A, B and C".
+func joinNames(services []docService) string {
+	names := make([]string, len(services))
+	for i, service := range services {
+		names[i] = service.Name
+	}
+	if len(names) <= 1 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
@@ -0,0 +1,141 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+
+	options "google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+// docTestMethod is an RPC and its HTTP binding.
+type docTestMethod struct {
+	name string
+	rule *options.HttpRule
+}
+
+// docTestFile returns a proto file named name with one annotated service.
+func docTestFile(name, service string, rpcs ...docTestMethod) *descriptor.FileDescriptorProto {
+	methods := make([]*descriptor.MethodDescriptorProto, 0, len(rpcs))
+	for _, rpc := range rpcs {
+		opts := &descriptor.MethodOptions{}
+		proto.SetExtension(opts, options.E_Http, rpc.rule)
+		methods = append(methods, &descriptor.MethodDescriptorProto{
+			Name:       proto.String(rpc.name),
+			InputType:  proto.String(".docs.v1.Request"),
+			OutputType: proto.String(".docs.v1.Response"),
+			Options:    opts,
+		})
+	}
+
+	return &descriptor.FileDescriptorProto{
+		Name:    proto.String(name),
+		Package: proto.String("docs.v1"),
+		Options: &descriptor.FileOptions{GoPackage: proto.String("example.com/docs/v1;docsv1")},
+		Service: []*descriptor.ServiceDescriptorProto{
+			{Name: proto.String(service), Method: methods},
+		},
+	}
+}
+
+func TestGenerateDoc(t *testing.T) {
+	t.Parallel()
+
+	files := []*descriptor.FileDescriptorProto{
+		docTestFile("docs/v1/users.proto", "UserService",
+			docTestMethod{"GetUser", &options.HttpRule{Pattern: &options.HttpRule_Get{Get: "/v1/users/{id}"}}},
+			docTestMethod{"PurgeUsers", &options.HttpRule{Pattern: &options.HttpRule_Delete{Delete: "/v1/users"}}},
+		),
+		docTestFile("docs/v1/teams.proto", "TeamService",
+			docTestMethod{"GetTeam", &options.HttpRule{Pattern: &options.HttpRule_Get{Get: "/v1/teams/{id}"}}},
+		),
+		docTestFile("other/v1/other.proto", "OtherService",
+			docTestMethod{"GetOther", &options.HttpRule{Pattern: &options.HttpRule_Get{Get: "/v1/other"}}},
+		),
+	}
+	fileNames := []string{"docs/v1/users.proto", "docs/v1/teams.proto", "other/v1/other.proto"}
+
+	tests := []struct {
+		name      string
+		parameter string
+		wantDocs  map[string][]string
+	}{
+		{
+			name:      "doc disabled",
+			parameter: "",
+			wantDocs:  map[string][]string{},
+		},
+		{
+			name:      "one doc.go for all files in the import-path output directory",
+			parameter: "doc=true",
+			wantDocs: map[string][]string{
+				"doc.go": {
+					"// Package docsv1 serves UserService, TeamService and OtherService over HTTP.",
+					"// UserServiceHandler, generated from docs/v1/users.proto, serves:",
+					"//\tGET    /v1/users/{id}  HandleGetUser\n//\tDELETE /v1/users       HandlePurgeUsers\n",
+					"// TeamServiceHandler, generated from docs/v1/teams.proto, serves:",
+					"//\tif err := docsv1.RegisterOtherServiceRoutes(router, otherServiceHandler); err != nil {",
+				},
+			},
+		},
+		{
+			name:      "one doc.go per source-relative directory",
+			parameter: "doc=true,paths=source_relative",
+			wantDocs: map[string][]string{
+				"docs/v1/doc.go": {
+					"// Package docsv1 serves UserService and TeamService over HTTP.",
+					"//\trouter := docsv1.NewRouter(nil)",
+				},
+				"other/v1/doc.go": {
+					"// Package docsv1 serves OtherService over HTTP.",
+					"//\tGET /v1/other  HandleGetOther\n",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp := New().Generate(&plugin.CodeGeneratorRequest{
+				Parameter:      proto.String(tt.parameter),
+				ProtoFile:      files,
+				FileToGenerate: fileNames,
+			})
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+
+			docs := map[string]string{}
+			for _, f := range resp.File {
+				if strings.HasSuffix(f.GetName(), "doc.go") {
+					docs[f.GetName()] = f.GetContent()
+				} else if tt.parameter != "" && !strings.Contains(f.GetContent(), "DO NOT EDIT.\n\npackage docsv1\n") {
+					// The generated marker must not become part of the package documentation
+					t.Errorf("%s: package clause is not separated from the generated comment", f.GetName())
+				}
+			}
+
+			if len(docs) != len(tt.wantDocs) {
+				t.Fatalf("generated doc files %v, want %d", docs, len(tt.wantDocs))
+			}
+			for name, wants := range tt.wantDocs {
+				content, ok := docs[name]
+				if !ok {
+					t.Errorf("missing %s", name)
+					continue
+				}
+				if !strings.HasSuffix(content, "\npackage docsv1\n") {
+					t.Errorf("%s does not end with the package clause", name)
+				}
+				for _, want := range wants {
+					if !strings.Contains(content, want) {
+						t.Errorf("%s doesn't contain %q\n%s", name, want, content)
+					}
+				}
+			}
+		})
+	}
+}
@@ -2,9 +2,12 @@ package httpinterface
 
 import (
 	"net/http"
+	"os"
 	"strings"
 	"testing"
 
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpinterface/parser"
+
 	options "google.golang.org/genproto/googleapis/api/annotations"
 	"google.golang.org/protobuf/proto"
 	descriptor "google.golang.org/protobuf/types/descriptorpb"
@@ -21,55 +24,11 @@ func TestGeneratorEditionsSupport(t *testing.T) {
 		t.Error("Generator should support editions")
 	}
 
-	// Create a simple request
+	// Request the compiled edition 2023 fixture
 	req := &plugin.CodeGeneratorRequest{
-		Parameter: proto.String("paths=source_relative"),
-		ProtoFile: []*descriptor.FileDescriptorProto{
-			{
-				Name:    proto.String("test.proto"),
-				Package: proto.String("test"),
-				// Simulate an editions file
-				Options: &descriptor.FileOptions{
-					UninterpretedOption: []*descriptor.UninterpretedOption{
-						{
-							Name: []*descriptor.UninterpretedOption_NamePart{
-								{
-									NamePart:    proto.String("edition"),
-									IsExtension: proto.Bool(false),
-								},
-							},
-						},
-					},
-				},
-				Service: []*descriptor.ServiceDescriptorProto{
-					{
-						Name: proto.String("TestService"),
-						Method: []*descriptor.MethodDescriptorProto{
-							{
-								Name:       proto.String("GetUser"),
-								InputType:  proto.String(".test.GetUserRequest"),
-								OutputType: proto.String(".test.User"),
-								Options: func() *descriptor.MethodOptions {
-									opts := &descriptor.MethodOptions{}
-									httpRule := &options.HttpRule{
-										Pattern: &options.HttpRule_Get{
-											Get: "/v1/users/{id}",
-										},
-									}
-									proto.SetExtension(opts, options.E_Http, httpRule)
-									return opts
-								}(),
-							},
-						},
-					},
-				},
-				MessageType: []*descriptor.DescriptorProto{
-					{Name: proto.String("GetUserRequest")},
-					{Name: proto.String("User")},
-				},
-			},
-		},
-		FileToGenerate: []string{"test.proto"},
+		Parameter:      proto.String("paths=source_relative"),
+		ProtoFile:      []*descriptor.FileDescriptorProto{editionsFixture(t, "tasks.proto")},
+		FileToGenerate: []string{"tasks.proto"},
 	}
 
 	resp := g.Generate(req)
@@ -106,22 +65,7 @@ func TestGeneratorWithEditionsParser(t *testing.T) {
 	t.Parallel()
 
 	// Create a file descriptor that triggers editions parser
-	editionsFile := &descriptor.FileDescriptorProto{
-		Name:    proto.String("editions_test.proto"),
-		Package: proto.String("test"),
-		Options: &descriptor.FileOptions{
-			UninterpretedOption: []*descriptor.UninterpretedOption{
-				{
-					Name: []*descriptor.UninterpretedOption_NamePart{
-						{
-							NamePart:    proto.String("edition"),
-							IsExtension: proto.Bool(false),
-						},
-					},
-				},
-			},
-		},
-	}
+	editionsFile := editionsFixture(t, "tasks.proto")
 
 	// Test that the correct extractors are created
 	httpExtractor := CreateHTTPRuleExtractorForFile(editionsFile)
@@ -229,21 +173,8 @@ func TestEditionsFileDetection(t *testing.T) {
 			shouldBeEditions: false,
 		},
 		{
-			name: "editions_file",
-			file: &descriptor.FileDescriptorProto{
-				Options: &descriptor.FileOptions{
-					UninterpretedOption: []*descriptor.UninterpretedOption{
-						{
-							Name: []*descriptor.UninterpretedOption_NamePart{
-								{
-									NamePart:    proto.String("edition"),
-									IsExtension: proto.Bool(false),
-								},
-							},
-						},
-					},
-				},
-			},
+			name:             "editions_file",
+			file:             editionsFixture(t, "tasks.proto"),
 			shouldBeEditions: true,
 		},
 		{
@@ -304,105 +235,10 @@ func TestEditionsFileDetection(t *testing.T) {
 func TestCompleteEditionsWorkflow(t *testing.T) {
 	t.Parallel()
 
-	// Create a complete editions proto file
+	// Request an edition 2023 file with body fields and additional bindings
 	req := &plugin.CodeGeneratorRequest{
-		Parameter: proto.String("paths=source_relative"),
-		ProtoFile: []*descriptor.FileDescriptorProto{
-			{
-				Name:    proto.String("complete_test.proto"),
-				Package: proto.String("complete"),
-				// Editions file
-				Options: &descriptor.FileOptions{
-					UninterpretedOption: []*descriptor.UninterpretedOption{
-						{
-							Name: []*descriptor.UninterpretedOption_NamePart{
-								{
-									NamePart:    proto.String("edition"),
-									IsExtension: proto.Bool(false),
-								},
-							},
-						},
-					},
-				},
-				Service: []*descriptor.ServiceDescriptorProto{
-					{
-						Name: proto.String("UserService"),
-						Method: []*descriptor.MethodDescriptorProto{
-							{
-								Name:       proto.String("GetUser"),
-								InputType:  proto.String(".complete.GetUserRequest"),
-								OutputType: proto.String(".complete.User"),
-								Options: func() *descriptor.MethodOptions {
-									opts := &descriptor.MethodOptions{}
-									httpRule := &options.HttpRule{
-										Pattern: &options.HttpRule_Get{
-											Get: "/v1/users/{id}",
-										},
-									}
-									proto.SetExtension(opts, options.E_Http, httpRule)
-									return opts
-								}(),
-							},
-							{
-								Name:       proto.String("CreateUser"),
-								InputType:  proto.String(".complete.CreateUserRequest"),
-								OutputType: proto.String(".complete.User"),
-								Options: func() *descriptor.MethodOptions {
-									opts := &descriptor.MethodOptions{}
-									httpRule := &options.HttpRule{
-										Pattern: &options.HttpRule_Post{
-											Post: "/v1/users",
-										},
-										Body: "user",
-									}
-									proto.SetExtension(opts, options.E_Http, httpRule)
-									return opts
-								}(),
-							},
-							{
-								Name:       proto.String("UpdateUser"),
-								InputType:  proto.String(".complete.UpdateUserRequest"),
-								OutputType: proto.String(".complete.User"),
-								Options: func() *descriptor.MethodOptions {
-									opts := &descriptor.MethodOptions{}
-									httpRule := &options.HttpRule{
-										Pattern: &options.HttpRule_Put{
-											Put: "/v1/users/{user_id}",
-										},
-										Body: "user",
-										AdditionalBindings: []*options.HttpRule{
-											{
-												Pattern: &options.HttpRule_Patch{
-													Patch: "/v1/users/{user_id}",
-												},
-												Body: "user",
-											},
-											{
-												Pattern: &options.HttpRule_Custom{
-													Custom: &options.CustomHttpPattern{
-														Kind: "MERGE",
-														Path: "/v1/users/{user_id}/merge",
-													},
-												},
-												Body: "user",
-											},
-										},
-									}
-									proto.SetExtension(opts, options.E_Http, httpRule)
-									return opts
-								}(),
-							},
-						},
-					},
-				},
-				MessageType: []*descriptor.DescriptorProto{
-					{Name: proto.String("GetUserRequest")},
-					{Name: proto.String("CreateUserRequest")},
-					{Name: proto.String("UpdateUserRequest")},
-					{Name: proto.String("User")},
-				},
-			},
-		},
+		Parameter:      proto.String("paths=source_relative"),
+		ProtoFile:      []*descriptor.FileDescriptorProto{editionsFixture(t, "complete_test.proto")},
 		FileToGenerate: []string{"complete_test.proto"},
 	}
 
@@ -461,3 +297,171 @@ func TestCompleteEditionsWorkflow(t *testing.T) {
 		}
 	}
 }
+
+// editionsFixture returns the descriptor of the .proto file name in testdata,
+// as compiled into testdata/editions.binpb by make generate-testdata.
+func editionsFixture(t *testing.T, name string) *descriptor.FileDescriptorProto {
+	t.Helper()
+
+	data, err := os.ReadFile("testdata/editions.binpb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	set := &descriptor.FileDescriptorSet{}
+	if err := proto.Unmarshal(data, set); err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range set.GetFile() {
+		if file.GetName() == name {
+			return file
+		}
+	}
+	t.Fatalf("testdata/editions.binpb has no %s", name)
+	return nil
+}
+
+// protocEditionsFile returns the testdata fixture of edition, compiled into
+// testdata/editions.binpb: tasks.proto for edition 2023 and tasks_2024.proto
+// for edition 2024.
+func protocEditionsFile(t *testing.T, edition descriptor.Edition) *descriptor.FileDescriptorProto {
+	t.Helper()
+
+	name := "tasks.proto"
+	if edition == descriptor.Edition_EDITION_2024 {
+		name = "tasks_2024.proto"
+	}
+	file := editionsFixture(t, name)
+	if got := file.GetEdition(); got != edition {
+		t.Fatalf("testdata/%s is edition %v, want %v", name, got, edition)
+	}
+	return file
+}
+
+func TestProtocEditionsDescriptors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		edition   descriptor.Edition
+		goPackage string
+	}{
+		{name: "edition_2023", edition: descriptor.Edition_EDITION_2023, goPackage: "tasksv1"},
+		{name: "edition_2024", edition: descriptor.Edition_EDITION_2024, goPackage: "tasksv2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			file := protocEditionsFile(t, tt.edition)
+
+			if got := parser.FileEdition(file); got != tt.edition {
+				t.Errorf("FileEdition() = %v, want %v", got, tt.edition)
+			}
+			if _, ok := parser.CreateParser(file).(*parser.EditionsParser); !ok {
+				t.Errorf("CreateParser() = %T, want *parser.EditionsParser", parser.CreateParser(file))
+			}
+
+			resp := New().Generate(&plugin.CodeGeneratorRequest{
+				Parameter:      proto.String("paths=source_relative,max_edition=2024"),
+				ProtoFile:      []*descriptor.FileDescriptorProto{file},
+				FileToGenerate: []string{file.GetName()},
+			})
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+			if len(resp.File) != 1 {
+				t.Fatalf("len(resp.File) = %d, want 1", len(resp.File))
+			}
+
+			content := resp.File[0].GetContent()
+			for _, want := range []string{
+				"package " + tt.goPackage,
+				"HandleGetTask(w http.ResponseWriter, r *http.Request)",
+				`r.HandleFunc(http.MethodGet, "/v1/tasks/{id}", handler.HandleGetTask)`,
+			} {
+				if !strings.Contains(content, want) {
+					t.Errorf("generated code missing %q", want)
+				}
+			}
+		})
+	}
+}
+
+func TestGeneratorEditionCeiling(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		parameter string
+		edition   descriptor.Edition
+		wantMax   descriptor.Edition
+		wantErr   string
+	}{
+		{
+			name:    "edition 2023 within default ceiling",
+			edition: descriptor.Edition_EDITION_2023,
+			wantMax: descriptor.Edition_EDITION_2023,
+		},
+		{
+			name:    "edition 2024 above default ceiling",
+			edition: descriptor.Edition_EDITION_2024,
+			wantMax: descriptor.Edition_EDITION_2023,
+			wantErr: "tasks_2024.proto uses edition 2024, which is newer than the maximum edition 2023; set max_edition=2024 to generate it",
+		},
+		{
+			name:      "edition 2024 with max_edition=2024",
+			parameter: "max_edition=2024",
+			edition:   descriptor.Edition_EDITION_2024,
+			wantMax:   descriptor.Edition_EDITION_2024,
+		},
+		{
+			name:      "edition newer than any supported",
+			parameter: "max_edition=2024",
+			edition:   descriptor.Edition_EDITION_99997_TEST_ONLY,
+			wantMax:   descriptor.Edition_EDITION_2024,
+			wantErr:   "tasks_2024.proto uses edition 99997_TEST_ONLY, which is newer than the newest supported edition 2024",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var file *descriptor.FileDescriptorProto
+			if tt.edition == descriptor.Edition_EDITION_99997_TEST_ONLY {
+				// No compiler accepts the test-only editions, so the edition
+				// 2024 fixture declares it instead
+				file = protocEditionsFile(t, descriptor.Edition_EDITION_2024)
+				file.Edition = tt.edition.Enum()
+			} else {
+				file = protocEditionsFile(t, tt.edition)
+			}
+			resp := New().Generate(&plugin.CodeGeneratorRequest{
+				Parameter:      proto.String(tt.parameter),
+				ProtoFile:      []*descriptor.FileDescriptorProto{file},
+				FileToGenerate: []string{file.GetName()},
+			})
+
+			if got := resp.GetMinimumEdition(); got != int32(descriptor.Edition_EDITION_PROTO2) {
+				t.Errorf("MinimumEdition = %d, want %d", got, int32(descriptor.Edition_EDITION_PROTO2))
+			}
+			if got := resp.GetMaximumEdition(); got != int32(tt.wantMax) {
+				t.Errorf("MaximumEdition = %d, want %d", got, int32(tt.wantMax))
+			}
+
+			if tt.wantErr == "" {
+				if resp.Error != nil {
+					t.Fatalf("Generate() error = %s", resp.GetError())
+				}
+				return
+			}
+			if resp.GetError() != tt.wantErr {
+				t.Errorf("Generate() error = %q, want %q", resp.GetError(), tt.wantErr)
+			}
+			if len(resp.File) != 0 {
+				t.Errorf("len(resp.File) = %d, want 0", len(resp.File))
+			}
+		})
+	}
+}
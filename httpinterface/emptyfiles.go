@@ -0,0 +1,26 @@
+package httpinterface
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+// emptyFiles returns the output of a file that declares no HTTP routes: with
+// empty_files=true a file holding only the package clause, so build systems
+// that declare every output before running the plugin, like Bazel, find it,
+// and otherwise nothing.
+func (g *Generator) emptyFiles(file *descriptor.FileDescriptorProto) []*plugin.CodeGeneratorResponse_File {
+	if !g.Options.EmptyFiles {
+		return nil
+	}
+	outputFile := &plugin.CodeGeneratorResponse_File{
+		Name: proto.String(g.getOutputFilename(file.GetName())),
+		Content: proto.String(fmt.Sprintf("// Code generated by protoc-gen-go-http-server-interface. DO NOT EDIT.\n"+
+			"// %s declares no HTTP routes.\n\npackage %s\n", file.GetName(), g.getPackageName(file))),
+	}
+	g.applySourceRelativePath(outputFile, file.GetName())
+	return []*plugin.CodeGeneratorResponse_File{outputFile}
+}
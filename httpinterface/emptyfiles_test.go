@@ -0,0 +1,90 @@
+package httpinterface
+
+import (
+	"go/format"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+func TestGenerateEmptyFiles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		parameter string
+		service   bool
+		wantName  string
+		wantCode  string
+	}{
+		{
+			name:      "no services",
+			parameter: "paths=source_relative,empty_files=true",
+			wantName:  "items/v1/items_http.pb.go",
+			wantCode: "// Code generated by protoc-gen-go-http-server-interface. DO NOT EDIT.\n" +
+				"// items/v1/items.proto declares no HTTP routes.\n\npackage itemsv1\n",
+		},
+		{
+			name:      "service without HTTP rules",
+			parameter: "empty_files=true,output_prefix=api",
+			service:   true,
+			wantName:  "api_items.pb.go",
+			wantCode: "// Code generated by protoc-gen-go-http-server-interface. DO NOT EDIT.\n" +
+				"// items/v1/items.proto declares no HTTP routes.\n\npackage itemsv1\n",
+		},
+		{
+			name:      "disabled",
+			parameter: "paths=source_relative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			file := &descriptor.FileDescriptorProto{
+				Name:        proto.String("items/v1/items.proto"),
+				Package:     proto.String("items.v1"),
+				Syntax:      proto.String("proto3"),
+				MessageType: []*descriptor.DescriptorProto{{Name: proto.String("Item")}},
+			}
+			if tt.service {
+				file.Service = []*descriptor.ServiceDescriptorProto{{
+					Name: proto.String("ItemService"),
+					Method: []*descriptor.MethodDescriptorProto{
+						{Name: proto.String("GetItem"), InputType: proto.String(".items.v1.Item"), OutputType: proto.String(".items.v1.Item")},
+					},
+				}}
+			}
+			resp := New().Generate(&plugin.CodeGeneratorRequest{
+				Parameter:      proto.String(tt.parameter),
+				FileToGenerate: []string{"items/v1/items.proto"},
+				ProtoFile:      []*descriptor.FileDescriptorProto{file},
+			})
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+
+			if tt.wantName == "" {
+				if len(resp.File) != 0 {
+					t.Fatalf("Generate() wrote %d files, want none", len(resp.File))
+				}
+				return
+			}
+			if len(resp.File) != 1 {
+				t.Fatalf("Generate() wrote %d files, want 1", len(resp.File))
+			}
+			if got := resp.File[0].GetName(); got != tt.wantName {
+				t.Errorf("file name = %q, want %q", got, tt.wantName)
+			}
+			code := resp.File[0].GetContent()
+			if code != tt.wantCode {
+				t.Errorf("file content = %q, want %q", code, tt.wantCode)
+			}
+			if formatted, err := format.Source([]byte(code)); err != nil || string(formatted) != code {
+				t.Errorf("file content is not gofmt-clean: %v", err)
+			}
+		})
+	}
+}
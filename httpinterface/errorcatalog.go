@@ -0,0 +1,105 @@
+package httpinterface
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+)
+
+// errorReasonEnum is the name of the package-level enum, in the style of
+// AIP-193, from which the error catalog is generated.
+const errorReasonEnum = "ErrorReason"
+
+// ErrorCatalog is the error catalog generated from the ErrorReason enum of the
+// proto package of a file.
+type ErrorCatalog struct {
+	// Domain is the ErrorInfo domain of the errors, the proto package, e.g.
+	// "tasks.v1".
+	Domain  string
+	Reasons []ErrorReasonInfo
+}
+
+// ErrorReasonInfo is a value of the ErrorReason enum.
+type ErrorReasonInfo struct {
+	// Name is the enum value name, e.g. "TASK_NOT_FOUND".
+	Name string
+	// Func is the name of the error constructor, e.g. "ErrTaskNotFound".
+	Func string
+	// Status is the (http_server.http_status) option of the value, or
+	// http.StatusInternalServerError when unset.
+	Status int32
+}
+
+// StatusText returns the text of Status, e.g. "404 Not Found".
+func (r ErrorReasonInfo) StatusText() string {
+	return fmt.Sprintf("%d %s", r.Status, http.StatusText(int(r.Status)))
+}
+
+// errorCatalog returns the catalog of the ErrorReason enum declared by any
+// file of file's proto package, or nil if there is none or it only declares
+// the zero value, the unspecified reason, which gets no constructor.
+func (g *Generator) errorCatalog(file *descriptor.FileDescriptorProto) *ErrorCatalog {
+	name := "." + errorReasonEnum
+	if file.GetPackage() != "" {
+		name = "." + file.GetPackage() + name
+	}
+	enum := g.protoTypes.enums[name]
+	if enum == nil {
+		return nil
+	}
+
+	catalog := &ErrorCatalog{Domain: file.GetPackage()}
+	for _, value := range enum.GetValue() {
+		if value.GetNumber() == 0 {
+			continue
+		}
+		status := enumValueHTTPStatus(value)
+		if status == 0 {
+			status = http.StatusInternalServerError
+		}
+		catalog.Reasons = append(catalog.Reasons, ErrorReasonInfo{
+			Name:   value.GetName(),
+			Func:   "Err" + errorReasonFunc(value.GetName()),
+			Status: status,
+		})
+	}
+	if len(catalog.Reasons) == 0 {
+		return nil
+	}
+	return catalog
+}
+
+// errorReasonFunc converts an enum value name to camel case, dropping an
+// ERROR_REASON_ prefix, e.g. "ERROR_REASON_TASK_NOT_FOUND" to "TaskNotFound".
+func errorReasonFunc(name string) string {
+	var b strings.Builder
+	for _, word := range strings.Split(strings.TrimPrefix(name, "ERROR_REASON_"), "_") {
+		if word != "" {
+			b.WriteString(strings.ToUpper(word[:1]) + strings.ToLower(word[1:]))
+		}
+	}
+	return b.String()
+}
+
+// checkErrorCatalog reports an error if a reason of data's error catalog sets
+// an (http_server.http_status) that is not an error status, or if two reasons
+// get the same constructor.
+func checkErrorCatalog(data *ServiceData) error {
+	if data.ErrorCatalog == nil {
+		return nil
+	}
+	funcs := map[string]string{}
+	for _, reason := range data.ErrorCatalog.Reasons {
+		if reason.Status < 400 || reason.Status > 599 {
+			return fmt.Errorf("%s.%s sets (http_server.http_status) = %d, want a status from 400 to 599",
+				errorReasonEnum, reason.Name, reason.Status)
+		}
+		if prev, ok := funcs[reason.Func]; ok {
+			return fmt.Errorf("%s values %s and %s both generate %s", errorReasonEnum, prev, reason.Name, reason.Func)
+		}
+		funcs[reason.Func] = reason.Name
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpserver"
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+)
+
+// errorReasons returns an ErrorReason enum with the given values, numbered
+// from zero, each setting (http_server.http_status) when its status is not
+// zero.
+func errorReasons(values map[string]int32, names ...string) *descriptor.EnumDescriptorProto {
+	enum := &descriptor.EnumDescriptorProto{Name: proto.String("ErrorReason")}
+	for i, name := range names {
+		value := &descriptor.EnumValueDescriptorProto{Name: proto.String(name), Number: proto.Int32(int32(i))}
+		if status := values[name]; status != 0 {
+			value.Options = &descriptor.EnumValueOptions{}
+			proto.SetExtension(value.Options, httpserver.E_HttpStatus, status)
+		}
+		enum.Value = append(enum.Value, value)
+	}
+	return enum
+}
+
+func TestGenerateErrorCatalog(t *testing.T) {
+	t.Parallel()
+
+	req := graphqlRequest(t, "binding=true,client=true")
+	req.ProtoFile[0].EnumType = append(req.ProtoFile[0].EnumType, errorReasons(
+		map[string]int32{"ERROR_REASON_ITEM_NOT_FOUND": 404},
+		"ERROR_REASON_UNSPECIFIED", "ERROR_REASON_ITEM_NOT_FOUND", "QUOTA_EXCEEDED"))
+	resp := New().Generate(req)
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	content := resp.File[0].GetContent()
+	for _, expected := range []string{
+		"const errorDomain = \"items.v1\"\n",
+		"// ErrItemNotFound returns an error with reason ERROR_REASON_ITEM_NOT_FOUND, answered with\n// 404 Not Found.\n" +
+			"func ErrItemNotFound(msg string) *ReasonError {\n" +
+			"\treturn &ReasonError{Reason: \"ERROR_REASON_ITEM_NOT_FOUND\", Status: 404, Message: msg}\n}\n",
+		"func ErrQuotaExceeded(msg string) *ReasonError {\n" +
+			"\treturn &ReasonError{Reason: \"QUOTA_EXCEEDED\", Status: 500, Message: msg}\n}\n",
+		"func WriteError(w http.ResponseWriter, err error) {",
+		"func writeUnaryError(w http.ResponseWriter, err error) {\n\tWriteError(w, err)\n}\n",
+		"func (e *ClientError) Unwrap() error {",
+		"\tif delay := setRetryAfter(w, err); delay > 0 {\n",
+		"func (e *ReasonError) WithRetryDelay(d time.Duration) *ReasonError {",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("generated code doesn't contain %q", expected)
+		}
+	}
+	if strings.Contains(content, "ErrUnspecified") {
+		t.Error("generated code has a constructor for the unspecified reason")
+	}
+}
+
+func TestGenerateWithoutErrorCatalog(t *testing.T) {
+	t.Parallel()
+
+	for _, enums := range [][]*descriptor.EnumDescriptorProto{nil, {errorReasons(nil, "ERROR_REASON_UNSPECIFIED")}} {
+		req := graphqlRequest(t, "binding=true")
+		req.ProtoFile[0].EnumType = append(req.ProtoFile[0].EnumType, enums...)
+		resp := New().Generate(req)
+		if resp.Error != nil {
+			t.Fatalf("Generate() error = %s", resp.GetError())
+		}
+		if content := resp.File[0].GetContent(); strings.Contains(content, "ReasonError") {
+			t.Error("generated code contains ReasonError without ErrorReason values")
+		}
+	}
+}
+
+func TestGenerateErrorDetails(t *testing.T) {
+	t.Parallel()
+
+	for _, enums := range [][]*descriptor.EnumDescriptorProto{nil, {errorReasons(nil, "ERROR_REASON_UNSPECIFIED", "ITEM_NOT_FOUND")}} {
+		req := graphqlRequest(t, "binding=true,error_details=true")
+		req.ProtoFile[0].EnumType = append(req.ProtoFile[0].EnumType, enums...)
+		resp := New().Generate(req)
+		if resp.Error != nil {
+			t.Fatalf("Generate() error = %s", resp.GetError())
+		}
+		content := resp.File[0].GetContent()
+		for _, expected := range []string{
+			"func NewBadRequestError(msg string, violations ...FieldViolation) *DetailError {\n" +
+				"\treturn &DetailError{Status: http.StatusBadRequest, Message: msg, FieldViolations: violations}\n}\n",
+			"func NewPreconditionFailureError(msg string, violations ...PreconditionViolation) *DetailError {\n" +
+				"\treturn &DetailError{Status: http.StatusPreconditionFailed, Message: msg, PreconditionViolations: violations}\n}\n",
+			"func NewQuotaFailureError(msg string, violations ...QuotaViolation) *DetailError {\n" +
+				"\treturn &DetailError{Status: http.StatusTooManyRequests, Message: msg, QuotaViolations: violations}\n}\n",
+			"func (e *DetailError) WithFieldViolation(field, description string) *DetailError {",
+			"\tFieldViolations []FieldViolation  `json:\"fieldViolations,omitempty\"`\n",
+			"\tViolations any `json:\"violations,omitempty\"`\n",
+			"\tpreconditionFailureType = \"type.googleapis.com/google.rpc.PreconditionFailure\"\n",
+			"if errors.As(err, &detailErr) {\n" +
+				"\t\tbody.Code, body.Message = detailErr.Status, detailErr.Message\n" +
+				"\t\tbody.Details = append(body.Details, detailErr.details()...)\n",
+			"func writeUnaryError(w http.ResponseWriter, err error) {\n\tWriteError(w, err)\n}\n",
+		} {
+			if !strings.Contains(content, expected) {
+				t.Errorf("generated code doesn't contain %q", expected)
+			}
+		}
+	}
+
+	req := graphqlRequest(t, "binding=true")
+	req.ProtoFile[0].EnumType = append(req.ProtoFile[0].EnumType, errorReasons(nil, "ERROR_REASON_UNSPECIFIED", "ITEM_NOT_FOUND"))
+	resp := New().Generate(req)
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	if content := resp.File[0].GetContent(); strings.Contains(content, "DetailError") {
+		t.Error("generated code contains DetailError without error_details=true")
+	}
+}
+
+func TestGenerateClientErrorUnwrap(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		parameter      string
+		wantContain    []string
+		wantNotContain []string
+	}{
+		{
+			name:      "error catalog",
+			parameter: "binding=true,client=true",
+			wantContain: []string{
+				"if detail.Type == errorInfoType && json.Unmarshal(detail.Value, &info) == nil && info.Domain == errorDomain {",
+			},
+			wantNotContain: []string{"detailErr := &DetailError{"},
+		},
+		{
+			name:      "error catalog and details",
+			parameter: "binding=true,client=true,error_details=true",
+			wantContain: []string{
+				"// errors.As find it: a *ReasonError for an ErrorInfo detail",
+				"if detail.Type == errorInfoType && json.Unmarshal(detail.Value, &info) == nil && info.Domain == errorDomain {",
+				"detailErr := &DetailError{Status: e.StatusCode, Message: e.Message}",
+				"\t\tcase preconditionFailureType:\n\t\t\tvar v []PreconditionViolation\n",
+				"\tif len(detailErr.details()) > 0 {\n\t\treturn detailErr\n\t}\n\treturn nil\n}\n",
+			},
+		},
+		{
+			name:           "without client",
+			parameter:      "binding=true,error_details=true",
+			wantNotContain: []string{"Unwrap()"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := graphqlRequest(t, tt.parameter)
+			req.ProtoFile[0].EnumType = append(req.ProtoFile[0].EnumType, errorReasons(nil, "ERROR_REASON_UNSPECIFIED", "ITEM_NOT_FOUND"))
+			resp := New().Generate(req)
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+			content := resp.File[0].GetContent()
+			for _, expected := range tt.wantContain {
+				if !strings.Contains(content, expected) {
+					t.Errorf("generated code doesn't contain %q", expected)
+				}
+			}
+			for _, unexpected := range tt.wantNotContain {
+				if strings.Contains(content, unexpected) {
+					t.Errorf("generated code contains %q", unexpected)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateErrorCatalogErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		values  map[string]int32
+		names   []string
+		wantErr string
+	}{
+		{
+			name:    "success status",
+			values:  map[string]int32{"ITEM_NOT_FOUND": 200},
+			names:   []string{"ERROR_REASON_UNSPECIFIED", "ITEM_NOT_FOUND"},
+			wantErr: "ErrorReason.ITEM_NOT_FOUND sets (http_server.http_status) = 200, want a status from 400 to 599",
+		},
+		{
+			name:    "duplicate constructor",
+			names:   []string{"ERROR_REASON_UNSPECIFIED", "ITEM_NOT_FOUND", "ERROR_REASON_ITEM_NOT_FOUND"},
+			wantErr: "ErrorReason values ITEM_NOT_FOUND and ERROR_REASON_ITEM_NOT_FOUND both generate ErrItemNotFound",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := graphqlRequest(t, "binding=true")
+			req.ProtoFile[0].EnumType = append(req.ProtoFile[0].EnumType, errorReasons(tt.values, tt.names...))
+			resp := New().Generate(req)
+			if !strings.Contains(resp.GetError(), tt.wantErr) {
+				t.Errorf("Generate() error = %q, want it to contain %q", resp.GetError(), tt.wantErr)
+			}
+		})
+	}
+}
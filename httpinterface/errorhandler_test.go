@@ -0,0 +1,80 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateErrorHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		parameter      string
+		wantContain    []string
+		wantNotContain []string
+	}{
+		{
+			name:      "typed handlers",
+			parameter: "binding=true,error_handler=true,unexpected_body=reject",
+			wantContain: []string{
+				"type ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)",
+				"func NewRouter(mux *http.ServeMux, opts ...RouterOption) *RouteGroup {",
+				"\tif g.onError != nil {\n\t\thandler = withErrorHandler(g.onError, handler)\n\t}\n",
+				"\t\tonError:     g.onError,\n",
+				"\t\tHandleError(w, r, &StatusError{Status: http.StatusBadRequest, Err: err})\n",
+				"\t\tHandleError(w, r, err)\n",
+				`handleStatus(w, r, http.StatusBadRequest, "bad request: the route takes no request body")`,
+				"func DefaultErrorHandler(w http.ResponseWriter, r *http.Request, err error) {",
+				"\tbody.Error.Status = rpcCodeName(body.Error.Code)\n",
+				"func rpcCodeName(status int) string {",
+				"func setRetryAfter(w http.ResponseWriter, err error) time.Duration {",
+			},
+			wantNotContain: []string{"writeUnaryError", "http.Error(w, err.Error(), http.StatusBadRequest)"},
+		},
+		{
+			name:      "error details",
+			parameter: "binding=true,error_handler=true,error_details=true,validate=true,decode_helpers=true",
+			wantContain: []string{
+				"\tvar validationErr *ValidationError\n" +
+					"\tif errors.As(err, &validationErr) {\n" +
+					"\t\tWriteValidationError(w, validationErr)\n" +
+					"\t\treturn\n" +
+					"\t}\n" +
+					"\tWriteError(w, err)\n}",
+				"\t\treturn nil, &StatusError{Status: http.StatusBadRequest, Err: err}\n",
+				"// *StatusError with 400 Bad Request, for HandleError to report.",
+			},
+			wantNotContain: []string{"writeUnaryError", "body.Error.Status = rpcCodeName"},
+		},
+		{
+			name:           "disabled",
+			parameter:      "binding=true,decode_helpers=true",
+			wantNotContain: []string{"ErrorHandler", "HandleError", "StatusError", "RouterOption"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp := New().Generate(graphqlRequest(t, tt.parameter))
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+			code := resp.File[0].GetContent()
+			for _, expected := range tt.wantContain {
+				if !strings.Contains(code, expected) {
+					t.Errorf("Generated code doesn't contain %q", expected)
+				}
+			}
+			for _, unexpected := range tt.wantNotContain {
+				if strings.Contains(code, unexpected) {
+					t.Errorf("Generated code contains %q", unexpected)
+				}
+			}
+			if count := strings.Count(code, "func rpcCodeName("); count > 1 {
+				t.Errorf("rpcCodeName is generated %d times", count)
+			}
+		})
+	}
+}
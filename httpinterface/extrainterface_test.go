@@ -0,0 +1,61 @@
+package httpinterface
+
+import (
+	"go/format"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+func TestGenerateExtraInterfaces(t *testing.T) {
+	t.Parallel()
+
+	const options = "extra_interface=mycorp.dev/httpx.Audited,fmt.Stringer,extra_import=mycorp.dev/httpx/register"
+	for _, parameter := range []string{
+		"paths=source_relative,binding=true,mock=true," + options,
+		"paths=source_relative,layout=split,binding=true,mock=true," + options,
+	} {
+		t.Run(parameter, func(t *testing.T) {
+			t.Parallel()
+			file := layoutTestFile()
+			file.Service = file.Service[:1]
+			resp := New().Generate(&plugin.CodeGeneratorRequest{
+				Parameter:      proto.String(parameter),
+				FileToGenerate: []string{"shop/v1/shop.proto"},
+				ProtoFile:      []*descriptor.FileDescriptorProto{file},
+			})
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+
+			var generated strings.Builder
+			for _, f := range resp.File {
+				if !strings.HasSuffix(f.GetName(), ".go") {
+					continue
+				}
+				if formatted, err := format.Source([]byte(f.GetContent())); err != nil || string(formatted) != f.GetContent() {
+					t.Errorf("%s is not gofmt'ed (err = %v)", f.GetName(), err)
+				}
+				generated.WriteString(f.GetContent())
+			}
+
+			code := generated.String()
+			for _, expected := range []string{
+				"\t\"fmt\"\n",
+				"\thttpx \"mycorp.dev/httpx\"\n",
+				"\t_ \"mycorp.dev/httpx/register\"\n",
+				"Handler interface {\n\thttpx.Audited\n\tfmt.Stringer\n",
+				"TypedHandler interface {\n\thttpx.Audited\n\tfmt.Stringer\n",
+				"TypedAdapter{Audited: srv, Stringer: srv, srv: srv, ",
+				"Mock struct {\n\thttpx.Audited\n\tfmt.Stringer\n\topts MockOptions\n",
+			} {
+				if !strings.Contains(code, expected) {
+					t.Errorf("generated code doesn't contain %q", expected)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpinterface/parser"
+	options "google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+// newServiceData returns service data for package pkg generated with opts.
+func newServiceData(pkg string, opts Options, services ...ServiceInfo) *ServiceData {
+	return &ServiceData{PackageName: pkg, Options: opts, Services: services}
+}
+
+// newService returns the service fullName, such as "items.v1.ItemService",
+// holding methods.
+func newService(fullName string, methods ...MethodInfo) ServiceInfo {
+	return ServiceInfo{Name: fullName[strings.LastIndex(fullName, ".")+1:], FullName: fullName, Methods: methods}
+}
+
+// newMethod returns a method taking input and returning output, messages of
+// the generated package, served at rules.
+func newMethod(name, input, output string, rules ...parser.HTTPRule) MethodInfo {
+	return MethodInfo{
+		Name:         name,
+		InputType:    input,
+		OutputType:   output,
+		InputGoType:  input,
+		OutputGoType: output,
+		HTTPRules:    rules,
+	}
+}
+
+// newRule returns a binding of method and pattern with body, taking its path
+// parameters from the pattern.
+func newRule(method, pattern, body string) parser.HTTPRule {
+	return parser.HTTPRule{Method: method, Pattern: pattern, Body: body, PathParams: parser.PathParams(pattern)}
+}
+
+// itemServiceData returns service data with a GET method bound twice, a
+// method with a body field, and a method streaming a JSON array.
+func itemServiceData(opts Options) *ServiceData {
+	list := newMethod("ListItems", "ListItemsRequest", "Item", newRule("GET", "/v1/items", ""))
+	list.StreamArray = true
+	return newServiceData("api", opts, newService("items.v1.ItemService",
+		newMethod("GetItem", "GetItemRequest", "Item",
+			newRule("GET", "/v1/items/{id}", ""),
+			newRule("GET", "/v1/things/{id}", ""),
+		),
+		newMethod("UpdateItem", "UpdateItemRequest", "Item", newRule("PATCH", "/v1/items/{item.id}", "item")),
+		list,
+	))
+}
+
+// uploadServiceData returns service data with one body-less and one
+// body-carrying route.
+func uploadServiceData(opts Options) *ServiceData {
+	return newServiceData("uploads", opts, newService("UploadService",
+		newMethod("GetUpload", "GetUploadRequest", "Upload", newRule("GET", "/uploads/{id}", "")),
+		newMethod("CreateUpload", "CreateUploadRequest", "Upload", newRule("POST", "/uploads", "*")),
+	))
+}
+
+// taskServiceData returns service data for a list method bound from the
+// query string.
+func taskServiceData(opts Options) *ServiceData {
+	return newServiceData("tasks", opts, newService("TaskService",
+		newMethod("ListTasks", "ListTasksRequest", "ListTasksResponse", newRule("GET", "/tasks", "")),
+	))
+}
+
+// pluginRequest returns a request generating file with parameter,
+// round-tripped through the wire format as protoc would send it.
+func pluginRequest(t *testing.T, parameter string, file *descriptor.FileDescriptorProto) *plugin.CodeGeneratorRequest {
+	t.Helper()
+
+	data, err := proto.Marshal(&plugin.CodeGeneratorRequest{
+		Parameter:      proto.String(parameter),
+		FileToGenerate: []string{file.GetName()},
+		ProtoFile:      []*descriptor.FileDescriptorProto{file},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &plugin.CodeGeneratorRequest{}
+	if err := proto.Unmarshal(data, req); err != nil {
+		t.Fatal(err)
+	}
+	return req
+}
+
+// itemsRequest returns a request generating itemsFile(methods...) with
+// parameter.
+func itemsRequest(t *testing.T, parameter string, methods ...*descriptor.MethodDescriptorProto) *plugin.CodeGeneratorRequest {
+	t.Helper()
+	return pluginRequest(t, parameter, itemsFile(methods...))
+}
+
+// itemsFile returns items.proto of package items.v1, whose ItemService
+// holds methods over its Item message with a string id.
+func itemsFile(methods ...*descriptor.MethodDescriptorProto) *descriptor.FileDescriptorProto {
+	return &descriptor.FileDescriptorProto{
+		Name:    proto.String("items.proto"),
+		Package: proto.String("items.v1"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptor.DescriptorProto{{
+			Name: proto.String("Item"),
+			Field: []*descriptor.FieldDescriptorProto{{
+				Name:     proto.String("id"),
+				Number:   proto.Int32(1),
+				Type:     descriptor.FieldDescriptorProto_TYPE_STRING.Enum(),
+				JsonName: proto.String("id"),
+			}},
+		}},
+		Service: []*descriptor.ServiceDescriptorProto{{
+			Name:   proto.String("ItemService"),
+			Method: methods,
+		}},
+	}
+}
+
+// itemMethod returns an ItemService method taking and returning Item, bound
+// to rule, with the method options of each of with set.
+func itemMethod(name string, rule *options.HttpRule, with ...func(*descriptor.MethodOptions)) *descriptor.MethodDescriptorProto {
+	opts := &descriptor.MethodOptions{}
+	proto.SetExtension(opts, options.E_Http, rule)
+	for _, set := range with {
+		set(opts)
+	}
+	return &descriptor.MethodDescriptorProto{
+		Name:       proto.String(name),
+		InputType:  proto.String(".items.v1.Item"),
+		OutputType: proto.String(".items.v1.Item"),
+		Options:    opts,
+	}
+}
+
+// withExtension returns a func setting the method option xt to value.
+func withExtension(xt protoreflect.ExtensionType, value any) func(*descriptor.MethodOptions) {
+	return func(opts *descriptor.MethodOptions) {
+		proto.SetExtension(opts, xt, value)
+	}
+}
+
+// getRule returns a GET binding of path.
+func getRule(path string) *options.HttpRule {
+	return &options.HttpRule{Pattern: &options.HttpRule_Get{Get: path}}
+}
+
+// postRule returns a POST binding of path decoding body.
+func postRule(path, body string) *options.HttpRule {
+	return &options.HttpRule{Pattern: &options.HttpRule_Post{Post: path}, Body: body}
+}
+
+// putRule returns a PUT binding of path decoding body.
+func putRule(path, body string) *options.HttpRule {
+	return &options.HttpRule{Pattern: &options.HttpRule_Put{Put: path}, Body: body}
+}
+
+// deleteRule returns a DELETE binding of path decoding body.
+func deleteRule(path, body string) *options.HttpRule {
+	return &options.HttpRule{Pattern: &options.HttpRule_Delete{Delete: path}, Body: body}
+}
+
+// setServiceExtension sets the option xt of the first service of file to
+// value, returning file.
+func setServiceExtension(file *descriptor.FileDescriptorProto, xt protoreflect.ExtensionType, value any) *descriptor.FileDescriptorProto {
+	service := file.Service[0]
+	if service.Options == nil {
+		service.Options = &descriptor.ServiceOptions{}
+	}
+	proto.SetExtension(service.Options, xt, value)
+	return file
+}
+
+// setMethodExtension sets the option xt of the method named method of the
+// first service of file to value, returning file.
+func setMethodExtension(file *descriptor.FileDescriptorProto, method string, xt protoreflect.ExtensionType, value any) *descriptor.FileDescriptorProto {
+	for _, m := range file.Service[0].Method {
+		if m.GetName() == method {
+			if m.Options == nil {
+				m.Options = &descriptor.MethodOptions{}
+			}
+			proto.SetExtension(m.Options, xt, value)
+		}
+	}
+	return file
+}
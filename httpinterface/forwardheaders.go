@@ -0,0 +1,38 @@
+package httpinterface
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HasForwardHeaders reports whether any service in d sets the
+// (http_server.forward_headers) option, so the generated file needs
+// ForwardedHeaders.
+func (d *ServiceData) HasForwardHeaders() bool {
+	for _, service := range d.Services {
+		if len(service.ForwardHeaders) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// checkForwardHeaders reports services whose (http_server.forward_headers)
+// option lists a name that is not a valid header field name.
+func checkForwardHeaders(data *ServiceData) error {
+	for _, service := range data.Services {
+		for _, name := range service.ForwardHeaders {
+			if name == "" || strings.IndexFunc(name, func(r rune) bool { return !isTokenRune(r) }) >= 0 {
+				return fmt.Errorf("%s sets (http_server.forward_headers) %q, which is not a header name", service.Name, name)
+			}
+		}
+	}
+	return nil
+}
+
+// isTokenRune reports whether r may appear in an HTTP token such as a header
+// field name (RFC 9110, section 5.6.2).
+func isTokenRune(r rune) bool {
+	return r < 0x7f && (r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' ||
+		strings.ContainsRune("!#$%&'*+-.^_`|~", r))
+}
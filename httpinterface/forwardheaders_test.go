@@ -0,0 +1,86 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpserver"
+)
+
+func TestGenerateForwardHeaders(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		headers        []string
+		param          string
+		wantErr        string
+		wantContain    []string
+		wantNotContain []string
+	}{
+		{
+			name:    "canonical and deduplicated",
+			headers: []string{"x-request-id", "Authorization", "X-Request-ID"},
+			wantContain: []string{
+				`var ItemServiceForwardedHeaders = []string{"X-Request-Id", "Authorization"}`,
+				"func PropagateItemServiceHeaders(next http.Handler) http.Handler {\n" +
+					"\treturn propagateHeaders(ItemServiceForwardedHeaders, next)\n}",
+				"func ForwardedHeaders(ctx context.Context) http.Header {",
+				"func WithForwardedHeaders(ctx context.Context, h http.Header) context.Context {",
+				"\t\"context\"\n",
+			},
+		},
+		{
+			name:    "client",
+			headers: []string{"x-tenant-id"},
+			param:   "binding=true,client=true",
+			wantContain: []string{
+				"\tfor key, values := range ForwardedHeaders(ctx) {\n\t\thttpReq.Header[key] = slices.Clone(values)\n\t}\n" +
+					"\tfor key, values := range c.header {",
+				"generated clients send them with every request made with ctx",
+			},
+		},
+		{
+			name:           "unset",
+			param:          "binding=true,client=true",
+			wantNotContain: []string{"ForwardedHeaders", "forwardedHeadersKey"},
+		},
+		{
+			name:    "invalid name",
+			headers: []string{"x-request-id", "x request"},
+			wantErr: `items.proto: ItemService sets (http_server.forward_headers) "x request", which is not a header name`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			file := itemsFile(itemMethod("GetItem", getRule("/items/{id}")))
+			if tt.headers != nil {
+				setServiceExtension(file, httpserver.E_ForwardHeaders, tt.headers)
+			}
+			resp := New().Generate(pluginRequest(t, tt.param, file))
+
+			if tt.wantErr != "" {
+				if resp.GetError() != tt.wantErr {
+					t.Fatalf("Generate() error = %q, want %q", resp.GetError(), tt.wantErr)
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+			code := resp.File[0].GetContent()
+			for _, expected := range tt.wantContain {
+				if !strings.Contains(code, expected) {
+					t.Errorf("Generated code doesn't contain %q", expected)
+				}
+			}
+			for _, unexpected := range tt.wantNotContain {
+				if strings.Contains(code, unexpected) {
+					t.Errorf("Generated code contains %q", unexpected)
+				}
+			}
+		})
+	}
+}
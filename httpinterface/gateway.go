@@ -0,0 +1,187 @@
+package httpinterface
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+	"sigs.k8s.io/yaml"
+)
+
+// Cloud gateways accepted by the gateway_openapi option.
+const (
+	// GatewayAWS adds x-amazon-apigateway-integration HTTP proxy integrations
+	// for AWS API Gateway.
+	GatewayAWS = "aws"
+	// GatewayGCP adds an x-google-backend for GCP API Gateway.
+	GatewayGCP = "gcp"
+)
+
+// gatewayPathParamRegex matches a path parameter, with or without a
+// "{name=segments}" template.
+var gatewayPathParamRegex = regexp.MustCompile(`\{([^/{}=]+)(=[^{}]*)?\}`)
+
+// gatewaySpec is the Swagger 2.0 document written for a cloud API gateway.
+// Both AWS and GCP API Gateway import Swagger 2.0.
+type gatewaySpec struct {
+	Swagger       string                                  `json:"swagger"`
+	Info          gatewayInfo                             `json:"info"`
+	Schemes       []string                                `json:"schemes"`
+	Produces      []string                                `json:"produces"`
+	GoogleBackend *gatewayGoogleBackend                   `json:"x-google-backend,omitempty"`
+	Paths         map[string]map[string]*gatewayOperation `json:"paths"`
+}
+
+type gatewayInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type gatewayGoogleBackend struct {
+	Address         string `json:"address"`
+	PathTranslation string `json:"path_translation"`
+}
+
+type gatewayOperation struct {
+	OperationID    string                     `json:"operationId"`
+	Description    string                     `json:"description,omitempty"`
+	Deprecated     bool                       `json:"deprecated,omitempty"`
+	Tags           []string                   `json:"tags,omitempty"`
+	Parameters     []gatewayParameter         `json:"parameters,omitempty"`
+	Responses      map[string]gatewayResponse `json:"responses"`
+	AWSIntegration *gatewayAWSIntegration     `json:"x-amazon-apigateway-integration,omitempty"`
+}
+
+type gatewayParameter struct {
+	Name     string `json:"name"`
+	In       string `json:"in"`
+	Required bool   `json:"required"`
+	Type     string `json:"type"`
+}
+
+type gatewayResponse struct {
+	Description string `json:"description"`
+}
+
+type gatewayAWSIntegration struct {
+	Type                string            `json:"type"`
+	HTTPMethod          string            `json:"httpMethod"`
+	URI                 string            `json:"uri"`
+	PassthroughBehavior string            `json:"passthroughBehavior"`
+	RequestParameters   map[string]string `json:"requestParameters,omitempty"`
+}
+
+// gatewayMethods are the HTTP methods Swagger 2.0 can describe; bindings with
+// custom methods are left out of the gateway document.
+var gatewayMethods = []string{
+	http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete,
+	http.MethodOptions, http.MethodHead, http.MethodPatch,
+}
+
+// generateGatewayFile returns the <file>_gateway.swagger.yaml of file for the
+// cloud gateway selected by the gateway_openapi option, routing every public
+// binding to gateway_backend. Internal methods are not exposed through the
+// gateway.
+func (g *Generator) generateGatewayFile(file *descriptor.FileDescriptorProto, data *ServiceData) (*plugin.CodeGeneratorResponse_File, error) {
+	spec := gatewaySpec{
+		Swagger:  "2.0",
+		Info:     gatewayInfo{Title: file.GetPackage(), Version: "1.0.0"},
+		Schemes:  []string{"https"},
+		Produces: []string{"application/json"},
+		Paths:    map[string]map[string]*gatewayOperation{},
+	}
+	if spec.Info.Title == "" {
+		spec.Info.Title = strings.TrimSuffix(path.Base(file.GetName()), ".proto")
+	}
+
+	backend := strings.TrimSuffix(g.Options.GatewayBackend, "/")
+	if g.Options.GatewayOpenAPI == GatewayGCP {
+		spec.GoogleBackend = &gatewayGoogleBackend{Address: backend, PathTranslation: "APPEND_PATH_TO_ADDRESS"}
+	}
+
+	for _, service := range data.Services {
+		for _, method := range service.Methods {
+			if method.Internal {
+				continue
+			}
+			for i, rule := range method.HTTPRules {
+				if !slices.Contains(gatewayMethods, rule.Method) {
+					continue
+				}
+				path, params := gatewayPath(rule.PathTemplate())
+
+				op := &gatewayOperation{
+					OperationID: service.Name + "_" + method.Name,
+					Description: method.Comment,
+					Deprecated:  method.Deprecates(rule),
+					Tags:        method.Tags,
+					Responses:   map[string]gatewayResponse{"200": {Description: "A successful response."}},
+				}
+				if i > 0 {
+					op.OperationID += fmt.Sprint(i + 1)
+				}
+				for _, name := range params {
+					op.Parameters = append(op.Parameters, gatewayParameter{Name: name, In: "path", Required: true, Type: "string"})
+				}
+				if g.Options.GatewayOpenAPI == GatewayAWS {
+					op.AWSIntegration = &gatewayAWSIntegration{
+						Type:                "http_proxy",
+						HTTPMethod:          rule.Method,
+						URI:                 backend + path,
+						PassthroughBehavior: "when_no_match",
+					}
+					for _, name := range params {
+						if op.AWSIntegration.RequestParameters == nil {
+							op.AWSIntegration.RequestParameters = map[string]string{}
+						}
+						op.AWSIntegration.RequestParameters["integration.request.path."+name] = "method.request.path." + name
+					}
+				}
+
+				if spec.Paths[path] == nil {
+					spec.Paths[path] = map[string]*gatewayOperation{}
+				}
+				key := strings.ToLower(rule.Method)
+				if prev, ok := spec.Paths[path][key]; ok {
+					return nil, fmt.Errorf("%s: gateway route %s %s is bound by both %s and %s",
+						file.GetName(), rule.Method, path, prev.OperationID, op.OperationID)
+				}
+				spec.Paths[path][key] = op
+			}
+		}
+	}
+
+	content, err := yaml.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("error generating gateway document for %s: %v", file.GetName(), err)
+	}
+	header := "# Code generated by protoc-gen-go-http-server-interface. DO NOT EDIT.\n# source: " + file.GetName() + "\n\n"
+
+	name := strings.TrimSuffix(g.getOutputFilename(file.GetName()), ".pb.go") + "_gateway.swagger.yaml"
+	outputFile := &plugin.CodeGeneratorResponse_File{
+		Name:    proto.String(name),
+		Content: proto.String(header + string(content)),
+	}
+	g.applySourceRelativePath(outputFile, file.GetName())
+	return outputFile, nil
+}
+
+// gatewayPath converts a route pattern into a gateway path and its parameter
+// names. Gateways only accept plain "{name}" parameters, so segment templates
+// are dropped and dots in field paths become underscores: "/v1/{task.id}"
+// becomes "/v1/{task_id}".
+func gatewayPath(pattern string) (string, []string) {
+	var params []string
+	path := gatewayPathParamRegex.ReplaceAllStringFunc(pattern, func(match string) string {
+		name := strings.ReplaceAll(gatewayPathParamRegex.FindStringSubmatch(match)[1], ".", "_")
+		params = append(params, name)
+		return "{" + name + "}"
+	})
+	return path, params
+}
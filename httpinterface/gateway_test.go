@@ -0,0 +1,156 @@
+package httpinterface
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpserver"
+	options "google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+// gatewayTestFile returns an annotated proto file with a public service, an
+// internal method, an additional binding and a custom-method binding. Its
+// field-path and segment-template patterns are outside the http.ServeMux
+// grammar, so generating it needs raw_patterns=true.
+func gatewayTestFile() *descriptor.FileDescriptorProto {
+	file := docTestFile("gateway/v1/tasks.proto", "TaskService",
+		docTestMethod{"GetTask", &options.HttpRule{
+			Pattern: &options.HttpRule_Get{Get: "/v1/tasks/{task.id}"},
+			AdditionalBindings: []*options.HttpRule{
+				{Pattern: &options.HttpRule_Get{Get: "/v1/{name=projects/*/tasks/*}"}},
+			},
+		}},
+		docTestMethod{"CreateTask", &options.HttpRule{Pattern: &options.HttpRule_Post{Post: "/v1/tasks"}, Body: "*"}},
+		docTestMethod{"PurgeTasks", &options.HttpRule{Pattern: &options.HttpRule_Delete{Delete: "/admin/tasks"}}},
+		docTestMethod{"MergeTasks", &options.HttpRule{
+			Pattern: &options.HttpRule_Custom{Custom: &options.CustomHttpPattern{Kind: "MERGE", Path: "/v1/tasks"}},
+		}},
+	)
+	proto.SetExtension(file.Service[0].Method[2].Options, httpserver.E_Visibility, httpserver.Visibility_INTERNAL)
+	return file
+}
+
+func TestGenerateGatewayOpenAPI(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		parameter string
+		wantFile  string
+		expected  []string
+		// unexpected is checked in addition to the routes every document leaves out
+		unexpected []string
+	}{
+		{
+			name:      "aws",
+			parameter: "gateway_openapi=aws,gateway_backend=https://tasks.internal.example.com/,raw_patterns=true",
+			wantFile:  "tasks_http_gateway.swagger.yaml",
+			expected: []string{
+				"# Code generated by protoc-gen-go-http-server-interface. DO NOT EDIT.\n# source: gateway/v1/tasks.proto\n",
+				"swagger: \"2.0\"",
+				"title: docs.v1",
+				"  /v1/tasks/{task_id}:\n    get:\n      operationId: TaskService_GetTask\n",
+				"      - in: path\n        name: task_id\n        required: true\n        type: string\n",
+				"        httpMethod: GET\n",
+				"          integration.request.path.task_id: method.request.path.task_id\n",
+				"        type: http_proxy\n        uri: https://tasks.internal.example.com/v1/tasks/{task_id}\n",
+				"  /v1/{name}:\n    get:\n      operationId: TaskService_GetTask2\n",
+				"  /v1/tasks:\n    post:\n      operationId: TaskService_CreateTask\n",
+				"        uri: https://tasks.internal.example.com/v1/tasks\n",
+			},
+		},
+		{
+			name:      "gcp with source-relative paths",
+			parameter: "gateway_openapi=gcp,gateway_backend=https://tasks-abc123.a.run.app,paths=source_relative,raw_patterns=true",
+			wantFile:  "gateway/v1/tasks_http_gateway.swagger.yaml",
+			expected: []string{
+				"x-google-backend:\n  address: https://tasks-abc123.a.run.app\n  path_translation: APPEND_PATH_TO_ADDRESS\n",
+				"operationId: TaskService_GetTask\n",
+				"operationId: TaskService_CreateTask\n",
+			},
+			unexpected: []string{"x-amazon-apigateway-integration"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp := New().Generate(&plugin.CodeGeneratorRequest{
+				Parameter:      proto.String(tt.parameter),
+				ProtoFile:      []*descriptor.FileDescriptorProto{gatewayTestFile()},
+				FileToGenerate: []string{"gateway/v1/tasks.proto"},
+			})
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+
+			var content string
+			for _, f := range resp.File {
+				if f.GetName() == tt.wantFile {
+					content = f.GetContent()
+				}
+			}
+			if content == "" {
+				t.Fatalf("missing %s in %d generated files", tt.wantFile, len(resp.File))
+			}
+
+			for _, expected := range tt.expected {
+				if !strings.Contains(content, expected) {
+					t.Errorf("gateway document doesn't contain %q:\n%s", expected, content)
+				}
+			}
+			for _, unexpected := range append([]string{"PurgeTasks", "/admin/tasks", "MergeTasks", "merge:"}, tt.unexpected...) {
+				if strings.Contains(content, unexpected) {
+					t.Errorf("gateway document contains %q", unexpected)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateWithoutGatewayOpenAPI(t *testing.T) {
+	t.Parallel()
+
+	resp := New().Generate(&plugin.CodeGeneratorRequest{
+		Parameter:      proto.String("raw_patterns=true"),
+		ProtoFile:      []*descriptor.FileDescriptorProto{gatewayTestFile()},
+		FileToGenerate: []string{"gateway/v1/tasks.proto"},
+	})
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	for _, f := range resp.File {
+		if strings.HasSuffix(f.GetName(), ".yaml") {
+			t.Errorf("generated %s without gateway_openapi", f.GetName())
+		}
+	}
+}
+
+func TestGatewayPath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern    string
+		wantPath   string
+		wantParams []string
+	}{
+		{"/v1/tasks", "/v1/tasks", nil},
+		{"/v1/tasks/{id}:archive", "/v1/tasks/{id}:archive", []string{"id"}},
+		{"/v1/projects/{project_id}/tasks/{task.id}", "/v1/projects/{project_id}/tasks/{task_id}", []string{"project_id", "task_id"}},
+		{"/v1/{name=projects/*/tasks/*}", "/v1/{name}", []string{"name"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			t.Parallel()
+			path, params := gatewayPath(tt.pattern)
+			if path != tt.wantPath || !reflect.DeepEqual(params, tt.wantParams) {
+				t.Errorf("gatewayPath(%q) = %q, %v, want %q, %v", tt.pattern, path, params, tt.wantPath, tt.wantParams)
+			}
+		})
+	}
+}
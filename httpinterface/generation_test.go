@@ -0,0 +1,50 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+
+	options "google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+func TestGenerateGenerationInfo(t *testing.T) {
+	t.Parallel()
+
+	rules := map[string]*options.HttpRule{"GetItem": {Pattern: &options.HttpRule_Get{Get: "/v1/items/{id}"}}}
+	req := &plugin.CodeGeneratorRequest{
+		FileToGenerate: []string{"items/v1/items.proto"},
+		Parameter:      proto.String("paths=source_relative,build_tags=integration,!windows"),
+		ProtoFile:      []*descriptor.FileDescriptorProto{conflictFile("items/v1/items.proto", "items.v1", "ItemService", rules)},
+	}
+
+	g := New()
+	g.Version = "v1.4.0"
+	resp := g.Generate(req)
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	content := resp.File[0].GetContent()
+	for _, want := range []string{
+		`const GeneratorVersion = "v1.4.0"`,
+		`const GeneratorParameters = "paths=source_relative,build_tags=integration,!windows"`,
+		`return GeneratorInfo{Version: GeneratorVersion, Parameters: GeneratorParameters, Source: "items/v1/items.proto"}`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("generated code missing %q", want)
+		}
+	}
+
+	// Code generated from ServiceData alone reports a development version
+	code, err := New().GenerateCode(newServiceData("items", Options{}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	for _, want := range []string{`const GeneratorVersion = "dev"`, `const GeneratorParameters = ""`, `Source: ""}`} {
+		if !strings.Contains(code, want) {
+			t.Errorf("GenerateCode() missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,312 @@
+package httpinterface
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+// protoTypes indexes the messages and enums of a request by fully-qualified
+// name with a leading dot, as used in field type names.
+type protoTypes struct {
+	messages map[string]*descriptor.DescriptorProto
+	enums    map[string]*descriptor.EnumDescriptorProto
+	// names holds the Go name protoc-gen-go gives each message and enum,
+	// nested types joined with underscores as in Page_Token.
+	names map[string]string
+	// packages holds the proto package declaring each message, e.g.
+	// "tasks.v1".
+	packages map[string]string
+}
+
+// indexProtoTypes indexes every message and enum, including nested ones,
+// declared by files.
+func indexProtoTypes(files []*descriptor.FileDescriptorProto) protoTypes {
+	types := protoTypes{
+		messages: map[string]*descriptor.DescriptorProto{},
+		enums:    map[string]*descriptor.EnumDescriptorProto{},
+		names:    map[string]string{},
+		packages: map[string]string{},
+	}
+	var walk func(pkg, prefix, goPrefix string, messages []*descriptor.DescriptorProto, enums []*descriptor.EnumDescriptorProto)
+	walk = func(pkg, prefix, goPrefix string, messages []*descriptor.DescriptorProto, enums []*descriptor.EnumDescriptorProto) {
+		for _, enum := range enums {
+			name := prefix + "." + enum.GetName()
+			types.enums[name] = enum
+			types.names[name] = goPrefix + enum.GetName()
+		}
+		for _, msg := range messages {
+			name := prefix + "." + msg.GetName()
+			types.messages[name] = msg
+			types.names[name] = goPrefix + msg.GetName()
+			types.packages[name] = pkg
+			walk(pkg, name, types.names[name]+"_", msg.GetNestedType(), msg.GetEnumType())
+		}
+	}
+	for _, file := range files {
+		prefix := ""
+		if pkg := file.GetPackage(); pkg != "" {
+			prefix = "." + pkg
+		}
+		walk(file.GetPackage(), prefix, "", file.GetMessageType(), file.GetEnumType())
+	}
+	return types
+}
+
+// GraphQLField returns the name of the Query or Mutation field of m in the
+// generated GraphQL schema.
+func (m MethodInfo) GraphQLField() string {
+	return strings.ToLower(m.Name[:1]) + m.Name[1:]
+}
+
+// GraphQLRoot returns "Query" when m's primary binding is a GET and "Mutation"
+// otherwise.
+func (m MethodInfo) GraphQLRoot() string {
+	if m.PrimaryRule().Method == http.MethodGet {
+		return "Query"
+	}
+	return "Mutation"
+}
+
+// graphqlWellKnownTypes maps google.protobuf messages to the GraphQL type of
+// their JSON form.
+var graphqlWellKnownTypes = map[string]string{
+	".google.protobuf.Timestamp":   "String",
+	".google.protobuf.Duration":    "String",
+	".google.protobuf.FieldMask":   "String",
+	".google.protobuf.DoubleValue": "Float",
+	".google.protobuf.FloatValue":  "Float",
+	".google.protobuf.Int32Value":  "Int",
+	".google.protobuf.UInt32Value": "Float",
+	".google.protobuf.Int64Value":  "String",
+	".google.protobuf.UInt64Value": "String",
+	".google.protobuf.BoolValue":   "Boolean",
+	".google.protobuf.StringValue": "String",
+	".google.protobuf.BytesValue":  "String",
+	".google.protobuf.Struct":      "JSON",
+	".google.protobuf.Value":       "JSON",
+	".google.protobuf.ListValue":   "JSON",
+	".google.protobuf.Any":         "JSON",
+}
+
+// graphqlScalars maps proto scalar types to GraphQL scalars. 64-bit integers
+// are strings in the proto JSON mapping, and unsigned 32-bit integers do not
+// fit GraphQL's signed Int.
+var graphqlScalars = map[descriptor.FieldDescriptorProto_Type]string{
+	descriptor.FieldDescriptorProto_TYPE_DOUBLE:   "Float",
+	descriptor.FieldDescriptorProto_TYPE_FLOAT:    "Float",
+	descriptor.FieldDescriptorProto_TYPE_INT32:    "Int",
+	descriptor.FieldDescriptorProto_TYPE_SINT32:   "Int",
+	descriptor.FieldDescriptorProto_TYPE_SFIXED32: "Int",
+	descriptor.FieldDescriptorProto_TYPE_UINT32:   "Float",
+	descriptor.FieldDescriptorProto_TYPE_FIXED32:  "Float",
+	descriptor.FieldDescriptorProto_TYPE_INT64:    "String",
+	descriptor.FieldDescriptorProto_TYPE_SINT64:   "String",
+	descriptor.FieldDescriptorProto_TYPE_SFIXED64: "String",
+	descriptor.FieldDescriptorProto_TYPE_UINT64:   "String",
+	descriptor.FieldDescriptorProto_TYPE_FIXED64:  "String",
+	descriptor.FieldDescriptorProto_TYPE_BOOL:     "Boolean",
+	descriptor.FieldDescriptorProto_TYPE_STRING:   "String",
+	descriptor.FieldDescriptorProto_TYPE_BYTES:    "String",
+}
+
+// graphqlSchema accumulates the type definitions of a GraphQL schema in the
+// order they are first referenced.
+type graphqlSchema struct {
+	g       *Generator
+	defs    []string
+	defined map[string]bool
+	// usesJSON records that the JSON scalar, for maps and dynamic values, is referenced.
+	usesJSON bool
+}
+
+// generateGraphQLFile returns the <file>_http.graphql schema of file: a Query
+// field for each method whose primary binding is a GET and a Mutation field
+// for every other method, taking the request message as an input argument and
+// returning the response message, for resolvers built on the generated client.
+// Methods marked (http_server.stream_body) are left out.
+func (g *Generator) generateGraphQLFile(file *descriptor.FileDescriptorProto, data *ServiceData) (*plugin.CodeGeneratorResponse_File, error) {
+	schema := &graphqlSchema{g: g, defined: map[string]bool{}}
+	roots := map[string][]string{}
+	seen := map[string]string{}
+
+	for _, service := range data.Services {
+		for _, method := range service.Methods {
+			if method.StreamBody {
+				continue
+			}
+			root, field := method.GraphQLRoot(), method.GraphQLField()
+			if prev, ok := seen[root+"."+field]; ok {
+				return nil, fmt.Errorf("%s: %s and %s.%s both map to GraphQL field %s.%s",
+					file.GetName(), prev, service.Name, method.Name, root, field)
+			}
+			seen[root+"."+field] = service.Name + "." + method.Name
+
+			rpc := findMethod(file, service.Name, method.Name)
+			input := schema.messageType(rpc.GetInputType(), true)
+			var output string
+			switch {
+			case method.Async:
+				output = schema.operationRefType()
+			case method.StreamArray:
+				output = "[" + schema.messageType(rpc.GetOutputType(), false) + "!]"
+			default:
+				output = schema.messageType(rpc.GetOutputType(), false)
+			}
+
+			rule := method.PrimaryRule()
+			roots[root] = append(roots[root], fmt.Sprintf("  %q\n  %s(input: %s): %s\n",
+				service.Name+"."+method.Name+": "+rule.Method+" "+rule.Pattern, field, input, output))
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("# Code generated by protoc-gen-go-http-server-interface. DO NOT EDIT.\n")
+	b.WriteString("# source: " + file.GetName() + "\n")
+	if len(roots["Query"]) == 0 {
+		// A schema must define Query; it has no fields of its own here
+		roots["Query"] = []string{"  _empty: Boolean\n"}
+	}
+	for _, root := range []string{"Query", "Mutation"} {
+		if fields := roots[root]; len(fields) > 0 {
+			b.WriteString("\ntype " + root + " {\n" + strings.Join(fields, "") + "}\n")
+		}
+	}
+	for _, def := range schema.defs {
+		b.WriteString("\n" + def)
+	}
+	if schema.usesJSON {
+		b.WriteString("\n\"Maps and dynamic values in their proto JSON form.\"\nscalar JSON\n")
+	}
+
+	name := strings.TrimSuffix(g.getOutputFilename(file.GetName()), ".pb.go") + ".graphql"
+	outputFile := &plugin.CodeGeneratorResponse_File{
+		Name:    proto.String(name),
+		Content: proto.String(b.String()),
+	}
+	g.applySourceRelativePath(outputFile, file.GetName())
+	return outputFile, nil
+}
+
+// findMethod returns the descriptor of service.method in file.
+func findMethod(file *descriptor.FileDescriptorProto, service, method string) *descriptor.MethodDescriptorProto {
+	for _, svc := range file.GetService() {
+		if svc.GetName() != service {
+			continue
+		}
+		for _, m := range svc.GetMethod() {
+			if m.GetName() == method {
+				return m
+			}
+		}
+	}
+	return nil
+}
+
+// operationRefType defines the OperationRef object returned by async methods.
+func (s *graphqlSchema) operationRefType() string {
+	if !s.defined["OperationRef"] {
+		s.defined["OperationRef"] = true
+		s.defs = append(s.defs, "type OperationRef {\n  id: String!\n  method: String!\n}\n")
+	}
+	return "OperationRef"
+}
+
+// messageType returns the GraphQL type of the message typeName, defining it
+// and the types it refers to on first use: an object type for output, or an
+// input type named with an Input suffix for input.
+func (s *graphqlSchema) messageType(typeName string, input bool) string {
+	if scalar, ok := graphqlWellKnownTypes[typeName]; ok {
+		s.usesJSON = s.usesJSON || scalar == "JSON"
+		return scalar
+	}
+	msg := s.g.protoTypes.messages[typeName]
+	if msg == nil {
+		s.usesJSON = true
+		return "JSON"
+	}
+
+	name, kind := s.g.protoTypes.names[typeName], "type"
+	if input {
+		name, kind = name+"Input", "input"
+	}
+	if s.defined[name] {
+		return name
+	}
+	s.defined[name] = true
+
+	// Reserve the definition's position before defining the field types
+	i := len(s.defs)
+	s.defs = append(s.defs, "")
+
+	var b strings.Builder
+	b.WriteString(kind + " " + name + " {\n")
+	for _, field := range msg.GetField() {
+		b.WriteString("  " + jsonFieldName(field) + ": " + s.fieldType(field, input) + "\n")
+	}
+	if len(msg.GetField()) == 0 {
+		// GraphQL types need at least one field
+		b.WriteString("  _empty: Boolean\n")
+	}
+	b.WriteString("}\n")
+	s.defs[i] = b.String()
+	return name
+}
+
+// fieldType returns the GraphQL type of field. Maps have no GraphQL
+// counterpart and use the JSON scalar.
+func (s *graphqlSchema) fieldType(field *descriptor.FieldDescriptorProto, input bool) string {
+	var elem string
+	switch field.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_MESSAGE, descriptor.FieldDescriptorProto_TYPE_GROUP:
+		if msg := s.g.protoTypes.messages[field.GetTypeName()]; msg.GetOptions().GetMapEntry() {
+			s.usesJSON = true
+			return "JSON"
+		}
+		elem = s.messageType(field.GetTypeName(), input)
+	case descriptor.FieldDescriptorProto_TYPE_ENUM:
+		elem = s.enumType(field.GetTypeName())
+	default:
+		elem = graphqlScalars[field.GetType()]
+	}
+	if field.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED {
+		return "[" + elem + "!]"
+	}
+	return elem
+}
+
+// enumType returns the GraphQL enum of the proto enum typeName, defining it
+// on first use.
+func (s *graphqlSchema) enumType(typeName string) string {
+	enum := s.g.protoTypes.enums[typeName]
+	if enum == nil {
+		return "String"
+	}
+	name := s.g.protoTypes.names[typeName]
+	if s.defined[name] {
+		return name
+	}
+	s.defined[name] = true
+
+	var b strings.Builder
+	b.WriteString("enum " + name + " {\n")
+	for _, value := range enum.GetValue() {
+		b.WriteString("  " + value.GetName() + "\n")
+	}
+	b.WriteString("}\n")
+	s.defs = append(s.defs, b.String())
+	return name
+}
+
+// jsonFieldName returns the JSON name of field, the name its value has in
+// requests and responses.
+func jsonFieldName(field *descriptor.FieldDescriptorProto) string {
+	if name := field.GetJsonName(); name != "" {
+		return name
+	}
+	return field.GetName()
+}
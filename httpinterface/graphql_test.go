@@ -0,0 +1,161 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpserver"
+	options "google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+// graphqlRequest returns a request for a file with a GET method, a write
+// method with nested, enum, repeated and map fields, and an async method.
+func graphqlRequest(t *testing.T, parameter string) *plugin.CodeGeneratorRequest {
+	t.Helper()
+
+	field := func(name, jsonName string, number int32, typ descriptor.FieldDescriptorProto_Type, typeName string, repeated bool) *descriptor.FieldDescriptorProto {
+		label := descriptor.FieldDescriptorProto_LABEL_OPTIONAL
+		if repeated {
+			label = descriptor.FieldDescriptorProto_LABEL_REPEATED
+		}
+		f := &descriptor.FieldDescriptorProto{
+			Name: proto.String(name), JsonName: proto.String(jsonName), Number: proto.Int32(number),
+			Type: typ.Enum(), Label: label.Enum(),
+		}
+		if typeName != "" {
+			f.TypeName = proto.String(typeName)
+		}
+		return f
+	}
+
+	file := itemsFile(
+		itemMethod("GetItem", &options.HttpRule{Pattern: &options.HttpRule_Get{Get: "/v1/items/{item_id}"}}),
+		itemMethod("SaveItem", &options.HttpRule{Pattern: &options.HttpRule_Put{Put: "/v1/items/{item_id}"}, Body: "*"}),
+		itemMethod("ExportItems", &options.HttpRule{Pattern: &options.HttpRule_Post{Post: "/v1/items:export"}, Body: "*"}, withExtension(httpserver.E_Async, true)),
+	)
+	file.Service[0].Method[0].InputType = proto.String(".items.v1.GetItemRequest")
+	file.Service[0].Method[2].InputType = proto.String(".items.v1.ExportRequest")
+	file.EnumType = []*descriptor.EnumDescriptorProto{{
+		Name: proto.String("State"),
+		Value: []*descriptor.EnumValueDescriptorProto{
+			{Name: proto.String("STATE_UNSPECIFIED"), Number: proto.Int32(0)},
+			{Name: proto.String("STATE_ACTIVE"), Number: proto.Int32(1)},
+		},
+	}}
+	file.MessageType = []*descriptor.DescriptorProto{
+		{
+			Name: proto.String("Item"),
+			Field: []*descriptor.FieldDescriptorProto{
+				field("item_id", "itemId", 1, descriptor.FieldDescriptorProto_TYPE_STRING, "", false),
+				field("size", "size", 2, descriptor.FieldDescriptorProto_TYPE_INT64, "", false),
+				field("state", "state", 3, descriptor.FieldDescriptorProto_TYPE_ENUM, ".items.v1.State", false),
+				field("tags", "tags", 4, descriptor.FieldDescriptorProto_TYPE_STRING, "", true),
+				field("labels", "labels", 5, descriptor.FieldDescriptorProto_TYPE_MESSAGE, ".items.v1.Item.LabelsEntry", true),
+				field("owner", "owner", 6, descriptor.FieldDescriptorProto_TYPE_MESSAGE, ".items.v1.Item.Owner", false),
+			},
+			NestedType: []*descriptor.DescriptorProto{
+				{
+					Name:    proto.String("LabelsEntry"),
+					Options: &descriptor.MessageOptions{MapEntry: proto.Bool(true)},
+					Field: []*descriptor.FieldDescriptorProto{
+						field("key", "key", 1, descriptor.FieldDescriptorProto_TYPE_STRING, "", false),
+						field("value", "value", 2, descriptor.FieldDescriptorProto_TYPE_STRING, "", false),
+					},
+				},
+				{
+					Name:  proto.String("Owner"),
+					Field: []*descriptor.FieldDescriptorProto{field("name", "name", 1, descriptor.FieldDescriptorProto_TYPE_STRING, "", false)},
+				},
+			},
+		},
+		{
+			Name:  proto.String("GetItemRequest"),
+			Field: []*descriptor.FieldDescriptorProto{field("item_id", "itemId", 1, descriptor.FieldDescriptorProto_TYPE_STRING, "", false)},
+		},
+		{Name: proto.String("ExportRequest")},
+	}
+	return pluginRequest(t, parameter, file)
+}
+
+func TestGenerateGraphQL(t *testing.T) {
+	t.Parallel()
+
+	resp := New().Generate(graphqlRequest(t, "binding=true,client=true,graphql=true"))
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	files := map[string]string{}
+	for _, f := range resp.File {
+		files[f.GetName()] = f.GetContent()
+	}
+
+	schema, ok := files["items_http.graphql"]
+	if !ok {
+		t.Fatalf("missing items_http.graphql in %d generated files", len(resp.File))
+	}
+	for _, expected := range []string{
+		"# Code generated by protoc-gen-go-http-server-interface. DO NOT EDIT.\n# source: items.proto\n",
+		"type Query {\n  \"ItemService.GetItem: GET /v1/items/{item_id}\"\n  getItem(input: GetItemRequestInput): Item\n}\n",
+		"  saveItem(input: ItemInput): Item\n",
+		"  exportItems(input: ExportRequestInput): OperationRef\n",
+		"type Item {\n  itemId: String\n  size: String\n  state: State\n  tags: [String!]\n  labels: JSON\n  owner: Item_Owner\n}\n",
+		"input ItemInput {\n",
+		"  owner: Item_OwnerInput\n",
+		"enum State {\n  STATE_UNSPECIFIED\n  STATE_ACTIVE\n}\n",
+		"input ExportRequestInput {\n  _empty: Boolean\n}\n",
+		"type OperationRef {\n  id: String!\n  method: String!\n}\n",
+		"scalar JSON\n",
+	} {
+		if !strings.Contains(schema, expected) {
+			t.Errorf("schema doesn't contain %q:\n%s", expected, schema)
+		}
+	}
+	if n := strings.Count(schema, "enum State {"); n != 1 {
+		t.Errorf("enum State defined %d times, want 1", n)
+	}
+
+	code := files["items_http.pb.go"]
+	for _, expected := range []string{
+		"type ItemServiceResolver struct {\n\tClient *ItemServiceClient\n}",
+		"// GetItem resolves Query.getItem.\nfunc (r *ItemServiceResolver) GetItem(ctx context.Context, input *GetItemRequest) (*Item, error) {\n\treturn r.Client.GetItem(ctx, input)\n}",
+		"func (r *ItemServiceResolver) ExportItems(ctx context.Context, input *ExportRequest) (*OperationRef, error) {",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+}
+
+func TestGenerateGraphQLFieldConflict(t *testing.T) {
+	t.Parallel()
+
+	req := graphqlRequest(t, "binding=true,client=true,graphql=true")
+	file := req.ProtoFile[0]
+	file.Service = append(file.Service, &descriptor.ServiceDescriptorProto{
+		Name:   proto.String("LegacyItemService"),
+		Method: []*descriptor.MethodDescriptorProto{proto.Clone(file.Service[0].Method[0]).(*descriptor.MethodDescriptorProto)},
+	})
+
+	resp := New().Generate(req)
+	want := "items.proto: ItemService.GetItem and LegacyItemService.GetItem both map to GraphQL field Query.getItem"
+	if !strings.Contains(resp.GetError(), want) {
+		t.Errorf("Generate() error = %q, want %q", resp.GetError(), want)
+	}
+}
+
+func TestGenerateWithoutGraphQL(t *testing.T) {
+	t.Parallel()
+
+	resp := New().Generate(graphqlRequest(t, "binding=true,client=true"))
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	for _, f := range resp.File {
+		if strings.HasSuffix(f.GetName(), ".graphql") || strings.Contains(f.GetContent(), "Resolver") {
+			t.Errorf("%s: GraphQL output without graphql=true", f.GetName())
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateGRPCStatus(t *testing.T) {
+	t.Parallel()
+
+	const grpcStatusError = "\tif _, ok := status.FromError(err); ok {\n\t\tWriteGRPCError(w, err)\n\t\treturn\n\t}\n"
+	tests := []struct {
+		name           string
+		parameter      string
+		wantContain    []string
+		wantNotContain []string
+	}{
+		{
+			name:      "typed handlers",
+			parameter: "binding=true,grpc_status=true",
+			wantContain: []string{
+				"\t\"google.golang.org/grpc/codes\"\n\t\"google.golang.org/grpc/status\"\n",
+				"\tcodes.NotFound:           {http.StatusNotFound, \"NOT_FOUND\"},\n",
+				"\tcodes.InvalidArgument:    {http.StatusBadRequest, \"INVALID_ARGUMENT\"},\n",
+				"func HTTPStatusFromCode(code codes.Code) int {",
+				"func WriteGRPCError(w http.ResponseWriter, err error) {",
+				"func writeUnaryError(w http.ResponseWriter, err error) {\n" + grpcStatusError,
+			},
+		},
+		{
+			name:      "error handler",
+			parameter: "binding=true,grpc_status=true,error_handler=true,validate=true",
+			wantContain: []string{
+				"\t\tWriteValidationError(w, validationErr)\n\t\treturn\n\t}\n" + grpcStatusError,
+			},
+			wantNotContain: []string{"writeUnaryError"},
+		},
+		{
+			name:      "error details",
+			parameter: "binding=true,grpc_status=true,error_details=true",
+			wantContain: []string{
+				"// Errors with a gRPC status are written by WriteGRPCError.\nfunc WriteError(w http.ResponseWriter, err error) {\n" + grpcStatusError,
+				"func writeUnaryError(w http.ResponseWriter, err error) {\n\tWriteError(w, err)\n}",
+			},
+		},
+		{
+			name:           "without binding",
+			parameter:      "grpc_status=true",
+			wantContain:    []string{"func HTTPStatusFromCode(code codes.Code) int {", "func WriteGRPCError(w http.ResponseWriter, err error) {"},
+			wantNotContain: []string{"writeUnaryError", "status.FromError(err); ok {\n"},
+		},
+		{
+			name:           "disabled",
+			parameter:      "binding=true",
+			wantNotContain: []string{"google.golang.org/grpc", "HTTPStatusFromCode", "WriteGRPCError"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := graphqlRequest(t, tt.parameter)
+			if !strings.Contains(tt.parameter, "binding=true") {
+				// ExportItems is async, which requires binding=true
+				service := req.ProtoFile[0].Service[0]
+				service.Method = service.Method[:2]
+			}
+			resp := New().Generate(req)
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+			code := resp.File[0].GetContent()
+			for _, expected := range tt.wantContain {
+				if !strings.Contains(code, expected) {
+					t.Errorf("Generated code doesn't contain %q", expected)
+				}
+			}
+			for _, unexpected := range tt.wantNotContain {
+				if strings.Contains(code, unexpected) {
+					t.Errorf("Generated code contains %q", unexpected)
+				}
+			}
+		})
+	}
+}
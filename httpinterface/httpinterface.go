@@ -5,12 +5,14 @@ import (
 	"bytes"
 	_ "embed"
 	"fmt"
-	"path/filepath"
+	"io"
+	"path"
 	"slices"
 	"strings"
 	"text/template"
 
 	"github.com/farhaan/protoc-gen-go-http-server-interface/httpinterface/parser"
+	"github.com/farhaan/protoc-gen-go-http-server-interface/version"
 	"google.golang.org/protobuf/proto"
 	descriptor "google.golang.org/protobuf/types/descriptorpb"
 	plugin "google.golang.org/protobuf/types/pluginpb"
@@ -20,8 +22,127 @@ import (
 var (
 	//go:embed templates/header-template.go.tmpl
 	headerTemplate string
+	//go:embed templates/preamble-template.go.tmpl
+	preambleTemplate string
+	//go:embed templates/runtime-template.go.tmpl
+	runtimeTemplate string
+	//go:embed templates/router-template.go.tmpl
+	routerTemplate string
+	//go:embed templates/runtime-aliases-template.go.tmpl
+	runtimeAliasesTemplate string
+	//go:embed templates/runtime-module-template.go.tmpl
+	runtimeModuleTemplate string
+	//go:embed templates/runtime-module-test-template.go.tmpl
+	runtimeModuleTestTemplate string
 	//go:embed templates/service-template.go.tmpl
 	serviceTemplate string
+	//go:embed templates/service-iface-template.go.tmpl
+	serviceIfaceTemplate string
+	//go:embed templates/service-register-template.go.tmpl
+	serviceRegisterTemplate string
+	//go:embed templates/decompress-template.go.tmpl
+	decompressTemplate string
+	//go:embed templates/binding-template.go.tmpl
+	bindingTemplate string
+	//go:embed templates/stream-template.go.tmpl
+	streamTemplate string
+	//go:embed templates/cache-template.go.tmpl
+	cacheTemplate string
+	//go:embed templates/conditional-template.go.tmpl
+	conditionalTemplate string
+	//go:embed templates/async-template.go.tmpl
+	asyncTemplate string
+	//go:embed templates/unitofwork-template.go.tmpl
+	unitOfWorkTemplate string
+	//go:embed templates/pathvalue-template.go.tmpl
+	pathValueTemplate string
+	//go:embed templates/adapters-template.go.tmpl
+	adaptersTemplate string
+	//go:embed templates/deadline-template.go.tmpl
+	deadlineTemplate string
+	//go:embed templates/ratelimit-template.go.tmpl
+	rateLimitTemplate string
+	//go:embed templates/dedupe-template.go.tmpl
+	dedupeTemplate string
+	//go:embed templates/unary-template.go.tmpl
+	unaryTemplate string
+	//go:embed templates/service-unary-template.go.tmpl
+	serviceUnaryTemplate string
+	//go:embed templates/client-template.go.tmpl
+	clientTemplate string
+	//go:embed templates/service-client-template.go.tmpl
+	serviceClientTemplate string
+	//go:embed templates/service-graphql-template.go.tmpl
+	serviceGraphQLTemplate string
+	//go:embed templates/chaos-template.go.tmpl
+	chaosTemplate string
+	//go:embed templates/recording-template.go.tmpl
+	recordingTemplate string
+
+	//go:embed templates/capture-template.go.tmpl
+	captureTemplate string
+
+	//go:embed templates/tags-template.go.tmpl
+	tagsTemplate string
+
+	//go:embed templates/scope-template.go.tmpl
+	scopeTemplate string
+	//go:embed templates/deprecation-template.go.tmpl
+	deprecationTemplate string
+	//go:embed templates/timeouts-template.go.tmpl
+	timeoutsTemplate string
+	//go:embed templates/rejectbody-template.go.tmpl
+	rejectBodyTemplate string
+	//go:embed templates/staticerrors-template.go.tmpl
+	staticErrorsTemplate string
+	//go:embed templates/normalize-template.go.tmpl
+	normalizeTemplate string
+	//go:embed templates/servertiming-template.go.tmpl
+	serverTimingTemplate string
+	//go:embed templates/generation-template.go.tmpl
+	generationTemplate string
+	//go:embed templates/errors-template.go.tmpl
+	errorsTemplate string
+	//go:embed templates/mock-template.go.tmpl
+	mockTemplate string
+	//go:embed templates/service-mock-template.go.tmpl
+	serviceMockTemplate string
+	//go:embed templates/ts-client-runtime.ts
+	tsClientRuntime string
+	//go:embed templates/cli-main-template.go.tmpl
+	cliMainTemplate string
+	//go:embed templates/scaffold-handler-template.go.tmpl
+	scaffoldHandlerTemplate string
+	//go:embed templates/scaffold-service-template.go.tmpl
+	scaffoldServiceTemplate string
+	//go:embed templates/scaffold-main-template.go.tmpl
+	scaffoldMainTemplate string
+	//go:embed templates/scaffold-dockerfile.tmpl
+	scaffoldDockerfileTemplate string
+	//go:embed templates/scaffold-makefile.tmpl
+	scaffoldMakefileTemplate string
+	//go:embed templates/routeconfig-template.go.tmpl
+	routeConfigTemplate string
+	//go:embed templates/otel-template.go.tmpl
+	otelTemplate string
+	//go:embed templates/validate-template.go.tmpl
+	validateTemplate string
+	//go:embed templates/forwardheaders-template.go.tmpl
+	forwardHeadersTemplate string
+	//go:embed templates/errorhandler-template.go.tmpl
+	errorHandlerTemplate string
+	//go:embed templates/grpcstatus-template.go.tmpl
+	grpcStatusTemplate string
+	//go:embed templates/routetable-template.go.tmpl
+	routeTableTemplate string
+	//go:embed templates/longpoll-template.go.tmpl
+	longPollTemplate string
+	//go:embed templates/merge-template.go.tmpl
+	mergeTemplate string
+	//go:embed templates/negotiation-template.go.tmpl
+	negotiationTemplate string
+	//go:embed templates/doc-template.go.tmpl
+	docTemplate string
 )
 
 // toHTTPMethodConstant converts an HTTP method string to a net/http constant name.
@@ -65,18 +186,124 @@ type Generator struct {
 	PathPatternConverter PathPatternConverter
 	// SupportsEditions indicates if this generator supports editions
 	SupportsEditions bool
+	// APIConfigRules holds HTTP rules from a gateway-style API configuration,
+	// merged with each method's annotations by selector
+	APIConfigRules APIConfigRules
+	// Warnings receives generation warnings, one per line, such as routes bound
+	// by more than one service; nil discards them
+	Warnings io.Writer
+	// Version is stamped into generated files as their GeneratorVersion
+	Version string
+
+	// messageFiles maps fully-qualified message names to their files for the current request
+	messageFiles map[string]*descriptor.FileDescriptorProto
+	// protoTypes indexes the messages and enums of the current request
+	protoTypes protoTypes
+	// parameter is the plugin parameter string of the current request
+	parameter string
 }
 
 // ServiceData contains the data for a service definition.
 type ServiceData struct {
 	PackageName string
-	Services    []ServiceInfo
+	// GoImport is the Go package of the generated file. Message types from
+	// other packages are referred to through their import.
+	GoImport GoImport
+	Services []ServiceInfo
+	// MediaTypes lists the vendor media types of the request and response
+	// messages when media_type_vendor is set.
+	MediaTypes []MediaTypeInfo
+	// ErrorCatalog is generated from the ErrorReason enum of the file's proto
+	// package, or nil if it declares none.
+	ErrorCatalog *ErrorCatalog
+	// NormalizedFields lists the fields of the request messages that set the
+	// (http_server.normalize) option when binding is set.
+	NormalizedFields []NormalizedField
+	// Options holds the plugin options that toggle optional generated code.
+	Options Options
+	// Source is the proto file the code is generated from.
+	Source string
+	// GeneratorVersion and GeneratorParameters are the generator version and
+	// plugin parameter string stamped into the generated code.
+	GeneratorVersion, GeneratorParameters string
+}
+
+// headerTemplateData is the data passed to the header template.
+type headerTemplateData struct {
+	*ServiceData
+	// StdImports lists the standard library packages imported by the generated file.
+	StdImports []string
+	// ThirdPartyImports lists the non-standard packages imported by the generated file.
+	ThirdPartyImports []GoImport
+}
+
+// serviceTemplateData is the data passed to the service template.
+type serviceTemplateData struct {
+	ServiceInfo
+	Options Options
+}
+
+// routeHandlerData is the data passed to the route-handler template, which
+// wraps the handler of one binding the way its route serves it.
+type routeHandlerData struct {
+	Method  MethodInfo
+	Rule    parser.HTTPRule
+	Options Options
+	// Handler is the expression of the handler to wrap, such as "h.ServeHTTP".
+	Handler string
+	// Middlewares is the expression of the per-route middlewares Handler is
+	// wrapped in, such as "middlewares", or empty if it is not.
+	Middlewares string
+	// unrouted reports that another framework routes the handler, setting
+	// path template variables whole rather than through ServeMux wildcards.
+	unrouted bool
+}
+
+// newRouteHandlerData returns the route-handler template data of rule.
+func newRouteHandlerData(options Options, method MethodInfo, rule parser.HTTPRule, handler, middlewares string) routeHandlerData {
+	return routeHandlerData{Method: method, Rule: rule, Options: options, Handler: handler, Middlewares: middlewares}
+}
+
+// Unrouted returns d for a handler another framework routes, which sets path
+// template variables under their field paths itself.
+func (d routeHandlerData) Unrouted() routeHandlerData {
+	d.unrouted = true
+	return d
+}
+
+// CachedHandler returns the expression of the handler of d served through the
+// response cache. The cache goes inside the per-route middlewares, so they
+// still run, authenticating the request for one, on cache hits.
+func (d routeHandlerData) CachedHandler() string {
+	if d.Middlewares == "" {
+		return fmt.Sprintf("cacheResponse(%q, %d, %s)", d.Rule.Pattern, d.Method.CacheTTLSeconds, d.Handler)
+	}
+	return fmt.Sprintf("applyMiddlewares(cacheResponse(%q, %d, handler.Handle%s), %s).ServeHTTP",
+		d.Rule.Pattern, d.Method.CacheTTLSeconds, d.Method.Name, d.Middlewares)
+}
+
+// Wrapped reports whether the route-handler template wraps the handler of d
+// rather than rendering it as is.
+func (d routeHandlerData) Wrapped() bool {
+	o := d.Options
+	return (o.StrictContentType && !d.Method.StreamBody) || d.Method.DedupeWindowSeconds > 0 || d.Method.HasTimeouts() ||
+		(o.ConditionalGet && d.Rule.Method == "GET") || (o.Decompress && d.Rule.Body != "") ||
+		d.Method.CachesRule(d.Rule) || len(d.Method.PathParamAliases) > 0 || d.Method.Deprecates(d.Rule) ||
+		d.RejectsBody() || len(d.PathVariables()) > 0
 }
 
 // ServiceInfo contains information about a service.
 type ServiceInfo struct {
-	Name    string
-	Methods []MethodInfo
+	Name string
+	// FullName is the fully-qualified proto name, e.g. "tasks.v1.TaskService".
+	FullName string
+	// BasePath is the path Register<Service>RoutesAt is usually given, e.g.
+	// "/tasks.v1", from the proto package or the (http_server.base_path) option.
+	BasePath string
+	// ForwardHeaders are the canonical names of the request headers listed by
+	// the (http_server.forward_headers) option.
+	ForwardHeaders []string
+	Methods        []MethodInfo
 }
 
 // MethodInfo contains information about a method.
@@ -85,21 +312,101 @@ type MethodInfo struct {
 	InputType  string
 	OutputType string
 	HTTPRules  []parser.HTTPRule
+	// InputImport and OutputImport are the Go packages of the request and response types.
+	InputImport  GoImport
+	OutputImport GoImport
+	// InputGoType and OutputGoType are the Go types of the request and response
+	// messages, qualified with their package name when it is not the generated
+	// file's own, e.g. "GetTaskRequest" or "commonv1.Page".
+	InputGoType  string
+	OutputGoType string
+	// PathParamAliases maps path parameter names used only by additional
+	// bindings to the primary binding's name for the same parameter.
+	PathParamAliases map[string]string
+	// StreamArray is set by the (http_server.stream_array) method option.
+	StreamArray bool
+	// Internal is set by (http_server.visibility) = INTERNAL; the method's routes
+	// are registered by Register<Service>InternalRoutes only.
+	Internal bool
+	// CacheTTLSeconds is the ttl_seconds of the (http_server.cache) method
+	// option; its GET routes are cached through the ResponseCache of their
+	// router when set.
+	CacheTTLSeconds uint32
+	// Async is set by the (http_server.async) method option; requests are
+	// accepted with 202 and handed to an Enqueuer instead of a handler.
+	Async bool
+	// StreamBody is set by the (http_server.stream_body) method option; the
+	// typed handler reads the request body itself from an io.Reader.
+	StreamBody bool
+	// LongPollMs is the (http_server.long_poll_ms) method option; the typed
+	// handler returns a channel its result is sent on, served through an
+	// AsyncAdapter, when set.
+	LongPollMs uint32
+	// DedupeWindowSeconds is the window_seconds of the (http_server.dedupe)
+	// method option; duplicate requests are answered through
+	// DefaultRequestDeduper when set.
+	DedupeWindowSeconds uint32
+	// ReadTimeoutMs and WriteTimeoutMs are the read_ms and write_ms of the
+	// (http_server.timeouts) method option; its routes set the request's
+	// deadlines through http.ResponseController when either is set.
+	ReadTimeoutMs  uint32
+	WriteTimeoutMs uint32
+	// SkipUnitOfWork is set by (http_server.unit_of_work) = false; the method's
+	// routes run outside the unit of work of a router with WithUnitOfWork.
+	SkipUnitOfWork bool
+	// Idempotent is set by an idempotency_level of IDEMPOTENT or
+	// NO_SIDE_EFFECTS; generated clients retry the method's failed calls.
+	Idempotent bool
+	// Pagination is set for list methods following AIP-158; generated
+	// clients get an iterator over the items of all pages.
+	Pagination *PaginationInfo
+	// Tags are the (http_server.service_tags) of the service followed by the
+	// (http_server.tags) of the method, grouping its routes in generated
+	// artifacts.
+	Tags []string
+	// Scopes are the (http_server.service_scopes) of the service followed by
+	// the (http_server.scopes) of the method, exported to the permission
+	// matrix.
+	Scopes []string
+	// Comment is the leading comment of the rpc in the proto file, copied into
+	// the docs of its handler method, route registration and gateway operation.
+	Comment string
+	// DeprecatedBindings lists the bindings marked with an "http: deprecated"
+	// comment directive, as "METHOD pattern"; their routes set the Deprecation
+	// header.
+	DeprecatedBindings []string
 }
 
-// New creates a new httpinterface generator with an optional custom HTTP rule extractor.
-// If no extractor is provided, uses the default extractHTTPRules.
-func New(httpExtractor ...HTTPRuleExtractor) *Generator {
-	// Parse the templates
+// parseTemplates parses the embedded templates into a single template set.
+func parseTemplates() *template.Template {
 	tmpl := template.New("httpinterface").Funcs(template.FuncMap{
 		"lower": strings.ToLower,
+		"upper": strings.ToUpper,
 		"title": func(s string) string {
 			if s == "" {
 				return ""
 			}
 			return strings.ToUpper(s[:1]) + s[1:]
 		},
-		"httpMethod": toHTTPMethodConstant,
+		"lowerFirst": func(s string) string {
+			if s == "" {
+				return ""
+			}
+			return strings.ToLower(s[:1]) + s[1:]
+		},
+		"httpMethod":     toHTTPMethodConstant,
+		"scaffoldRoutes": scaffoldRoutes,
+		"routeHandler":   newRouteHandlerData,
+		"stringSlice":    goStringSlice,
+		"stringMap":      goStringMap,
+		"goComment":      goComment,
+		"mutatingMethod": func(method string) bool {
+			switch method {
+			case "POST", "PUT", "PATCH", "DELETE":
+				return true
+			}
+			return false
+		},
 	})
 
 	// Parse header template
@@ -108,6 +415,81 @@ func New(httpExtractor ...HTTPRuleExtractor) *Generator {
 	// Parse service template
 	tmpl = template.Must(tmpl.New("service").Parse(serviceTemplate))
 
+	// Parse the sections shared by the single-file and split layouts, and the
+	// optional feature templates. Trailing newlines are trimmed so the
+	// including template controls the blank lines around each section.
+	tmpl = template.Must(tmpl.New("preamble").Parse(strings.TrimRight(preambleTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("runtime").Parse(strings.TrimRight(runtimeTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("router").Parse(strings.TrimRight(routerTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("runtime-aliases").Parse(strings.TrimRight(runtimeAliasesTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("service-iface").Parse(strings.TrimRight(serviceIfaceTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("service-register").Parse(strings.TrimRight(serviceRegisterTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("decompress").Parse(strings.TrimRight(decompressTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("binding").Parse(strings.TrimRight(bindingTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("stream").Parse(strings.TrimRight(streamTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("cache").Parse(strings.TrimRight(cacheTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("conditional").Parse(strings.TrimRight(conditionalTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("unitofwork").Parse(strings.TrimRight(unitOfWorkTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("pathvalue").Parse(strings.TrimRight(pathValueTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("adapters").Parse(strings.TrimRight(adaptersTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("deadline").Parse(strings.TrimRight(deadlineTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("ratelimit").Parse(strings.TrimRight(rateLimitTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("dedupe").Parse(strings.TrimRight(dedupeTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("unary").Parse(strings.TrimRight(unaryTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("async").Parse(strings.TrimRight(asyncTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("service-unary").Parse(strings.TrimRight(serviceUnaryTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("client").Parse(strings.TrimRight(clientTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("service-client").Parse(strings.TrimRight(serviceClientTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("service-graphql").Parse(strings.TrimRight(serviceGraphQLTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("chaos").Parse(strings.TrimRight(chaosTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("recording").Parse(strings.TrimRight(recordingTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("capture").Parse(strings.TrimRight(captureTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("tags").Parse(strings.TrimRight(tagsTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("scope").Parse(strings.TrimRight(scopeTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("deprecation").Parse(strings.TrimRight(deprecationTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("timeouts").Parse(strings.TrimRight(timeoutsTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("routeconfig").Parse(strings.TrimRight(routeConfigTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("otel").Parse(strings.TrimRight(otelTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("validate").Parse(strings.TrimRight(validateTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("forwardheaders").Parse(strings.TrimRight(forwardHeadersTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("errorhandler").Parse(strings.TrimRight(errorHandlerTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("grpcstatus").Parse(strings.TrimRight(grpcStatusTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("routetable").Parse(strings.TrimRight(routeTableTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("longpoll").Parse(strings.TrimRight(longPollTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("merge").Parse(strings.TrimRight(mergeTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("negotiation").Parse(strings.TrimRight(negotiationTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("rejectbody").Parse(strings.TrimRight(rejectBodyTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("servertiming").Parse(strings.TrimRight(serverTimingTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("staticerrors").Parse(strings.TrimRight(staticErrorsTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("normalize").Parse(strings.TrimRight(normalizeTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("generation").Parse(strings.TrimRight(generationTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("errors").Parse(strings.TrimRight(errorsTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("mock").Parse(strings.TrimRight(mockTemplate, "\n")))
+	tmpl = template.Must(tmpl.New("service-mock").Parse(strings.TrimRight(serviceMockTemplate, "\n")))
+
+	// Parse the package documentation template
+	tmpl = template.Must(tmpl.New("doc").Parse(docTemplate))
+
+	// Parse the runtime module templates
+	tmpl = template.Must(tmpl.New("runtime-module").Parse(runtimeModuleTemplate))
+	tmpl = template.Must(tmpl.New("runtime-module-test").Parse(runtimeModuleTestTemplate))
+
+	// Parse the command-line client template
+	tmpl = template.Must(tmpl.New("cli-main").Parse(cliMainTemplate))
+
+	// Parse the scaffold templates
+	tmpl = template.Must(tmpl.New("scaffold-handler").Parse(scaffoldHandlerTemplate))
+	tmpl = template.Must(tmpl.New("scaffold-service").Parse(scaffoldServiceTemplate))
+	tmpl = template.Must(tmpl.New("scaffold-main").Parse(scaffoldMainTemplate))
+	tmpl = template.Must(tmpl.New("scaffold-dockerfile").Parse(scaffoldDockerfileTemplate))
+	tmpl = template.Must(tmpl.New("scaffold-makefile").Parse(scaffoldMakefileTemplate))
+
+	return tmpl
+}
+
+// New creates a new httpinterface generator with an optional custom HTTP rule extractor.
+// If no extractor is provided, uses the default extractHTTPRules.
+func New(httpExtractor ...HTTPRuleExtractor) *Generator {
 	// Set up defaults
 	var extractor HTTPRuleExtractor = extractHTTPRules
 	if len(httpExtractor) > 0 {
@@ -115,43 +497,27 @@ func New(httpExtractor ...HTTPRuleExtractor) *Generator {
 	}
 
 	return &Generator{
-		ParsedTemplates:      tmpl,
+		ParsedTemplates:      parseTemplates(),
 		Options:              &Options{},
 		HTTPRuleExtractor:    extractor,
 		PathParamExtractor:   extractPathParams,
 		PathPatternConverter: convertPathPattern,
 		SupportsEditions:     true,
+		Version:              version.GetVersion(),
 	}
 }
 
 // NewWith creates a new generator with all custom dependencies.
 func NewWith(httpExtractor HTTPRuleExtractor, pathExtractor PathParamExtractor,
 	converter PathPatternConverter) *Generator {
-	// Parse the templates
-	tmpl := template.New("httpinterface").Funcs(template.FuncMap{
-		"lower": strings.ToLower,
-		"title": func(s string) string {
-			if s == "" {
-				return ""
-			}
-			return strings.ToUpper(s[:1]) + s[1:]
-		},
-		"httpMethod": toHTTPMethodConstant,
-	})
-
-	// Parse header template
-	tmpl = template.Must(tmpl.New("header").Parse(headerTemplate))
-
-	// Parse service template
-	tmpl = template.Must(tmpl.New("service").Parse(serviceTemplate))
-
 	return &Generator{
-		ParsedTemplates:      tmpl,
+		ParsedTemplates:      parseTemplates(),
 		Options:              &Options{},
 		HTTPRuleExtractor:    httpExtractor,
 		PathParamExtractor:   pathExtractor,
 		PathPatternConverter: converter,
 		SupportsEditions:     true,
+		Version:              version.GetVersion(),
 	}
 }
 
@@ -182,18 +548,65 @@ func (g *Generator) Generate(req *plugin.CodeGeneratorRequest) *plugin.CodeGener
 
 	// Set edition support range for editions
 	if g.SupportsEditions {
-		resp.MinimumEdition = proto.Int32(int32(descriptor.Edition_EDITION_PROTO3))
-		resp.MaximumEdition = proto.Int32(int32(descriptor.Edition_EDITION_2023))
+		resp.MinimumEdition = proto.Int32(int32(descriptor.Edition_EDITION_PROTO2))
+		resp.MaximumEdition = proto.Int32(int32(g.Options.maxEdition()))
+	}
+
+	// Reject files newer than the edition ceiling before generating anything
+	for _, file := range req.ProtoFile {
+		if !g.shouldGenerate(file.GetName(), req.FileToGenerate) {
+			continue
+		}
+		if err := g.checkEdition(file); err != nil {
+			resp.Error = proto.String(err.Error())
+			return resp
+		}
+	}
+
+	g.messageFiles = indexMessageFiles(req.ProtoFile)
+	g.protoTypes = indexProtoTypes(req.ProtoFile)
+
+	// Scaffolding replaces the generated code, so it can use its own output directory
+	if g.Options.Scaffold != "" {
+		scaffoldFiles, err := g.generateScaffoldFiles(req)
+		if err != nil {
+			resp.Error = proto.String(err.Error())
+			return resp
+		}
+		resp.File = scaffoldFiles
+		return resp
 	}
 
 	// Process each proto file
 	for _, file := range req.ProtoFile {
-		if outputFile, err := g.processFile(file, req.FileToGenerate); err != nil {
+		outputFiles, err := g.processFile(file, req.FileToGenerate)
+		if err != nil {
+			resp.Error = proto.String(err.Error())
+			return resp
+		}
+		resp.File = append(resp.File, outputFiles...)
+	}
+
+	for _, warning := range g.routeConflicts(req) {
+		g.warn(warning)
+	}
+
+	if g.Options.Doc {
+		docFiles, err := g.generateDocFiles(req)
+		if err != nil {
 			resp.Error = proto.String(err.Error())
 			return resp
-		} else if outputFile != nil {
-			resp.File = append(resp.File, outputFile)
 		}
+		resp.File = append(resp.File, docFiles...)
+	}
+
+	if g.Options.RuntimeModule != "" && !g.Options.RuntimeImport {
+		runtimeFiles, err := g.generateRuntimeModule()
+		if err != nil {
+			resp.Error = proto.String(err.Error())
+			return resp
+		}
+		resp.File = append(resp.File, runtimeFiles...)
 	}
 
 	return resp
@@ -206,59 +619,240 @@ func (g *Generator) applyOptions(parameter string) error {
 		return err
 	}
 	g.Options = options
+	g.parameter = parameter
+
+	if options.APIConfiguration != "" {
+		rules, err := LoadAPIConfig(options.APIConfiguration)
+		if err != nil {
+			return err
+		}
+		g.APIConfigRules = rules
+	}
 	return nil
 }
 
-// processFile processes a single proto file and returns an output file if generation is needed
+// checkEdition reports an error if file uses an edition newer than the configured maximum.
+func (g *Generator) checkEdition(file *descriptor.FileDescriptorProto) error {
+	edition := parser.FileEdition(file)
+	ceiling := g.Options.maxEdition()
+	if edition <= ceiling {
+		return nil
+	}
+
+	newest := maxEditionValues[len(maxEditionValues)-1]
+	if edition > newest.edition {
+		return fmt.Errorf("%s uses edition %s, which is newer than the newest supported edition %s",
+			file.GetName(), editionName(edition), newest.name)
+	}
+	return fmt.Errorf("%s uses edition %s, which is newer than the maximum edition %s; set max_edition=%s to generate it",
+		file.GetName(), editionName(edition), editionName(ceiling), editionName(edition))
+}
+
+// editionName returns the short name of an edition, such as "2023".
+func editionName(edition descriptor.Edition) string {
+	return strings.TrimPrefix(edition.String(), "EDITION_")
+}
+
+// processFile processes a single proto file and returns its output files if generation is needed
 func (g *Generator) processFile(
 	file *descriptor.FileDescriptorProto,
 	filesToGenerate []string,
-) (*plugin.CodeGeneratorResponse_File, error) {
+) ([]*plugin.CodeGeneratorResponse_File, error) {
 	if !g.shouldGenerate(file.GetName(), filesToGenerate) {
 		return nil, nil
 	}
 
 	// Check if the file has any services with HTTP annotations
 	if !g.hasHTTPRules(file) {
-		return nil, nil
+		return g.emptyFiles(file), nil
 	}
 
 	// Prepare the data for code generation
 	data := g.buildServiceData(file)
 	if len(data.Services) == 0 {
-		return nil, nil
+		return g.emptyFiles(file), nil
 	}
-
-	// Generate code
-	content, err := g.GenerateCode(data)
-	if err != nil {
-		return nil, fmt.Errorf("error generating code for %s: %v", file.GetName(), err)
+	if err := checkPathParams(data); err != nil {
+		return nil, fmt.Errorf("%s: %v", file.GetName(), err)
+	}
+	if err := checkStreamArray(data); err != nil {
+		return nil, fmt.Errorf("%s: %v", file.GetName(), err)
+	}
+	if err := checkCache(data); err != nil {
+		return nil, fmt.Errorf("%s: %v", file.GetName(), err)
+	}
+	if err := checkAsync(data); err != nil {
+		return nil, fmt.Errorf("%s: %v", file.GetName(), err)
+	}
+	if err := checkStreamBody(data); err != nil {
+		return nil, fmt.Errorf("%s: %v", file.GetName(), err)
+	}
+	if err := checkLongPoll(data); err != nil {
+		return nil, fmt.Errorf("%s: %v", file.GetName(), err)
+	}
+	if err := checkErrorCatalog(data); err != nil {
+		return nil, fmt.Errorf("%s: %v", file.GetName(), err)
+	}
+	if err := checkDeprecatedBindings(data); err != nil {
+		return nil, fmt.Errorf("%s: %v", file.GetName(), err)
+	}
+	if err := checkNormalize(data); err != nil {
+		return nil, fmt.Errorf("%s: %v", file.GetName(), err)
+	}
+	if err := checkBasePath(data); err != nil {
+		return nil, fmt.Errorf("%s: %v", file.GetName(), err)
+	}
+	if err := checkForwardHeaders(data); err != nil {
+		return nil, fmt.Errorf("%s: %v", file.GetName(), err)
+	}
+	if err := checkRuntimeModule(data); err != nil {
+		return nil, fmt.Errorf("%s: %v", file.GetName(), err)
+	}
+	if !g.Options.RawPatterns {
+		if err := checkPatterns(data); err != nil {
+			return nil, fmt.Errorf("%s: %v", file.GetName(), err)
+		}
 	}
 
-	// Create output file
 	filename := g.getOutputFilename(file.GetName())
-	outputFile := &plugin.CodeGeneratorResponse_File{
-		Name:    proto.String(filename),
-		Content: proto.String(content),
+
+	// Generate code
+	var outputFiles []*plugin.CodeGeneratorResponse_File
+	if g.Options.Layout == LayoutSplit {
+		splitFiles, err := g.GenerateSplitCode(data)
+		if err != nil {
+			return nil, fmt.Errorf("error generating code for %s: %v", file.GetName(), err)
+		}
+		for _, f := range splitFiles {
+			outputFiles = append(outputFiles, &plugin.CodeGeneratorResponse_File{
+				Name:    proto.String(strings.TrimSuffix(filename, ".pb.go") + "_" + f.Suffix + ".pb.go"),
+				Content: proto.String(f.Content),
+			})
+		}
+	} else {
+		content, err := g.GenerateCode(data)
+		if err != nil {
+			return nil, fmt.Errorf("error generating code for %s: %v", file.GetName(), err)
+		}
+		outputFiles = append(outputFiles, &plugin.CodeGeneratorResponse_File{
+			Name:    proto.String(filename),
+			Content: proto.String(content),
+		})
 	}
 
 	// Handle source_relative paths option
-	g.applySourceRelativePath(outputFile, file.GetName())
+	for _, outputFile := range outputFiles {
+		g.applySourceRelativePath(outputFile, file.GetName())
+	}
 
-	return outputFile, nil
+	if g.Options.Router != "" {
+		adapterFile, err := g.generateAdapterFile(file, data)
+		if err != nil {
+			return nil, err
+		}
+		outputFiles = append(outputFiles, adapterFile)
+	}
+
+	if g.Options.GraphQL {
+		graphqlFile, err := g.generateGraphQLFile(file, data)
+		if err != nil {
+			return nil, err
+		}
+		outputFiles = append(outputFiles, graphqlFile)
+	}
+
+	if g.Options.TSClient {
+		tsFile, err := g.generateTSFile(file, data)
+		if err != nil {
+			return nil, err
+		}
+		outputFiles = append(outputFiles, tsFile)
+	}
+
+	if g.Options.CLI {
+		cliFiles, err := g.generateCLIFiles(file, data)
+		if err != nil {
+			return nil, err
+		}
+		outputFiles = append(outputFiles, cliFiles...)
+	}
+
+	if g.Options.LoadTest != "" {
+		loadTestFile, err := g.generateLoadTestFile(file, data)
+		if err != nil {
+			return nil, err
+		}
+		outputFiles = append(outputFiles, loadTestFile)
+	}
+
+	if g.Options.SLO {
+		sloFiles, err := g.generateSLOFiles(file, data)
+		if err != nil {
+			return nil, err
+		}
+		outputFiles = append(outputFiles, sloFiles...)
+	}
+
+	if g.Options.GatewayOpenAPI != "" {
+		gatewayFile, err := g.generateGatewayFile(file, data)
+		if err != nil {
+			return nil, err
+		}
+		outputFiles = append(outputFiles, gatewayFile)
+	}
+
+	if g.Options.OpenAPI {
+		openAPIFile, err := g.generateOpenAPIFile(file, data)
+		if err != nil {
+			return nil, err
+		}
+		outputFiles = append(outputFiles, openAPIFile)
+	}
+
+	if data.HasScopes() {
+		permissionsFile, err := g.generatePermissionsFile(file, data)
+		if err != nil {
+			return nil, err
+		}
+		outputFiles = append(outputFiles, permissionsFile)
+	}
+
+	if g.Options.RuntimeModule != "" {
+		if err := pruneRouterImports(outputFiles); err != nil {
+			return nil, fmt.Errorf("%s: %v", file.GetName(), err)
+		}
+	}
+
+	if g.Options.Minimal {
+		if err := minimizeFiles(outputFiles); err != nil {
+			return nil, fmt.Errorf("%s: %v", file.GetName(), err)
+		}
+	}
+
+	if g.Options.Report {
+		reportFile, err := g.generateReportFile(file, data, outputFiles)
+		if err != nil {
+			return nil, err
+		}
+		outputFiles = append(outputFiles, reportFile)
+	}
+
+	return outputFiles, nil
 }
 
 // applySourceRelativePath adjusts the output filename when paths=source_relative is set.
-// It prefixes the output filename with the proto file's directory.
+// It prefixes the output filename with the proto file's directory. Proto file
+// names and output names are slash-separated on every platform, so they are
+// joined with path rather than filepath.
 func (g *Generator) applySourceRelativePath(
 	outputFile *plugin.CodeGeneratorResponse_File,
 	protoFileName string,
 ) {
 	if g.Options.PathsSourceRelative {
-		dir := filepath.Dir(protoFileName)
+		dir := path.Dir(protoFileName)
 		if dir != "." {
-			filename := filepath.Base(outputFile.GetName())
-			outputFile.Name = proto.String(filepath.Join(dir, filename))
+			filename := path.Base(outputFile.GetName())
+			outputFile.Name = proto.String(path.Join(dir, filename))
 		}
 	}
 }
@@ -272,7 +866,7 @@ func (g *Generator) shouldGenerate(file string, filesToGenerate []string) bool {
 func (g *Generator) hasHTTPRules(file *descriptor.FileDescriptorProto) bool {
 	for _, service := range file.Service {
 		for _, method := range service.Method {
-			rules := g.HTTPRuleExtractor(method)
+			rules := g.methodHTTPRules(file, service, method)
 			if len(rules) > 0 {
 				return true
 			}
@@ -281,21 +875,50 @@ func (g *Generator) hasHTTPRules(file *descriptor.FileDescriptorProto) bool {
 	return false
 }
 
+// methodHTTPRules returns the HTTP rules of method: those from its annotations
+// followed by any declared for it in the API configuration.
+func (g *Generator) methodHTTPRules(
+	file *descriptor.FileDescriptorProto,
+	service *descriptor.ServiceDescriptorProto,
+	method *descriptor.MethodDescriptorProto,
+) []parser.HTTPRule {
+	rules := g.HTTPRuleExtractor(method)
+	if len(g.APIConfigRules) == 0 {
+		return rules
+	}
+
+	fullName := service.GetName() + "." + method.GetName()
+	if pkg := file.GetPackage(); pkg != "" {
+		fullName = pkg + "." + fullName
+	}
+	return g.APIConfigRules.merge(fullName, rules)
+}
+
 // buildServiceData builds the service data for code generation.
 func (g *Generator) buildServiceData(file *descriptor.FileDescriptorProto) *ServiceData {
 	data := &ServiceData{
-		PackageName: g.getPackageName(file),
-		Services:    make([]ServiceInfo, 0, len(file.Service)),
+		PackageName:         g.getPackageName(file),
+		GoImport:            g.goImportFor(file),
+		Services:            make([]ServiceInfo, 0, len(file.Service)),
+		Source:              file.GetName(),
+		GeneratorVersion:    g.Version,
+		GeneratorParameters: g.parameter,
+	}
+	if g.Options != nil {
+		data.Options = *g.Options
 	}
 
-	for _, service := range file.Service {
+	for serviceIndex, service := range file.Service {
 		serviceInfo := ServiceInfo{
-			Name:    service.GetName(),
-			Methods: make([]MethodInfo, 0, len(service.Method)),
+			Name:           service.GetName(),
+			FullName:       strings.TrimPrefix(file.GetPackage()+"."+service.GetName(), "."),
+			BasePath:       serviceBasePath(file, service),
+			ForwardHeaders: serviceForwardHeaders(service),
+			Methods:        make([]MethodInfo, 0, len(service.Method)),
 		}
 
-		for _, method := range service.Method {
-			httpRules := g.HTTPRuleExtractor(method)
+		for methodIndex, method := range service.Method {
+			httpRules := g.methodHTTPRules(file, service, method)
 			if len(httpRules) == 0 {
 				continue
 			}
@@ -305,14 +928,45 @@ func (g *Generator) buildServiceData(file *descriptor.FileDescriptorProto) *Serv
 				InputType:  g.getTypeName(method.GetInputType()),
 				OutputType: g.getTypeName(method.GetOutputType()),
 				HTTPRules:  httpRules,
+
+				InputImport:  g.messageImport(method.GetInputType()),
+				OutputImport: g.messageImport(method.GetOutputType()),
+				InputGoType:  g.qualifiedGoType(file, method.GetInputType()),
+				OutputGoType: g.qualifiedGoType(file, method.GetOutputType()),
+
+				StreamArray: methodStreamArray(method),
+				Internal:    methodInternal(method),
+
+				CacheTTLSeconds: methodCacheTTL(method),
+				Async:           methodAsync(method),
+				StreamBody:      methodStreamBody(method),
+				LongPollMs:      methodLongPollMs(method),
+				SkipUnitOfWork:  methodSkipUnitOfWork(method),
+				Idempotent:      methodIdempotent(method),
+				Pagination:      g.pagination(file, method),
+
+				DedupeWindowSeconds: methodDedupeWindow(method),
+				Tags:                methodTags(service, method),
+				Scopes:              methodScopes(service, method),
+				Comment:             methodComment(file, serviceIndex, methodIndex),
+				DeprecatedBindings:  methodDeprecatedBindings(file, serviceIndex, methodIndex, data.Options.PathPrefix),
 			}
+			methodInfo.ReadTimeoutMs, methodInfo.WriteTimeoutMs = methodTimeouts(method)
 
 			// Process HTTP rules
 			for i := range methodInfo.HTTPRules {
 				rule := &methodInfo.HTTPRules[i]
 				rule.PathParams = g.PathParamExtractor(rule.Pattern)
-				rule.Pattern = g.PathPatternConverter(rule.Pattern)
+				template := rule.Pattern
+				if !data.Options.RawPatterns {
+					rule.Pattern = g.PathPatternConverter(rule.Pattern)
+				}
+				if rule.Pattern != template {
+					rule.Template = data.Options.PathPrefix + template
+				}
+				rule.Pattern = data.Options.PathPrefix + rule.Pattern
 			}
+			methodInfo.PathParamAliases = pathParamAliases(methodInfo.HTTPRules)
 
 			serviceInfo.Methods = append(serviceInfo.Methods, methodInfo)
 		}
@@ -321,6 +975,13 @@ func (g *Generator) buildServiceData(file *descriptor.FileDescriptorProto) *Serv
 			data.Services = append(data.Services, serviceInfo)
 		}
 	}
+	if data.Options.MediaTypeVendor != "" {
+		data.MediaTypes = g.mediaTypes(file, data)
+	}
+	data.ErrorCatalog = g.errorCatalog(file)
+	if data.Options.Binding {
+		data.NormalizedFields = g.normalizedFields(file)
+	}
 
 	return data
 }
@@ -330,13 +991,17 @@ func (g *Generator) GenerateCode(data *ServiceData) (string, error) {
 	var buf bytes.Buffer
 
 	// Execute header template
-	if err := g.ParsedTemplates.ExecuteTemplate(&buf, "header", data); err != nil {
+	header := headerTemplateData{ServiceData: data}
+	header.StdImports, header.ThirdPartyImports = fileImports(data)
+	header.StdImports, header.ThirdPartyImports = data.withExtraInterfaceImports(header.StdImports, header.ThirdPartyImports)
+	if err := g.ParsedTemplates.ExecuteTemplate(&buf, "header", header); err != nil {
 		return "", fmt.Errorf("failed to execute header template: %v", err)
 	}
 
 	// Execute service template for each service
 	for _, service := range data.Services {
-		if err := g.ParsedTemplates.ExecuteTemplate(&buf, "service", service); err != nil {
+		serviceData := serviceTemplateData{ServiceInfo: service, Options: data.Options}
+		if err := g.ParsedTemplates.ExecuteTemplate(&buf, "service", serviceData); err != nil {
 			return "", fmt.Errorf("failed to execute service template for %s: %v", service.Name, err)
 		}
 	}
@@ -344,10 +1009,271 @@ func (g *Generator) GenerateCode(data *ServiceData) (string, error) {
 	return buf.String(), nil
 }
 
+// SplitFile is one of the files generated for a proto file with layout=split.
+type SplitFile struct {
+	// Suffix distinguishes the file and is appended to its name: "iface", "router" or "register".
+	Suffix string
+	// Content is the generated Go source.
+	Content string
+}
+
+// GenerateSplitCode generates the split layout: the handler interfaces, the
+// router runtime, and the route registration functions as separate files of
+// the same package, so tools can depend on the interfaces alone.
+func (g *Generator) GenerateSplitCode(data *ServiceData) ([]SplitFile, error) {
+	std, thirdParty := fileImports(data)
+	messageImports := data.messageImports()
+	register := headerTemplateData{ServiceData: data, StdImports: []string{"net/http"}}
+	registerTemplates := []string{"service-register"}
+	if data.Options.Binding {
+		// The typed handlers refer to context, proto and the message types
+		register.StdImports = []string{"context", "net/http"}
+		register.ThirdPartyImports = append([]GoImport{{Path: "google.golang.org/protobuf/proto"}}, messageImports...)
+		sortImports(register.ThirdPartyImports)
+		registerTemplates = append(registerTemplates, "service-unary")
+		if data.Options.Client {
+			if data.HasStreamArray() {
+				// The clients decode streamed arrays with encoding/json and protojson
+				register.StdImports = []string{"context", "encoding/json", "fmt", "net/http"}
+				register.ThirdPartyImports = append(register.ThirdPartyImports, GoImport{Path: "google.golang.org/protobuf/encoding/protojson"})
+				sortImports(register.ThirdPartyImports)
+			}
+			registerTemplates = append(registerTemplates, "service-client")
+		}
+		if data.Options.GraphQL {
+			registerTemplates = append(registerTemplates, "service-graphql")
+		}
+		if data.Options.Mock {
+			registerTemplates = append(registerTemplates, "service-mock")
+		}
+		// The typed handlers, their adapters and mocks embed the extra interfaces
+		register.StdImports, register.ThirdPartyImports = data.withExtraInterfaceImports(register.StdImports, register.ThirdPartyImports)
+	}
+	iface := headerTemplateData{ServiceData: data}
+	iface.StdImports, iface.ThirdPartyImports = data.withExtraInterfaceImports([]string{"net/http"}, nil)
+	sections := []struct {
+		suffix    string
+		header    headerTemplateData
+		templates []string
+	}{
+		{"iface", iface, []string{"service-iface"}},
+		{"router", headerTemplateData{ServiceData: data, StdImports: std, ThirdPartyImports: thirdParty}, []string{"runtime"}},
+		{"register", register, registerTemplates},
+	}
+
+	files := make([]SplitFile, 0, len(sections))
+	for _, section := range sections {
+		var buf bytes.Buffer
+		if err := g.ParsedTemplates.ExecuteTemplate(&buf, "preamble", section.header); err != nil {
+			return nil, fmt.Errorf("failed to execute header template: %v", err)
+		}
+
+		if section.templates[0] == "runtime" {
+			buf.WriteString("\n\n")
+			if err := g.ParsedTemplates.ExecuteTemplate(&buf, "runtime", section.header); err != nil {
+				return nil, fmt.Errorf("failed to execute runtime template: %v", err)
+			}
+		} else {
+			for _, service := range data.Services {
+				serviceData := serviceTemplateData{ServiceInfo: service, Options: data.Options}
+				for _, name := range section.templates {
+					buf.WriteString("\n\n")
+					if err := g.ParsedTemplates.ExecuteTemplate(&buf, name, serviceData); err != nil {
+						return nil, fmt.Errorf("failed to execute service template for %s: %v", service.Name, err)
+					}
+				}
+			}
+		}
+		buf.WriteString("\n")
+
+		// Each file imports the packages of the message types it refers to only
+		content, err := pruneImports(buf.String(), func(importPath string) bool {
+			return slices.ContainsFunc(messageImports, func(imp GoImport) bool { return imp.Path == importPath })
+		})
+		if err != nil {
+			return nil, fmt.Errorf("pruning imports of the %s file: %v", section.suffix, err)
+		}
+		files = append(files, SplitFile{Suffix: section.suffix, Content: content})
+	}
+	return files, nil
+}
+
+// fileImports returns the sorted standard library and third-party imports
+// required by the generated file.
+func fileImports(data *ServiceData) (std []string, thirdParty []GoImport) {
+	opts := data.Options
+	std = []string{"errors", "net/http", "strings", "sync/atomic"}
+	if opts.RuntimeModule != "" {
+		// The router lives in the runtime module
+		std = []string{"errors", "net/http", "strings"}
+		thirdParty = append(thirdParty, GoImport{Path: opts.RuntimeModule})
+	}
+	if opts.Decompress {
+		std = append(std, "compress/gzip", "compress/zlib", "io")
+	}
+	if opts.Binding {
+		std = append(std, "bytes", "context", "encoding/base64", "encoding/json", "fmt", "io", "mime", "net/url", "slices", "sort", "strconv", "time")
+		if opts.StrictContentType || opts.SelfDescription || opts.StrictQuery {
+			std = append(std, "maps")
+		}
+		if opts.NegotiatesResponses() {
+			std = append(std, "cmp", "maps")
+		}
+		thirdParty = append(thirdParty,
+			GoImport{Path: "google.golang.org/protobuf/encoding/protojson"},
+			GoImport{Path: "google.golang.org/protobuf/proto"},
+			GoImport{Path: "google.golang.org/protobuf/reflect/protoreflect"},
+		)
+		thirdParty = append(thirdParty, data.messageImports()...)
+	}
+	if opts.Client {
+		std = append(std, "cmp", "maps", "math/rand")
+	}
+	if opts.Chaos {
+		std = append(std, "math/rand", "sync", "time")
+	}
+	if opts.Mock {
+		std = append(std, "context", "math/rand", "time")
+	}
+	if opts.Recording {
+		std = append(std, "bytes", "encoding/json", "fmt", "io", "os", "path/filepath", "sync")
+	}
+	if opts.SelfDescription {
+		std = append(std, "encoding/json")
+	}
+	if data.ErrorCatalog != nil || opts.ErrorDetails {
+		std = append(std, "encoding/json", "strconv", "time")
+	}
+	if opts.Capture {
+		std = append(std, "bytes", "encoding/json", "io", "strconv", "sync", "time")
+	}
+	if opts.ConditionalGet {
+		std = append(std, "context", "time")
+	}
+	if opts.UnitOfWork {
+		std = append(std, "bytes", "context")
+	}
+	if opts.Scope {
+		std = append(std, "context")
+	}
+	if opts.StaticErrors {
+		std = append(std, "bytes", "compress/gzip", "strconv")
+	}
+	if opts.ServerTiming {
+		std = append(std, "context", "strconv", "sync", "time")
+	}
+	if opts.Router == "" {
+		// With router, the adapter file imports them instead
+		adapterStd, adapterThirdParty := adapterImports(opts)
+		std = append(std, adapterStd...)
+		thirdParty = append(thirdParty, adapterThirdParty...)
+	}
+	if opts.PropagateDeadline {
+		std = append(std, "context", "strconv", "time")
+	}
+	if opts.RateLimitHeaders {
+		std = append(std, "strconv", "time")
+	}
+	if data.HasCache() {
+		std = append(std, "bytes", "context", "sync/atomic", "time")
+	}
+	if data.HasDedupe() {
+		std = append(std, "bytes", "crypto/sha256", "encoding/hex", "io", "sync", "time")
+	}
+	if data.HasTags() {
+		std = append(std, "slices")
+	}
+	if data.HasForwardHeaders() {
+		std = append(std, "context")
+	}
+	if data.HasTimeouts() || opts.RouteConfig {
+		std = append(std, "time")
+	}
+	if data.RejectsBodies() {
+		std = append(std, "io")
+	}
+	if opts.OTelAttributes {
+		thirdParty = append(thirdParty, GoImport{Path: "go.opentelemetry.io/otel/attribute"})
+	}
+	if opts.GRPCStatus {
+		std = append(std, "encoding/json")
+		thirdParty = append(thirdParty,
+			GoImport{Path: "google.golang.org/grpc/codes"},
+			GoImport{Path: "google.golang.org/grpc/status"},
+			GoImport{Path: "google.golang.org/protobuf/encoding/protojson"},
+		)
+	}
+	if opts.RouteChecksum {
+		std = append(std, "crypto/sha256", "encoding/hex", "io", "slices")
+	}
+	for _, importPath := range opts.ExtraImports {
+		thirdParty = append(thirdParty, GoImport{Path: importPath, Name: "_"})
+	}
+	slices.Sort(std)
+	sortImports(thirdParty)
+	return slices.Compact(std), slices.Compact(thirdParty)
+}
+
+// adapterImports returns the imports of the PathValue accessor and the router
+// adapters.
+func adapterImports(opts Options) (std []string, thirdParty []GoImport) {
+	switch opts.PathValueSource {
+	case PathValueChi:
+		thirdParty = append(thirdParty, GoImport{Path: "github.com/go-chi/chi/v5"})
+	case PathValueGorilla:
+		thirdParty = append(thirdParty, GoImport{Path: "github.com/gorilla/mux"})
+	}
+	if opts.HasAdapter(AdapterGin) {
+		thirdParty = append(thirdParty, GoImport{Path: "github.com/gin-gonic/gin"})
+	}
+	if opts.HasAdapter(AdapterFiber) {
+		std = append(std, "net/url")
+		thirdParty = append(thirdParty,
+			GoImport{Path: "github.com/gofiber/fiber/v2"},
+			GoImport{Path: "github.com/gofiber/fiber/v2/middleware/adaptor"},
+		)
+	}
+	if opts.HasAdapter(AdapterChi) {
+		std = append(std, "net/url")
+		thirdParty = append(thirdParty, GoImport{Path: "github.com/go-chi/chi/v5"})
+	}
+	if opts.HasAdapter(AdapterGorilla) {
+		thirdParty = append(thirdParty, GoImport{Path: "github.com/gorilla/mux"})
+	}
+	return std, thirdParty
+}
+
+// generateAdapterFile returns the <file>_http_<router>_adapter.pb.go file of
+// router=chi or router=gorilla, holding the router's Routes adapter and the
+// PathValue accessor reading path values from it.
+func (g *Generator) generateAdapterFile(file *descriptor.FileDescriptorProto, data *ServiceData) (*plugin.CodeGeneratorResponse_File, error) {
+	std, thirdParty := adapterImports(data.Options)
+	std = append(std, "net/http", "strings")
+	slices.Sort(std)
+	sortImports(thirdParty)
+	header := headerTemplateData{ServiceData: data, StdImports: slices.Compact(std), ThirdPartyImports: slices.Compact(thirdParty)}
+	var buf bytes.Buffer
+	for i, tmpl := range []string{"preamble", "pathvalue", "adapters"} {
+		if i > 0 {
+			buf.WriteString("\n\n")
+		}
+		if err := g.ParsedTemplates.ExecuteTemplate(&buf, tmpl, header); err != nil {
+			return nil, fmt.Errorf("error generating the %s adapter for %s: %v", data.Options.Router, file.GetName(), err)
+		}
+	}
+	buf.WriteString("\n")
+	name := strings.TrimSuffix(g.getOutputFilename(file.GetName()), ".pb.go") + "_" + data.Options.Router + "_adapter.pb.go"
+	adapterFile := &plugin.CodeGeneratorResponse_File{
+		Name:    proto.String(name),
+		Content: proto.String(buf.String()),
+	}
+	g.applySourceRelativePath(adapterFile, file.GetName())
+	return adapterFile, nil
+}
+
 // getOutputFilename returns the output filename for a proto file.
 func (g *Generator) getOutputFilename(protoFilename string) string {
-	base := filepath.Base(protoFilename)
-	filename := strings.TrimSuffix(base, ".proto")
+	filename := outputBaseName(protoFilename)
 
 	if g.Options.OutputPrefix != "" {
 		filename = g.Options.OutputPrefix + "_" + filename
@@ -358,8 +1284,41 @@ func (g *Generator) getOutputFilename(protoFilename string) string {
 	return filename + ".pb.go"
 }
 
+// outputBaseName returns the base of the output file names of a proto file:
+// its base name without the .proto or .protodevel extension, with every
+// character other than ASCII letters, digits, '-' and '_' replaced by '_'.
+// Dots would otherwise end the part of the name the go command reads build
+// constraints from, so "tasks.linux.proto" does not become a Linux-only file,
+// and leading underscores are dropped, as the go command ignores such files.
+func outputBaseName(protoFilename string) string {
+	base := path.Base(protoFilename)
+	if ext := path.Ext(base); ext == ".proto" || ext == ".protodevel" {
+		base = strings.TrimSuffix(base, ext)
+	}
+	base = strings.Map(func(r rune) rune {
+		if 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, base)
+	if trimmed := strings.TrimLeft(base, "_"); trimmed != "" {
+		return trimmed
+	}
+	return "proto"
+}
+
 // getPackageName returns the Go package name for a proto file.
 func (g *Generator) getPackageName(file *descriptor.FileDescriptorProto) string {
+	// Explicit package_name options win over any derived name
+	if g.Options != nil {
+		if name := g.Options.FilePackageNames[file.GetName()]; name != "" {
+			return name
+		}
+		if g.Options.PackageName != "" {
+			return g.Options.PackageName
+		}
+	}
+
 	// Use go_package option if available
 	if goPackage := file.GetOptions().GetGoPackage(); goPackage != "" {
 		return g.extractPackageFromGoPackage(goPackage)
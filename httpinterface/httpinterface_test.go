@@ -280,6 +280,36 @@ func TestOutputFilename(t *testing.T) {
 			options:       &Options{},
 			want:          "service_http.pb.go",
 		},
+		{
+			name:          "dots in the name",
+			protoFilename: "api/v1/tasks.linux.v1.proto",
+			options:       &Options{},
+			want:          "tasks_linux_v1_http.pb.go",
+		},
+		{
+			name:          "unusual characters",
+			protoFilename: "api/v1/my service+é.proto",
+			options:       &Options{},
+			want:          "my_service___http.pb.go",
+		},
+		{
+			name:          "leading underscore",
+			protoFilename: "_internal.proto",
+			options:       &Options{OutputPrefix: "api"},
+			want:          "api_internal.pb.go",
+		},
+		{
+			name:          "protodevel extension",
+			protoFilename: "service.protodevel",
+			options:       &Options{},
+			want:          "service_http.pb.go",
+		},
+		{
+			name:          "other extension kept",
+			protoFilename: "service.v2",
+			options:       &Options{},
+			want:          "service_v2_http.pb.go",
+		},
 	}
 
 	for _, tt := range tests {
@@ -296,6 +326,35 @@ func TestOutputFilename(t *testing.T) {
 	}
 }
 
+// Output names are slash-separated whatever the platform, as protoc expects.
+func TestApplySourceRelativePath(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		protoFilename string
+		output        string
+		want          string
+	}{
+		{protoFilename: "service.proto", output: "service_http.pb.go", want: "service_http.pb.go"},
+		{protoFilename: "api/v1/service.proto", output: "service_http.pb.go", want: "api/v1/service_http.pb.go"},
+		{protoFilename: "api/v1.2/tasks.v1.proto", output: "tasks_v1_http.pb.go", want: "api/v1.2/tasks_v1_http.pb.go"},
+		{protoFilename: "a/b/c/d.proto", output: "d_http_openapi.yaml", want: "a/b/c/d_http_openapi.yaml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.protoFilename, func(t *testing.T) {
+			t.Parallel()
+			g := New()
+			g.Options = &Options{PathsSourceRelative: true}
+
+			file := &plugin.CodeGeneratorResponse_File{Name: proto.String(tt.output)}
+			g.applySourceRelativePath(file, tt.protoFilename)
+			if got := file.GetName(); got != tt.want {
+				t.Errorf("applySourceRelativePath(%q) name = %q, want %q", tt.protoFilename, got, tt.want)
+			}
+		})
+	}
+}
+
 // Test getPackageName function
 func TestGetPackageName(t *testing.T) {
 	t.Parallel()
@@ -496,6 +555,65 @@ func TestGetPackageName(t *testing.T) {
 	}
 }
 
+// TestGetPackageNameOverride tests the package_name option, globally and per file
+func TestGetPackageNameOverride(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		parameter   string
+		protoFile   *descriptor.FileDescriptorProto
+		wantPackage string
+	}{
+		{
+			name:        "global override replaces derived name",
+			parameter:   "package_name=myhttp",
+			protoFile:   &descriptor.FileDescriptorProto{Name: proto.String("a/b.proto"), Package: proto.String("a.b.c.v1")},
+			wantPackage: "myhttp",
+		},
+		{
+			name:      "global override replaces go_package name",
+			parameter: "package_name=myhttp",
+			protoFile: &descriptor.FileDescriptorProto{
+				Name:    proto.String("a/b.proto"),
+				Options: &descriptor.FileOptions{GoPackage: proto.String("example.com/api;apiv1")},
+			},
+			wantPackage: "myhttp",
+		},
+		{
+			name:        "per-file override",
+			parameter:   "package_name=a/b.proto=billinghttp",
+			protoFile:   &descriptor.FileDescriptorProto{Name: proto.String("a/b.proto"), Package: proto.String("a.b.c.v1")},
+			wantPackage: "billinghttp",
+		},
+		{
+			name:        "per-file override for another file",
+			parameter:   "package_name=a/other.proto=billinghttp",
+			protoFile:   &descriptor.FileDescriptorProto{Name: proto.String("a/b.proto"), Package: proto.String("a.b.c.v1")},
+			wantPackage: "cv1",
+		},
+		{
+			name:        "per-file override wins over global",
+			parameter:   "package_name=myhttp,package_name=a/b.proto=billinghttp",
+			protoFile:   &descriptor.FileDescriptorProto{Name: proto.String("a/b.proto"), Package: proto.String("a.b.c.v1")},
+			wantPackage: "billinghttp",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := New()
+			if err := g.applyOptions(tt.parameter); err != nil {
+				t.Fatalf("applyOptions(%q) error = %v", tt.parameter, err)
+			}
+			if got := g.getPackageName(tt.protoFile); got != tt.wantPackage {
+				t.Errorf("getPackageName() = %q, want %q", got, tt.wantPackage)
+			}
+		})
+	}
+}
+
 // Test getTypeName function
 func TestGetTypeName(t *testing.T) {
 	t.Parallel()
@@ -683,6 +801,62 @@ func TestBuildServiceDataWithOptions(t *testing.T) {
 // var GetPathParams = func(pattern string) []string { return nil }
 // var ConvertPathPattern = func(pattern string) string { return pattern }
 
+func TestGenerateHandlerFor(t *testing.T) {
+	t.Parallel()
+
+	rules := []parser.HTTPRule{
+		{Method: "PUT", Pattern: "/v1/echo/{id}", Body: "*", PathParams: []string{"id"}},
+		{Method: "POST", Pattern: "/v2/echo/{echo_id}", Body: "*", PathParams: []string{"echo_id"}},
+	}
+	tests := []struct {
+		name     string
+		options  Options
+		expected string
+	}{
+		{
+			name:    "plain",
+			options: Options{},
+			expected: "func HandlerForEcho(handler EchoServiceHandler, middlewares ...Middleware) http.Handler {\n" +
+				"\treturn applyMiddlewares(http.HandlerFunc(handler.HandleEcho), middlewares)\n}",
+		},
+		{
+			name:    "wrapped like the primary route",
+			options: Options{Binding: true, StrictContentType: true, Decompress: true},
+			expected: "func HandlerForEcho(handler EchoServiceHandler, middlewares ...Middleware) http.Handler {\n" +
+				"\th := applyMiddlewares(http.HandlerFunc(handler.HandleEcho), middlewares)\n" +
+				"\treturn requireContentType(decompressBody(h.ServeHTTP), true)\n}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			code, err := New().GenerateCode(&ServiceData{
+				PackageName: "testpkg",
+				Services: []ServiceInfo{{
+					Name: "EchoService",
+					Methods: []MethodInfo{{
+						Name:       "Echo",
+						InputType:  "EchoRequest",
+						OutputType: "EchoResponse",
+						HTTPRules:  rules,
+					}},
+				}},
+				Options: tt.options,
+			})
+			if err != nil {
+				t.Fatalf("GenerateCode() error = %v", err)
+			}
+			if !strings.Contains(code, tt.expected) {
+				t.Errorf("Generated code doesn't contain %q", tt.expected)
+			}
+			if !strings.Contains(code, `served like its "PUT /v1/echo/{id}" route`) {
+				t.Error("HandlerForEcho doc doesn't name the route it is served like")
+			}
+		})
+	}
+}
+
 // TestTemplateExecution tests that the template execution produces expected output
 func TestTemplateExecution(t *testing.T) {
 	g := New()
@@ -1248,3 +1422,125 @@ func TestParseHTTPRuleNilCustom(t *testing.T) {
 		}
 	}()
 }
+
+// TestGenerateCodeBuildTags tests that build_tags stamps a //go:build line on generated files
+func TestGenerateCodeBuildTags(t *testing.T) {
+	t.Parallel()
+	g := New()
+
+	tests := []struct {
+		name       string
+		buildTags  string
+		wantPrefix string
+	}{
+		{
+			name:       "no build tags",
+			wantPrefix: "// Code generated by protoc-gen-go-http-server-interface. DO NOT EDIT.\npackage api\n",
+		},
+		{
+			name:       "build tags",
+			buildTags:  "integration && !windows",
+			wantPrefix: "//go:build integration && !windows\n\n// Code generated by protoc-gen-go-http-server-interface. DO NOT EDIT.\npackage api\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			code, err := g.GenerateCode(&ServiceData{
+				PackageName: "api",
+				Options:     Options{BuildTags: tt.buildTags},
+				Services: []ServiceInfo{{
+					Name: "PingService",
+					Methods: []MethodInfo{{
+						Name:       "Ping",
+						InputType:  "PingRequest",
+						OutputType: "PingResponse",
+						HTTPRules:  []parser.HTTPRule{{Method: "GET", Pattern: "/ping", PathParams: []string{}}},
+					}},
+				}},
+			})
+			if err != nil {
+				t.Fatalf("GenerateCode() error = %v", err)
+			}
+			if !strings.HasPrefix(code, tt.wantPrefix) {
+				t.Errorf("generated code starts with %q, want prefix %q", code[:min(len(code), len(tt.wantPrefix))], tt.wantPrefix)
+			}
+		})
+	}
+}
+
+func TestGenerateCodeMount(t *testing.T) {
+	t.Parallel()
+	g := New()
+
+	code, err := g.GenerateCode(&ServiceData{
+		PackageName: "api",
+		Services: []ServiceInfo{{
+			Name: "PingService",
+			Methods: []MethodInfo{{
+				Name:       "Ping",
+				InputType:  "PingRequest",
+				OutputType: "PingResponse",
+				HTTPRules:  []parser.HTTPRule{{Method: "GET", Pattern: "/ping", PathParams: []string{}}},
+			}},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+
+	for _, expected := range []string{
+		"func (g *RouteGroup) Mount(prefix string, h http.Handler) error",
+		"type MountConflictError struct",
+		"func (e *MountConflictError) Error() string",
+		"registry:    g.registry,",
+		"panic(&MountConflictError{",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+}
+
+func TestGenerateCodeConflictPolicy(t *testing.T) {
+	t.Parallel()
+	g := New()
+
+	code, err := g.GenerateCode(&ServiceData{
+		PackageName: "api",
+		Services: []ServiceInfo{{
+			Name: "PingService",
+			Methods: []MethodInfo{{
+				Name:       "Liveness",
+				InputType:  "LivenessRequest",
+				OutputType: "LivenessResponse",
+				HTTPRules:  []parser.HTTPRule{{Method: "GET", Pattern: "/liveness", PathParams: []string{}}},
+			}},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+
+	for _, expected := range []string{
+		"type ConflictPolicy int",
+		"\tConflictError ConflictPolicy = iota\n\t// ConflictSkip keeps the route registered first.\n\tConflictSkip\n",
+		"func (g *RouteGroup) OnConflict(policy ConflictPolicy) Router {",
+		"onConflict:  g.onConflict,",
+		"\tif g.handle(routeKey, finalHandler) {\n\t\tg.routes = append(g.routes, routeKey)\n\t}\n",
+		"if existing, pattern := registeredRoute(g.mux, routeKey); pattern != \"\" && patternShape(pattern) == patternShape(routeKey) {",
+		"if r, ok := existing.(interface{ ReplaceHandler(http.Handler) }); ok {",
+		"panic(&RouteConflictError{Route: routeKey, Existing: pattern})",
+		"func (h *replaceableHandler) ReplaceHandler(handler http.Handler) {",
+		// Routes registered under the default policy are served unwrapped
+		"\tif g.onConflict == ConflictError {\n\t\tdefer func() {\n",
+		"\t\tg.mux.Handle(routeKey, h)\n\t\treturn true\n\t}\n",
+		"\tif g.onConflict == ConflictReplace {\n\t\trh := &replaceableHandler{}\n\t\trh.ReplaceHandler(h)\n\t\th = rh\n\t}\n",
+		"\t\"sync/atomic\"\n",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package httpinterface
+
+import (
+	"path"
+	"slices"
+	"strings"
+
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+)
+
+// GoImport identifies the Go package holding the generated message types of a
+// proto file, so code that refers to request and response types can import it.
+type GoImport struct {
+	// Path is the Go import path, e.g. "example.com/api/tasks/v1". It is empty
+	// when the file has neither an import_alias mapping nor a go_package option.
+	Path string
+	// Name is the Go package name, e.g. "tasksv1".
+	Name string
+}
+
+// parseGoImport parses a go_package style value, "path" or "path;name".
+func parseGoImport(value string) GoImport {
+	path, name, ok := strings.Cut(value, ";")
+	if !ok {
+		name = path[strings.LastIndex(path, "/")+1:]
+	}
+	return GoImport{Path: path, Name: name}
+}
+
+// goImportFor returns the Go package of file's message types. An import_alias
+// mapping for the file takes precedence over its go_package option.
+func (g *Generator) goImportFor(file *descriptor.FileDescriptorProto) GoImport {
+	if g.Options != nil {
+		if alias := g.Options.ImportAliases[file.GetName()]; alias != "" {
+			return parseGoImport(alias)
+		}
+	}
+	if goPackage := file.GetOptions().GetGoPackage(); goPackage != "" {
+		return parseGoImport(goPackage)
+	}
+	return GoImport{Name: g.extractPackageFromProtoPackage(file.GetPackage())}
+}
+
+// indexMessageFiles maps the fully-qualified name of every message, including
+// nested messages, to the file that declares it.
+func indexMessageFiles(files []*descriptor.FileDescriptorProto) map[string]*descriptor.FileDescriptorProto {
+	index := map[string]*descriptor.FileDescriptorProto{}
+	var walk func(file *descriptor.FileDescriptorProto, prefix string, messages []*descriptor.DescriptorProto)
+	walk = func(file *descriptor.FileDescriptorProto, prefix string, messages []*descriptor.DescriptorProto) {
+		for _, msg := range messages {
+			name := prefix + "." + msg.GetName()
+			index[name] = file
+			walk(file, name, msg.GetNestedType())
+		}
+	}
+	for _, file := range files {
+		prefix := ""
+		if pkg := file.GetPackage(); pkg != "" {
+			prefix = "." + pkg
+		}
+		walk(file, prefix, file.GetMessageType())
+	}
+	return index
+}
+
+// messageImport returns the Go package of the message with the fully-qualified
+// name typeName, or the zero GoImport if its file is not part of the request.
+func (g *Generator) messageImport(typeName string) GoImport {
+	if file := g.messageFiles[typeName]; file != nil {
+		return g.goImportFor(file)
+	}
+	return GoImport{}
+}
+
+// goTypeName returns the Go name protoc-gen-go gives the message with the
+// fully-qualified name typeName: nested messages are joined with underscores,
+// as in Page_Token.
+func (g *Generator) goTypeName(typeName string) string {
+	file := g.messageFiles[typeName]
+	if file == nil {
+		return g.getTypeName(typeName)
+	}
+	name := strings.TrimPrefix(typeName, ".")
+	if pkg := file.GetPackage(); pkg != "" {
+		name = strings.TrimPrefix(name, pkg+".")
+	}
+	return strings.ReplaceAll(name, ".", "_")
+}
+
+// qualifiedGoType returns the Go type of the message typeName as referred to
+// from the code generated for file, qualified with the name of its package
+// when it lives in another Go package.
+func (g *Generator) qualifiedGoType(file *descriptor.FileDescriptorProto, typeName string) string {
+	name := g.goTypeName(typeName)
+	if imp := g.messageImport(typeName); imp.Path != "" && imp.Path != g.goImportFor(file).Path {
+		return imp.Name + "." + name
+	}
+	return name
+}
+
+// messageImports returns the packages, other than the generated file's own,
+// holding the request and response types of d's methods, and the items of
+// paginated methods when clients are generated, sorted by path.
+func (d *ServiceData) messageImports() []GoImport {
+	var imports []GoImport
+	for _, service := range d.Services {
+		for _, method := range service.Methods {
+			methodImports := []GoImport{method.InputImport, method.OutputImport}
+			if d.Options.Client && method.Pagination != nil {
+				methodImports = append(methodImports, method.Pagination.ItemImport)
+			}
+			for _, imp := range methodImports {
+				if imp.Path != "" && imp.Path != d.GoImport.Path {
+					imports = append(imports, imp)
+				}
+			}
+		}
+	}
+	sortImports(imports)
+	return slices.Compact(imports)
+}
+
+// withExtraInterfaceImports adds the packages of the interfaces the
+// extra_interface option embeds in the handler interfaces to the imports of a
+// generated file: standard library packages to std, as the go command tells
+// them apart by the missing dot in their first path element, and others to
+// thirdParty.
+func (d *ServiceData) withExtraInterfaceImports(std []string, thirdParty []GoImport) ([]string, []GoImport) {
+	for _, iface := range d.Options.ExtraInterfaces {
+		imp := iface.Import
+		if imp.Path == "" {
+			continue
+		}
+		if first, _, _ := strings.Cut(imp.Path, "/"); !strings.Contains(first, ".") && imp.Name == path.Base(imp.Path) {
+			std = append(std, imp.Path)
+		} else {
+			thirdParty = append(thirdParty, imp)
+		}
+	}
+	slices.Sort(std)
+	sortImports(thirdParty)
+	return slices.Compact(std), slices.Compact(thirdParty)
+}
+
+// sortImports sorts imports by path, as gofmt orders import specs.
+func sortImports(imports []GoImport) {
+	slices.SortFunc(imports, func(a, b GoImport) int { return strings.Compare(a.Path, b.Path) })
+}
@@ -0,0 +1,165 @@
+package httpinterface
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	options "google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+func TestParseGoImport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value string
+		want  GoImport
+	}{
+		{value: "example.com/api/tasks/v1;tasksv1", want: GoImport{Path: "example.com/api/tasks/v1", Name: "tasksv1"}},
+		{value: "example.com/api/tasks", want: GoImport{Path: "example.com/api/tasks", Name: "tasks"}},
+		{value: "tasks", want: GoImport{Path: "tasks", Name: "tasks"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+			if got := parseGoImport(tt.value); got != tt.want {
+				t.Errorf("parseGoImport(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestMessageImports tests that request and response types resolve to the Go
+// package of their declaring file, honoring import_alias mappings.
+func TestMessageImports(t *testing.T) {
+	t.Parallel()
+
+	common := &descriptor.FileDescriptorProto{
+		Name:    proto.String("common/v1/common.proto"),
+		Package: proto.String("common.v1"),
+		Options: &descriptor.FileOptions{GoPackage: proto.String("example.com/wrong/guess;common")},
+		MessageType: []*descriptor.DescriptorProto{
+			{
+				Name:       proto.String("Page"),
+				NestedType: []*descriptor.DescriptorProto{{Name: proto.String("Token")}},
+			},
+		},
+	}
+	tasks := &descriptor.FileDescriptorProto{
+		Name:       proto.String("tasks/v1/tasks.proto"),
+		Package:    proto.String("tasks.v1"),
+		Dependency: []string{"common/v1/common.proto"},
+		Options:    &descriptor.FileOptions{GoPackage: proto.String("example.com/api/tasks/v1;tasksv1")},
+		MessageType: []*descriptor.DescriptorProto{
+			{Name: proto.String("ListTasksResponse")},
+		},
+		Service: []*descriptor.ServiceDescriptorProto{
+			{
+				Name: proto.String("TaskService"),
+				Method: []*descriptor.MethodDescriptorProto{
+					{
+						Name:       proto.String("ListTasks"),
+						InputType:  proto.String(".common.v1.Page.Token"),
+						OutputType: proto.String(".tasks.v1.ListTasksResponse"),
+						Options: func() *descriptor.MethodOptions {
+							opts := &descriptor.MethodOptions{}
+							proto.SetExtension(opts, options.E_Http, &options.HttpRule{
+								Pattern: &options.HttpRule_Get{Get: "/v1/tasks"},
+							})
+							return opts
+						}(),
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		parameter  string
+		wantInput  GoImport
+		wantOutput GoImport
+		// wantInputType is the qualified Go type of the request message
+		wantInputType string
+	}{
+		{
+			name:       "go_package",
+			wantInput:  GoImport{Path: "example.com/wrong/guess", Name: "common"},
+			wantOutput: GoImport{Path: "example.com/api/tasks/v1", Name: "tasksv1"},
+
+			wantInputType: "common.Page_Token",
+		},
+		{
+			name:       "import_alias with package name",
+			parameter:  "import_alias=common/v1/common.proto=example.com/shared/common/v1;commonv1",
+			wantInput:  GoImport{Path: "example.com/shared/common/v1", Name: "commonv1"},
+			wantOutput: GoImport{Path: "example.com/api/tasks/v1", Name: "tasksv1"},
+
+			wantInputType: "commonv1.Page_Token",
+		},
+		{
+			name:       "import_alias without package name",
+			parameter:  "import_alias=common/v1/common.proto=example.com/shared/commonpb",
+			wantInput:  GoImport{Path: "example.com/shared/commonpb", Name: "commonpb"},
+			wantOutput: GoImport{Path: "example.com/api/tasks/v1", Name: "tasksv1"},
+
+			wantInputType: "commonpb.Page_Token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := New()
+			if err := g.applyOptions(tt.parameter); err != nil {
+				t.Fatalf("applyOptions(%q) error = %v", tt.parameter, err)
+			}
+			g.messageFiles = indexMessageFiles([]*descriptor.FileDescriptorProto{common, tasks})
+
+			data := g.buildServiceData(tasks)
+			if len(data.Services) != 1 || len(data.Services[0].Methods) != 1 {
+				t.Fatalf("buildServiceData() services = %+v, want one method", data.Services)
+			}
+			method := data.Services[0].Methods[0]
+			if method.InputImport != tt.wantInput {
+				t.Errorf("InputImport = %+v, want %+v", method.InputImport, tt.wantInput)
+			}
+			if method.OutputImport != tt.wantOutput {
+				t.Errorf("OutputImport = %+v, want %+v", method.OutputImport, tt.wantOutput)
+			}
+			if method.InputGoType != tt.wantInputType {
+				t.Errorf("InputGoType = %q, want %q", method.InputGoType, tt.wantInputType)
+			}
+			// The response type lives in the generated file's own package
+			if method.OutputGoType != "ListTasksResponse" {
+				t.Errorf("OutputGoType = %q, want %q", method.OutputGoType, "ListTasksResponse")
+			}
+			if imports := data.messageImports(); len(imports) != 1 || imports[0] != tt.wantInput {
+				t.Errorf("messageImports() = %+v, want [%+v]", imports, tt.wantInput)
+			}
+
+			// Typed handlers import the request package and refer to its type
+			resp := New().Generate(&plugin.CodeGeneratorRequest{
+				Parameter:      proto.String(strings.TrimPrefix(tt.parameter+",binding=true", ",")),
+				FileToGenerate: []string{"tasks/v1/tasks.proto"},
+				ProtoFile:      []*descriptor.FileDescriptorProto{common, tasks},
+			})
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+			code := resp.File[0].GetContent()
+			for _, expected := range []string{
+				fmt.Sprintf("\t%s %q\n", tt.wantInput.Name, tt.wantInput.Path),
+				"ListTasks(ctx context.Context, req *" + tt.wantInputType + ") (*ListTasksResponse, error)",
+			} {
+				if !strings.Contains(code, expected) {
+					t.Errorf("Generated code doesn't contain %q", expected)
+				}
+			}
+		})
+	}
+}
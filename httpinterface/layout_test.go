@@ -0,0 +1,203 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+
+	options "google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+// layoutTestFile returns a proto file with two annotated services.
+func layoutTestFile() *descriptor.FileDescriptorProto {
+	method := func(name, path string) *descriptor.MethodDescriptorProto {
+		opts := &descriptor.MethodOptions{}
+		proto.SetExtension(opts, options.E_Http, &options.HttpRule{
+			Pattern: &options.HttpRule_Get{Get: path},
+		})
+		return &descriptor.MethodDescriptorProto{
+			Name:       proto.String(name),
+			InputType:  proto.String(".shop.v1.Request"),
+			OutputType: proto.String(".shop.v1.Response"),
+			Options:    opts,
+		}
+	}
+
+	return &descriptor.FileDescriptorProto{
+		Name:    proto.String("shop/v1/shop.proto"),
+		Package: proto.String("shop.v1"),
+		Options: &descriptor.FileOptions{GoPackage: proto.String("example.com/shop/v1;shopv1")},
+		MessageType: []*descriptor.DescriptorProto{
+			{Name: proto.String("Request")},
+			{Name: proto.String("Response")},
+		},
+		Service: []*descriptor.ServiceDescriptorProto{
+			{Name: proto.String("CartService"), Method: []*descriptor.MethodDescriptorProto{method("GetCart", "/v1/cart")}},
+			{Name: proto.String("OrderService"), Method: []*descriptor.MethodDescriptorProto{method("GetOrder", "/v1/orders/{id}")}},
+		},
+	}
+}
+
+func TestGenerateSplitLayout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		parameter string
+		wantNames []string
+	}{
+		{
+			name:      "split",
+			parameter: "layout=split",
+			wantNames: []string{"shop_http_iface.pb.go", "shop_http_router.pb.go", "shop_http_register.pb.go"},
+		},
+		{
+			name:      "split with source_relative and output_prefix",
+			parameter: "layout=split,paths=source_relative,output_prefix=api",
+			wantNames: []string{"shop/v1/api_shop_iface.pb.go", "shop/v1/api_shop_router.pb.go", "shop/v1/api_shop_register.pb.go"},
+		},
+		{
+			name:      "single",
+			parameter: "layout=single",
+			wantNames: []string{"shop_http.pb.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp := New().Generate(&plugin.CodeGeneratorRequest{
+				Parameter:      proto.String(tt.parameter),
+				ProtoFile:      []*descriptor.FileDescriptorProto{layoutTestFile()},
+				FileToGenerate: []string{"shop/v1/shop.proto"},
+			})
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+
+			if len(resp.File) != len(tt.wantNames) {
+				t.Fatalf("len(resp.File) = %d, want %d", len(resp.File), len(tt.wantNames))
+			}
+			for i, want := range tt.wantNames {
+				if got := resp.File[i].GetName(); got != want {
+					t.Errorf("resp.File[%d].Name = %q, want %q", i, got, want)
+				}
+				if !strings.HasPrefix(resp.File[i].GetContent(), "// Code generated by protoc-gen-go-http-server-interface. DO NOT EDIT.\npackage shopv1\n") {
+					t.Errorf("resp.File[%d] is missing the generated header and package clause", i)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateSplitCodeSections(t *testing.T) {
+	t.Parallel()
+
+	g := New()
+	if err := g.applyOptions("layout=split,decompress=true"); err != nil {
+		t.Fatal(err)
+	}
+	files, err := g.GenerateSplitCode(g.buildServiceData(layoutTestFile()))
+	if err != nil {
+		t.Fatalf("GenerateSplitCode() error = %v", err)
+	}
+	if len(files) != 3 {
+		t.Fatalf("len(files) = %d, want 3", len(files))
+	}
+
+	tests := []struct {
+		suffix  string
+		want    []string
+		notWant []string
+	}{
+		{
+			suffix: "iface",
+			want: []string{
+				"import (\n\t\"net/http\"\n)\n",
+				"type CartServiceHandler interface",
+				"type OrderServiceHandler interface",
+			},
+			notWant: []string{"func Register", "type RouteGroup struct"},
+		},
+		{
+			suffix: "router",
+			want: []string{
+				`"compress/gzip"`,
+				"type Middleware func(http.Handler) http.Handler",
+				"type RouteGroup struct",
+				"func DecompressRequest(maxBytes int64) Middleware",
+			},
+			notWant: []string{"Handler interface {\n\tHandle", "func Register"},
+		},
+		{
+			suffix: "register",
+			want: []string{
+				"import (\n\t\"net/http\"\n)\n",
+				"func RegisterCartServiceRoutes(r Routes, handler CartServiceHandler) error",
+				"func RegisterOrderServiceRoutes(r Routes, handler OrderServiceHandler) error",
+				"func RegisterGetOrderRoute(r Routes, handler OrderServiceHandler, middlewares ...Middleware) error",
+			},
+			notWant: []string{"type CartServiceHandler interface", "type RouteGroup struct"},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.suffix, func(t *testing.T) {
+			t.Parallel()
+			file := files[i]
+			if file.Suffix != tt.suffix {
+				t.Fatalf("files[%d].Suffix = %q, want %q", i, file.Suffix, tt.suffix)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(file.Content, want) {
+					t.Errorf("%s file doesn't contain %q", tt.suffix, want)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(file.Content, notWant) {
+					t.Errorf("%s file unexpectedly contains %q", tt.suffix, notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateSplitLayoutMessageImports(t *testing.T) {
+	t.Parallel()
+
+	for _, parameter := range []string{"layout=split,binding=true", "layout=split,binding=true,client=true"} {
+		t.Run(parameter, func(t *testing.T) {
+			t.Parallel()
+			file := layoutTestFile()
+			file.Dependency = []string{"google/protobuf/empty.proto"}
+			opts := &descriptor.MethodOptions{}
+			proto.SetExtension(opts, options.E_Http, &options.HttpRule{Pattern: &options.HttpRule_Delete{Delete: "/v1/cart"}})
+			file.Service[0].Method = append(file.Service[0].Method, &descriptor.MethodDescriptorProto{
+				Name:       proto.String("ClearCart"),
+				InputType:  proto.String(".shop.v1.Request"),
+				OutputType: proto.String(".google.protobuf.Empty"),
+				Options:    opts,
+			})
+			resp := New().Generate(&plugin.CodeGeneratorRequest{
+				Parameter:      proto.String(parameter),
+				FileToGenerate: []string{file.GetName()},
+				ProtoFile:      []*descriptor.FileDescriptorProto{protodesc.ToFileDescriptorProto(emptypb.File_google_protobuf_empty_proto), file},
+			})
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+
+			const emptyImport = `emptypb "google.golang.org/protobuf/types/known/emptypb"`
+			for _, f := range resp.File {
+				imported := strings.Contains(f.GetContent(), emptyImport)
+				if used := strings.Contains(f.GetContent(), "emptypb."); imported != used {
+					t.Errorf("%s imports emptypb = %t, refers to it = %t", f.GetName(), imported, used)
+				}
+			}
+		})
+	}
+}
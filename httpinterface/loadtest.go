@@ -0,0 +1,405 @@
+package httpinterface
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+// Load-testing tools accepted by the loadtest option.
+const (
+	// LoadTestK6 writes a k6 script with a constant-arrival-rate scenario per
+	// route.
+	LoadTestK6 = "k6"
+	// LoadTestVegeta writes vegeta targets in its JSON format, one per route.
+	LoadTestVegeta = "vegeta"
+)
+
+// DefaultLoadTestBaseURL is the server load tests target when the
+// loadtest_base_url option is not set.
+const DefaultLoadTestBaseURL = "http://localhost:8080"
+
+// exampleDepth is how deep example requests nest messages, matching
+// mockExampleDepth in the generated mocks.
+const exampleDepth = 3
+
+// exampleWellKnownTypes holds the JSON form of the example value of the
+// google.protobuf messages that have one. Other well-known types, such as Any
+// and FieldMask, are left out of examples.
+var exampleWellKnownTypes = map[string]any{
+	".google.protobuf.Timestamp":   "1970-01-01T00:00:01Z",
+	".google.protobuf.Duration":    "1s",
+	".google.protobuf.DoubleValue": 1.5,
+	".google.protobuf.FloatValue":  1.5,
+	".google.protobuf.Int32Value":  1,
+	".google.protobuf.UInt32Value": 1,
+	".google.protobuf.Int64Value":  "1",
+	".google.protobuf.UInt64Value": "1",
+	".google.protobuf.BoolValue":   true,
+	".google.protobuf.StringValue": "value",
+	".google.protobuf.BytesValue":  base64.StdEncoding.EncodeToString([]byte("value")),
+	".google.protobuf.Empty":       map[string]any{},
+}
+
+// loadTestRoute is a request of a load test: a route of a public method with
+// its path parameters and body filled with example values.
+type loadTestRoute struct {
+	// Name is <Service>_<Method>, with a _<n> suffix for additional bindings.
+	Name    string
+	Method  string
+	Pattern string
+	Path    string
+	// Body is the JSON request body, empty for routes without one. JSON is
+	// also a JavaScript expression, so k6 scripts embed it as is.
+	Body string
+}
+
+// generateLoadTestFile returns the load test of file for the tool selected by
+// the loadtest option, sending example requests to every public route.
+// Internal methods are left out, as they are usually served on another
+// address.
+func (g *Generator) generateLoadTestFile(file *descriptor.FileDescriptorProto, data *ServiceData) (*plugin.CodeGeneratorResponse_File, error) {
+	var routes []loadTestRoute
+	for _, service := range data.Services {
+		for _, method := range service.Methods {
+			if method.Internal {
+				continue
+			}
+			inputType := findMethod(file, service.Name, method.Name).GetInputType()
+			for i, rule := range method.HTTPRules {
+				route, err := g.loadTestRoute(inputType, rule.Method, rule.PathTemplate(), rule.Body)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %s.%s: %v", file.GetName(), service.Name, method.Name, err)
+				}
+				route.Name = service.Name + "_" + method.Name
+				if i > 0 {
+					route.Name += "_" + strconv.Itoa(i)
+				}
+				routes = append(routes, route)
+			}
+		}
+	}
+
+	base := strings.TrimSuffix(g.getOutputFilename(file.GetName()), ".pb.go")
+	var name, content string
+	switch g.Options.LoadTest {
+	case LoadTestVegeta:
+		name = base + ".vegeta.jsonl"
+		content = g.vegetaTargets(routes)
+	default:
+		name = base + ".k6.js"
+		content = g.k6Script(file, path.Base(name), routes)
+	}
+
+	outputFile := &plugin.CodeGeneratorResponse_File{
+		Name:    proto.String(name),
+		Content: proto.String(content),
+	}
+	g.applySourceRelativePath(outputFile, file.GetName())
+	return outputFile, nil
+}
+
+// loadTestRoute fills the path parameters and body of a binding with the
+// example request of the message typeName. The wildcards of a path template
+// variable such as {name=projects/*} are each filled with the example value.
+func (g *Generator) loadTestRoute(typeName, method, pattern, body string) (loadTestRoute, error) {
+	example := g.exampleMessage(typeName, 0)
+
+	var urlPath strings.Builder
+	last := 0
+	for _, loc := range tsPathParamRegex.FindAllStringSubmatchIndex(pattern, -1) {
+		urlPath.WriteString(pattern[last:loc[0]])
+		last = loc[1]
+
+		param, wildcard := strings.CutSuffix(pattern[loc[2]:loc[3]], "...")
+		param, segments, templated := strings.Cut(param, "=")
+		field := g.fieldByPath(typeName, param)
+		if field == nil {
+			return loadTestRoute{}, fmt.Errorf("path parameter {%s} does not name a field of %s", param, strings.TrimPrefix(typeName, "."))
+		}
+		value := fmt.Sprint(g.exampleScalar(field))
+		switch {
+		case templated:
+			parts := strings.Split(segments, "/")
+			for i, part := range parts {
+				if part == "*" || part == "**" {
+					parts[i] = url.PathEscape(value)
+				}
+			}
+			urlPath.WriteString(strings.Join(parts, "/"))
+		case wildcard:
+			urlPath.WriteString(strings.ReplaceAll(url.PathEscape(value), "%2F", "/"))
+		default:
+			urlPath.WriteString(url.PathEscape(value))
+		}
+	}
+	urlPath.WriteString(pattern[last:])
+
+	route := loadTestRoute{Method: method, Pattern: pattern, Path: urlPath.String()}
+	var payload any
+	switch body {
+	case "":
+		return route, nil
+	case "*":
+		payload = example
+	default:
+		field := g.fieldByPath(typeName, body)
+		if field == nil {
+			return loadTestRoute{}, fmt.Errorf("body %q does not name a field of %s", body, strings.TrimPrefix(typeName, "."))
+		}
+		payload = g.exampleField(field, 0)
+	}
+	if payload == nil {
+		payload = map[string]any{}
+	}
+	content, err := json.Marshal(payload)
+	if err != nil {
+		return loadTestRoute{}, err
+	}
+	route.Body = string(content)
+	return route, nil
+}
+
+// k6Script returns a k6 script with a constant-arrival-rate scenario per
+// route, whose rate and duration are set through environment variables.
+func (g *Generator) k6Script(file *descriptor.FileDescriptorProto, name string, routes []loadTestRoute) string {
+	baseURL := g.Options.LoadTestBaseURL
+	if baseURL == "" {
+		baseURL = DefaultLoadTestBaseURL
+	}
+
+	var b strings.Builder
+	b.WriteString("// Code generated by protoc-gen-go-http-server-interface. DO NOT EDIT.\n")
+	fmt.Fprintf(&b, "// source: %s\n", file.GetName())
+	b.WriteString("//\n")
+	fmt.Fprintf(&b, "// Load test of the routes of %s, with a scenario per route:\n", file.GetName())
+	b.WriteString("//\n")
+	fmt.Fprintf(&b, "//   k6 run -e BASE_URL=%s -e RATE=10 -e DURATION=1m %s\n", baseURL, name)
+	b.WriteString("//\n")
+	b.WriteString("// Each scenario sends RATE requests per second, 10 by default, for DURATION,\n")
+	b.WriteString("// 1m by default. RATE_<scenario>, such as RATE_" + loadTestExampleName(routes) + ", sets the\n")
+	b.WriteString("// rate of one scenario, and 0 leaves it out. VUS is the number of virtual\n")
+	b.WriteString("// users preallocated per scenario.\n\n")
+	b.WriteString("import http from \"k6/http\";\n")
+	b.WriteString("import { check } from \"k6\";\n\n")
+	fmt.Fprintf(&b, "const baseUrl = (__ENV.BASE_URL || %s).replace(/\\/+$/, \"\");\n\n", jsString(baseURL))
+
+	b.WriteString("function scenario(name) {\n")
+	b.WriteString("  return {\n")
+	b.WriteString("    executor: \"constant-arrival-rate\",\n")
+	b.WriteString("    exec: name,\n")
+	b.WriteString("    rate: Number(__ENV[`RATE_${name}`] || __ENV.RATE || 10),\n")
+	b.WriteString("    timeUnit: \"1s\",\n")
+	b.WriteString("    duration: __ENV.DURATION || \"1m\",\n")
+	b.WriteString("    preAllocatedVUs: Number(__ENV.VUS || 10),\n")
+	b.WriteString("  };\n")
+	b.WriteString("}\n\n")
+
+	b.WriteString("const scenarios = [\n")
+	for _, route := range routes {
+		fmt.Fprintf(&b, "  %s,\n", jsString(route.Name))
+	}
+	b.WriteString("];\n\n")
+
+	b.WriteString("export const options = {\n")
+	b.WriteString("  scenarios: Object.fromEntries(\n")
+	b.WriteString("    scenarios.map((name) => [name, scenario(name)]).filter(([, s]) => s.rate > 0),\n")
+	b.WriteString("  ),\n")
+	b.WriteString("};\n\n")
+
+	b.WriteString("// send sends a method request for path with body as JSON, unless it is null,\n")
+	b.WriteString("// and checks for a 2xx response. The request metrics are tagged with the route\n")
+	b.WriteString("// instead of the URL.\n")
+	b.WriteString("function send(method, route, path, body) {\n")
+	b.WriteString("  const params = { tags: { name: route } };\n")
+	b.WriteString("  if (body !== null) {\n")
+	b.WriteString("    params.headers = { \"Content-Type\": \"application/json\" };\n")
+	b.WriteString("    body = JSON.stringify(body);\n")
+	b.WriteString("  }\n")
+	b.WriteString("  const res = http.request(method, baseUrl + path, body, params);\n")
+	b.WriteString("  check(res, { \"status is 2xx\": (r) => r.status >= 200 && r.status < 300 });\n")
+	b.WriteString("}\n")
+
+	for _, route := range routes {
+		body := "null"
+		if route.Body != "" {
+			body = route.Body
+		}
+		fmt.Fprintf(&b, "\n// %s sends %s %s.\n", route.Name, route.Method, route.Pattern)
+		fmt.Fprintf(&b, "export function %s() {\n", route.Name)
+		fmt.Fprintf(&b, "  send(%s, %s, %s, %s);\n", jsString(route.Method), jsString(route.Method+" "+route.Pattern), jsString(route.Path), body)
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
+
+// vegetaTarget is a request in vegeta's JSON target format.
+type vegetaTarget struct {
+	Method string              `json:"method"`
+	URL    string              `json:"url"`
+	Header map[string][]string `json:"header,omitempty"`
+	// Body is base64 encoded, which encoding/json does for byte slices.
+	Body []byte `json:"body,omitempty"`
+}
+
+// vegetaTargets returns the routes as vegeta targets, one JSON object per
+// line, for "vegeta attack -format=json".
+func (g *Generator) vegetaTargets(routes []loadTestRoute) string {
+	baseURL := g.Options.LoadTestBaseURL
+	if baseURL == "" {
+		baseURL = DefaultLoadTestBaseURL
+	}
+	baseURL = strings.TrimSuffix(baseURL, "/")
+
+	var b strings.Builder
+	for _, route := range routes {
+		target := vegetaTarget{Method: route.Method, URL: baseURL + route.Path}
+		if route.Body != "" {
+			target.Header = map[string][]string{"Content-Type": {"application/json"}}
+			target.Body = []byte(route.Body)
+		}
+		line, _ := json.Marshal(target)
+		b.Write(line)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// loadTestExampleName returns the name of the first scenario, for the usage
+// comment of the k6 script.
+func loadTestExampleName(routes []loadTestRoute) string {
+	if len(routes) == 0 {
+		return "Service_Method"
+	}
+	return routes[0].Name
+}
+
+// jsString quotes s as a JavaScript string literal.
+func jsString(s string) string {
+	quoted, _ := json.Marshal(s)
+	return string(quoted)
+}
+
+// fieldByPath returns the field of the message typeName named by the dotted
+// proto field path, or nil if there is none.
+func (g *Generator) fieldByPath(typeName, fieldPath string) *descriptor.FieldDescriptorProto {
+	var found *descriptor.FieldDescriptorProto
+	for _, name := range strings.Split(fieldPath, ".") {
+		msg := g.protoTypes.messages[typeName]
+		if msg == nil {
+			return nil
+		}
+		found = nil
+		for _, field := range msg.GetField() {
+			if field.GetName() == name {
+				found = field
+				break
+			}
+		}
+		if found == nil {
+			return nil
+		}
+		typeName = found.GetTypeName()
+	}
+	return found
+}
+
+// exampleMessage returns the JSON form of an example of the message typeName,
+// nested depth levels deep, filled like MockExample fills responses: strings
+// hold the field name, numbers are 1 or 1.5, booleans are true, enums take
+// their first non-zero value, repeated fields and maps get one element, and
+// only the first field of each oneof is set.
+func (g *Generator) exampleMessage(typeName string, depth int) map[string]any {
+	msg := g.protoTypes.messages[typeName]
+	example := map[string]any{}
+	if msg == nil {
+		return example
+	}
+	oneofs := map[int32]bool{}
+	for _, field := range msg.GetField() {
+		if field.OneofIndex != nil {
+			if oneofs[field.GetOneofIndex()] {
+				continue
+			}
+			oneofs[field.GetOneofIndex()] = true
+		}
+		if value := g.exampleField(field, depth); value != nil {
+			example[jsonFieldName(field)] = value
+		}
+	}
+	return example
+}
+
+// exampleField returns the JSON form of the example value of field in a
+// message nested depth levels deep, or nil if it is left out.
+func (g *Generator) exampleField(field *descriptor.FieldDescriptorProto, depth int) any {
+	if entry := g.protoTypes.messages[field.GetTypeName()]; entry.GetOptions().GetMapEntry() {
+		key, value := entry.GetField()[0], entry.GetField()[1]
+		v := g.exampleSingular(value, depth)
+		if v == nil {
+			return nil
+		}
+		return map[string]any{fmt.Sprint(g.exampleScalar(key)): v}
+	}
+	value := g.exampleSingular(field, depth)
+	if value == nil || field.GetLabel() != descriptor.FieldDescriptorProto_LABEL_REPEATED {
+		return value
+	}
+	return []any{value}
+}
+
+// exampleSingular returns the JSON form of one example value of the type of
+// field, ignoring its cardinality, or nil if it is left out.
+func (g *Generator) exampleSingular(field *descriptor.FieldDescriptorProto, depth int) any {
+	switch field.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_MESSAGE, descriptor.FieldDescriptorProto_TYPE_GROUP:
+		if value, ok := exampleWellKnownTypes[field.GetTypeName()]; ok {
+			return value
+		}
+		if strings.HasPrefix(field.GetTypeName(), ".google.protobuf.") || depth+1 >= exampleDepth {
+			return nil
+		}
+		return g.exampleMessage(field.GetTypeName(), depth+1)
+	default:
+		return g.exampleScalar(field)
+	}
+}
+
+// exampleScalar returns the JSON form of the example value of a scalar or
+// enum field. 64-bit integers are strings, as in the proto JSON mapping.
+func (g *Generator) exampleScalar(field *descriptor.FieldDescriptorProto) any {
+	switch field.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_BOOL:
+		return true
+	case descriptor.FieldDescriptorProto_TYPE_ENUM:
+		values := g.protoTypes.enums[field.GetTypeName()].GetValue()
+		for _, value := range values {
+			if value.GetNumber() != 0 {
+				return value.GetName()
+			}
+		}
+		if len(values) > 0 {
+			return values[0].GetName()
+		}
+		return 0
+	case descriptor.FieldDescriptorProto_TYPE_STRING:
+		return field.GetName()
+	case descriptor.FieldDescriptorProto_TYPE_BYTES:
+		return base64.StdEncoding.EncodeToString([]byte(field.GetName()))
+	case descriptor.FieldDescriptorProto_TYPE_FLOAT, descriptor.FieldDescriptorProto_TYPE_DOUBLE:
+		return 1.5
+	default:
+		if tsScalars[field.GetType()] == "string" {
+			return "1"
+		}
+		return 1
+	}
+}
@@ -0,0 +1,89 @@
+package httpinterface
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestGenerateLoadTestK6(t *testing.T) {
+	t.Parallel()
+
+	resp := New().Generate(graphqlRequest(t, "binding=true,loadtest=k6"))
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	files := map[string]string{}
+	for _, f := range resp.File {
+		files[f.GetName()] = f.GetContent()
+	}
+
+	script, ok := files["items_http.k6.js"]
+	if !ok {
+		t.Fatalf("missing items_http.k6.js in %d generated files", len(resp.File))
+	}
+	for _, expected := range []string{
+		"// Code generated by protoc-gen-go-http-server-interface. DO NOT EDIT.\n// source: items.proto\n",
+		"//   k6 run -e BASE_URL=http://localhost:8080 -e RATE=10 -e DURATION=1m items_http.k6.js\n",
+		"const baseUrl = (__ENV.BASE_URL || \"http://localhost:8080\").replace(/\\/+$/, \"\");\n",
+		"    executor: \"constant-arrival-rate\",\n",
+		"const scenarios = [\n  \"ItemService_GetItem\",\n  \"ItemService_SaveItem\",\n  \"ItemService_ExportItems\",\n];\n",
+		"// ItemService_GetItem sends GET /v1/items/{item_id}.\nexport function ItemService_GetItem() {\n" +
+			"  send(\"GET\", \"GET /v1/items/{item_id}\", \"/v1/items/item_id\", null);\n}\n",
+		"  send(\"PUT\", \"PUT /v1/items/{item_id}\", \"/v1/items/item_id\", " +
+			`{"itemId":"item_id","labels":{"key":"value"},"owner":{"name":"name"},"size":"1","state":"STATE_ACTIVE","tags":["tags"]});` + "\n",
+		"  send(\"POST\", \"POST /v1/items:export\", \"/v1/items:export\", {});\n",
+	} {
+		if !strings.Contains(script, expected) {
+			t.Errorf("k6 script doesn't contain %q:\n%s", expected, script)
+		}
+	}
+}
+
+func TestGenerateLoadTestVegeta(t *testing.T) {
+	t.Parallel()
+
+	resp := New().Generate(graphqlRequest(t, "binding=true,loadtest=vegeta,loadtest_base_url=https://items.example.com/,paths=source_relative"))
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	var content string
+	for _, f := range resp.File {
+		if f.GetName() == "items_http.vegeta.jsonl" {
+			content = f.GetContent()
+		}
+	}
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d targets, want 3:\n%s", len(lines), content)
+	}
+
+	var targets []vegetaTarget
+	for _, line := range lines {
+		var target vegetaTarget
+		if err := json.Unmarshal([]byte(line), &target); err != nil {
+			t.Fatalf("invalid target %q: %v", line, err)
+		}
+		targets = append(targets, target)
+	}
+	if got := targets[0]; got.Method != "GET" || got.URL != "https://items.example.com/v1/items/item_id" || got.Header != nil || got.Body != nil {
+		t.Errorf("GetItem target = %+v", got)
+	}
+	if got := targets[1]; got.Method != "PUT" || got.Header["Content-Type"][0] != "application/json" || !strings.HasPrefix(string(got.Body), `{"itemId":"item_id",`) {
+		t.Errorf("SaveItem target = %+v", got)
+	}
+}
+
+func TestGenerateWithoutLoadTest(t *testing.T) {
+	t.Parallel()
+
+	resp := New().Generate(graphqlRequest(t, "binding=true"))
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	for _, f := range resp.File {
+		if strings.HasSuffix(f.GetName(), ".k6.js") || strings.HasSuffix(f.GetName(), ".vegeta.jsonl") {
+			t.Errorf("Generate() wrote %s without loadtest", f.GetName())
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package httpinterface
+
+import "fmt"
+
+// HasLongPoll reports whether any method in d sets the
+// (http_server.long_poll_ms) option, so the generated file needs the
+// AsyncAdapter.
+func (d *ServiceData) HasLongPoll() bool {
+	for _, service := range d.Services {
+		if service.HasLongPollMethods() {
+			return true
+		}
+	}
+	return false
+}
+
+// HasLongPollMethods reports whether any method of s sets the
+// (http_server.long_poll_ms) option, so its typed handler returns a channel.
+func (s ServiceInfo) HasLongPollMethods() bool {
+	for _, method := range s.Methods {
+		if method.LongPollMs > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// checkLongPoll reports an error if a method in data sets the
+// (http_server.long_poll_ms) option without binding=true, which generates the
+// typed handler returning the channel, or together with an option that
+// changes how the typed handler answers: async, which hands the request to an
+// Enqueuer, and stream_array and stream_body, whose handlers write or read the
+// body themselves.
+func checkLongPoll(data *ServiceData) error {
+	for _, service := range data.Services {
+		for _, method := range service.Methods {
+			if method.LongPollMs == 0 {
+				continue
+			}
+			var other string
+			switch {
+			case !data.Options.Binding:
+				return fmt.Errorf("%s.%s sets (http_server.long_poll_ms), which requires binding=true",
+					service.Name, method.Name)
+			case method.Async:
+				other = "async"
+			case method.StreamArray:
+				other = "stream_array"
+			case method.StreamBody:
+				other = "stream_body"
+			default:
+				continue
+			}
+			return fmt.Errorf("%s.%s sets both (http_server.long_poll_ms) and (http_server.%s)",
+				service.Name, method.Name, other)
+		}
+	}
+	return nil
+}
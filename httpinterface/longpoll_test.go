@@ -0,0 +1,103 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpserver"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestGenerateLongPoll(t *testing.T) {
+	t.Parallel()
+
+	// Each case generates WatchItem with the options in watch next to the
+	// unary GetItem.
+	longPoll := withExtension(httpserver.E_LongPollMs, uint32(30000))
+	tests := []struct {
+		name           string
+		parameter      string
+		watch          []func(*descriptor.MethodOptions)
+		wantErr        string
+		wantContain    []string
+		wantNotContain []string
+	}{
+		{
+			name:      "with binding",
+			parameter: "binding=true,mock=true",
+			watch:     []func(*descriptor.MethodOptions){longPoll},
+			wantContain: []string{
+				"type ItemServiceTypedHandler interface {\n" +
+					"\tGetItem(ctx context.Context, req *Item) (*Item, error)\n" +
+					"\tWatchItem(ctx context.Context, req *Item) <-chan Result[*Item]\n}",
+				"type Result[T proto.Message] struct {",
+				"func Async[T proto.Message](ctx context.Context, fn func(ctx context.Context) (T, error)) <-chan Result[T] {",
+				"type AsyncAdapter[T proto.Message] struct {",
+				"func (e *LongPollTimeoutError) HTTPStatus() int {\n\treturn http.StatusGatewayTimeout\n}",
+				"\t\tAsyncAdapter[*Item]{\n" +
+					"\t\t\tTimeout: 30000 * time.Millisecond,\n" +
+					"\t\t\tHandler: func(ctx context.Context, req proto.Message) <-chan Result[*Item] {\n" +
+					"\t\t\t\treturn a.srv.WatchItem(ctx, req.(*Item))\n" +
+					"\t\t\t},\n" +
+					"\t\t}.Call)",
+				"func (m *itemServiceMock) WatchItem(ctx context.Context, req *Item) <-chan Result[*Item] {\n\treturn Async(ctx,",
+				"func (m *itemServiceMock) GetItem(ctx context.Context, req *Item) (*Item, error) {",
+			},
+		},
+		{
+			name:      "without binding",
+			parameter: "",
+			watch:     []func(*descriptor.MethodOptions){longPoll},
+			wantErr:   "items.proto: ItemService.WatchItem sets (http_server.long_poll_ms), which requires binding=true",
+		},
+		{
+			name:      "with async",
+			parameter: "binding=true",
+			watch:     []func(*descriptor.MethodOptions){longPoll, withExtension(httpserver.E_Async, true)},
+			wantErr:   "items.proto: ItemService.WatchItem sets both (http_server.long_poll_ms) and (http_server.async)",
+		},
+		{
+			name:      "with stream body",
+			parameter: "binding=true",
+			watch:     []func(*descriptor.MethodOptions){longPoll, withExtension(httpserver.E_StreamBody, true)},
+			wantErr:   "items.proto: ItemService.WatchItem sets both (http_server.long_poll_ms) and (http_server.stream_body)",
+		},
+		{
+			name:           "unset",
+			parameter:      "binding=true",
+			wantContain:    []string{"\tWatchItem(ctx context.Context, req *Item) (*Item, error)\n"},
+			wantNotContain: []string{"AsyncAdapter", "Result[", "LongPollTimeoutError"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp := New().Generate(itemsRequest(t, tt.parameter,
+				itemMethod("GetItem", getRule("/items/{id}")),
+				itemMethod("WatchItem", getRule("/items/{id}/watch"), tt.watch...),
+			))
+
+			if tt.wantErr != "" {
+				if !strings.Contains(resp.GetError(), tt.wantErr) {
+					t.Fatalf("Generate() error = %q, want error containing %q", resp.GetError(), tt.wantErr)
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+			code := resp.File[0].GetContent()
+			for _, expected := range tt.wantContain {
+				if !strings.Contains(code, expected) {
+					t.Errorf("Generated code doesn't contain %q", expected)
+				}
+			}
+			for _, unexpected := range tt.wantNotContain {
+				if strings.Contains(code, unexpected) {
+					t.Errorf("Generated code contains %q", unexpected)
+				}
+			}
+		})
+	}
+}
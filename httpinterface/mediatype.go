@@ -0,0 +1,75 @@
+package httpinterface
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+)
+
+// mediaTypeVersionRegex matches the version component of a proto package,
+// such as "v1" or "v2beta1".
+var mediaTypeVersionRegex = regexp.MustCompile(`^v[0-9]+((alpha|beta)[0-9]*)?$`)
+
+// MediaTypeInfo is the vendor media type of a request or response message.
+type MediaTypeInfo struct {
+	// Name is the media type, e.g. "application/vnd.myco.task.v1+json".
+	Name string
+	// Message is the full proto name of the message, e.g. "tasks.v1.Task".
+	Message string
+	// Version is the version component of the message's package, e.g. "v1",
+	// or "" when it has none.
+	Version string
+}
+
+// mediaTypes returns the vendor media types of the request and response
+// messages of the methods of data, in the order the methods use them.
+// Well-known types get none.
+func (g *Generator) mediaTypes(file *descriptor.FileDescriptorProto, data *ServiceData) []MediaTypeInfo {
+	var types []MediaTypeInfo
+	var seen []string
+	for _, service := range data.Services {
+		for _, method := range service.Methods {
+			desc := findMethod(file, service.Name, method.Name)
+			for _, typeName := range []string{desc.GetInputType(), desc.GetOutputType()} {
+				if slices.Contains(seen, typeName) || strings.HasPrefix(typeName, ".google.protobuf.") {
+					continue
+				}
+				seen = append(seen, typeName)
+				types = append(types, g.mediaType(typeName))
+			}
+		}
+	}
+	return types
+}
+
+// mediaType returns the vendor media type of the message typeName, named
+// after the message in kebab case, with nested messages joined by dots, and
+// the version of its package, e.g. "application/vnd.myco.task-list.v1+json"
+// for ".tasks.v1.TaskList".
+func (g *Generator) mediaType(typeName string) MediaTypeInfo {
+	message := strings.TrimPrefix(typeName, ".")
+	pkg, ok := g.protoTypes.packages[typeName]
+	if !ok {
+		// Messages of files outside the request are assumed to be top-level.
+		pkg = message[:max(strings.LastIndex(message, "."), 0)]
+	}
+
+	var version string
+	if i := strings.LastIndex(pkg, "."); mediaTypeVersionRegex.MatchString(pkg[i+1:]) {
+		version = pkg[i+1:]
+	}
+	parts := strings.Split(strings.TrimPrefix(strings.TrimPrefix(message, pkg), "."), ".")
+	for i, part := range parts {
+		parts[i] = strings.ReplaceAll(snakeCase(part), "_", "-")
+	}
+	if version != "" {
+		parts = append(parts, version)
+	}
+	return MediaTypeInfo{
+		Name:    "application/vnd." + g.Options.MediaTypeVendor + "." + strings.Join(parts, ".") + "+json",
+		Message: message,
+		Version: version,
+	}
+}
@@ -0,0 +1,66 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateMediaTypes(t *testing.T) {
+	t.Parallel()
+
+	resp := New().Generate(graphqlRequest(t, "binding=true,media_type_vendor=myco"))
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	content := resp.File[0].GetContent()
+	for _, expected := range []string{
+		"var MediaTypes = mediaTypeRegistry([]MediaType{\n" +
+			"\t{\"application/vnd.myco.get-item-request.v1+json\", \"items.v1.GetItemRequest\", \"v1\", JSONMarshaler{}},\n" +
+			"\t{\"application/vnd.myco.item.v1+json\", \"items.v1.Item\", \"v1\", JSONMarshaler{}},\n",
+		"func NegotiateMediaType(r *http.Request, msg proto.Message) (string, Marshaler, bool) {",
+		"\tif mt, ok := MediaTypes[mediaType]; ok {\n\t\treturn mediaType, mt.Marshaler, true\n\t}\n",
+		"\t_ = WriteNegotiatedResponse(w, r, http.StatusOK, resp)\n",
+		"\t\"cmp\"\n",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("generated code doesn't contain %q", expected)
+		}
+	}
+}
+
+func TestGenerateWithoutMediaTypes(t *testing.T) {
+	t.Parallel()
+
+	resp := New().Generate(graphqlRequest(t, "binding=true"))
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	content := resp.File[0].GetContent()
+	for _, unexpected := range []string{"MediaTypes", "NegotiateMediaType", "WriteNegotiatedResponse"} {
+		if strings.Contains(content, unexpected) {
+			t.Errorf("generated code contains %q without media_type_vendor", unexpected)
+		}
+	}
+}
+
+func TestMediaTypeName(t *testing.T) {
+	t.Parallel()
+
+	g := New()
+	g.Options = &Options{MediaTypeVendor: "myco"}
+	g.protoTypes = protoTypes{packages: map[string]string{".tasks.v2beta1.Page.Token": "tasks.v2beta1"}}
+	tests := []struct {
+		typeName string
+		want     MediaTypeInfo
+	}{
+		{".tasks.v1.TaskList", MediaTypeInfo{"application/vnd.myco.task-list.v1+json", "tasks.v1.TaskList", "v1"}},
+		{".tasks.v2beta1.Page.Token", MediaTypeInfo{"application/vnd.myco.page.token.v2beta1+json", "tasks.v2beta1.Page.Token", "v2beta1"}},
+		{".tasks.HTTPProxy", MediaTypeInfo{"application/vnd.myco.http-proxy+json", "tasks.HTTPProxy", ""}},
+		{".Task", MediaTypeInfo{"application/vnd.myco.task+json", "Task", ""}},
+	}
+	for _, tt := range tests {
+		if got := g.mediaType(tt.typeName); got != tt.want {
+			t.Errorf("mediaType(%q) = %+v, want %+v", tt.typeName, got, tt.want)
+		}
+	}
+}
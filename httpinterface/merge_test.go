@@ -0,0 +1,73 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateMergeRouters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		parameter      string
+		wantContain    []string
+		wantNotContain []string
+	}{
+		{
+			name:      "enabled",
+			parameter: "merge_routers=true",
+			wantContain: []string{
+				"type RouteDef struct {",
+				"type RouteProvider interface {\n\tRoutes() []RouteDef\n}",
+				"func (l *RouteList) HandleFunc(method, pattern string, handler http.HandlerFunc) {",
+				"func MergeRouters(prefix string, providers ...RouteProvider) (*RouteGroup, error) {",
+				"//\trouter, err := MergeRouters(\"/api\",\n//\t\tTaskServiceRouteProvider{Handler: tasks},\n",
+				"// every package is generated with runtime_import or the same runtime_module.\n",
+				"type ItemServiceRouteProvider struct {\n\tHandler ItemServiceHandler\n}",
+				"func (p ItemServiceRouteProvider) Routes() []RouteDef {\n\tvar routes RouteList\n\tMustRegisterItemServiceRoutes(&routes, p.Handler)\n\treturn routes\n}",
+			},
+		},
+		{
+			name:      "with runtime module",
+			parameter: "merge_routers=true,runtime_module=example.com/api/httpserverts",
+			wantContain: []string{
+				"\tRouteDef = httpserverts.RouteDef\n",
+				"\tRouteProvider = httpserverts.RouteProvider\n",
+				"\tRouteList = httpserverts.RouteList\n",
+				"\treturn httpserverts.MergeRouters(prefix, providers...)",
+				"func (p ItemServiceRouteProvider) Routes() []RouteDef {",
+			},
+			wantNotContain: []string{"func mergeRoutes(", "tasksv1.TaskServiceRouteProvider"},
+		},
+		{
+			name:           "disabled",
+			parameter:      "",
+			wantNotContain: []string{"RouteDef", "RouteProvider", "RouteList", "MergeRouters"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp := New().Generate(itemsRequest(t, tt.parameter,
+				itemMethod("ExportItems", postRule("/items:export", "*")),
+				itemMethod("ListItems", getRule("/items")),
+			))
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+			code := resp.File[0].GetContent()
+			for _, expected := range tt.wantContain {
+				if !strings.Contains(code, expected) {
+					t.Errorf("Generated code doesn't contain %q", expected)
+				}
+			}
+			for _, unexpected := range tt.wantNotContain {
+				if strings.Contains(code, unexpected) {
+					t.Errorf("Generated code contains %q", unexpected)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package httpinterface
+
+import (
+	"fmt"
+	"go/format"
+	goparser "go/parser"
+	"go/token"
+	"path"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+// minimizeFiles strips the comments of the Go files among files for
+// minimal=true, leaving other outputs such as TypeScript clients untouched.
+func minimizeFiles(files []*plugin.CodeGeneratorResponse_File) error {
+	for _, f := range files {
+		if path.Ext(f.GetName()) != ".go" {
+			continue
+		}
+		content, err := stripComments(f.GetContent())
+		if err != nil {
+			return fmt.Errorf("minimizing %s: %v", f.GetName(), err)
+		}
+		f.Content = proto.String(content)
+	}
+	return nil
+}
+
+// stripComments removes the comments of the Go source src, keeping the
+// "Code generated ... DO NOT EDIT." header that marks the file as generated
+// and //go: directives such as build constraints and embeds. Lines holding
+// only a comment are removed with it, and the result is gofmt'ed.
+func stripComments(src string) (string, error) {
+	fset := token.NewFileSet()
+	file, err := goparser.ParseFile(fset, "", src, goparser.ParseComments)
+	if err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+	last := 0
+	for _, group := range file.Comments {
+		for _, c := range group.List {
+			if keepComment(c.Text) {
+				continue
+			}
+			start, end := fset.Position(c.Pos()).Offset, fset.Position(c.End()).Offset
+			lineStart := strings.LastIndexByte(src[:start], '\n') + 1
+			lineEnd := len(src)
+			if i := strings.IndexByte(src[end:], '\n'); i >= 0 {
+				lineEnd = end + i + 1
+			}
+			if strings.TrimSpace(src[lineStart:start]) == "" && strings.TrimSpace(src[end:lineEnd]) == "" {
+				start, end = lineStart, lineEnd
+			} else {
+				start = lineStart + len(strings.TrimRight(src[lineStart:start], " \t"))
+			}
+			if start < last {
+				start = last
+			}
+			out.WriteString(src[last:start])
+			last = end
+		}
+	}
+	out.WriteString(src[last:])
+
+	formatted, err := format.Source([]byte(out.String()))
+	if err != nil {
+		return "", err
+	}
+	return string(formatted), nil
+}
+
+// keepComment reports whether the comment text is the generated-code header
+// or a directive, which minimal output keeps.
+func keepComment(text string) bool {
+	return strings.HasPrefix(text, "//go:") ||
+		(strings.HasPrefix(text, "// Code generated ") && strings.HasSuffix(text, " DO NOT EDIT."))
+}
@@ -0,0 +1,139 @@
+package httpinterface
+
+import (
+	"go/format"
+	"strings"
+	"testing"
+
+	options "google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+func TestStripComments(t *testing.T) {
+	t.Parallel()
+
+	src := `//go:build integration
+
+// Code generated by protoc-gen-go-http-server-interface. DO NOT EDIT.
+
+// Package pb serves the item routes.
+package pb
+
+import (
+	_ "embed"
+	"net/http" // routing
+)
+
+//go:embed routes.txt
+var routes string
+
+/*
+Handler serves items.
+*/
+type Handler interface {
+	// HandleGetItem serves GET /items/{id}.
+	HandleGetItem(w http.ResponseWriter, r *http.Request)
+	HandleListItems(w http.ResponseWriter, r *http.Request) // GET /items
+}
+
+// url keeps the // inside strings.
+const url = "https://example.com/items" // base
+
+func register() {
+	// Register routes
+	/* inline */ http.HandleFunc(url, nil)
+}
+`
+	want := `//go:build integration
+
+// Code generated by protoc-gen-go-http-server-interface. DO NOT EDIT.
+
+package pb
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+//go:embed routes.txt
+var routes string
+
+type Handler interface {
+	HandleGetItem(w http.ResponseWriter, r *http.Request)
+	HandleListItems(w http.ResponseWriter, r *http.Request)
+}
+
+const url = "https://example.com/items"
+
+func register() {
+	http.HandleFunc(url, nil)
+}
+`
+	got, err := stripComments(src)
+	if err != nil {
+		t.Fatalf("stripComments() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("stripComments() =\n%s\nwant\n%s", got, want)
+	}
+
+	if _, err := stripComments("package"); err == nil {
+		t.Error("stripComments() of invalid source: want error")
+	}
+}
+
+func TestGenerateMinimal(t *testing.T) {
+	t.Parallel()
+
+	rules := map[string]*options.HttpRule{"GetItem": {Pattern: &options.HttpRule_Get{Get: "/v1/items"}}}
+	request := func(parameter string) *plugin.CodeGeneratorRequest {
+		return &plugin.CodeGeneratorRequest{
+			FileToGenerate: []string{"items.proto"},
+			Parameter:      proto.String(parameter),
+			ProtoFile:      []*descriptor.FileDescriptorProto{conflictFile("items.proto", "items.v1", "ItemService", rules)},
+		}
+	}
+
+	for _, parameter := range []string{"binding=true,build_tags=integration", "layout=split,ts_client=true"} {
+		full := New().Generate(request(parameter))
+		minimal := New().Generate(request(parameter + ",minimal=true"))
+		if full.Error != nil || minimal.Error != nil {
+			t.Fatalf("Generate(%s) error = %s %s", parameter, full.GetError(), minimal.GetError())
+		}
+		if len(minimal.File) != len(full.File) {
+			t.Fatalf("Generate(%s) minimal files = %d, want %d", parameter, len(minimal.File), len(full.File))
+		}
+		for i, f := range minimal.File {
+			content := f.GetContent()
+			if !strings.HasSuffix(f.GetName(), ".go") {
+				if content != full.File[i].GetContent() {
+					t.Errorf("%s: minimal=true changed a non-Go file", f.GetName())
+				}
+				continue
+			}
+			if !strings.Contains(content, "// Code generated by protoc-gen-go-http-server-interface. DO NOT EDIT.") {
+				t.Errorf("%s: DO NOT EDIT header stripped", f.GetName())
+			}
+			if strings.Contains(parameter, "build_tags") && !strings.HasPrefix(content, "//go:build integration\n") {
+				t.Errorf("%s: build constraint stripped", f.GetName())
+			}
+			var comments int
+			for _, line := range strings.Split(content, "\n") {
+				if line = strings.TrimSpace(line); strings.HasPrefix(line, "//") && !strings.HasPrefix(line, "//go:") {
+					comments++
+				}
+			}
+			if comments != 1 {
+				t.Errorf("%s: %d comment lines left, want only the header", f.GetName(), comments)
+			}
+			if formatted, err := format.Source([]byte(content)); err != nil || string(formatted) != content {
+				t.Errorf("%s: minimal output is not gofmt'ed (err %v)", f.GetName(), err)
+			}
+			if len(content) >= len(full.File[i].GetContent())*3/4 {
+				t.Errorf("%s: minimal output is %d bytes, full output %d", f.GetName(), len(content), len(full.File[i].GetContent()))
+			}
+		}
+	}
+}
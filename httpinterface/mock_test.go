@@ -0,0 +1,73 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateMock(t *testing.T) {
+	t.Parallel()
+
+	code, err := New().GenerateCode(itemServiceData(Options{Binding: true, Mock: true}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+
+	for _, expected := range []string{
+		"type MockOptions struct",
+		"func (o MockOptions) respond(ctx context.Context, resp proto.Message) error {",
+		"func MockExample(msg proto.Message) {",
+		`"math/rand"`,
+		"func NewItemServiceMock(opts MockOptions) ItemServiceHandler {",
+		"h.ItemServiceHandler = NewItemServiceHandler(&itemServiceMock{opts: opts})",
+		"func NewItemServiceMockServer(opts MockOptions) *RouteGroup {",
+		"func (m *itemServiceMock) GetItem(ctx context.Context, req *GetItemRequest) (*Item, error) {",
+		"func (h *itemServiceMockHandler) HandleListItems(w http.ResponseWriter, r *http.Request) {",
+		`BindRequest(r, &ListItemsRequest{}, ""); err != nil`,
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+	if strings.Contains(code, "func (h *itemServiceMockHandler) HandleGetItem(") {
+		t.Error("Generated mock handler overrides a unary method")
+	}
+}
+
+func TestGenerateMockAsyncAndInternal(t *testing.T) {
+	t.Parallel()
+
+	data := itemServiceData(Options{Binding: true, Mock: true})
+	data.Services[0].Methods[1].Async = true
+	data.Services[0].Methods[0].Internal = true
+	code, err := New().GenerateCode(data)
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+
+	for _, expected := range []string{
+		"func (m *itemServiceMock) Enqueue(ctx context.Context, rpc string, req proto.Message) (string, error) {",
+		"if err := RegisterItemServiceInternalRoutes(router, handler); err != nil {",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+	if strings.Contains(code, "func (m *itemServiceMock) UpdateItem(") {
+		t.Error("Generated mock implements an async method")
+	}
+}
+
+func TestGenerateWithoutMock(t *testing.T) {
+	t.Parallel()
+
+	code, err := New().GenerateCode(itemServiceData(Options{Binding: true}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	for _, unexpected := range []string{"MockOptions", "MockExample", "itemServiceMock", `"math/rand"`} {
+		if strings.Contains(code, unexpected) {
+			t.Errorf("Generated code contains %q without mock=true", unexpected)
+		}
+	}
+}
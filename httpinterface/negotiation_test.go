@@ -0,0 +1,84 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateContentNegotiation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		parameter      string
+		wantContain    []string
+		wantNotContain []string
+	}{
+		{
+			name:      "enabled",
+			parameter: "binding=true,content_negotiation=true",
+			wantContain: []string{
+				`var Marshalers = map[string]Marshaler{"application/json": JSONMarshaler{}, "application/x-protobuf": ProtoMarshaler{}}`,
+				"func (ProtoMarshaler) Unmarshal(data []byte, msg proto.Message) error {\n\treturn proto.Unmarshal(data, msg)\n}",
+				"type Codecs map[string]Marshaler",
+				"func WithCodecs(codecs Codecs) RouterOption {",
+				"func NewRouter(mux *http.ServeMux, opts ...RouterOption) *RouteGroup {",
+				"\tif g.codecs != nil {\n\t\thandler = withCodecs(g.codecs, handler)\n\t}",
+				"\t\tcodecs:      g.codecs,\n",
+				"func lookupMarshaler(r *http.Request) (string, Marshaler, bool) {",
+				"\tmarshaler, ok := routeMarshalers(r)[mediaType]",
+				"func NegotiateMediaType(r *http.Request, msg proto.Message) (string, Marshaler, bool) {",
+				"\tmarshalers := routeMarshalers(r)\n",
+				"\t\tavailable := slices.Sorted(maps.Keys(routeMarshalers(r)))\n",
+				"\t_ = WriteNegotiatedResponse(w, r, http.StatusOK, resp)",
+				"\t\"cmp\"\n",
+			},
+			wantNotContain: []string{"MediaTypes", "message := string(msg.ProtoReflect()"},
+		},
+		{
+			name:      "with media type vendor and strict content type",
+			parameter: "binding=true,content_negotiation=true,media_type_vendor=acme,strict_content_type=true",
+			wantContain: []string{
+				"\t\tif mt, ok := MediaTypes[rng.name]; ok && mt.Message == message {",
+				"func acceptedMediaTypes(r *http.Request) []string {\n\taccepted := slices.Sorted(maps.Keys(routeMarshalers(r)))",
+				"\t\taccepted := acceptedMediaTypes(r)\n",
+				"\t\tif _, _, ok := lookupMarshaler(r); ok {",
+			},
+		},
+		{
+			name:      "disabled",
+			parameter: "binding=true,strict_content_type=true",
+			wantContain: []string{
+				`var Marshalers = map[string]Marshaler{"application/json": JSONMarshaler{}}`,
+				"\tmarshaler, ok := Marshalers[mediaType]",
+				"func acceptedMediaTypes() []string {",
+				"\t_ = WriteResponse(w, http.StatusOK, resp)",
+			},
+			wantNotContain: []string{"ProtoMarshaler", "Codecs", "routeMarshalers", "NegotiateMediaType", "RouterOption"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp := New().Generate(itemsRequest(t, tt.parameter,
+				itemMethod("ExportItems", postRule("/items:export", "*")),
+				itemMethod("ListItems", getRule("/items")),
+			))
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+			code := resp.File[0].GetContent()
+			for _, expected := range tt.wantContain {
+				if !strings.Contains(code, expected) {
+					t.Errorf("Generated code doesn't contain %q", expected)
+				}
+			}
+			for _, unexpected := range tt.wantNotContain {
+				if strings.Contains(code, unexpected) {
+					t.Errorf("Generated code contains %q", unexpected)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package httpinterface
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpserver"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+)
+
+// NormalizedField is a field of a request message that sets the
+// (http_server.normalize) option.
+type NormalizedField struct {
+	// Name is the fully-qualified field name, e.g. "users.v1.User.email".
+	Name string
+	// Funcs are the generated functions applied to the value, in order, e.g.
+	// "strings.TrimSpace".
+	Funcs []string
+	// String reports whether the field is a string field, the only kind the
+	// option applies to.
+	String bool
+}
+
+// normalizeFuncs maps each normalization to the function applying it in the
+// generated code.
+var normalizeFuncs = map[httpserver.Normalization]string{
+	httpserver.Normalization_TRIM:            "strings.TrimSpace",
+	httpserver.Normalization_LOWER:           "strings.ToLower",
+	httpserver.Normalization_UPPER:           "strings.ToUpper",
+	httpserver.Normalization_COLLAPSE_SPACES: "collapseSpaces",
+}
+
+// normalizedFields returns the fields that set (http_server.normalize) in the
+// request messages of file's methods and the messages they hold, sorted by
+// name.
+func (g *Generator) normalizedFields(file *descriptor.FileDescriptorProto) []NormalizedField {
+	var fields []NormalizedField
+	seen := map[string]bool{}
+	var walk func(typeName string)
+	walk = func(typeName string) {
+		if seen[typeName] {
+			return
+		}
+		seen[typeName] = true
+		for _, field := range g.protoTypes.messages[typeName].GetField() {
+			if field.GetType() == descriptor.FieldDescriptorProto_TYPE_MESSAGE {
+				walk(field.GetTypeName())
+			}
+			normalizations := fieldNormalize(field)
+			if len(normalizations) == 0 {
+				continue
+			}
+			normalized := NormalizedField{
+				Name:   strings.TrimPrefix(typeName, ".") + "." + field.GetName(),
+				String: field.GetType() == descriptor.FieldDescriptorProto_TYPE_STRING,
+			}
+			for _, normalization := range normalizations {
+				if fn, ok := normalizeFuncs[normalization]; ok {
+					normalized.Funcs = append(normalized.Funcs, fn)
+				}
+			}
+			if len(normalized.Funcs) > 0 || !normalized.String {
+				fields = append(fields, normalized)
+			}
+		}
+	}
+	for _, service := range file.GetService() {
+		for _, method := range service.GetMethod() {
+			walk(method.GetInputType())
+		}
+	}
+	slices.SortFunc(fields, func(a, b NormalizedField) int { return strings.Compare(a.Name, b.Name) })
+	return fields
+}
+
+// CollapsesSpaces reports whether a field of d is normalized with
+// COLLAPSE_SPACES, so the generated file needs the collapseSpaces helper.
+func (d *ServiceData) CollapsesSpaces() bool {
+	for _, field := range d.NormalizedFields {
+		if slices.Contains(field.Funcs, normalizeFuncs[httpserver.Normalization_COLLAPSE_SPACES]) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkNormalize reports fields that set (http_server.normalize) without
+// being string fields.
+func checkNormalize(data *ServiceData) error {
+	for _, field := range data.NormalizedFields {
+		if !field.String {
+			return fmt.Errorf("%s sets (http_server.normalize), which applies only to string fields", field.Name)
+		}
+	}
+	return nil
+}
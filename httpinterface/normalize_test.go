@@ -0,0 +1,137 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpserver"
+	options "google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+// normalizeRequest returns a request for a file whose CreateUser method takes
+// a request holding a User, whose email field sets (http_server.normalize) to
+// normalizations and whose age field to ageNormalizations.
+func normalizeRequest(t *testing.T, parameter string, normalizations, ageNormalizations []httpserver.Normalization) *plugin.CodeGeneratorRequest {
+	t.Helper()
+
+	field := func(name string, number int32, typ descriptor.FieldDescriptorProto_Type, typeName string, normalize []httpserver.Normalization) *descriptor.FieldDescriptorProto {
+		fd := &descriptor.FieldDescriptorProto{Name: proto.String(name), Number: proto.Int32(number), Type: typ.Enum(), JsonName: proto.String(name)}
+		if typeName != "" {
+			fd.TypeName = proto.String(typeName)
+		}
+		if normalize != nil {
+			fd.Options = &descriptor.FieldOptions{}
+			proto.SetExtension(fd.Options, httpserver.E_Normalize, normalize)
+		}
+		return fd
+	}
+	createOpts := &descriptor.MethodOptions{}
+	proto.SetExtension(createOpts, options.E_Http, &options.HttpRule{Pattern: &options.HttpRule_Post{Post: "/users"}, Body: "user"})
+
+	return pluginRequest(t, parameter, &descriptor.FileDescriptorProto{
+		Name:    proto.String("users.proto"),
+		Package: proto.String("users.v1"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptor.DescriptorProto{
+			{Name: proto.String("User"), Field: []*descriptor.FieldDescriptorProto{
+				field("email", 1, descriptor.FieldDescriptorProto_TYPE_STRING, "", normalizations),
+				field("age", 2, descriptor.FieldDescriptorProto_TYPE_INT32, "", ageNormalizations),
+			}},
+			{Name: proto.String("CreateUserRequest"), Field: []*descriptor.FieldDescriptorProto{
+				field("user", 1, descriptor.FieldDescriptorProto_TYPE_MESSAGE, ".users.v1.User", nil),
+			}},
+		},
+		Service: []*descriptor.ServiceDescriptorProto{{
+			Name: proto.String("UserService"),
+			Method: []*descriptor.MethodDescriptorProto{
+				{Name: proto.String("CreateUser"), InputType: proto.String(".users.v1.CreateUserRequest"), OutputType: proto.String(".users.v1.User"), Options: createOpts},
+			},
+		}},
+	})
+}
+
+func TestGenerateNormalize(t *testing.T) {
+	t.Parallel()
+
+	trimLower := []httpserver.Normalization{httpserver.Normalization_TRIM, httpserver.Normalization_LOWER}
+	tests := []struct {
+		name           string
+		parameter      string
+		normalizations []httpserver.Normalization
+		age            []httpserver.Normalization
+		wantErr        string
+		wantContain    []string
+		wantNotContain []string
+	}{
+		{
+			name:           "nested request field",
+			parameter:      "binding=true",
+			normalizations: trimLower,
+			wantContain: []string{
+				"\tcase \"users.v1.User.email\":\n\t\treturn []func(string) string{strings.TrimSpace, strings.ToLower}\n",
+				"func Normalize(msg proto.Message) {",
+				"\tnormalizeMessage(m)\n",
+				"// The string fields that set (http_server.normalize) are then normalized",
+			},
+			wantNotContain: []string{"func collapseSpaces("},
+		},
+		{
+			name:           "collapse spaces",
+			parameter:      "binding=true",
+			normalizations: []httpserver.Normalization{httpserver.Normalization_COLLAPSE_SPACES, httpserver.Normalization_UPPER},
+			wantContain: []string{
+				"return []func(string) string{collapseSpaces, strings.ToUpper}",
+				"func collapseSpaces(s string) string {",
+			},
+		},
+		{
+			name:           "without binding",
+			parameter:      "",
+			normalizations: trimLower,
+			wantNotContain: []string{"Normalize", "normalizeMessage"},
+		},
+		{
+			name:           "no normalized fields",
+			parameter:      "binding=true",
+			wantNotContain: []string{"Normalize", "normalizeMessage"},
+		},
+		{
+			name:           "non-string field",
+			parameter:      "binding=true",
+			normalizations: trimLower,
+			age:            []httpserver.Normalization{httpserver.Normalization_TRIM},
+			wantErr:        "users.proto: users.v1.User.age sets (http_server.normalize), which applies only to string fields",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp := New().Generate(normalizeRequest(t, tt.parameter, tt.normalizations, tt.age))
+
+			if tt.wantErr != "" {
+				if !strings.Contains(resp.GetError(), tt.wantErr) {
+					t.Fatalf("Generate() error = %q, want error containing %q", resp.GetError(), tt.wantErr)
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+			code := resp.File[0].GetContent()
+			for _, expected := range tt.wantContain {
+				if !strings.Contains(code, expected) {
+					t.Errorf("Generated code doesn't contain %q", expected)
+				}
+			}
+			for _, unexpected := range tt.wantNotContain {
+				if strings.Contains(code, unexpected) {
+					t.Errorf("Generated code contains %q", unexpected)
+				}
+			}
+		})
+	}
+}
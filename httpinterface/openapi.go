@@ -0,0 +1,397 @@
+package httpinterface
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+	"sigs.k8s.io/yaml"
+)
+
+// openAPISpec is the OpenAPI 3 document written by the openapi option.
+type openAPISpec struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Info       gatewayInfo                             `json:"info"`
+	Paths      map[string]map[string]*openAPIOperation `json:"paths"`
+	Components *openAPIComponents                      `json:"components,omitempty"`
+}
+
+type openAPIComponents struct {
+	Schemas map[string]*openAPISchema `json:"schemas,omitempty"`
+}
+
+type openAPIOperation struct {
+	OperationID string                     `json:"operationId"`
+	Description string                     `json:"description,omitempty"`
+	Deprecated  bool                       `json:"deprecated,omitempty"`
+	Tags        []string                   `json:"tags,omitempty"`
+	Parameters  []openAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]openAPIResponse `json:"responses"`
+}
+
+type openAPIParameter struct {
+	Name     string         `json:"name"`
+	In       string         `json:"in"`
+	Required bool           `json:"required,omitempty"`
+	Schema   *openAPISchema `json:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIMediaType struct {
+	Schema *openAPISchema `json:"schema"`
+}
+
+type openAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Enum                 []string                  `json:"enum,omitempty"`
+	Items                *openAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*openAPISchema `json:"properties,omitempty"`
+	AdditionalProperties *openAPISchema            `json:"additionalProperties,omitempty"`
+}
+
+// openAPIMethods are the HTTP methods OpenAPI 3 can describe; bindings with
+// other custom methods are left out of the document.
+var openAPIMethods = []string{
+	http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete,
+	http.MethodOptions, http.MethodHead, http.MethodPatch, http.MethodTrace,
+}
+
+// openAPIWellKnownTypes maps google.protobuf messages to the schema of their
+// JSON form.
+var openAPIWellKnownTypes = map[string]openAPISchema{
+	".google.protobuf.Timestamp":   {Type: "string", Format: "date-time"},
+	".google.protobuf.Duration":    {Type: "string"},
+	".google.protobuf.FieldMask":   {Type: "string"},
+	".google.protobuf.DoubleValue": {Type: "number", Format: "double"},
+	".google.protobuf.FloatValue":  {Type: "number", Format: "float"},
+	".google.protobuf.Int32Value":  {Type: "integer", Format: "int32"},
+	".google.protobuf.UInt32Value": {Type: "integer", Format: "int64"},
+	".google.protobuf.Int64Value":  {Type: "string", Format: "int64"},
+	".google.protobuf.UInt64Value": {Type: "string", Format: "uint64"},
+	".google.protobuf.BoolValue":   {Type: "boolean"},
+	".google.protobuf.StringValue": {Type: "string"},
+	".google.protobuf.BytesValue":  {Type: "string", Format: "byte"},
+	".google.protobuf.Struct":      {Type: "object"},
+	".google.protobuf.Value":       {},
+	".google.protobuf.ListValue":   {Type: "array", Items: &openAPISchema{}},
+	".google.protobuf.Any":         {Type: "object"},
+	".google.protobuf.Empty":       {Type: "object"},
+}
+
+// openAPIScalars maps proto scalar types to the schema of their JSON form.
+// 64-bit integers are strings in the proto JSON mapping.
+var openAPIScalars = map[descriptor.FieldDescriptorProto_Type]openAPISchema{
+	descriptor.FieldDescriptorProto_TYPE_DOUBLE:   {Type: "number", Format: "double"},
+	descriptor.FieldDescriptorProto_TYPE_FLOAT:    {Type: "number", Format: "float"},
+	descriptor.FieldDescriptorProto_TYPE_INT32:    {Type: "integer", Format: "int32"},
+	descriptor.FieldDescriptorProto_TYPE_SINT32:   {Type: "integer", Format: "int32"},
+	descriptor.FieldDescriptorProto_TYPE_SFIXED32: {Type: "integer", Format: "int32"},
+	descriptor.FieldDescriptorProto_TYPE_UINT32:   {Type: "integer", Format: "int64"},
+	descriptor.FieldDescriptorProto_TYPE_FIXED32:  {Type: "integer", Format: "int64"},
+	descriptor.FieldDescriptorProto_TYPE_INT64:    {Type: "string", Format: "int64"},
+	descriptor.FieldDescriptorProto_TYPE_SINT64:   {Type: "string", Format: "int64"},
+	descriptor.FieldDescriptorProto_TYPE_SFIXED64: {Type: "string", Format: "int64"},
+	descriptor.FieldDescriptorProto_TYPE_UINT64:   {Type: "string", Format: "uint64"},
+	descriptor.FieldDescriptorProto_TYPE_FIXED64:  {Type: "string", Format: "uint64"},
+	descriptor.FieldDescriptorProto_TYPE_BOOL:     {Type: "boolean"},
+	descriptor.FieldDescriptorProto_TYPE_STRING:   {Type: "string"},
+	descriptor.FieldDescriptorProto_TYPE_BYTES:    {Type: "string", Format: "byte"},
+}
+
+// openAPISchemas accumulates the component schemas of the messages and enums
+// an OpenAPI document refers to.
+type openAPISchemas struct {
+	g       *Generator
+	schemas map[string]*openAPISchema
+}
+
+// generateOpenAPIFile returns the <file>_http_openapi.yaml of file: an
+// OpenAPI 3 document describing every public route generated for it, with
+// its path and query parameters, request body and response, and the schemas
+// of the messages they carry in the proto JSON mapping. Internal methods are
+// left out like in gateway documents.
+func (g *Generator) generateOpenAPIFile(file *descriptor.FileDescriptorProto, data *ServiceData) (*plugin.CodeGeneratorResponse_File, error) {
+	spec := openAPISpec{
+		OpenAPI: "3.0.3",
+		Info:    gatewayInfo{Title: file.GetPackage(), Version: "1.0.0"},
+		Paths:   map[string]map[string]*openAPIOperation{},
+	}
+	if spec.Info.Title == "" {
+		spec.Info.Title = strings.TrimSuffix(path.Base(file.GetName()), ".proto")
+	}
+	schemas := &openAPISchemas{g: g, schemas: map[string]*openAPISchema{}}
+
+	for _, service := range data.Services {
+		for _, method := range service.Methods {
+			if method.Internal {
+				continue
+			}
+			rpc := findMethod(file, service.Name, method.Name)
+			for i, rule := range method.HTTPRules {
+				if !slices.Contains(openAPIMethods, rule.Method) {
+					continue
+				}
+				path, pathParams := openAPIPath(rule.PathTemplate())
+
+				op := &openAPIOperation{
+					OperationID: service.Name + "_" + method.Name,
+					Description: method.Comment,
+					Deprecated:  method.Deprecates(rule),
+					Tags:        method.Tags,
+					Parameters:  schemas.parameters(rpc.GetInputType(), pathParams, rule.Body),
+					Responses:   schemas.responses(rpc.GetOutputType(), method),
+				}
+				if i > 0 {
+					op.OperationID += fmt.Sprint(i + 1)
+				}
+				if method.StreamBody {
+					op.RequestBody = &openAPIRequestBody{Required: true, Content: map[string]openAPIMediaType{
+						"application/octet-stream": {Schema: &openAPISchema{Type: "string", Format: "binary"}},
+					}}
+				} else if rule.Body != "" {
+					op.RequestBody = &openAPIRequestBody{Required: true, Content: map[string]openAPIMediaType{
+						"application/json": {Schema: schemas.bodySchema(rpc.GetInputType(), rule.Body)},
+					}}
+				}
+
+				if spec.Paths[path] == nil {
+					spec.Paths[path] = map[string]*openAPIOperation{}
+				}
+				key := strings.ToLower(rule.Method)
+				if prev, ok := spec.Paths[path][key]; ok {
+					return nil, fmt.Errorf("%s: OpenAPI route %s %s is bound by both %s and %s",
+						file.GetName(), rule.Method, path, prev.OperationID, op.OperationID)
+				}
+				spec.Paths[path][key] = op
+			}
+		}
+	}
+	if len(schemas.schemas) > 0 {
+		spec.Components = &openAPIComponents{Schemas: schemas.schemas}
+	}
+
+	content, err := yaml.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("error generating OpenAPI document for %s: %v", file.GetName(), err)
+	}
+	header := "# Code generated by protoc-gen-go-http-server-interface. DO NOT EDIT.\n# source: " + file.GetName() + "\n\n"
+
+	name := strings.TrimSuffix(g.getOutputFilename(file.GetName()), ".pb.go") + "_openapi.yaml"
+	outputFile := &plugin.CodeGeneratorResponse_File{
+		Name:    proto.String(name),
+		Content: proto.String(header + string(content)),
+	}
+	g.applySourceRelativePath(outputFile, file.GetName())
+	return outputFile, nil
+}
+
+// openAPIPath converts a route pattern into an OpenAPI path and its parameter
+// names, dropping the segment templates of its parameters:
+// "/v1/{name=projects/*}" becomes "/v1/{name}". Field paths such as
+// {task.id} are kept.
+func openAPIPath(pattern string) (string, []string) {
+	var params []string
+	for _, match := range gatewayPathParamRegex.FindAllStringSubmatch(pattern, -1) {
+		params = append(params, match[1])
+	}
+	return gatewayPathParamRegex.ReplaceAllString(pattern, "{$1}"), params
+}
+
+// responses returns the responses of method: its output message, an array
+// of them for stream_array methods, or the OperationRef of async methods.
+func (s *openAPISchemas) responses(outputType string, method MethodInfo) map[string]openAPIResponse {
+	switch {
+	case method.Async:
+		ref := &openAPISchema{Type: "object", Properties: map[string]*openAPISchema{
+			"id":     {Type: "string"},
+			"method": {Type: "string"},
+		}}
+		return map[string]openAPIResponse{"202": {
+			Description: "The request was accepted for asynchronous processing.",
+			Content:     map[string]openAPIMediaType{"application/json": {Schema: ref}},
+		}}
+	case method.StreamArray:
+		return map[string]openAPIResponse{"200": {
+			Description: "A successful response.",
+			Content: map[string]openAPIMediaType{"application/json": {
+				Schema: &openAPISchema{Type: "array", Items: s.messageSchema(outputType)},
+			}},
+		}}
+	default:
+		return map[string]openAPIResponse{"200": {
+			Description: "A successful response.",
+			Content:     map[string]openAPIMediaType{"application/json": {Schema: s.messageSchema(outputType)}},
+		}}
+	}
+}
+
+// bodySchema returns the schema of the request body of a binding with the
+// body selector body: the whole input message for "*", else the named field.
+func (s *openAPISchemas) bodySchema(inputType, body string) *openAPISchema {
+	if body == "*" {
+		return s.messageSchema(inputType)
+	}
+	if field := s.field(inputType, body); field != nil {
+		return s.fieldSchema(field)
+	}
+	return &openAPISchema{}
+}
+
+// parameters returns the path parameters of a binding followed by the query
+// parameters it leaves to the query string: the fields of the input message
+// bound neither by the path nor the body, with singular message fields
+// expanded into dotted keys.
+func (s *openAPISchemas) parameters(inputType string, pathParams []string, body string) []openAPIParameter {
+	var params []openAPIParameter
+	for _, name := range pathParams {
+		schema := &openAPISchema{Type: "string"}
+		if field := s.field(inputType, name); field != nil {
+			schema = s.fieldSchema(field)
+		}
+		params = append(params, openAPIParameter{Name: name, In: "path", Required: true, Schema: schema})
+	}
+	if body == "*" {
+		return params
+	}
+	bound := slices.Clone(pathParams)
+	if body != "" {
+		bound = append(bound, body)
+	}
+	return s.queryParameters(params, inputType, "", bound, map[string]bool{})
+}
+
+// queryParameters appends the query parameters of the fields of the message
+// typeName, named with prefix, to params, skipping the field paths in bound.
+// seen holds the messages being expanded, so recursive messages end.
+func (s *openAPISchemas) queryParameters(params []openAPIParameter, typeName, prefix string, bound []string, seen map[string]bool) []openAPIParameter {
+	msg := s.g.protoTypes.messages[typeName]
+	if msg == nil || seen[typeName] {
+		return params
+	}
+	seen[typeName] = true
+	defer delete(seen, typeName)
+
+	for _, field := range msg.GetField() {
+		name := prefix + field.GetName()
+		if slices.Contains(bound, name) {
+			continue
+		}
+		repeated := field.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED
+		if field.GetType() == descriptor.FieldDescriptorProto_TYPE_MESSAGE {
+			if _, ok := openAPIWellKnownTypes[field.GetTypeName()]; !ok {
+				if !repeated {
+					params = s.queryParameters(params, field.GetTypeName(), name+".", bound, seen)
+				}
+				continue
+			}
+		}
+		params = append(params, openAPIParameter{Name: name, In: "query", Schema: s.fieldSchema(field)})
+	}
+	return params
+}
+
+// field returns the field of the message typeName addressed by the dotted
+// field path, or nil if there is none.
+func (s *openAPISchemas) field(typeName, path string) *descriptor.FieldDescriptorProto {
+	var field *descriptor.FieldDescriptorProto
+	for _, name := range strings.Split(path, ".") {
+		msg := s.g.protoTypes.messages[typeName]
+		field = nil
+		for _, f := range msg.GetField() {
+			if f.GetName() == name {
+				field = f
+				break
+			}
+		}
+		if field == nil {
+			return nil
+		}
+		typeName = field.GetTypeName()
+	}
+	return field
+}
+
+// messageSchema returns the schema of the message typeName: a reference to
+// its component schema, defined with the schemas it refers to on first use,
+// or the schema of the JSON form of a well-known type.
+func (s *openAPISchemas) messageSchema(typeName string) *openAPISchema {
+	if schema, ok := openAPIWellKnownTypes[typeName]; ok {
+		return &schema
+	}
+	msg := s.g.protoTypes.messages[typeName]
+	if msg == nil {
+		return &openAPISchema{Type: "object"}
+	}
+	name := strings.TrimPrefix(typeName, ".")
+	ref := &openAPISchema{Ref: "#/components/schemas/" + name}
+	if s.schemas[name] != nil {
+		return ref
+	}
+
+	// Define the schema before its fields, which may refer back to it
+	schema := &openAPISchema{Type: "object"}
+	s.schemas[name] = schema
+	for _, field := range msg.GetField() {
+		if schema.Properties == nil {
+			schema.Properties = map[string]*openAPISchema{}
+		}
+		schema.Properties[jsonFieldName(field)] = s.fieldSchema(field)
+	}
+	return ref
+}
+
+// fieldSchema returns the schema of the JSON value of field.
+func (s *openAPISchemas) fieldSchema(field *descriptor.FieldDescriptorProto) *openAPISchema {
+	var elem *openAPISchema
+	switch field.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_MESSAGE, descriptor.FieldDescriptorProto_TYPE_GROUP:
+		if entry := s.g.protoTypes.messages[field.GetTypeName()]; entry.GetOptions().GetMapEntry() {
+			return &openAPISchema{Type: "object", AdditionalProperties: s.fieldSchema(entry.GetField()[1])}
+		}
+		elem = s.messageSchema(field.GetTypeName())
+	case descriptor.FieldDescriptorProto_TYPE_ENUM:
+		elem = s.enumSchema(field.GetTypeName())
+	default:
+		scalar := openAPIScalars[field.GetType()]
+		elem = &scalar
+	}
+	if field.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED {
+		return &openAPISchema{Type: "array", Items: elem}
+	}
+	return elem
+}
+
+// enumSchema returns a reference to the component schema of the enum
+// typeName, listing its value names, defined on first use.
+func (s *openAPISchemas) enumSchema(typeName string) *openAPISchema {
+	enum := s.g.protoTypes.enums[typeName]
+	if enum == nil {
+		return &openAPISchema{Type: "string"}
+	}
+	name := strings.TrimPrefix(typeName, ".")
+	if s.schemas[name] == nil {
+		schema := &openAPISchema{Type: "string"}
+		for _, value := range enum.GetValue() {
+			schema.Enum = append(schema.Enum, value.GetName())
+		}
+		s.schemas[name] = schema
+	}
+	return &openAPISchema{Ref: "#/components/schemas/" + name}
+}
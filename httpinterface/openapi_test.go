@@ -0,0 +1,262 @@
+package httpinterface
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpserver"
+	options "google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+// openAPITestFile returns a proto file whose TaskService gets tasks by id,
+// with an additional segment-template binding, creates them from a body
+// field, and purges them through an internal method. Its messages cover
+// scalar, repeated, map, enum, well-known and recursive fields.
+func openAPITestFile() *descriptor.FileDescriptorProto {
+	field := func(name string, number int32, typ descriptor.FieldDescriptorProto_Type, typeName string) *descriptor.FieldDescriptorProto {
+		f := &descriptor.FieldDescriptorProto{
+			Name:   proto.String(name),
+			Number: proto.Int32(number),
+			Label:  descriptor.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:   typ.Enum(),
+		}
+		if typeName != "" {
+			f.TypeName = proto.String(typeName)
+		}
+		return f
+	}
+	repeated := func(f *descriptor.FieldDescriptorProto) *descriptor.FieldDescriptorProto {
+		f.Label = descriptor.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		return f
+	}
+	withJSONName := func(f *descriptor.FieldDescriptorProto, name string) *descriptor.FieldDescriptorProto {
+		f.JsonName = proto.String(name)
+		return f
+	}
+	method := func(name, input string, rule *options.HttpRule) *descriptor.MethodDescriptorProto {
+		opts := &descriptor.MethodOptions{}
+		proto.SetExtension(opts, options.E_Http, rule)
+		return &descriptor.MethodDescriptorProto{
+			Name:       proto.String(name),
+			InputType:  proto.String(input),
+			OutputType: proto.String(".tasks.v1.Task"),
+			Options:    opts,
+		}
+	}
+
+	purge := method("PurgeTasks", ".tasks.v1.GetTaskRequest", &options.HttpRule{Pattern: &options.HttpRule_Delete{Delete: "/admin/tasks"}})
+	proto.SetExtension(purge.Options, httpserver.E_Visibility, httpserver.Visibility_INTERNAL)
+
+	return &descriptor.FileDescriptorProto{
+		Name:    proto.String("openapi/v1/tasks.proto"),
+		Package: proto.String("tasks.v1"),
+		Syntax:  proto.String("proto3"),
+		Options: &descriptor.FileOptions{GoPackage: proto.String("example.com/tasks/v1;tasksv1")},
+		EnumType: []*descriptor.EnumDescriptorProto{{
+			Name: proto.String("Status"),
+			Value: []*descriptor.EnumValueDescriptorProto{
+				{Name: proto.String("STATUS_UNSPECIFIED"), Number: proto.Int32(0)},
+				{Name: proto.String("OPEN"), Number: proto.Int32(1)},
+			},
+		}},
+		MessageType: []*descriptor.DescriptorProto{
+			{
+				Name: proto.String("Filter"),
+				Field: []*descriptor.FieldDescriptorProto{
+					field("status", 1, descriptor.FieldDescriptorProto_TYPE_ENUM, ".tasks.v1.Status"),
+					field("and", 2, descriptor.FieldDescriptorProto_TYPE_MESSAGE, ".tasks.v1.Filter"),
+				},
+			},
+			{
+				Name: proto.String("Task"),
+				Field: []*descriptor.FieldDescriptorProto{
+					field("id", 1, descriptor.FieldDescriptorProto_TYPE_STRING, ""),
+					withJSONName(field("display_name", 2, descriptor.FieldDescriptorProto_TYPE_STRING, ""), "displayName"),
+					repeated(field("tags", 3, descriptor.FieldDescriptorProto_TYPE_STRING, "")),
+					repeated(field("counts", 4, descriptor.FieldDescriptorProto_TYPE_MESSAGE, ".tasks.v1.Task.CountsEntry")),
+					withJSONName(field("create_time", 5, descriptor.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.Timestamp"), "createTime"),
+					field("status", 6, descriptor.FieldDescriptorProto_TYPE_ENUM, ".tasks.v1.Status"),
+					field("parent", 7, descriptor.FieldDescriptorProto_TYPE_MESSAGE, ".tasks.v1.Task"),
+				},
+				NestedType: []*descriptor.DescriptorProto{{
+					Name: proto.String("CountsEntry"),
+					Field: []*descriptor.FieldDescriptorProto{
+						field("key", 1, descriptor.FieldDescriptorProto_TYPE_STRING, ""),
+						field("value", 2, descriptor.FieldDescriptorProto_TYPE_INT64, ""),
+					},
+					Options: &descriptor.MessageOptions{MapEntry: proto.Bool(true)},
+				}},
+			},
+			{
+				Name: proto.String("GetTaskRequest"),
+				Field: []*descriptor.FieldDescriptorProto{
+					field("id", 1, descriptor.FieldDescriptorProto_TYPE_STRING, ""),
+					field("filter", 2, descriptor.FieldDescriptorProto_TYPE_MESSAGE, ".tasks.v1.Filter"),
+					withJSONName(field("page_size", 3, descriptor.FieldDescriptorProto_TYPE_INT32, ""), "pageSize"),
+				},
+			},
+			{
+				Name: proto.String("CreateTaskRequest"),
+				Field: []*descriptor.FieldDescriptorProto{
+					field("parent", 1, descriptor.FieldDescriptorProto_TYPE_STRING, ""),
+					field("task", 2, descriptor.FieldDescriptorProto_TYPE_MESSAGE, ".tasks.v1.Task"),
+					field("validate_only", 3, descriptor.FieldDescriptorProto_TYPE_BOOL, ""),
+				},
+			},
+		},
+		Service: []*descriptor.ServiceDescriptorProto{{
+			Name: proto.String("TaskService"),
+			Method: []*descriptor.MethodDescriptorProto{
+				method("GetTask", ".tasks.v1.GetTaskRequest", &options.HttpRule{
+					Pattern: &options.HttpRule_Get{Get: "/v1/tasks/{id}"},
+					AdditionalBindings: []*options.HttpRule{
+						{Pattern: &options.HttpRule_Get{Get: "/v1/{id=projects/*/tasks/*}"}},
+					},
+				}),
+				method("CreateTask", ".tasks.v1.CreateTaskRequest", &options.HttpRule{
+					Pattern: &options.HttpRule_Post{Post: "/v1/{parent=projects/*}/tasks"},
+					Body:    "task",
+				}),
+				purge,
+			},
+		}},
+	}
+}
+
+func TestGenerateOpenAPI(t *testing.T) {
+	t.Parallel()
+
+	resp := New().Generate(&plugin.CodeGeneratorRequest{
+		Parameter:      proto.String("openapi=true,paths=source_relative,raw_patterns=true"),
+		ProtoFile:      []*descriptor.FileDescriptorProto{openAPITestFile()},
+		FileToGenerate: []string{"openapi/v1/tasks.proto"},
+	})
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+
+	var content string
+	for _, f := range resp.File {
+		if f.GetName() == "openapi/v1/tasks_http_openapi.yaml" {
+			content = f.GetContent()
+		}
+	}
+	if content == "" {
+		t.Fatal("Generate() wrote no openapi/v1/tasks_http_openapi.yaml")
+	}
+
+	for _, expected := range []string{
+		"# Code generated by protoc-gen-go-http-server-interface. DO NOT EDIT.\n# source: openapi/v1/tasks.proto\n",
+		"openapi: 3.0.3\n",
+		"  title: tasks.v1\n",
+		// The primary binding, with the fields left to the query string
+		"  /v1/tasks/{id}:\n    get:\n      operationId: TaskService_GetTask\n" +
+			"      parameters:\n      - in: path\n        name: id\n        required: true\n        schema:\n          type: string\n" +
+			"      - in: query\n        name: filter.status\n        schema:\n          $ref: '#/components/schemas/tasks.v1.Status'\n" +
+			"      - in: query\n        name: page_size\n        schema:\n          format: int32\n          type: integer\n",
+		// The segment template is dropped from the additional binding
+		"  /v1/{id}:\n    get:\n      operationId: TaskService_GetTask2\n",
+		// A body field binding takes the field's schema as request body
+		"      requestBody:\n        content:\n          application/json:\n            schema:\n" +
+			"              $ref: '#/components/schemas/tasks.v1.Task'\n        required: true\n",
+		"      - in: query\n        name: validate_only\n",
+		"      responses:\n        \"200\":\n          content:\n            application/json:\n              schema:\n" +
+			"                $ref: '#/components/schemas/tasks.v1.Task'\n          description: A successful response.\n",
+		// Schemas follow the proto JSON mapping
+		"        counts:\n          additionalProperties:\n            format: int64\n            type: string\n          type: object\n",
+		"        createTime:\n          format: date-time\n          type: string\n",
+		"        displayName:\n          type: string\n",
+		"        parent:\n          $ref: '#/components/schemas/tasks.v1.Task'\n",
+		"        tags:\n          items:\n            type: string\n          type: array\n",
+		"    tasks.v1.Status:\n      enum:\n      - STATUS_UNSPECIFIED\n      - OPEN\n      type: string\n",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("OpenAPI document doesn't contain %q:\n%s", expected, content)
+		}
+	}
+	for _, unexpected := range []string{"/admin/tasks", "PurgeTasks", "filter.and", "tasks.v1.GetTaskRequest", "tasks.v1.Filter"} {
+		if strings.Contains(content, unexpected) {
+			t.Errorf("OpenAPI document contains %q", unexpected)
+		}
+	}
+}
+
+func TestGenerateOpenAPIMethodKinds(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		option   protoreflect.ExtensionType
+		expected string
+	}{
+		{
+			name:   "async",
+			option: httpserver.E_Async,
+			expected: "        \"202\":\n          content:\n            application/json:\n              schema:\n" +
+				"                properties:\n                  id:\n                    type: string\n",
+		},
+		{
+			name:   "stream array",
+			option: httpserver.E_StreamArray,
+			expected: "              schema:\n                items:\n                  $ref: '#/components/schemas/tasks.v1.Task'\n" +
+				"                type: array\n",
+		},
+		{
+			name:   "stream body",
+			option: httpserver.E_StreamBody,
+			expected: "      requestBody:\n        content:\n          application/octet-stream:\n            schema:\n" +
+				"              format: binary\n              type: string\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			file := openAPITestFile()
+			proto.SetExtension(file.Service[0].Method[1].Options, tt.option, true)
+			resp := New().Generate(&plugin.CodeGeneratorRequest{
+				Parameter:      proto.String("openapi=true,binding=true,raw_patterns=true"),
+				ProtoFile:      []*descriptor.FileDescriptorProto{file},
+				FileToGenerate: []string{"openapi/v1/tasks.proto"},
+			})
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+			for _, f := range resp.File {
+				if f.GetName() != "tasks_http_openapi.yaml" {
+					continue
+				}
+				if !strings.Contains(f.GetContent(), tt.expected) {
+					t.Errorf("OpenAPI document doesn't contain %q:\n%s", tt.expected, f.GetContent())
+				}
+				return
+			}
+			t.Fatal("Generate() wrote no tasks_http_openapi.yaml")
+		})
+	}
+}
+
+func TestOpenAPIPath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern    string
+		wantPath   string
+		wantParams []string
+	}{
+		{"/v1/tasks", "/v1/tasks", nil},
+		{"/v1/tasks/{task.id}", "/v1/tasks/{task.id}", []string{"task.id"}},
+		{"/v1/{name=projects/*/tasks/*}:archive", "/v1/{name}:archive", []string{"name"}},
+	}
+	for _, tt := range tests {
+		path, params := openAPIPath(tt.pattern)
+		if path != tt.wantPath || !slices.Equal(params, tt.wantParams) {
+			t.Errorf("openAPIPath(%q) = %q, %q, want %q, %q", tt.pattern, path, params, tt.wantPath, tt.wantParams)
+		}
+	}
+}
@@ -2,9 +2,173 @@ package httpinterface
 
 import (
 	"fmt"
+	"go/build/constraint"
+	"go/token"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
 	"strings"
+
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Layouts accepted by the layout option.
+const (
+	// LayoutSingle generates one <file>_http.pb.go per proto file.
+	LayoutSingle = "single"
+	// LayoutSplit generates <file>_http_iface.pb.go, <file>_http_router.pb.go
+	// and <file>_http_register.pb.go per proto file.
+	LayoutSplit = "split"
+)
+
+// Scaffold kinds accepted by the scaffold option.
+const (
+	// ScaffoldHandler writes a starter <Service>Handler implementation per service.
+	ScaffoldHandler = "handler"
+	// ScaffoldProject writes a runnable skeleton: main.go, plus a service and a
+	// handler per service in the service and handler packages.
+	ScaffoldProject = "project"
+	// ScaffoldDeploy writes a multi-stage Dockerfile and a Makefile with
+	// generate, build, run and test targets.
+	ScaffoldDeploy = "deploy"
+)
+
+// Path value sources accepted by the pathvalue_source option.
+const (
+	// PathValueStd reads path values with http.Request.PathValue, as set by
+	// http.ServeMux.
+	PathValueStd = "std"
+	// PathValueChi reads path values with chi.URLParam.
+	PathValueChi = "chi"
+	// PathValueGorilla reads path values with mux.Vars.
+	PathValueGorilla = "gorilla"
+)
+
+// Values of the unexpected_body option.
+const (
+	// UnexpectedBodyReject answers requests with a body on GET and DELETE
+	// routes without a body rule with 400 Bad Request.
+	UnexpectedBodyReject = "reject"
+	// UnexpectedBodyIgnore serves such requests, leaving the body unread.
+	UnexpectedBodyIgnore = "ignore"
+)
+
+// Routers accepted by the router option.
+const (
+	// RouterChi generates ChiRoutes and PathValue reading chi.URLParam.
+	RouterChi = "chi"
+	// RouterGorilla generates GorillaRoutes and PathValue reading mux.Vars.
+	RouterGorilla = "gorilla"
+)
+
+// Router adapters accepted by the adapters option.
+const (
+	// AdapterGin generates GinRoutes, registering routes on a gin.IRoutes.
+	AdapterGin = "gin"
+	// AdapterFiber generates FiberRoutes, registering routes on a fiber.Router.
+	AdapterFiber = "fiber"
+	// AdapterChi generates ChiRoutes, registering routes on a chi.Router.
+	AdapterChi = "chi"
+	// AdapterGorilla generates GorillaRoutes, registering routes on a *mux.Router.
+	AdapterGorilla = "gorilla"
 )
 
+// DefaultScaffoldPackage is the package of scaffolded files when scaffold_package is not set.
+const DefaultScaffoldPackage = "handler"
+
+// DefaultMaxEdition is the newest protobuf edition accepted when max_edition is not set.
+const DefaultMaxEdition = descriptor.Edition_EDITION_2023
+
+// maxEditionValues maps the accepted max_edition values to editions, oldest first.
+var maxEditionValues = []struct {
+	name    string
+	edition descriptor.Edition
+}{
+	{"2023", descriptor.Edition_EDITION_2023},
+	{"2024", descriptor.Edition_EDITION_2024},
+}
+
+// optionNames lists the recognized plugin options, in the order they are documented.
+var optionNames = []string{
+	"paths",
+	"output_prefix",
+	"editions",
+	"decompress",
+	"decompress_max_bytes",
+	"binding",
+	"apply_defaults",
+	"strict_content_type",
+	"emit_unset_optionals",
+	"media_type_vendor",
+	"max_edition",
+	"grpc_api_configuration",
+	"build_tags",
+	"package_name",
+	"import_alias",
+	"layout",
+	"doc",
+	"auto_options",
+	"self_description",
+	"discovery_url",
+	"conditional_get",
+	"unit_of_work",
+	"propagate_deadline",
+	"rate_limit_headers",
+	"scaffold",
+	"scaffold_dir",
+	"scaffold_package",
+	"scaffold_import_path",
+	"client",
+	"graphql",
+	"cli",
+	"mock",
+	"chaos",
+	"recording",
+	"capture",
+	"loadtest",
+	"loadtest_base_url",
+	"slo",
+	"slo_prometheus",
+	"ts_client",
+	"gateway_openapi",
+	"gateway_backend",
+	"report",
+	"pathvalue_source",
+	"adapters",
+	"router",
+	"error_details",
+	"scope",
+	"raw_patterns",
+	"path_prefix",
+	"doc_deprecated",
+	"minimal",
+	"server_timing",
+	"unexpected_body",
+	"static_errors",
+	"strict_query",
+	"decode_helpers",
+	"empty_files",
+	"openapi",
+	"runtime_module",
+	"extra_interface",
+	"extra_import",
+	"route_config",
+	"otel_attributes",
+	"validate",
+	"error_handler",
+	"grpc_status",
+	"route_checksum",
+	"runtime_import",
+	"merge_routers",
+	"content_negotiation",
+}
+
+// listOptions are options whose value is a comma-separated list. protoc splits
+// parameters on commas, so bare items following one of these options are
+// rejoined into its value.
+var listOptions = []string{"build_tags", "adapters", "extra_interface", "extra_import"}
+
 // Options represents the plugin options
 type Options struct {
 	// PathsSourceRelative determines if the output files should use source-relative paths
@@ -13,6 +177,144 @@ type Options struct {
 	OutputPrefix string
 	// Editions enables support for protobuf editions
 	Editions bool
+	// Decompress generates gzip/deflate decoding of compressed request bodies
+	Decompress bool
+	// DecompressMaxBytes limits the decoded size of compressed request bodies (0 = default)
+	DecompressMaxBytes int64
+	// Binding generates reflection-based helpers that bind requests into proto messages
+	Binding bool
+	// ApplyDefaults makes BindRequest set unset fields to their declared default values
+	ApplyDefaults bool
+	// StrictContentType answers request bodies whose Content-Type is not in Marshalers with 415 Unsupported Media Type
+	StrictContentType bool
+	// EmitUnsetOptionals makes DefaultResponseEncoder write unset optional fields as null
+	EmitUnsetOptionals bool
+	// MediaTypeVendor generates the MediaTypes registry of application/vnd.<vendor>.* media types and Accept negotiation
+	MediaTypeVendor string
+	// MaxEdition is the newest edition files may use (EDITION_UNKNOWN = DefaultMaxEdition)
+	MaxEdition descriptor.Edition
+	// APIConfiguration is the path of a gateway-style API configuration file with extra HTTP rules
+	APIConfiguration string
+	// BuildTags is the //go:build expression stamped onto generated files
+	BuildTags string
+	// PackageName overrides the derived Go package name of every generated file
+	PackageName string
+	// FilePackageNames overrides the Go package name per proto file, taking precedence over PackageName
+	FilePackageNames map[string]string
+	// ImportAliases maps proto files to the Go import path ("path" or "path;name") of their message types
+	ImportAliases map[string]string
+	// Layout selects how generated code is split into files (LayoutSingle or LayoutSplit)
+	Layout string
+	// Doc generates a doc.go per output directory summarizing services and routes
+	Doc bool
+	// AutoOptions registers an OPTIONS responder for every path of a service's routes
+	AutoOptions bool
+	// SelfDescription makes the auto_options responders describe their path's routes, linking to its schema
+	SelfDescription bool
+	// DiscoveryURL is the OpenAPI document self-describing OPTIONS responses link to, DefaultDiscoveryURL when empty
+	DiscoveryURL string
+	// ConditionalGet answers conditional GET requests from handler-provided ResponseMeta
+	ConditionalGet bool
+	// UnitOfWork generates the WithUnitOfWork router option for running mutating routes in a UnitOfWork
+	UnitOfWork bool
+	// PropagateDeadline generates PropagateDeadline and makes clients send the deadline of their context
+	PropagateDeadline bool
+	// RateLimitHeaders generates RateLimit with the helpers writing and parsing its response headers
+	RateLimitHeaders bool
+	// Scaffold replaces the generated code with starter files of this kind (ScaffoldHandler)
+	Scaffold string
+	// ScaffoldDir is the output directory of scaffolded files; files already there are not overwritten
+	ScaffoldDir string
+	// ScaffoldPackage is the Go package name of scaffolded files (empty = DefaultScaffoldPackage)
+	ScaffoldPackage string
+	// ScaffoldImportPath is the Go import path of ScaffoldDir with scaffold=project or deploy (empty = parent of the generated package)
+	ScaffoldImportPath string
+	// Client generates a <Service>Client per service calling its routes over HTTP
+	Client bool
+	// GraphQL writes a <file>_http.graphql schema per proto file and generates a <Service>Resolver per service
+	GraphQL bool
+	// Mock generates New<Service>Mock handlers answering with example responses
+	Mock bool
+	// Chaos generates the Chaos fault-injection middleware
+	Chaos bool
+	// Recording generates RecordingTransport, recording and replaying exchanges with golden files
+	Recording bool
+	// Capture generates Capture, storing sampled request and response pairs for a debug endpoint
+	Capture bool
+	// LoadTest writes a load test per proto file for this tool (LoadTestK6 or LoadTestVegeta)
+	LoadTest string
+	// LoadTestBaseURL is the server the load test targets, DefaultLoadTestBaseURL when empty
+	LoadTestBaseURL string
+	// SLO writes a <file>_http.slo.yaml manifest of the (http_server.slo) objectives per proto file
+	SLO bool
+	// SLOPrometheus also writes <file>_http.slo.rules.yaml Prometheus recording and alerting rules
+	SLOPrometheus bool
+	// CLI writes a cobra command-line client per service to cmd/<service>cli/main.go
+	CLI bool
+	// TSClient writes a <file>_http.ts TypeScript client per proto file
+	TSClient bool
+	// GatewayOpenAPI writes a <file>_gateway.swagger.yaml per proto file for this cloud gateway (GatewayAWS or GatewayGCP)
+	GatewayOpenAPI string
+	// GatewayBackend is the base URL the gateway document routes requests to
+	GatewayBackend string
+	// Report writes a <file>_http.report.json per proto file with its route count and generated code size
+	Report bool
+	// PathValueSource generates a PathValue accessor reading path values from this router (PathValueStd, PathValueChi or PathValueGorilla)
+	PathValueSource string
+	// Adapters lists the router adapters (AdapterGin, AdapterFiber, AdapterChi, AdapterGorilla) to generate Routes implementations for
+	Adapters []string
+	// Router writes the adapter of this router (RouterChi or RouterGorilla) and a PathValue accessor reading from it to a <file>_http_<router>_adapter.pb.go file
+	Router string
+	// ErrorDetails generates WriteError and DetailError, answering errors with google.rpc standard error details
+	ErrorDetails bool
+	// Scope generates the WithScope router option for running every route in a request scope of a ScopeFactory
+	Scope bool
+	// RawPatterns passes route patterns to the mux untranslated and unchecked, instead of translating path templates and rejecting patterns outside the http.ServeMux grammar
+	RawPatterns bool
+	// PathPrefix is a static path, such as "/api", prepended to every route pattern
+	PathPrefix string
+	// DocDeprecated lists bindings marked with an "http: deprecated" directive in doc.go, which leaves them out otherwise
+	DocDeprecated bool
+	// Minimal strips explanatory comments from generated Go files, keeping the DO NOT EDIT header and directives
+	Minimal bool
+	// ServerTiming generates the ServerTiming middleware, adding a Server-Timing header with the phases of each request
+	ServerTiming bool
+	// UnexpectedBody selects how GET and DELETE routes without a body rule treat request bodies (UnexpectedBodyIgnore, the default, or UnexpectedBodyReject)
+	UnexpectedBody string
+	// StaticErrors generates WriteStaticError and JSON 404 and 405 responses for requests matching no route
+	StaticErrors bool
+	// StrictQuery makes BindRequest reject query parameters that name no field the query string may set
+	StrictQuery bool
+	// DecodeHelpers generates a Decode<Method>Request function per method binding an HTTP request into its request message
+	DecodeHelpers bool
+	// EmptyFiles writes a file holding only the package clause for each file to generate that declares no HTTP routes
+	EmptyFiles bool
+	// OpenAPI writes a <file>_http_openapi.yaml per proto file describing the generated routes and their messages in OpenAPI 3
+	OpenAPI bool
+	// RuntimeModule is the import path of a runtime module written alongside the generated code, holding the router types every generated package aliases
+	RuntimeModule string
+	// ExtraInterfaces are embedded in the generated handler interfaces, such as an organization-wide interface every handler implements
+	ExtraInterfaces []ExtraInterface
+	// ExtraImports are imported for their side effects by the generated files
+	ExtraImports []string
+	// RouteConfig generates <Service>RouteConfig and Register<Service>RoutesWithConfig for tuning individual routes at registration
+	RouteConfig bool
+	// OTelAttributes generates an AttrRoute<Method> OpenTelemetry http.route attribute for each route
+	OTelAttributes bool
+	// Validate validates bound request messages with the WithValidator router option, or RequestValidator, before they reach the handler
+	Validate bool
+	// ErrorHandler generates the WithErrorHandler router option answering the errors of generated code through an ErrorHandler
+	ErrorHandler bool
+	// GRPCStatus generates HTTPStatusFromCode and WriteGRPCError, answering errors with a gRPC status with the HTTP status of their code
+	GRPCStatus bool
+	// RouteChecksum generates RouteTable and RouteTableChecksum listing and hashing the routes of the file in registration order
+	RouteChecksum bool
+	// RuntimeImport aliases the router types of the generated packages to the runtime package of this repository, RuntimeImportPath
+	RuntimeImport bool
+	// MergeRouters generates a RouteProvider per service and MergeRouters, serving the routes of many providers on one router
+	MergeRouters bool
+	// ContentNegotiation accepts and writes application/x-protobuf besides JSON, negotiating responses by Accept, and generates the WithCodecs router option
+	ContentNegotiation bool
 }
 
 // ParseOptions parses the parameter string from protoc into an Options struct
@@ -23,16 +325,139 @@ func ParseOptions(parameter string) (*Options, error) {
 		return options, nil
 	}
 
-	params := strings.Split(parameter, ",")
+	params := splitParameters(parameter)
 	for _, p := range params {
 		if err := parseParameter(options, p); err != nil {
 			return nil, err
 		}
 	}
 
+	if options.Router != "" {
+		// router=chi and router=gorilla are the adapter of the router and the
+		// pathvalue_source reading from it, in a file of their own
+		if len(options.Adapters) > 0 || options.PathValueSource != "" {
+			return nil, fmt.Errorf("router=%s cannot be combined with adapters or pathvalue_source, which it sets", options.Router)
+		}
+		options.Adapters = []string{options.Router}
+		options.PathValueSource = options.Router
+	}
+
+	if options.RuntimeImport {
+		// The runtime package of this repository is a runtime module the
+		// plugin does not write
+		if options.RuntimeModule != "" {
+			return nil, fmt.Errorf("runtime_import cannot be combined with runtime_module")
+		}
+		options.RuntimeModule = RuntimeImportPath
+	}
+
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+
 	return options, nil
 }
 
+// splitParameters splits the protoc parameter string into key=value pairs,
+// keeping the items of list options such as build_tags=a,b together.
+func splitParameters(parameter string) []string {
+	var params []string
+	for _, p := range strings.Split(parameter, ",") {
+		if n := len(params); n > 0 && !strings.Contains(p, "=") {
+			key, _, _ := strings.Cut(params[n-1], "=")
+			if slices.Contains(listOptions, strings.TrimSpace(key)) {
+				params[n-1] += "," + p
+				continue
+			}
+		}
+		params = append(params, p)
+	}
+	return params
+}
+
+// validate checks that options which depend on each other are set together.
+func (o *Options) validate() error {
+	if o.ApplyDefaults && !o.Binding {
+		return fmt.Errorf("apply_defaults requires binding=true")
+	}
+	if o.StrictContentType && !o.Binding {
+		return fmt.Errorf("strict_content_type requires binding=true")
+	}
+	if o.StrictQuery && !o.Binding {
+		return fmt.Errorf("strict_query requires binding=true")
+	}
+	if o.DecodeHelpers && !o.Binding {
+		return fmt.Errorf("decode_helpers requires binding=true")
+	}
+	if o.Validate && !o.Binding {
+		return fmt.Errorf("validate requires binding=true")
+	}
+	if o.ErrorHandler && !o.Binding {
+		return fmt.Errorf("error_handler requires binding=true")
+	}
+	if o.EmitUnsetOptionals && !o.Binding {
+		return fmt.Errorf("emit_unset_optionals requires binding=true")
+	}
+	if o.MediaTypeVendor != "" && !o.Binding {
+		return fmt.Errorf("media_type_vendor requires binding=true")
+	}
+	if o.ContentNegotiation && !o.Binding {
+		return fmt.Errorf("content_negotiation requires binding=true")
+	}
+	if o.SelfDescription && !o.AutoOptions {
+		return fmt.Errorf("self_description requires auto_options=true")
+	}
+	if o.DiscoveryURL != "" && !o.SelfDescription {
+		return fmt.Errorf("discovery_url requires self_description=true")
+	}
+	if o.Scaffold != "" && o.ScaffoldDir == "" {
+		return fmt.Errorf("scaffold requires scaffold_dir, the plugin's output directory, so existing files are not overwritten")
+	}
+	if o.Client && !o.Binding {
+		return fmt.Errorf("client requires binding=true")
+	}
+	if o.GraphQL && !o.Client {
+		return fmt.Errorf("graphql requires client=true, whose clients the resolvers call")
+	}
+	if o.Mock && !o.Binding {
+		return fmt.Errorf("mock requires binding=true")
+	}
+	if o.CLI && !o.Client {
+		return fmt.Errorf("cli requires client=true, whose clients the commands call")
+	}
+	if o.LoadTestBaseURL != "" && o.LoadTest == "" {
+		return fmt.Errorf("loadtest_base_url requires loadtest")
+	}
+	if o.SLOPrometheus && !o.SLO {
+		return fmt.Errorf("slo_prometheus requires slo=true")
+	}
+	if o.GatewayOpenAPI != "" && o.GatewayBackend == "" {
+		return fmt.Errorf("gateway_openapi requires gateway_backend, the base URL of the service behind the gateway")
+	}
+	if o.GatewayBackend != "" && o.GatewayOpenAPI == "" {
+		return fmt.Errorf("gateway_backend requires gateway_openapi")
+	}
+	if o.RuntimeModule != "" {
+		// The shared RouteGroup cannot carry the state these options add to it
+		for _, option := range []struct {
+			name string
+			set  bool
+		}{{"unit_of_work", o.UnitOfWork}, {"scope", o.Scope}, {"server_timing", o.ServerTiming}, {"static_errors", o.StaticErrors},
+			{"error_handler", o.ErrorHandler}, {"content_negotiation", o.ContentNegotiation}} {
+			if option.set {
+				return fmt.Errorf("%s cannot be combined with %s, which changes the router of each package", o.runtimeOptionName(), option.name)
+			}
+		}
+	}
+	// chi.URLParam and mux.Vars keep working on routes registered through
+	// ChiRoutes and GorillaRoutes, the adapters of the routers they read
+	sameRouter := slices.Equal(o.Adapters, []string{o.PathValueSource})
+	if len(o.Adapters) > 0 && o.PathValueSource != "" && o.PathValueSource != PathValueStd && !sameRouter {
+		return fmt.Errorf("adapters set r.PathValue, so they require pathvalue_source=%s or no pathvalue_source", PathValueStd)
+	}
+	return nil
+}
+
 // parseParameter parses a single parameter key=value pair
 func parseParameter(options *Options, param string) error {
 	kv := strings.SplitN(param, "=", 2)
@@ -51,9 +476,228 @@ func parseParameter(options *Options, param string) error {
 		return nil
 	case "editions":
 		return applyEditionsOption(options, value)
+	case "decompress":
+		return applyBoolOption(&options.Decompress, key, value)
+	case "decompress_max_bytes":
+		return applyPositiveIntOption(&options.DecompressMaxBytes, key, value)
+	case "binding":
+		return applyBoolOption(&options.Binding, key, value)
+	case "apply_defaults":
+		return applyBoolOption(&options.ApplyDefaults, key, value)
+	case "strict_content_type":
+		return applyBoolOption(&options.StrictContentType, key, value)
+	case "emit_unset_optionals":
+		return applyBoolOption(&options.EmitUnsetOptionals, key, value)
+	case "media_type_vendor":
+		return applyMediaTypeVendorOption(options, value)
+	case "max_edition":
+		return applyMaxEditionOption(options, value)
+	case "grpc_api_configuration":
+		options.APIConfiguration = value
+		return nil
+	case "build_tags":
+		return applyBuildTagsOption(options, value)
+	case "package_name":
+		return applyPackageNameOption(options, value)
+	case "import_alias":
+		return applyImportAliasOption(options, value)
+	case "layout":
+		return applyLayoutOption(options, value)
+	case "doc":
+		return applyBoolOption(&options.Doc, key, value)
+	case "auto_options":
+		return applyBoolOption(&options.AutoOptions, key, value)
+	case "self_description":
+		return applyBoolOption(&options.SelfDescription, key, value)
+	case "discovery_url":
+		return applyDiscoveryURLOption(options, value)
+	case "conditional_get":
+		return applyBoolOption(&options.ConditionalGet, key, value)
+	case "unit_of_work":
+		return applyBoolOption(&options.UnitOfWork, key, value)
+	case "propagate_deadline":
+		return applyBoolOption(&options.PropagateDeadline, key, value)
+	case "rate_limit_headers":
+		return applyBoolOption(&options.RateLimitHeaders, key, value)
+	case "scaffold":
+		return applyScaffoldOption(options, value)
+	case "scaffold_dir":
+		options.ScaffoldDir = value
+		return nil
+	case "scaffold_import_path":
+		options.ScaffoldImportPath = strings.TrimSuffix(value, "/")
+		return nil
+	case "scaffold_package":
+		if !token.IsIdentifier(value) || value == "_" {
+			return fmt.Errorf("invalid scaffold_package option: %q is not a valid Go package name", value)
+		}
+		options.ScaffoldPackage = value
+		return nil
+	case "client":
+		return applyBoolOption(&options.Client, key, value)
+	case "graphql":
+		return applyBoolOption(&options.GraphQL, key, value)
+	case "mock":
+		return applyBoolOption(&options.Mock, key, value)
+	case "chaos":
+		return applyBoolOption(&options.Chaos, key, value)
+	case "recording":
+		return applyBoolOption(&options.Recording, key, value)
+	case "capture":
+		return applyBoolOption(&options.Capture, key, value)
+	case "loadtest":
+		return applyLoadTestOption(options, value)
+	case "loadtest_base_url":
+		return applyLoadTestBaseURLOption(options, value)
+	case "slo":
+		return applyBoolOption(&options.SLO, key, value)
+	case "slo_prometheus":
+		return applyBoolOption(&options.SLOPrometheus, key, value)
+	case "cli":
+		return applyBoolOption(&options.CLI, key, value)
+	case "ts_client":
+		return applyBoolOption(&options.TSClient, key, value)
+	case "gateway_openapi":
+		return applyGatewayOpenAPIOption(options, value)
+	case "gateway_backend":
+		return applyGatewayBackendOption(options, value)
+	case "report":
+		return applyBoolOption(&options.Report, key, value)
+	case "pathvalue_source":
+		return applyPathValueSourceOption(options, value)
+	case "adapters":
+		return applyAdaptersOption(options, value)
+	case "router":
+		return applyRouterOption(options, value)
+	case "error_details":
+		return applyBoolOption(&options.ErrorDetails, key, value)
+	case "scope":
+		return applyBoolOption(&options.Scope, key, value)
+	case "raw_patterns":
+		return applyBoolOption(&options.RawPatterns, key, value)
+	case "path_prefix":
+		return applyPathPrefixOption(options, value)
+	case "doc_deprecated":
+		return applyBoolOption(&options.DocDeprecated, key, value)
+	case "minimal":
+		return applyBoolOption(&options.Minimal, key, value)
+	case "server_timing":
+		return applyBoolOption(&options.ServerTiming, key, value)
+	case "unexpected_body":
+		return applyUnexpectedBodyOption(options, value)
+	case "static_errors":
+		return applyBoolOption(&options.StaticErrors, key, value)
+	case "strict_query":
+		return applyBoolOption(&options.StrictQuery, key, value)
+	case "decode_helpers":
+		return applyBoolOption(&options.DecodeHelpers, key, value)
+	case "empty_files":
+		return applyBoolOption(&options.EmptyFiles, key, value)
+	case "openapi":
+		return applyBoolOption(&options.OpenAPI, key, value)
+	case "runtime_module":
+		return applyRuntimeModuleOption(options, value)
+	case "extra_interface":
+		return applyExtraInterfaceOption(options, value)
+	case "extra_import":
+		return applyExtraImportOption(options, value)
+	case "route_config":
+		return applyBoolOption(&options.RouteConfig, key, value)
+	case "otel_attributes":
+		return applyBoolOption(&options.OTelAttributes, key, value)
+	case "validate":
+		return applyBoolOption(&options.Validate, key, value)
+	case "error_handler":
+		return applyBoolOption(&options.ErrorHandler, key, value)
+	case "grpc_status":
+		return applyBoolOption(&options.GRPCStatus, key, value)
+	case "route_checksum":
+		return applyBoolOption(&options.RouteChecksum, key, value)
+	case "runtime_import":
+		return applyBoolOption(&options.RuntimeImport, key, value)
+	case "merge_routers":
+		return applyBoolOption(&options.MergeRouters, key, value)
+	case "content_negotiation":
+		return applyBoolOption(&options.ContentNegotiation, key, value)
 	default:
-		return fmt.Errorf("unknown option: %s (valid options: paths, output_prefix, editions)", key)
+		return fmt.Errorf("unknown option: %s (valid options: %s)", key, strings.Join(optionNames, ", "))
+	}
+}
+
+// applyRuntimeModuleOption validates and applies the runtime_module option
+// value, an import path whose last element names the runtime package.
+func applyRuntimeModuleOption(options *Options, value string) error {
+	value = strings.TrimSuffix(value, "/")
+	if name := path.Base(value); !token.IsIdentifier(name) || name == "_" {
+		return fmt.Errorf("invalid runtime_module option: %q does not end in a valid Go package name", value)
+	}
+	options.RuntimeModule = value
+	return nil
+}
+
+// ExtraInterface is an interface the extra_interface option embeds in the
+// generated handler interfaces.
+type ExtraInterface struct {
+	// Import is the package declaring the interface, with an empty Path for
+	// an interface of the generated package.
+	Import GoImport
+	// Name is the name of the interface type, such as "Audited".
+	Name string
+}
+
+// String returns the interface as referred to from the generated code, such
+// as "httpx.Audited".
+func (e ExtraInterface) String() string {
+	if e.Import.Path == "" {
+		return e.Name
 	}
+	return e.Import.Name + "." + e.Name
+}
+
+// applyExtraInterfaceOption validates and applies the extra_interface option
+// value, a comma-separated list of interfaces written as path.Type,
+// path;name.Type when the package name is not the last element of its import
+// path, or Type for an interface of the generated package.
+func applyExtraInterfaceOption(options *Options, value string) error {
+	for _, ref := range strings.Split(value, ",") {
+		ref = strings.TrimSpace(ref)
+		var iface ExtraInterface
+		if i := strings.LastIndex(ref, "."); i >= 0 {
+			iface = ExtraInterface{Import: parseGoImport(ref[:i]), Name: ref[i+1:]}
+			if iface.Import.Path == "" || !token.IsIdentifier(iface.Import.Name) || !token.IsExported(iface.Name) {
+				return fmt.Errorf("invalid extra_interface option: %q is not an exported interface written as path.Type or path;name.Type", ref)
+			}
+		} else {
+			iface.Name = ref
+		}
+		if !token.IsIdentifier(iface.Name) {
+			return fmt.Errorf("invalid extra_interface option: %q is not an interface type", ref)
+		}
+		for _, prev := range options.ExtraInterfaces {
+			if prev.Name == iface.Name && prev != iface {
+				return fmt.Errorf("invalid extra_interface option: %s and %s would both be embedded as %s", prev, iface, iface.Name)
+			}
+		}
+		if !slices.Contains(options.ExtraInterfaces, iface) {
+			options.ExtraInterfaces = append(options.ExtraInterfaces, iface)
+		}
+	}
+	return nil
+}
+
+// applyExtraImportOption validates and applies the extra_import option value,
+// a comma-separated list of import paths.
+func applyExtraImportOption(options *Options, value string) error {
+	for _, importPath := range strings.Split(value, ",") {
+		importPath = strings.TrimSpace(importPath)
+		if importPath == "" || strings.ContainsAny(importPath, "\"; \t") {
+			return fmt.Errorf("invalid extra_import option: %q is not an import path", importPath)
+		}
+		if !slices.Contains(options.ExtraImports, importPath) {
+			options.ExtraImports = append(options.ExtraImports, importPath)
+		}
+	}
+	return nil
 }
 
 // applyPathsOption validates and applies the paths option value.
@@ -72,14 +716,303 @@ func applyPathsOption(options *Options, value string) error {
 
 // applyEditionsOption validates and applies the editions option value.
 func applyEditionsOption(options *Options, value string) error {
+	return applyBoolOption(&options.Editions, "editions", value)
+}
+
+// applyMaxEditionOption validates and applies the max_edition option value.
+func applyMaxEditionOption(options *Options, value string) error {
+	names := make([]string, 0, len(maxEditionValues))
+	for _, v := range maxEditionValues {
+		if v.name == value {
+			options.MaxEdition = v.edition
+			return nil
+		}
+		names = append(names, v.name)
+	}
+	return fmt.Errorf("unknown max_edition option: %s (valid values: %s)", value, strings.Join(names, ", "))
+}
+
+// applyBuildTagsOption validates and applies the build_tags option value. Each
+// comma-separated item is a tag or build expression, and all of them must hold.
+func applyBuildTagsOption(options *Options, value string) error {
+	var terms []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			terms = append(terms, "("+item+")")
+		}
+	}
+	if len(terms) == 0 {
+		return fmt.Errorf("invalid build_tags option: %s (must list at least one tag)", value)
+	}
+
+	expr, err := constraint.Parse("//go:build " + strings.Join(terms, " && "))
+	if err != nil {
+		return fmt.Errorf("invalid build_tags option: %s (%v)", value, err)
+	}
+	options.BuildTags = expr.String()
+	return nil
+}
+
+// applyPackageNameOption validates and applies the package_name option value,
+// either a package name for all files or "file.proto=name" for a single file.
+func applyPackageNameOption(options *Options, value string) error {
+	file, name, perFile := strings.Cut(value, "=")
+	if !perFile {
+		file, name = "", value
+	}
+	if !token.IsIdentifier(name) || name == "_" {
+		return fmt.Errorf("invalid package_name option: %s (%q is not a valid Go package name)", value, name)
+	}
+
+	if !perFile {
+		options.PackageName = name
+		return nil
+	}
+	if file == "" {
+		return fmt.Errorf("invalid package_name option: %s (missing proto file)", value)
+	}
+	if options.FilePackageNames == nil {
+		options.FilePackageNames = map[string]string{}
+	}
+	options.FilePackageNames[file] = name
+	return nil
+}
+
+// applyImportAliasOption validates and applies an import_alias option value of
+// the form "file.proto=import/path" or "file.proto=import/path;name", mirroring
+// protoc-gen-go's M flags.
+func applyImportAliasOption(options *Options, value string) error {
+	file, importPath, ok := strings.Cut(value, "=")
+	if !ok || file == "" || importPath == "" {
+		return fmt.Errorf("invalid import_alias option: %s (want file.proto=import/path[;name])", value)
+	}
+	if _, name, ok := strings.Cut(importPath, ";"); ok && (!token.IsIdentifier(name) || name == "_") {
+		return fmt.Errorf("invalid import_alias option: %s (%q is not a valid Go package name)", value, name)
+	}
+	if options.ImportAliases == nil {
+		options.ImportAliases = map[string]string{}
+	}
+	options.ImportAliases[file] = importPath
+	return nil
+}
+
+// maxEdition returns the newest edition files may use.
+func (o *Options) maxEdition() descriptor.Edition {
+	if o == nil || o.MaxEdition == descriptor.Edition_EDITION_UNKNOWN {
+		return DefaultMaxEdition
+	}
+	return o.MaxEdition
+}
+
+// applyLayoutOption validates and applies the layout option value.
+func applyLayoutOption(options *Options, value string) error {
+	switch value {
+	case LayoutSingle, LayoutSplit:
+		options.Layout = value
+		return nil
+	default:
+		return fmt.Errorf("unknown layout option: %s (valid values: %s, %s)", value, LayoutSingle, LayoutSplit)
+	}
+}
+
+// applyUnexpectedBodyOption validates and applies the unexpected_body option value.
+func applyUnexpectedBodyOption(options *Options, value string) error {
+	switch value {
+	case UnexpectedBodyReject, UnexpectedBodyIgnore:
+		options.UnexpectedBody = value
+		return nil
+	default:
+		return fmt.Errorf("unknown unexpected_body option: %s (valid values: %s, %s)", value, UnexpectedBodyReject, UnexpectedBodyIgnore)
+	}
+}
+
+// applyScaffoldOption validates and applies the scaffold option value.
+func applyScaffoldOption(options *Options, value string) error {
+	switch value {
+	case ScaffoldHandler, ScaffoldProject, ScaffoldDeploy:
+		options.Scaffold = value
+		return nil
+	default:
+		return fmt.Errorf("unknown scaffold option: %s (valid values: %s, %s, %s)", value, ScaffoldHandler, ScaffoldProject, ScaffoldDeploy)
+	}
+}
+
+// applyLoadTestOption validates and applies the loadtest option value.
+func applyLoadTestOption(options *Options, value string) error {
+	switch value {
+	case LoadTestK6, LoadTestVegeta:
+		options.LoadTest = value
+		return nil
+	default:
+		return fmt.Errorf("unknown loadtest option: %s (valid values: %s, %s)", value, LoadTestK6, LoadTestVegeta)
+	}
+}
+
+// applyLoadTestBaseURLOption validates and applies the loadtest_base_url
+// option value, an absolute http or https URL.
+func applyLoadTestBaseURLOption(options *Options, value string) error {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid loadtest_base_url option: %s (must be an http or https URL)", value)
+	}
+	options.LoadTestBaseURL = value
+	return nil
+}
+
+// applyDiscoveryURLOption validates and applies the discovery_url option
+// value, an absolute path or an http or https URL without a fragment.
+func applyDiscoveryURLOption(options *Options, value string) error {
+	u, err := url.Parse(value)
+	valid := err == nil && u.Fragment == "" &&
+		((u.Scheme == "" && u.Host == "" && strings.HasPrefix(u.Path, "/")) || ((u.Scheme == "http" || u.Scheme == "https") && u.Host != ""))
+	if !valid {
+		return fmt.Errorf("invalid discovery_url option: %s (must be an absolute path or an http or https URL without a fragment)", value)
+	}
+	options.DiscoveryURL = value
+	return nil
+}
+
+// applyMediaTypeVendorOption validates and applies the media_type_vendor
+// option value, the vendor prefix of media types such as "myco" in
+// application/vnd.myco.task.v1+json.
+func applyMediaTypeVendorOption(options *Options, value string) error {
+	valid := value != "" && !strings.HasPrefix(value, ".") && !strings.HasSuffix(value, ".")
+	for _, r := range value {
+		valid = valid && (r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '.' || r == '-')
+	}
+	if !valid {
+		return fmt.Errorf("invalid media_type_vendor option: %s (must be lower-case letters, digits, '.' and '-')", value)
+	}
+	options.MediaTypeVendor = value
+	return nil
+}
+
+// applyGatewayOpenAPIOption validates and applies the gateway_openapi option value.
+func applyGatewayOpenAPIOption(options *Options, value string) error {
+	switch value {
+	case GatewayAWS, GatewayGCP:
+		options.GatewayOpenAPI = value
+		return nil
+	default:
+		return fmt.Errorf("unknown gateway_openapi option: %s (valid values: %s, %s)", value, GatewayAWS, GatewayGCP)
+	}
+}
+
+// applyGatewayBackendOption validates and applies the gateway_backend option
+// value, an absolute http or https URL.
+func applyGatewayBackendOption(options *Options, value string) error {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid gateway_backend option: %s (must be an http or https URL)", value)
+	}
+	options.GatewayBackend = value
+	return nil
+}
+
+// applyPathPrefixOption validates and applies the path_prefix option value,
+// normalized to a cleaned path with a leading slash and no trailing slash, so
+// "api/" and "/api" both prefix routes with "/api".
+func applyPathPrefixOption(options *Options, value string) error {
+	if strings.ContainsAny(value, "{}?# \t") {
+		return fmt.Errorf("invalid path_prefix option: %s (must be a static path, such as /api)", value)
+	}
+	options.PathPrefix = strings.TrimSuffix(path.Clean("/"+value), "/")
+	return nil
+}
+
+// applyPathValueSourceOption validates and applies the pathvalue_source option value.
+func applyPathValueSourceOption(options *Options, value string) error {
+	switch value {
+	case PathValueStd, PathValueChi, PathValueGorilla:
+		options.PathValueSource = value
+		return nil
+	default:
+		return fmt.Errorf("unknown pathvalue_source option: %s (valid values: %s, %s, %s)", value, PathValueStd, PathValueChi, PathValueGorilla)
+	}
+}
+
+// applyRouterOption validates and applies the router option value.
+func applyRouterOption(options *Options, value string) error {
+	switch value {
+	case RouterChi, RouterGorilla:
+		options.Router = value
+		return nil
+	default:
+		return fmt.Errorf("unknown router option: %s (valid values: %s, %s)", value, RouterChi, RouterGorilla)
+	}
+}
+
+// applyAdaptersOption validates and applies the adapters option value, a
+// comma-separated list of router adapters.
+func applyAdaptersOption(options *Options, value string) error {
+	for _, adapter := range strings.Split(value, ",") {
+		adapter = strings.TrimSpace(adapter)
+		switch adapter {
+		case AdapterGin, AdapterFiber, AdapterChi, AdapterGorilla:
+			if !slices.Contains(options.Adapters, adapter) {
+				options.Adapters = append(options.Adapters, adapter)
+			}
+		default:
+			return fmt.Errorf("unknown adapters option: %s (valid values: %s, %s, %s, %s)", adapter, AdapterGin, AdapterFiber, AdapterChi, AdapterGorilla)
+		}
+	}
+	return nil
+}
+
+// RuntimePackage returns the name of the runtime_module package, the last
+// element of its import path.
+func (o Options) RuntimePackage() string {
+	return path.Base(o.RuntimeModule)
+}
+
+// runtimeOptionName returns the name of the option the router types of the
+// generated packages come from, runtime_import or runtime_module.
+func (o Options) runtimeOptionName() string {
+	if o.RuntimeImport {
+		return "runtime_import"
+	}
+	return "runtime_module"
+}
+
+// NegotiatesResponses reports whether responses are written in the media type
+// negotiated by Accept, with media_type_vendor or content_negotiation.
+func (o Options) NegotiatesResponses() bool {
+	return o.MediaTypeVendor != "" || o.ContentNegotiation
+}
+
+// HasAdapter reports whether the router adapter is generated.
+func (o Options) HasAdapter(adapter string) bool {
+	return slices.Contains(o.Adapters, adapter)
+}
+
+// scaffoldPackage returns the Go package name of scaffolded files.
+func (o *Options) scaffoldPackage() string {
+	if o.ScaffoldPackage != "" {
+		return o.ScaffoldPackage
+	}
+	return DefaultScaffoldPackage
+}
+
+// applyBoolOption validates a true/false option value and stores it in dst.
+func applyBoolOption(dst *bool, key, value string) error {
 	switch value {
 	case "true":
-		options.Editions = true
+		*dst = true
 		return nil
 	case "false":
-		options.Editions = false
+		*dst = false
 		return nil
 	default:
-		return fmt.Errorf("unknown editions option: %s (valid values: true, false)", value)
+		return fmt.Errorf("unknown %s option: %s (valid values: true, false)", key, value)
+	}
+}
+
+// applyPositiveIntOption validates a positive integer option value and stores it in dst.
+func applyPositiveIntOption(dst *int64, key, value string) error {
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n <= 0 {
+		return fmt.Errorf("invalid %s option: %s (must be a positive integer)", key, value)
 	}
+	*dst = n
+	return nil
 }
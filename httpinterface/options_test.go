@@ -0,0 +1,721 @@
+package httpinterface
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+)
+
+// TestParseOptionsFeatureFlags tests parsing of options that toggle optional generated code
+func TestParseOptionsFeatureFlags(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		parameter      string
+		check          func(*Options) bool
+		wantErrContain string
+	}{
+		{
+			name:      "decompress=true",
+			parameter: "decompress=true",
+			check:     func(o *Options) bool { return o.Decompress },
+		},
+		{
+			name:      "decompress=false",
+			parameter: "decompress=false",
+			check:     func(o *Options) bool { return !o.Decompress },
+		},
+		{
+			name:           "invalid decompress value",
+			parameter:      "decompress=yes",
+			wantErrContain: "unknown decompress option",
+		},
+		{
+			name:      "decompress with max bytes",
+			parameter: "decompress=true,decompress_max_bytes=1048576",
+			check:     func(o *Options) bool { return o.Decompress && o.DecompressMaxBytes == 1048576 },
+		},
+		{
+			name:           "non-numeric decompress_max_bytes",
+			parameter:      "decompress_max_bytes=lots",
+			wantErrContain: "must be a positive integer",
+		},
+		{
+			name:           "zero decompress_max_bytes",
+			parameter:      "decompress_max_bytes=0",
+			wantErrContain: "must be a positive integer",
+		},
+		{
+			name:      "binding=true",
+			parameter: "binding=true",
+			check:     func(o *Options) bool { return o.Binding },
+		},
+		{
+			name:           "invalid binding value",
+			parameter:      "binding=1",
+			wantErrContain: "unknown binding option",
+		},
+		{
+			name:      "apply_defaults with binding",
+			parameter: "binding=true,apply_defaults=true",
+			check:     func(o *Options) bool { return o.Binding && o.ApplyDefaults },
+		},
+		{
+			name:           "apply_defaults without binding",
+			parameter:      "apply_defaults=true",
+			wantErrContain: "apply_defaults requires binding=true",
+		},
+		{
+			name:      "strict_content_type with binding",
+			parameter: "binding=true,strict_content_type=true",
+			check:     func(o *Options) bool { return o.Binding && o.StrictContentType },
+		},
+		{
+			name:           "strict_content_type without binding",
+			parameter:      "strict_content_type=true",
+			wantErrContain: "strict_content_type requires binding=true",
+		},
+		{
+			name:      "emit_unset_optionals with binding",
+			parameter: "binding=true,emit_unset_optionals=true",
+			check:     func(o *Options) bool { return o.Binding && o.EmitUnsetOptionals },
+		},
+		{
+			name:           "emit_unset_optionals without binding",
+			parameter:      "emit_unset_optionals=true",
+			wantErrContain: "emit_unset_optionals requires binding=true",
+		},
+		{
+			name:      "media_type_vendor with binding",
+			parameter: "binding=true,media_type_vendor=myco",
+			check:     func(o *Options) bool { return o.MediaTypeVendor == "myco" },
+		},
+		{
+			name:           "media_type_vendor without binding",
+			parameter:      "media_type_vendor=myco",
+			wantErrContain: "media_type_vendor requires binding=true",
+		},
+		{
+			name:           "invalid media_type_vendor",
+			parameter:      "binding=true,media_type_vendor=MyCo",
+			wantErrContain: "invalid media_type_vendor option: MyCo",
+		},
+		{
+			name:      "max_edition 2024",
+			parameter: "max_edition=2024",
+			check:     func(o *Options) bool { return o.MaxEdition == descriptor.Edition_EDITION_2024 },
+		},
+		{
+			name:      "max_edition defaults to 2023",
+			parameter: "binding=true",
+			check:     func(o *Options) bool { return o.maxEdition() == descriptor.Edition_EDITION_2023 },
+		},
+		{
+			name:           "invalid max_edition",
+			parameter:      "max_edition=2022",
+			wantErrContain: "unknown max_edition option: 2022 (valid values: 2023, 2024)",
+		},
+		{
+			name:      "build_tags items are joined with &&",
+			parameter: "build_tags=integration,!windows",
+			check:     func(o *Options) bool { return o.BuildTags == "integration && !windows" },
+		},
+		{
+			name:      "build_tags followed by other options",
+			parameter: "build_tags=linux || darwin,cgo,paths=source_relative",
+			check: func(o *Options) bool {
+				return o.BuildTags == "(linux || darwin) && cgo" && o.PathsSourceRelative
+			},
+		},
+		{
+			name:           "build_tags with invalid expression",
+			parameter:      "build_tags=integration,!",
+			wantErrContain: "invalid build_tags option: integration,!",
+		},
+		{
+			name:           "empty build_tags",
+			parameter:      "build_tags=",
+			wantErrContain: "invalid build_tags option:  (must list at least one tag)",
+		},
+		{
+			name:           "bare item after non-list option",
+			parameter:      "paths=source_relative,integration",
+			wantErrContain: "invalid parameter: integration",
+		},
+		{
+			name:      "package_name for all files",
+			parameter: "package_name=myhttp",
+			check:     func(o *Options) bool { return o.PackageName == "myhttp" && o.FilePackageNames == nil },
+		},
+		{
+			name:      "package_name per file",
+			parameter: "package_name=billing/v1/billing.proto=billinghttp",
+			check: func(o *Options) bool {
+				return o.PackageName == "" && o.FilePackageNames["billing/v1/billing.proto"] == "billinghttp"
+			},
+		},
+		{
+			name:           "package_name that is not an identifier",
+			parameter:      "package_name=my-http",
+			wantErrContain: `invalid package_name option: my-http ("my-http" is not a valid Go package name)`,
+		},
+		{
+			name:           "package_name that is a keyword",
+			parameter:      "package_name=a.proto=func",
+			wantErrContain: `"func" is not a valid Go package name`,
+		},
+		{
+			name:           "package_name per file without file",
+			parameter:      "package_name==myhttp",
+			wantErrContain: "invalid package_name option: =myhttp (missing proto file)",
+		},
+		{
+			name:      "import_alias mappings accumulate",
+			parameter: "import_alias=a.proto=example.com/a,import_alias=b/b.proto=example.com/b;bpb",
+			check: func(o *Options) bool {
+				return o.ImportAliases["a.proto"] == "example.com/a" && o.ImportAliases["b/b.proto"] == "example.com/b;bpb"
+			},
+		},
+		{
+			name:           "import_alias without import path",
+			parameter:      "import_alias=a.proto",
+			wantErrContain: "invalid import_alias option: a.proto (want file.proto=import/path[;name])",
+		},
+		{
+			name:           "import_alias with invalid package name",
+			parameter:      "import_alias=a.proto=example.com/a;a-pb",
+			wantErrContain: `"a-pb" is not a valid Go package name`,
+		},
+		{
+			name:      "layout split",
+			parameter: "layout=split",
+			check:     func(o *Options) bool { return o.Layout == LayoutSplit },
+		},
+		{
+			name:           "unknown layout",
+			parameter:      "layout=multi",
+			wantErrContain: "unknown layout option: multi (valid values: single, split)",
+		},
+		{
+			name:      "doc",
+			parameter: "doc=true",
+			check:     func(o *Options) bool { return o.Doc },
+		},
+		{
+			name:      "auto options",
+			parameter: "auto_options=true",
+			check:     func(o *Options) bool { return o.AutoOptions },
+		},
+		{
+			name:      "self description",
+			parameter: "auto_options=true,self_description=true,discovery_url=https://tasks.example.com/openapi.yaml",
+			check: func(o *Options) bool {
+				return o.SelfDescription && o.DiscoveryURL == "https://tasks.example.com/openapi.yaml"
+			},
+		},
+		{
+			name:           "self description without auto options",
+			parameter:      "self_description=true",
+			wantErrContain: "self_description requires auto_options=true",
+		},
+		{
+			name:           "discovery url without self description",
+			parameter:      "auto_options=true,discovery_url=/openapi.json",
+			wantErrContain: "discovery_url requires self_description=true",
+		},
+		{
+			name:           "relative discovery url",
+			parameter:      "auto_options=true,self_description=true,discovery_url=openapi.json",
+			wantErrContain: "invalid discovery_url option: openapi.json",
+		},
+		{
+			name:      "conditional get",
+			parameter: "conditional_get=true",
+			check:     func(o *Options) bool { return o.ConditionalGet },
+		},
+		{
+			name:      "unit of work",
+			parameter: "unit_of_work=true",
+			check:     func(o *Options) bool { return o.UnitOfWork },
+		},
+		{
+			name:      "propagate deadline",
+			parameter: "propagate_deadline=true",
+			check:     func(o *Options) bool { return o.PropagateDeadline },
+		},
+		{
+			name:      "rate limit headers",
+			parameter: "rate_limit_headers=true",
+			check:     func(o *Options) bool { return o.RateLimitHeaders },
+		},
+		{
+			name:      "scaffold handler",
+			parameter: "scaffold=handler,scaffold_dir=internal/handler,scaffold_package=api",
+			check: func(o *Options) bool {
+				return o.Scaffold == ScaffoldHandler && o.ScaffoldDir == "internal/handler" && o.scaffoldPackage() == "api"
+			},
+		},
+		{
+			name:      "scaffold project",
+			parameter: "scaffold=project,scaffold_dir=.,scaffold_import_path=example.com/tasks/",
+			check: func(o *Options) bool {
+				return o.Scaffold == ScaffoldProject && o.ScaffoldImportPath == "example.com/tasks"
+			},
+		},
+		{
+			name:      "scaffold deploy",
+			parameter: "scaffold=deploy,scaffold_dir=.",
+			check:     func(o *Options) bool { return o.Scaffold == ScaffoldDeploy },
+		},
+		{
+			name:           "scaffold without directory",
+			parameter:      "scaffold=handler",
+			wantErrContain: "scaffold requires scaffold_dir",
+		},
+		{
+			name:           "unknown scaffold",
+			parameter:      "scaffold=everything,scaffold_dir=out",
+			wantErrContain: "unknown scaffold option: everything",
+		},
+		{
+			name:           "invalid scaffold package",
+			parameter:      "scaffold=handler,scaffold_dir=out,scaffold_package=my-handlers",
+			wantErrContain: "invalid scaffold_package option",
+		},
+		{
+			name:      "graphql with client",
+			parameter: "binding=true,client=true,graphql=true",
+			check:     func(o *Options) bool { return o.Client && o.GraphQL },
+		},
+		{
+			name:           "client without binding",
+			parameter:      "client=true",
+			wantErrContain: "client requires binding=true",
+		},
+		{
+			name:           "graphql without client",
+			parameter:      "binding=true,graphql=true",
+			wantErrContain: "graphql requires client=true",
+		},
+		{
+			name:      "cli with client",
+			parameter: "binding=true,client=true,cli=true",
+			check:     func(o *Options) bool { return o.Client && o.CLI },
+		},
+		{
+			name:           "cli without client",
+			parameter:      "binding=true,cli=true",
+			wantErrContain: "cli requires client=true",
+		},
+		{
+			name:      "mock with binding",
+			parameter: "binding=true,mock=true",
+			check:     func(o *Options) bool { return o.Mock },
+		},
+		{
+			name:           "mock without binding",
+			parameter:      "mock=true",
+			wantErrContain: "mock requires binding=true",
+		},
+		{
+			name:      "chaos",
+			parameter: "chaos=true",
+			check:     func(o *Options) bool { return o.Chaos },
+		},
+		{
+			name:      "recording",
+			parameter: "recording=true",
+			check:     func(o *Options) bool { return o.Recording },
+		},
+		{
+			name:      "capture",
+			parameter: "capture=true",
+			check:     func(o *Options) bool { return o.Capture },
+		},
+		{
+			name:      "k6 load test",
+			parameter: "loadtest=k6,loadtest_base_url=https://tasks.example.com",
+			check: func(o *Options) bool {
+				return o.LoadTest == LoadTestK6 && o.LoadTestBaseURL == "https://tasks.example.com"
+			},
+		},
+		{
+			name:      "report",
+			parameter: "report=true",
+			check:     func(o *Options) bool { return o.Report },
+		},
+		{
+			name:      "slo with prometheus rules",
+			parameter: "slo=true,slo_prometheus=true",
+			check:     func(o *Options) bool { return o.SLO && o.SLOPrometheus },
+		},
+		{
+			name:           "slo prometheus without slo",
+			parameter:      "slo_prometheus=true",
+			wantErrContain: "slo_prometheus requires slo=true",
+		},
+		{
+			name:           "unknown load-testing tool",
+			parameter:      "loadtest=jmeter",
+			wantErrContain: "unknown loadtest option: jmeter (valid values: k6, vegeta)",
+		},
+		{
+			name:           "load test base URL without load test",
+			parameter:      "loadtest_base_url=https://tasks.example.com",
+			wantErrContain: "loadtest_base_url requires loadtest",
+		},
+		{
+			name:      "typescript client",
+			parameter: "ts_client=true",
+			check:     func(o *Options) bool { return o.TSClient },
+		},
+		{
+			name:      "gateway openapi",
+			parameter: "gateway_openapi=gcp,gateway_backend=https://tasks.example.com",
+			check: func(o *Options) bool {
+				return o.GatewayOpenAPI == GatewayGCP && o.GatewayBackend == "https://tasks.example.com"
+			},
+		},
+		{
+			name:           "gateway openapi without backend",
+			parameter:      "gateway_openapi=aws",
+			wantErrContain: "gateway_openapi requires gateway_backend",
+		},
+		{
+			name:           "unknown gateway",
+			parameter:      "gateway_openapi=azure,gateway_backend=https://tasks.example.com",
+			wantErrContain: "unknown gateway_openapi option: azure",
+		},
+		{
+			name:           "relative gateway backend",
+			parameter:      "gateway_openapi=aws,gateway_backend=tasks.example.com",
+			wantErrContain: "invalid gateway_backend option",
+		},
+		{
+			name:      "pathvalue source",
+			parameter: "pathvalue_source=chi",
+			check:     func(o *Options) bool { return o.PathValueSource == PathValueChi },
+		},
+		{
+			name:           "unknown pathvalue source",
+			parameter:      "pathvalue_source=echo",
+			wantErrContain: "unknown pathvalue_source option: echo (valid values: std, chi, gorilla)",
+		},
+		{
+			name:      "adapters",
+			parameter: "adapters=gin,fiber,gin,binding=true",
+			check: func(o *Options) bool {
+				return slices.Equal(o.Adapters, []string{AdapterGin, AdapterFiber}) && o.Binding
+			},
+		},
+		{
+			name:           "unknown adapter",
+			parameter:      "adapters=gin,echo",
+			wantErrContain: "unknown adapters option: echo (valid values: gin, fiber, chi, gorilla)",
+		},
+		{
+			name:           "adapters with another pathvalue source",
+			parameter:      "adapters=gin,pathvalue_source=chi",
+			wantErrContain: "adapters set r.PathValue, so they require pathvalue_source=std",
+		},
+		{
+			name:      "chi adapter with chi pathvalue source",
+			parameter: "adapters=chi,pathvalue_source=chi",
+			check: func(o *Options) bool {
+				return slices.Equal(o.Adapters, []string{AdapterChi}) && o.PathValueSource == PathValueChi
+			},
+		},
+		{
+			name:      "gorilla adapter with gorilla pathvalue source",
+			parameter: "adapters=gorilla,pathvalue_source=gorilla",
+			check: func(o *Options) bool {
+				return slices.Equal(o.Adapters, []string{AdapterGorilla}) && o.PathValueSource == PathValueGorilla
+			},
+		},
+		{
+			name:           "gorilla adapter with chi pathvalue source",
+			parameter:      "adapters=gorilla,pathvalue_source=chi",
+			wantErrContain: "adapters set r.PathValue, so they require pathvalue_source=std",
+		},
+		{
+			name:           "chi and gin adapters with chi pathvalue source",
+			parameter:      "adapters=chi,gin,pathvalue_source=chi",
+			wantErrContain: "adapters set r.PathValue, so they require pathvalue_source=std",
+		},
+		{
+			name:      "router",
+			parameter: "router=gorilla",
+			check: func(o *Options) bool {
+				return o.Router == RouterGorilla && slices.Equal(o.Adapters, []string{AdapterGorilla}) && o.PathValueSource == PathValueGorilla
+			},
+		},
+		{
+			name:           "unknown router",
+			parameter:      "router=gin",
+			wantErrContain: "unknown router option: gin (valid values: chi, gorilla)",
+		},
+		{
+			name:           "router with adapters",
+			parameter:      "router=chi,adapters=gin",
+			wantErrContain: "router=chi cannot be combined with adapters or pathvalue_source, which it sets",
+		},
+		{
+			name:      "error details",
+			parameter: "error_details=true",
+			check:     func(o *Options) bool { return o.ErrorDetails },
+		},
+		{
+			name:      "scope",
+			parameter: "scope=true",
+			check:     func(o *Options) bool { return o.Scope },
+		},
+		{
+			name:      "raw patterns",
+			parameter: "raw_patterns=true",
+			check:     func(o *Options) bool { return o.RawPatterns },
+		},
+		{
+			name:      "path prefix",
+			parameter: "path_prefix=/api",
+			check:     func(o *Options) bool { return o.PathPrefix == "/api" },
+		},
+		{
+			name:      "path prefix normalized",
+			parameter: "path_prefix=api//v2/",
+			check:     func(o *Options) bool { return o.PathPrefix == "/api/v2" },
+		},
+		{
+			name:      "root path prefix",
+			parameter: "path_prefix=/",
+			check:     func(o *Options) bool { return o.PathPrefix == "" },
+		},
+		{
+			name:           "path prefix with a wildcard",
+			parameter:      "path_prefix=/{tenant}",
+			wantErrContain: "invalid path_prefix option: /{tenant} (must be a static path, such as /api)",
+		},
+		{
+			name:      "doc deprecated",
+			parameter: "doc_deprecated=true",
+			check:     func(o *Options) bool { return o.DocDeprecated },
+		},
+		{
+			name:      "minimal",
+			parameter: "minimal=true",
+			check:     func(o *Options) bool { return o.Minimal },
+		},
+		{
+			name:      "server timing",
+			parameter: "server_timing=true",
+			check:     func(o *Options) bool { return o.ServerTiming },
+		},
+		{
+			name:      "unexpected body",
+			parameter: "unexpected_body=ignore",
+			check:     func(o *Options) bool { return o.UnexpectedBody == UnexpectedBodyIgnore },
+		},
+		{
+			name:           "unknown unexpected body",
+			parameter:      "unexpected_body=drop",
+			wantErrContain: "unknown unexpected_body option: drop (valid values: reject, ignore)",
+		},
+		{
+			name:      "static errors",
+			parameter: "static_errors=true",
+			check:     func(o *Options) bool { return o.StaticErrors },
+		},
+		{
+			name:      "strict query",
+			parameter: "strict_query=true,binding=true",
+			check:     func(o *Options) bool { return o.StrictQuery && o.Binding },
+		},
+		{
+			name:           "strict query without binding",
+			parameter:      "strict_query=true",
+			wantErrContain: "strict_query requires binding=true",
+		},
+		{
+			name:      "decode helpers",
+			parameter: "decode_helpers=true,binding=true",
+			check:     func(o *Options) bool { return o.DecodeHelpers },
+		},
+		{
+			name:           "decode helpers without binding",
+			parameter:      "decode_helpers=true",
+			wantErrContain: "decode_helpers requires binding=true",
+		},
+		{
+			name:      "empty files",
+			parameter: "empty_files=true",
+			check:     func(o *Options) bool { return o.EmptyFiles },
+		},
+		{
+			name:      "openapi",
+			parameter: "openapi=true",
+			check:     func(o *Options) bool { return o.OpenAPI },
+		},
+		{
+			name:      "runtime module",
+			parameter: "runtime_module=example.com/api/httpserverts/",
+			check: func(o *Options) bool {
+				return o.RuntimeModule == "example.com/api/httpserverts" && o.RuntimePackage() == "httpserverts"
+			},
+		},
+		{
+			name:           "runtime module without package name",
+			parameter:      "runtime_module=example.com/api/http-server",
+			wantErrContain: `invalid runtime_module option: "example.com/api/http-server" does not end in a valid Go package name`,
+		},
+		{
+			name:           "runtime module with unit of work",
+			parameter:      "runtime_module=example.com/api/httpserverts,unit_of_work=true",
+			wantErrContain: "runtime_module cannot be combined with unit_of_work",
+		},
+		{
+			name:      "extra interfaces",
+			parameter: "extra_interface=mycorp.dev/httpx.Audited,mycorp.dev/http-x;httpx.Traced,Local,mycorp.dev/httpx.Audited",
+			check: func(o *Options) bool {
+				return slices.Equal(o.ExtraInterfaces, []ExtraInterface{
+					{Import: GoImport{Path: "mycorp.dev/httpx", Name: "httpx"}, Name: "Audited"},
+					{Import: GoImport{Path: "mycorp.dev/http-x", Name: "httpx"}, Name: "Traced"},
+					{Name: "Local"},
+				})
+			},
+		},
+		{
+			name:           "extra interface not exported",
+			parameter:      "extra_interface=mycorp.dev/httpx.audited",
+			wantErrContain: `invalid extra_interface option: "mycorp.dev/httpx.audited" is not an exported interface`,
+		},
+		{
+			name:           "extra interfaces embedded under the same name",
+			parameter:      "extra_interface=mycorp.dev/httpx.Audited,example.com/audit.Audited",
+			wantErrContain: "invalid extra_interface option: httpx.Audited and audit.Audited would both be embedded as Audited",
+		},
+		{
+			name:      "extra imports",
+			parameter: "extra_import=mycorp.dev/httpx/register,embed,embed",
+			check: func(o *Options) bool {
+				return slices.Equal(o.ExtraImports, []string{"mycorp.dev/httpx/register", "embed"})
+			},
+		},
+		{
+			name:           "extra import with quote",
+			parameter:      `extra_import=mycorp.dev/"httpx"`,
+			wantErrContain: `invalid extra_import option: "mycorp.dev/\"httpx\"" is not an import path`,
+		},
+		{
+			name:      "route config",
+			parameter: "route_config=true",
+			check:     func(o *Options) bool { return o.RouteConfig },
+		},
+		{
+			name:      "otel attributes",
+			parameter: "otel_attributes=true",
+			check:     func(o *Options) bool { return o.OTelAttributes },
+		},
+		{
+			name:      "validate",
+			parameter: "validate=true,binding=true",
+			check:     func(o *Options) bool { return o.Validate },
+		},
+		{
+			name:           "validate without binding",
+			parameter:      "validate=true",
+			wantErrContain: "validate requires binding=true",
+		},
+		{
+			name:      "error handler",
+			parameter: "error_handler=true,binding=true",
+			check:     func(o *Options) bool { return o.ErrorHandler },
+		},
+		{
+			name:           "error handler without binding",
+			parameter:      "error_handler=true",
+			wantErrContain: "error_handler requires binding=true",
+		},
+		{
+			name:           "error handler with runtime module",
+			parameter:      "error_handler=true,binding=true,runtime_module=example.com/api/httpserverts",
+			wantErrContain: "runtime_module cannot be combined with error_handler",
+		},
+		{
+			name:      "grpc status",
+			parameter: "grpc_status=true",
+			check:     func(o *Options) bool { return o.GRPCStatus },
+		},
+		{
+			name:      "route checksum",
+			parameter: "route_checksum=true",
+			check:     func(o *Options) bool { return o.RouteChecksum },
+		},
+		{
+			name:      "merge routers",
+			parameter: "merge_routers=true",
+			check:     func(o *Options) bool { return o.MergeRouters },
+		},
+		{
+			name:      "content negotiation",
+			parameter: "content_negotiation=true,binding=true",
+			check:     func(o *Options) bool { return o.ContentNegotiation && o.NegotiatesResponses() },
+		},
+		{
+			name:           "content negotiation without binding",
+			parameter:      "content_negotiation=true",
+			wantErrContain: "content_negotiation requires binding=true",
+		},
+		{
+			name:           "content negotiation with runtime module",
+			parameter:      "content_negotiation=true,binding=true,runtime_module=example.com/api/httpserverts",
+			wantErrContain: "runtime_module cannot be combined with content_negotiation",
+		},
+		{
+			name:      "runtime import",
+			parameter: "runtime_import=true",
+			check: func(o *Options) bool {
+				return o.RuntimeImport && o.RuntimeModule == RuntimeImportPath && o.RuntimePackage() == "runtime"
+			},
+		},
+		{
+			name:           "runtime import with runtime module",
+			parameter:      "runtime_import=true,runtime_module=example.com/api/httpserverts",
+			wantErrContain: "runtime_import cannot be combined with runtime_module",
+		},
+		{
+			name:           "runtime import with scope",
+			parameter:      "runtime_import=true,scope=true",
+			wantErrContain: "runtime_import cannot be combined with scope",
+		},
+		{
+			name:           "unknown option lists valid options",
+			parameter:      "nope=true",
+			wantErrContain: "decompress_max_bytes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			opts, err := ParseOptions(tt.parameter)
+
+			if tt.wantErrContain != "" {
+				if err == nil {
+					t.Fatalf("ParseOptions(%q) should have returned an error containing %q", tt.parameter, tt.wantErrContain)
+				}
+				if !strings.Contains(err.Error(), tt.wantErrContain) {
+					t.Errorf("ParseOptions(%q) error = %v, should contain %q", tt.parameter, err, tt.wantErrContain)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseOptions(%q) unexpected error: %v", tt.parameter, err)
+			}
+			if !tt.check(opts) {
+				t.Errorf("ParseOptions(%q) = %+v, unexpected result", tt.parameter, *opts)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package httpinterface
+
+import "strconv"
+
+// otelRoute is a route given an OpenTelemetry http.route attribute by the
+// otel template.
+type otelRoute struct {
+	// Name is the name of the attribute variable, such as "AttrRouteGetTask".
+	Name    string
+	Method  string
+	Pattern string
+}
+
+// OTelRoutes returns the routes of d in registration order, naming the
+// attribute of a method's primary binding AttrRoute<Method> and those of its
+// additional bindings AttrRoute<Method>2, AttrRoute<Method>3 and so on, like
+// the operation IDs of the OpenAPI document.
+func (d *ServiceData) OTelRoutes() []otelRoute {
+	var routes []otelRoute
+	for _, service := range d.Services {
+		for _, method := range service.Methods {
+			for i, rule := range method.HTTPRules {
+				name := "AttrRoute" + method.Name
+				if i > 0 {
+					name += strconv.Itoa(i + 1)
+				}
+				routes = append(routes, otelRoute{Name: name, Method: rule.Method, Pattern: rule.Pattern})
+			}
+		}
+	}
+	return routes
+}
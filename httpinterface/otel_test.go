@@ -0,0 +1,88 @@
+package httpinterface
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpinterface/parser"
+)
+
+func TestGenerateOTelAttributes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		parameter      string
+		wantContain    []string
+		wantNotContain []string
+	}{
+		{
+			name:      "enabled",
+			parameter: "otel_attributes=true",
+			wantContain: []string{
+				`"go.opentelemetry.io/otel/attribute"`,
+				"// AttrRouteExportItems is the http.route of POST /items:export.\n" +
+					"\tAttrRouteExportItems = attribute.String(\"http.route\", \"/items:export\")\n",
+				"// AttrRouteListItems is the http.route of GET /items.\n" +
+					"\tAttrRouteListItems = attribute.String(\"http.route\", \"/items\")\n",
+			},
+		},
+		{
+			name:      "path prefix",
+			parameter: "otel_attributes=true,path_prefix=/api",
+			wantContain: []string{
+				`AttrRouteListItems = attribute.String("http.route", "/api/items")`,
+			},
+		},
+		{
+			name:           "disabled",
+			parameter:      "",
+			wantNotContain: []string{"AttrRoute", "go.opentelemetry.io"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp := New().Generate(itemsRequest(t, tt.parameter,
+				itemMethod("ExportItems", postRule("/items:export", "*")),
+				itemMethod("ListItems", getRule("/items")),
+			))
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+			code := resp.File[0].GetContent()
+			for _, expected := range tt.wantContain {
+				if !strings.Contains(code, expected) {
+					t.Errorf("Generated code doesn't contain %q", expected)
+				}
+			}
+			for _, unexpected := range tt.wantNotContain {
+				if strings.Contains(code, unexpected) {
+					t.Errorf("Generated code contains %q", unexpected)
+				}
+			}
+		})
+	}
+}
+
+func TestOTelRoutes(t *testing.T) {
+	t.Parallel()
+
+	data := &ServiceData{Services: []ServiceInfo{{Methods: []MethodInfo{{
+		Name: "UpdateItem",
+		HTTPRules: []parser.HTTPRule{
+			{Method: "PUT", Pattern: "/v1/items/{id}"},
+			{Method: "PATCH", Pattern: "/v1/items/{id}"},
+		},
+	}}}}}
+	got := data.OTelRoutes()
+	want := []otelRoute{
+		{Name: "AttrRouteUpdateItem", Method: "PUT", Pattern: "/v1/items/{id}"},
+		{Name: "AttrRouteUpdateItem2", Method: "PATCH", Pattern: "/v1/items/{id}"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("OTelRoutes() = %v, want %v", got, want)
+	}
+}
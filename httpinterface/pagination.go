@@ -0,0 +1,108 @@
+package httpinterface
+
+import (
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+)
+
+// PaginationInfo describes a list method following AIP-158, whose generated
+// client gets an iterator over the items of all pages.
+type PaginationInfo struct {
+	// ItemsGetter is the getter of the response's repeated field of items,
+	// e.g. "GetTasks".
+	ItemsGetter string
+	// ItemGoType is the Go type of an item, e.g. "*Task" or "*commonv1.Tag".
+	ItemGoType string
+	// ItemImport is the Go package of the item message.
+	ItemImport GoImport
+}
+
+// HasPagination reports whether any method in d is paginated, so the client
+// runtime includes PageIterator.
+func (d *ServiceData) HasPagination() bool {
+	for _, service := range d.Services {
+		for _, method := range service.Methods {
+			if method.Pagination != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// pagination returns the PaginationInfo of method, or nil if it is not a list
+// method following AIP-158: the request must have the string field
+// page_token and the int32 field page_size, and the response the string
+// field next_page_token and, as its first repeated field, the message items.
+// Streamed and asynchronous methods are not paginated.
+func (g *Generator) pagination(file *descriptor.FileDescriptorProto, method *descriptor.MethodDescriptorProto) *PaginationInfo {
+	if methodStreamArray(method) || methodAsync(method) {
+		return nil
+	}
+	pageToken := g.fieldByPath(method.GetInputType(), "page_token")
+	pageSize := g.fieldByPath(method.GetInputType(), "page_size")
+	nextPageToken := g.fieldByPath(method.GetOutputType(), "next_page_token")
+	if !isSingular(pageToken, descriptor.FieldDescriptorProto_TYPE_STRING) ||
+		!isSingular(pageSize, descriptor.FieldDescriptorProto_TYPE_INT32) ||
+		!isSingular(nextPageToken, descriptor.FieldDescriptorProto_TYPE_STRING) {
+		return nil
+	}
+
+	resp := g.protoTypes.messages[method.GetOutputType()]
+	for _, field := range resp.GetField() {
+		if field.GetLabel() != descriptor.FieldDescriptorProto_LABEL_REPEATED {
+			continue
+		}
+		if field.GetType() != descriptor.FieldDescriptorProto_TYPE_MESSAGE || g.isMapEntry(field.GetTypeName()) {
+			return nil
+		}
+		return &PaginationInfo{
+			ItemsGetter: "Get" + goCamelCase(field.GetName()),
+			ItemGoType:  "*" + g.qualifiedGoType(file, field.GetTypeName()),
+			ItemImport:  g.messageImport(field.GetTypeName()),
+		}
+	}
+	return nil
+}
+
+// isSingular reports whether field is set and is a singular field of type t.
+func isSingular(field *descriptor.FieldDescriptorProto, t descriptor.FieldDescriptorProto_Type) bool {
+	return field != nil && field.GetLabel() != descriptor.FieldDescriptorProto_LABEL_REPEATED && field.GetType() == t
+}
+
+// isMapEntry reports whether the message typeName is the entry type of a map
+// field.
+func (g *Generator) isMapEntry(typeName string) bool {
+	return g.protoTypes.messages[typeName].GetOptions().GetMapEntry()
+}
+
+// goCamelCase returns the Go name protoc-gen-go gives the proto field name:
+// underscores followed by a lowercase letter are dropped and that letter
+// upper-cased, as in NextPageToken for next_page_token.
+func goCamelCase(name string) string {
+	var b []byte
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c == '_' && i == 0:
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(name) && isASCIILower(name[i+1]):
+			// Skip the underscore; the next letter is upper-cased
+		case '0' <= c && c <= '9':
+			b = append(b, c)
+		default:
+			if isASCIILower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(name) && isASCIILower(name[i+1]); i++ {
+				b = append(b, name[i+1])
+			}
+		}
+	}
+	return string(b)
+}
+
+// isASCIILower reports whether c is a lowercase ASCII letter.
+func isASCIILower(c byte) bool {
+	return 'a' <= c && c <= 'z'
+}
@@ -0,0 +1,158 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+
+	options "google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+// paginationRequest returns a request for books.proto, whose ListBooks
+// method follows AIP-158 unless edit changes its messages.
+func paginationRequest(t *testing.T, edit func(req, resp *descriptor.DescriptorProto)) *plugin.CodeGeneratorRequest {
+	t.Helper()
+
+	field := func(name string, number int32, typ descriptor.FieldDescriptorProto_Type, typeName string, label descriptor.FieldDescriptorProto_Label) *descriptor.FieldDescriptorProto {
+		f := &descriptor.FieldDescriptorProto{Name: proto.String(name), Number: proto.Int32(number), Type: typ.Enum(), Label: label.Enum()}
+		if typeName != "" {
+			f.TypeName = proto.String(typeName)
+		}
+		return f
+	}
+	optional := descriptor.FieldDescriptorProto_LABEL_OPTIONAL
+	repeated := descriptor.FieldDescriptorProto_LABEL_REPEATED
+
+	listReq := &descriptor.DescriptorProto{
+		Name: proto.String("ListBooksRequest"),
+		Field: []*descriptor.FieldDescriptorProto{
+			field("page_size", 1, descriptor.FieldDescriptorProto_TYPE_INT32, "", optional),
+			field("page_token", 2, descriptor.FieldDescriptorProto_TYPE_STRING, "", optional),
+		},
+	}
+	listResp := &descriptor.DescriptorProto{
+		Name: proto.String("ListBooksResponse"),
+		Field: []*descriptor.FieldDescriptorProto{
+			field("shelf_books", 1, descriptor.FieldDescriptorProto_TYPE_MESSAGE, ".books.v1.Book", repeated),
+			field("next_page_token", 2, descriptor.FieldDescriptorProto_TYPE_STRING, "", optional),
+		},
+	}
+	if edit != nil {
+		edit(listReq, listResp)
+	}
+
+	opts := &descriptor.MethodOptions{}
+	proto.SetExtension(opts, options.E_Http, &options.HttpRule{Pattern: &options.HttpRule_Get{Get: "/v1/books"}})
+	return &plugin.CodeGeneratorRequest{
+		Parameter:      proto.String("binding=true,client=true"),
+		FileToGenerate: []string{"books.proto"},
+		ProtoFile: []*descriptor.FileDescriptorProto{{
+			Name:    proto.String("books.proto"),
+			Package: proto.String("books.v1"),
+			Syntax:  proto.String("proto3"),
+			Options: &descriptor.FileOptions{GoPackage: proto.String("example.com/books/v1;booksv1")},
+			MessageType: []*descriptor.DescriptorProto{
+				{Name: proto.String("Book"), Field: []*descriptor.FieldDescriptorProto{field("title", 1, descriptor.FieldDescriptorProto_TYPE_STRING, "", optional)}},
+				listReq,
+				listResp,
+			},
+			Service: []*descriptor.ServiceDescriptorProto{{
+				Name: proto.String("BookService"),
+				Method: []*descriptor.MethodDescriptorProto{{
+					Name:       proto.String("ListBooks"),
+					InputType:  proto.String(".books.v1.ListBooksRequest"),
+					OutputType: proto.String(".books.v1.ListBooksResponse"),
+					Options:    opts,
+				}},
+			}},
+		}},
+	}
+}
+
+func TestGeneratePaginationIterator(t *testing.T) {
+	t.Parallel()
+
+	resp := New().Generate(paginationRequest(t, nil))
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	code := resp.File[0].GetContent()
+	for _, expected := range []string{
+		"type PageIterator[T any] struct {",
+		"func newPageIterator[Req proto.Message, T any](req Req, list func(req Req) ([]T, string, error)) *PageIterator[T] {",
+		"func (it *PageIterator[T]) Next() bool {",
+		"func (c *BookServiceClient) ListBooksIterator(ctx context.Context, req *ListBooksRequest, opts ...ClientOption) *PageIterator[*Book] {",
+		"\treturn newPageIterator(req, func(req *ListBooksRequest) ([]*Book, string, error) {\n\t\tresp, err := c.ListBooks(ctx, req, opts...)\n\t\treturn resp.GetShelfBooks(), resp.GetNextPageToken(), err\n\t})\n",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+}
+
+func TestGeneratePaginationNotAIP158(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		edit func(req, resp *descriptor.DescriptorProto)
+	}{
+		{
+			name: "no page_size",
+			edit: func(req, _ *descriptor.DescriptorProto) { req.Field = req.Field[1:] },
+		},
+		{
+			name: "page_token not a string",
+			edit: func(req, _ *descriptor.DescriptorProto) {
+				req.Field[1].Type = descriptor.FieldDescriptorProto_TYPE_INT64.Enum()
+			},
+		},
+		{
+			name: "no next_page_token",
+			edit: func(_, resp *descriptor.DescriptorProto) { resp.Field = resp.Field[:1] },
+		},
+		{
+			name: "scalar items",
+			edit: func(_, resp *descriptor.DescriptorProto) {
+				resp.Field[0].Type = descriptor.FieldDescriptorProto_TYPE_STRING.Enum()
+				resp.Field[0].TypeName = nil
+			},
+		},
+		{
+			name: "no items",
+			edit: func(_, resp *descriptor.DescriptorProto) { resp.Field = resp.Field[1:] },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp := New().Generate(paginationRequest(t, tt.edit))
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+			if code := resp.File[0].GetContent(); strings.Contains(code, "PageIterator") {
+				t.Error("Generated code has a PageIterator for a method not following AIP-158")
+			}
+		})
+	}
+}
+
+func TestGoCamelCase(t *testing.T) {
+	t.Parallel()
+
+	for name, want := range map[string]string{
+		"tasks":           "Tasks",
+		"next_page_token": "NextPageToken",
+		"shelf_books_2":   "ShelfBooks_2",
+		"v2_items":        "V2Items",
+		"_items":          "XItems",
+		"itemIDs":         "ItemIDs",
+	} {
+		if got := goCamelCase(name); got != want {
+			t.Errorf("goCamelCase(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
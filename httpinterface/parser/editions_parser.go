@@ -51,8 +51,8 @@ func (p *EditionsParser) ParsePathParams(pattern string) []string {
 	return PathParams(pattern)
 }
 
-// ConvertPathPattern converts a path pattern to Go format
+// ConvertPathPattern converts a google.api path template to the http.ServeMux
+// pattern syntax, see ConvertPathTemplate
 func (p *EditionsParser) ConvertPathPattern(pattern string) string {
-	// For editions, we just return the pattern as is
-	return pattern
+	return ConvertPathTemplate(pattern)
 }
@@ -204,6 +204,51 @@ func TestEditionsParser_ConvertPathPattern(t *testing.T) {
 			pattern:  "/v1/users/{user_id}/posts/{post_id}",
 			expected: "/v1/users/{user_id}/posts/{post_id}",
 		},
+		{
+			name:     "single_segment_variable",
+			pattern:  "/v1/users/{id=*}",
+			expected: "/v1/users/{id}",
+		},
+		{
+			name:     "multi_segment_variable",
+			pattern:  "/files/{path=**}",
+			expected: "/files/{path...}",
+		},
+		{
+			name:     "variable_with_literals",
+			pattern:  "/v1/{name=projects/*/locations/*}",
+			expected: "/v1/projects/{name_1}/locations/{name_2}",
+		},
+		{
+			name:     "variable_with_trailing_wildcard",
+			pattern:  "/v1/{name=buckets/*/objects/**}",
+			expected: "/v1/buckets/{name_1}/objects/{name_2...}",
+		},
+		{
+			name:     "field_path",
+			pattern:  "/v1/tasks/{task.id}",
+			expected: "/v1/tasks/{task_id}",
+		},
+		{
+			name:     "anonymous_wildcards",
+			pattern:  "/v1/*/items/**",
+			expected: "/v1/{_1}/items/{_2...}",
+		},
+		{
+			name:     "servemux_pattern_unchanged",
+			pattern:  "/v1/projects/{name_1}/files/{path...}/{$}",
+			expected: "/v1/projects/{name_1}/files/{path...}/{$}",
+		},
+		{
+			name:     "verb_unchanged",
+			pattern:  "/v1/{name=operations/*}:cancel",
+			expected: "/v1/operations/{name_1}:cancel",
+		},
+		{
+			name:     "malformed_variable_unchanged",
+			pattern:  "/v1/{name=projects/{id}}",
+			expected: "/v1/{name=projects/{id}}",
+		},
 	}
 
 	for _, tt := range tests {
@@ -3,25 +3,164 @@ package parser
 import (
 	"net/http"
 	"regexp"
+	"strconv"
+	"strings"
 
 	options "google.golang.org/genproto/googleapis/api/annotations"
 )
 
 // pathParamRegex matches {param} in URL patterns - unexported implementation detail
-var pathParamRegex = regexp.MustCompile(`\{([^/{}]+)\}`)
+var pathParamRegex = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// fieldPathRegex matches the field path of a path template variable, like
+// "name" or "task.id".
+var fieldPathRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
 
 // PathParams extracts path parameters from a URL pattern like "/users/{id}"
 // Returns empty slice (not nil) when no params found - this is the API contract.
+// Multi-segment wildcards like {path...} yield the name r.PathValue takes,
+// "path", and the {$} end anchor yields none. Path template variables like
+// {name=projects/*} yield their field path, "name".
 func PathParams(pattern string) []string {
 	params := []string{}
 	for _, match := range pathParamRegex.FindAllStringSubmatch(pattern, -1) {
-		if len(match) >= 2 {
-			params = append(params, match[1])
+		if len(match) < 2 {
+			continue
+		}
+		name, _, _ := strings.Cut(match[1], "=")
+		name = strings.TrimSuffix(name, "...")
+		if name != "" && name != "$" {
+			params = append(params, name)
 		}
 	}
 	return params
 }
 
+// ConvertPathTemplate translates a google.api path template into the
+// http.ServeMux pattern syntax:
+//
+//	/v1/{name}                        -> /v1/{name}
+//	/files/{path=**}                  -> /files/{path...}
+//	/v1/{name=projects/*/locations/*} -> /v1/projects/{name_1}/locations/{name_2}
+//	/v1/{task.id}                     -> /v1/{task_id}
+//	/v1/*/items/**                    -> /v1/{_1}/items/{_2...}
+//
+// Variables spanning several segments are expanded into their segments, with
+// one wildcard per * or ** named after the variable; PathVariables maps them
+// back. Patterns already in ServeMux syntax, and anything it cannot parse,
+// such as the :verb suffix, are returned unchanged, so the conversion is
+// idempotent.
+func ConvertPathTemplate(pattern string) string {
+	converted, _ := convertPathTemplate(pattern)
+	return converted
+}
+
+// PathVariables maps the variables of a google.api path template that
+// ConvertPathTemplate does not translate to a ServeMux wildcard of the same
+// name to the segments they were expanded into, such as "name" to
+// "projects/{name_1}/locations/{name_2}" or "task.id" to "{task_id}". Returns
+// nil when every variable keeps its name.
+func PathVariables(pattern string) map[string]string {
+	_, variables := convertPathTemplate(pattern)
+	return variables
+}
+
+// convertPathTemplate implements ConvertPathTemplate and PathVariables.
+func convertPathTemplate(pattern string) (string, map[string]string) {
+	if !strings.ContainsAny(pattern, "{*") {
+		return pattern, nil
+	}
+
+	var (
+		b         strings.Builder
+		variables map[string]string
+		anonymous int
+	)
+	for i := 0; i < len(pattern); {
+		switch c := pattern[i]; {
+		case c == '{':
+			end := strings.IndexByte(pattern[i:], '}')
+			if end < 0 {
+				b.WriteString(pattern[i:])
+				return b.String(), variables
+			}
+			variable := pattern[i : i+end+1]
+			name, segments, ok := expandPathVariable(variable[1 : len(variable)-1])
+			switch {
+			case !ok:
+				b.WriteString(variable)
+			case segments == "{"+name+"}" || segments == "{"+name+"...}":
+				b.WriteString(segments)
+			default:
+				if variables == nil {
+					variables = map[string]string{}
+				}
+				variables[name] = segments
+				b.WriteString(segments)
+			}
+			i += end + 1
+		case c == '*' && (i == 0 || pattern[i-1] == '/'):
+			segment, _, _ := strings.Cut(pattern[i:], "/")
+			if segment != "*" && segment != "**" {
+				b.WriteByte(c)
+				i++
+				continue
+			}
+			anonymous++
+			b.WriteString(pathWildcard("", anonymous, segment))
+			i += len(segment)
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String(), variables
+}
+
+// expandPathVariable expands the body of a path template variable, such as
+// "name=projects/*", into ServeMux segments, returning its field path and
+// false when it is not a well-formed variable.
+func expandPathVariable(variable string) (name, segments string, ok bool) {
+	name, template, found := strings.Cut(variable, "=")
+	if !fieldPathRegex.MatchString(name) {
+		return "", "", false
+	}
+	base := strings.ReplaceAll(name, ".", "_")
+	if !found {
+		template = "*"
+	}
+
+	parts := strings.Split(template, "/")
+	if len(parts) == 1 && (parts[0] == "*" || parts[0] == "**") {
+		return name, pathWildcard(base, 0, parts[0]), true
+	}
+
+	wildcards := 0
+	for i, part := range parts {
+		switch {
+		case part == "*" || part == "**":
+			wildcards++
+			parts[i] = pathWildcard(base, wildcards, part)
+		case part == "" || strings.ContainsAny(part, "{}*="):
+			return "", "", false
+		}
+	}
+	return name, strings.Join(parts, "/"), true
+}
+
+// pathWildcard returns the ServeMux wildcard for the nth * or ** segment of
+// a variable, or of the pattern itself when base is empty.
+func pathWildcard(base string, n int, segment string) string {
+	name := base
+	if n > 0 {
+		name += "_" + strconv.Itoa(n)
+	}
+	if segment == "**" {
+		return "{" + name + "...}"
+	}
+	return "{" + name + "}"
+}
+
 // ExtractHTTPRule converts a proto HttpRule to our HTTPRule type
 func ExtractHTTPRule(httpRule *options.HttpRule) HTTPRule {
 	if httpRule == nil {
@@ -10,6 +10,18 @@ type HTTPRule struct {
 	Pattern    string
 	Body       string
 	PathParams []string
+	// Template is the google.api path template Pattern was converted from,
+	// when the conversion changed it
+	Template string
+}
+
+// PathTemplate returns the google.api path template of the rule, as written
+// in its annotation.
+func (r HTTPRule) PathTemplate() string {
+	if r.Template != "" {
+		return r.Template
+	}
+	return r.Pattern
 }
 
 type Parser interface {
@@ -25,37 +37,32 @@ type Parser interface {
 
 // CreateParser creates a parser appropriate for the given FileDescriptorProto
 func CreateParser(file *descriptor.FileDescriptorProto) Parser {
-	// Check for edition option
-	if hasEditionOption(file) {
-		return NewEditionsParser()
-	}
-
-	// Check syntax field
-	syntax := file.GetSyntax()
-	if syntax == "proto3" {
+	switch FileEdition(file) {
+	case descriptor.Edition_EDITION_PROTO2:
+		return NewProto2Parser()
+	case descriptor.Edition_EDITION_PROTO3:
 		return NewProto3Parser()
+	default:
+		return NewEditionsParser()
 	}
-
-	// Default to proto2
-	return NewProto2Parser()
 }
 
-// hasEditionOption checks if a file has the edition option set
-func hasEditionOption(file *descriptor.FileDescriptorProto) bool {
-	if file.Options == nil {
-		return false
+// FileEdition reports the edition a file was compiled with. protoc (27 and
+// later) marks editions files with syntax "editions" and records the edition
+// in its own field; files without one map to EDITION_PROTO2 or
+// EDITION_PROTO3 from their syntax, defaulting to proto2 like protoc does.
+// An editions file whose edition field is missing reports EDITION_UNKNOWN.
+func FileEdition(file *descriptor.FileDescriptorProto) descriptor.Edition {
+	if file.Edition != nil && file.GetEdition() >= descriptor.Edition_EDITION_2023 {
+		return file.GetEdition()
 	}
 
-	// This would need to be implemented to check for the edition option
-	// in the uninterpreted options of the file
-	// Check for edition in uninterpreted options
-	for _, option := range file.Options.UninterpretedOption {
-		for _, name := range option.Name {
-			if name.GetNamePart() == "edition" {
-				return true
-			}
-		}
+	switch file.GetSyntax() {
+	case "editions":
+		return file.GetEdition()
+	case "proto3":
+		return descriptor.Edition_EDITION_PROTO3
+	default:
+		return descriptor.Edition_EDITION_PROTO2
 	}
-
-	return false
 }
@@ -1,6 +1,10 @@
 package parser
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
 	"testing"
 
 	"google.golang.org/protobuf/proto"
@@ -35,8 +39,32 @@ func TestCreateParser(t *testing.T) {
 			expected: "*parser.Proto2Parser",
 		},
 		{
-			name: "editions_file_with_option",
+			name: "edition_2023_file",
 			file: &descriptor.FileDescriptorProto{
+				Syntax:  proto.String("editions"),
+				Edition: descriptor.Edition_EDITION_2023.Enum(),
+			},
+			expected: "*parser.EditionsParser",
+		},
+		{
+			name: "edition_2024_file",
+			file: &descriptor.FileDescriptorProto{
+				Syntax:  proto.String("editions"),
+				Edition: descriptor.Edition_EDITION_2024.Enum(),
+			},
+			expected: "*parser.EditionsParser",
+		},
+		{
+			name: "editions_syntax_without_edition",
+			file: &descriptor.FileDescriptorProto{
+				Syntax: proto.String("editions"),
+			},
+			expected: "*parser.EditionsParser",
+		},
+		{
+			name: "uninterpreted_edition_option_is_ignored",
+			file: &descriptor.FileDescriptorProto{
+				Syntax: proto.String("proto3"),
 				Options: &descriptor.FileOptions{
 					UninterpretedOption: []*descriptor.UninterpretedOption{
 						{
@@ -50,7 +78,7 @@ func TestCreateParser(t *testing.T) {
 					},
 				},
 			},
-			expected: "*parser.EditionsParser",
+			expected: "*parser.Proto3Parser",
 		},
 	}
 
@@ -79,89 +107,173 @@ func TestCreateParser(t *testing.T) {
 	}
 }
 
-func TestHasEditionOption(t *testing.T) {
+func TestFileEdition(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
 		name     string
 		file     *descriptor.FileDescriptorProto
-		expected bool
+		expected descriptor.Edition
 	}{
 		{
-			name:     "no_options",
+			name:     "no_syntax",
 			file:     &descriptor.FileDescriptorProto{},
-			expected: false,
+			expected: descriptor.Edition_EDITION_PROTO2,
 		},
 		{
-			name: "options_but_no_edition",
+			name: "proto2_syntax",
 			file: &descriptor.FileDescriptorProto{
-				Options: &descriptor.FileOptions{
-					UninterpretedOption: []*descriptor.UninterpretedOption{
-						{
-							Name: []*descriptor.UninterpretedOption_NamePart{
-								{
-									NamePart:    proto.String("other"),
-									IsExtension: proto.Bool(false),
-								},
-							},
-						},
-					},
-				},
+				Syntax: proto.String("proto2"),
 			},
-			expected: false,
+			expected: descriptor.Edition_EDITION_PROTO2,
 		},
 		{
-			name: "has_edition_option",
+			name: "proto3_syntax",
 			file: &descriptor.FileDescriptorProto{
-				Options: &descriptor.FileOptions{
-					UninterpretedOption: []*descriptor.UninterpretedOption{
-						{
-							Name: []*descriptor.UninterpretedOption_NamePart{
-								{
-									NamePart:    proto.String("edition"),
-									IsExtension: proto.Bool(false),
-								},
-							},
-						},
-					},
-				},
+				Syntax: proto.String("proto3"),
 			},
-			expected: true,
+			expected: descriptor.Edition_EDITION_PROTO3,
 		},
 		{
-			name: "multiple_options_with_edition",
+			name: "edition_2023",
 			file: &descriptor.FileDescriptorProto{
-				Options: &descriptor.FileOptions{
-					UninterpretedOption: []*descriptor.UninterpretedOption{
-						{
-							Name: []*descriptor.UninterpretedOption_NamePart{
-								{
-									NamePart:    proto.String("other"),
-									IsExtension: proto.Bool(false),
-								},
-							},
-						},
-						{
-							Name: []*descriptor.UninterpretedOption_NamePart{
-								{
-									NamePart:    proto.String("edition"),
-									IsExtension: proto.Bool(false),
-								},
-							},
-						},
-					},
-				},
+				Syntax:  proto.String("editions"),
+				Edition: descriptor.Edition_EDITION_2023.Enum(),
 			},
-			expected: true,
+			expected: descriptor.Edition_EDITION_2023,
+		},
+		{
+			name: "edition_2024",
+			file: &descriptor.FileDescriptorProto{
+				Syntax:  proto.String("editions"),
+				Edition: descriptor.Edition_EDITION_2024.Enum(),
+			},
+			expected: descriptor.Edition_EDITION_2024,
+		},
+		{
+			name: "edition_without_syntax",
+			file: &descriptor.FileDescriptorProto{
+				Edition: descriptor.Edition_EDITION_2023.Enum(),
+			},
+			expected: descriptor.Edition_EDITION_2023,
+		},
+		{
+			name: "editions_syntax_without_edition",
+			file: &descriptor.FileDescriptorProto{
+				Syntax: proto.String("editions"),
+			},
+			expected: descriptor.Edition_EDITION_UNKNOWN,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := FileEdition(tt.file); got != tt.expected {
+				t.Errorf("FileEdition() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPathVariables(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		pattern  string
+		expected map[string]string
+	}{
+		{
+			name:     "plain_variables",
+			pattern:  "/v1/users/{id}/files/{path=**}",
+			expected: nil,
+		},
+		{
+			name:    "variable_with_literals",
+			pattern: "/v1/{name=projects/*/locations/*}",
+			expected: map[string]string{
+				"name": "projects/{name_1}/locations/{name_2}",
+			},
+		},
+		{
+			name:    "field_paths",
+			pattern: "/v1/{parent.name=shelves/*}/books/{book.id}",
+			expected: map[string]string{
+				"parent.name": "shelves/{parent_name_1}",
+				"book.id":     "{book_id}",
+			},
+		},
+		{
+			name:     "anonymous_wildcards",
+			pattern:  "/v1/*/items/**",
+			expected: nil,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			result := hasEditionOption(tt.file)
-			if result != tt.expected {
-				t.Errorf("hasEditionOption() = %v, want %v", result, tt.expected)
+			result := PathVariables(tt.pattern)
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("PathVariables(%q) = %v, want %v", tt.pattern, result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestPathParams checks that each name PathParams returns is one
+// http.ServeMux binds, so r.PathValue finds it.
+func TestPathParams(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		pattern  string
+		target   string
+		expected []string
+	}{
+		{
+			name:     "single_segment",
+			pattern:  "/v1/users/{id}",
+			target:   "/v1/users/42",
+			expected: []string{"id"},
+		},
+		{
+			name:     "multi_segment_wildcard",
+			pattern:  "/v1/files/{path...}",
+			target:   "/v1/files/a/b.txt",
+			expected: []string{"path"},
+		},
+		{
+			name:     "end_anchor",
+			pattern:  "/v1/{name}/{$}",
+			target:   "/v1/shelves/",
+			expected: []string{"name"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := PathParams(tt.pattern)
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Fatalf("PathParams(%q) = %v, want %v", tt.pattern, result, tt.expected)
+			}
+
+			var bound []string
+			mux := http.NewServeMux()
+			mux.HandleFunc(tt.pattern, func(_ http.ResponseWriter, r *http.Request) {
+				for _, name := range result {
+					bound = append(bound, r.PathValue(name))
+				}
+			})
+			mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			if len(bound) != len(result) || slices.Contains(bound, "") {
+				t.Errorf("r.PathValue(%v) on %s = %q, want every name bound", result, tt.target, bound)
 			}
 		})
 	}
@@ -131,8 +131,9 @@ func TestProperty_ParsePathParamsParamsAreSubstrings(t *testing.T) {
 			f := func(pattern string) bool {
 				params := p.parser.ParsePathParams(pattern)
 				for _, param := range params {
-					// Each param should appear in pattern wrapped in braces
-					if !strings.Contains(pattern, "{"+param+"}") {
+					// Each param should open a variable of the pattern, as in
+					// {param}, {param...} or {param=*}
+					if !strings.Contains(pattern, "{"+param) {
 						return false
 					}
 				}
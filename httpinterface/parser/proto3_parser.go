@@ -51,8 +51,8 @@ func (p *Proto3Parser) ParsePathParams(pattern string) []string {
 	return PathParams(pattern)
 }
 
-// ConvertPathPattern converts a path pattern to Go format
+// ConvertPathPattern converts a google.api path template to the http.ServeMux
+// pattern syntax, see ConvertPathTemplate
 func (p *Proto3Parser) ConvertPathPattern(pattern string) string {
-	// For proto3, we just return the pattern as is
-	return pattern
+	return ConvertPathTemplate(pattern)
 }
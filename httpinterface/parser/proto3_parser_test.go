@@ -199,6 +199,11 @@ func TestProto3Parser_ParsePathParams(t *testing.T) {
 			pattern:  "/v1/users/{user_id}/posts/{post_id}",
 			expected: []string{"user_id", "post_id"},
 		},
+		{
+			name:     "path_template_variables",
+			pattern:  "/v1/{name=projects/*/locations/*}/files/{path=**}",
+			expected: []string{"name", "path"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,141 @@
+package httpinterface
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpinterface/parser"
+)
+
+// pathParamMatches lines up the path parameters of each additional binding with
+// those of the primary binding, rules[0]. A parameter whose name the primary
+// binding does not use is matched to the primary parameter at the same position
+// when both bindings have the same number of parameters. The result maps each
+// such name to the distinct primary names it was matched to, in binding order.
+func pathParamMatches(rules []parser.HTTPRule) map[string][]string {
+	if len(rules) < 2 {
+		return nil
+	}
+	primary := rules[0].PathParams
+
+	var matches map[string][]string
+	for _, rule := range rules[1:] {
+		if len(rule.PathParams) != len(primary) {
+			continue
+		}
+		for i, name := range rule.PathParams {
+			if slices.Contains(primary, name) || slices.Contains(matches[name], primary[i]) {
+				continue
+			}
+			if matches == nil {
+				matches = map[string][]string{}
+			}
+			matches[name] = append(matches[name], primary[i])
+		}
+	}
+	return matches
+}
+
+// pathParamAliases returns the path parameter aliases of a method: the names
+// used by its additional bindings mapped to the primary binding's name for the
+// same position, so {id} in "/v1/users/{id}" reads as {user_id} when the
+// primary binding is "/users/{user_id}". Names matched to more than one primary
+// name are ambiguous and left out; checkPathParams reports them.
+func pathParamAliases(rules []parser.HTTPRule) map[string]string {
+	var aliases map[string]string
+	for name, targets := range pathParamMatches(rules) {
+		if len(targets) != 1 {
+			continue
+		}
+		if aliases == nil {
+			aliases = map[string]string{}
+		}
+		aliases[name] = targets[0]
+	}
+	return aliases
+}
+
+// checkPathParams reports path parameter collisions across the bindings of
+// each method in data: an alias matched to more than one primary parameter, or
+// a binding that would set the same primary parameter twice.
+func checkPathParams(data *ServiceData) error {
+	for _, service := range data.Services {
+		for _, method := range service.Methods {
+			matches := pathParamMatches(method.HTTPRules)
+			for _, name := range slices.Sorted(maps.Keys(matches)) {
+				if targets := matches[name]; len(targets) > 1 {
+					return fmt.Errorf("%s.%s: path parameter {%s} stands for {%s} in one binding and {%s} in another",
+						service.Name, method.Name, name, targets[0], targets[1])
+				}
+			}
+
+			for _, rule := range method.HTTPRules[min(1, len(method.HTTPRules)):] {
+				seen := map[string]string{}
+				for _, name := range rule.PathParams {
+					target := name
+					if alias, ok := method.PathParamAliases[name]; ok {
+						target = alias
+					}
+					if prev, ok := seen[target]; ok {
+						return fmt.Errorf("%s.%s: binding %s %s sets path parameter {%s} through both {%s} and {%s}",
+							service.Name, method.Name, rule.Method, rule.Pattern, target, prev, name)
+					}
+					seen[target] = name
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// HasPathParamAliases reports whether any method in d has path parameter
+// aliases, so the generated file needs the aliasPathValues helper.
+func (d *ServiceData) HasPathParamAliases() bool {
+	for _, service := range d.Services {
+		for _, method := range service.Methods {
+			if len(method.PathParamAliases) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PathVariables returns the path template variables of d's binding that its
+// ServeMux pattern spells differently, mapped to the segments they were
+// expanded into, for pathTemplateValues to set them under their field paths.
+// It is empty for unrouted handlers, whose variables are set by the caller.
+func (d routeHandlerData) PathVariables() map[string]string {
+	if d.Rule.Template == "" || d.unrouted {
+		return nil
+	}
+	return parser.PathVariables(d.Rule.Template)
+}
+
+// HasPathTemplates reports whether any route in d expands path template
+// variables, so the generated file needs the pathTemplateValues helper.
+func (d *ServiceData) HasPathTemplates() bool {
+	for _, service := range d.Services {
+		for _, method := range service.Methods {
+			for _, rule := range method.HTTPRules {
+				if len(newRouteHandlerData(d.Options, method, rule, "", "").PathVariables()) > 0 {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// goStringMap returns values as a Go map[string]string literal with sorted
+// keys, such as map[string]string{"name": "projects/{name_1}"}.
+func goStringMap(values map[string]string) string {
+	entries := make([]string, 0, len(values))
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		entries = append(entries, strconv.Quote(key)+": "+strconv.Quote(values[key]))
+	}
+	return "map[string]string{" + strings.Join(entries, ", ") + "}"
+}
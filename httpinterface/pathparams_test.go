@@ -0,0 +1,205 @@
+package httpinterface
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpinterface/parser"
+)
+
+func TestPathParamAliases(t *testing.T) {
+	t.Parallel()
+
+	rule := func(pattern string) parser.HTTPRule {
+		return parser.HTTPRule{Method: "GET", Pattern: pattern, PathParams: parser.PathParams(pattern)}
+	}
+
+	tests := []struct {
+		name  string
+		rules []parser.HTTPRule
+		want  map[string]string
+	}{
+		{
+			name:  "single binding",
+			rules: []parser.HTTPRule{rule("/users/{user_id}")},
+		},
+		{
+			name:  "same names",
+			rules: []parser.HTTPRule{rule("/users/{user_id}"), rule("/v2/users/{user_id}")},
+		},
+		{
+			name:  "renamed parameter",
+			rules: []parser.HTTPRule{rule("/users/{user_id}"), rule("/v2/users/{id}")},
+			want:  map[string]string{"id": "user_id"},
+		},
+		{
+			name: "renamed parameters by position",
+			rules: []parser.HTTPRule{
+				rule("/users/{user_id}/posts/{post_id}"),
+				rule("/u/{uid}/p/{post_id}"),
+				rule("/users/{id}/posts/{pid}"),
+			},
+			want: map[string]string{"uid": "user_id", "id": "user_id", "pid": "post_id"},
+		},
+		{
+			name:  "different parameter count is not aligned",
+			rules: []parser.HTTPRule{rule("/users/{user_id}/posts/{post_id}"), rule("/posts/{id}")},
+		},
+		{
+			name: "ambiguous alias is left out",
+			rules: []parser.HTTPRule{
+				rule("/users/{user_id}/posts/{post_id}"),
+				rule("/users/{id}/posts/{pid}"),
+				rule("/posts/{pid}/users/{id}"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := pathParamAliases(tt.rules); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("pathParamAliases() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckPathParams(t *testing.T) {
+	t.Parallel()
+
+	rule := func(pattern string) parser.HTTPRule {
+		return newRule("GET", pattern, "")
+	}
+	data := func(rules ...parser.HTTPRule) *ServiceData {
+		method := newMethod("GetPost", "GetPostRequest", "Post", rules...)
+		method.PathParamAliases = pathParamAliases(rules)
+		return newServiceData("api", Options{}, newService("UserService", method))
+	}
+
+	tests := []struct {
+		name    string
+		data    *ServiceData
+		wantErr string
+	}{
+		{
+			name: "aliases",
+			data: data(rule("/users/{user_id}/posts/{post_id}"), rule("/u/{id}/p/{pid}")),
+		},
+		{
+			name: "alias for two parameters",
+			data: data(
+				rule("/users/{user_id}/posts/{post_id}"),
+				rule("/users/{id}/posts/{pid}"),
+				rule("/posts/{pid}/users/{id}"),
+			),
+			wantErr: "UserService.GetPost: path parameter {id} stands for {user_id} in one binding and {post_id} in another",
+		},
+		{
+			name:    "parameter set twice",
+			data:    data(rule("/users/{user_id}/posts/{post_id}"), rule("/users/{id}/posts/{user_id}")),
+			wantErr: "binding GET /users/{id}/posts/{user_id} sets path parameter {user_id} through both {id} and {user_id}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := checkPathParams(tt.data)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("checkPathParams() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("checkPathParams() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerateCodePathParamAliases(t *testing.T) {
+	t.Parallel()
+	g := New()
+
+	get := newMethod("GetUser", "GetUserRequest", "User", newRule("GET", "/users/{user_id}", ""), newRule("GET", "/v2/users/{id}", ""))
+	get.PathParamAliases = pathParamAliases(get.HTTPRules)
+	code, err := g.GenerateCode(newServiceData("api", Options{}, newService("UserService",
+		get,
+		newMethod("ListUsers", "ListUsersRequest", "ListUsersResponse", newRule("GET", "/users", "")),
+	)))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+
+	for _, expected := range []string{
+		"func aliasPathValues(h http.HandlerFunc, aliases map[string]string) http.HandlerFunc",
+		"var GetUserPathParamAliases = map[string]string{\n\t\"id\": \"user_id\",\n}",
+		`r.HandleFunc(http.MethodGet, "/v2/users/{id}", aliasPathValues(handler.HandleGetUser, GetUserPathParamAliases))`,
+		`r.HandleFunc(http.MethodGet, "/v2/users/{id}", aliasPathValues(h.ServeHTTP, GetUserPathParamAliases))`,
+		`r.HandleFunc(http.MethodGet, "/users", handler.HandleListUsers)`,
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+	if strings.Contains(code, "ListUsersPathParamAliases") {
+		t.Error("Generated code contains aliases for a method without them")
+	}
+}
+
+func TestGenerateCodePathValueSource(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		source   string
+		expected []string
+	}{
+		{
+			source: PathValueStd,
+			expected: []string{
+				"func PathValue(r *http.Request, name string) string {\n\treturn r.PathValue(name)\n}",
+				"func setPathValue(r *http.Request, name, value string) {\n\tr.SetPathValue(name, value)\n}",
+			},
+		},
+		{
+			source: PathValueChi,
+			expected: []string{
+				"\t\"github.com/go-chi/chi/v5\"\n",
+				"func PathValue(r *http.Request, name string) string {\n\treturn chi.URLParam(r, name)\n}",
+				"\tif rctx := chi.RouteContext(r.Context()); rctx != nil {\n\t\trctx.URLParams.Add(name, value)\n\t}\n",
+			},
+		},
+		{
+			source: PathValueGorilla,
+			expected: []string{
+				"\t\"github.com/gorilla/mux\"\n",
+				"func PathValue(r *http.Request, name string) string {\n\treturn mux.Vars(r)[name]\n}",
+				"\tif vars := mux.Vars(r); vars != nil {\n\t\tvars[name] = value\n\t}\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			t.Parallel()
+			get := newMethod("GetUser", "GetUserRequest", "User", newRule("GET", "/users/{user_id}", ""), newRule("GET", "/v2/users/{id}", ""))
+			get.PathParamAliases = pathParamAliases(get.HTTPRules)
+			code, err := New().GenerateCode(newServiceData("api", Options{Binding: true, PathValueSource: tt.source}, newService("UserService", get)))
+			if err != nil {
+				t.Fatalf("GenerateCode() error = %v", err)
+			}
+
+			for _, expected := range append(tt.expected,
+				"\t\tif value := PathValue(r, name); value != \"\" {\n",
+				"\t\t\tif value := PathValue(r, alias); value != \"\" && PathValue(r, name) == \"\" {\n\t\t\t\tsetPathValue(r, name, value)\n",
+			) {
+				if !strings.Contains(code, expected) {
+					t.Errorf("Generated code doesn't contain %q", expected)
+				}
+			}
+		})
+	}
+}
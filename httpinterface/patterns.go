@@ -0,0 +1,79 @@
+package httpinterface
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+)
+
+// patternGrammarHint points rejected patterns to the grammar routes follow and
+// the option that skips the check.
+const patternGrammarHint = "route patterns follow the http.ServeMux grammar " +
+	"(https://pkg.go.dev/net/http#hdr-Patterns); set raw_patterns=true to pass them through unchecked"
+
+// checkPatterns reports a route pattern in data that http.ServeMux rejects or
+// would match other than as written, such as the matrix-style
+// "/v1/items;version={v}" or the custom verb of "/v1/{name=operations/*}:cancel".
+// Path templates are checked as converted to ServeMux patterns.
+func checkPatterns(data *ServiceData) error {
+	for _, service := range data.Services {
+		for _, method := range service.Methods {
+			for _, rule := range method.HTTPRules {
+				if err := checkPattern(rule.Pattern); err != nil {
+					return fmt.Errorf("%s.%s: binding %s %s: %v; %s",
+						service.Name, method.Name, rule.Method, rule.PathTemplate(), err, patternGrammarHint)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// checkPattern reports why pattern is outside the http.ServeMux grammar: a
+// path starting with a slash, whose segments are literals or whole-segment
+// {name} wildcards, with {name...} and {$} allowed last only.
+func checkPattern(pattern string) error {
+	if !strings.HasPrefix(pattern, "/") {
+		return fmt.Errorf("pattern must start with /")
+	}
+	if i := strings.IndexAny(pattern, "?#"); i >= 0 {
+		return fmt.Errorf("%q starts a query or fragment, which patterns cannot match", pattern[i])
+	}
+	for _, r := range pattern {
+		if r <= ' ' || r == 0x7f {
+			return fmt.Errorf("%q is not allowed in a pattern", r)
+		}
+	}
+
+	segments := strings.Split(pattern[1:], "/")
+	seen := map[string]bool{}
+	for i, segment := range segments {
+		if !strings.ContainsAny(segment, "{}") {
+			continue
+		}
+		if len(segment) < 2 || segment[0] != '{' || segment[len(segment)-1] != '}' ||
+			strings.Count(segment, "{") != 1 || strings.Count(segment, "}") != 1 {
+			return fmt.Errorf("segment %q: wildcards must be a whole path segment", segment)
+		}
+		name := segment[1 : len(segment)-1]
+		last := i == len(segments)-1
+		if name == "$" {
+			if !last {
+				return fmt.Errorf("{$} must be the last segment")
+			}
+			continue
+		}
+		name, rest := strings.CutSuffix(name, "...")
+		if rest && !last {
+			return fmt.Errorf("{%s...} must be the last segment", name)
+		}
+		if !token.IsIdentifier(name) {
+			return fmt.Errorf("wildcard {%s}: names must be Go identifiers", name)
+		}
+		if seen[name] {
+			return fmt.Errorf("wildcard {%s} appears more than once", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpserver"
+	options "google.golang.org/genproto/googleapis/api/annotations"
+)
+
+func TestCheckPattern(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern string
+		wantErr string
+	}{
+		{"/v1/items", ""},
+		{"/v1/items/{item_id}", ""},
+		{"/v1/items:export", ""},
+		{"/v1/items;version=1", ""},
+		{"/v1/files/{path...}", ""},
+		{"/v1/items/{$}", ""},
+		{"v1/items", "pattern must start with /"},
+		{"/v1/items;version={v}", `segment "items;version={v}": wildcards must be a whole path segment`},
+		{"/v1/tasks/{id}:archive", `segment "{id}:archive": wildcards must be a whole path segment`},
+		{"/v1/{name=projects/*}", "wildcards must be a whole path segment"},
+		{"/v1/{a}{b}", `segment "{a}{b}": wildcards must be a whole path segment`},
+		{"/v1/tasks/{task.id}", "wildcard {task.id}: names must be Go identifiers"},
+		{"/v1/{}", "wildcard {}: names must be Go identifiers"},
+		{"/v1/{path...}/items", "{path...} must be the last segment"},
+		{"/v1/{$}/items", "{$} must be the last segment"},
+		{"/v1/{id}/items/{id}", "wildcard {id} appears more than once"},
+		{"/v1/items?version=1", "'?' starts a query or fragment, which patterns cannot match"},
+		{"/v1/my items", "' ' is not allowed in a pattern"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			t.Parallel()
+			err := checkPattern(tt.pattern)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkPattern(%q) error = %v", tt.pattern, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("checkPattern(%q) error = %v, want %q", tt.pattern, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerateRejectsPatterns(t *testing.T) {
+	t.Parallel()
+
+	rule := &options.HttpRule{
+		Pattern:            &options.HttpRule_Get{Get: "/v1/items/{id}"},
+		AdditionalBindings: []*options.HttpRule{{Pattern: &options.HttpRule_Get{Get: "/v1/items;version={v}"}}},
+	}
+
+	resp := New().Generate(itemsRequest(t, "", itemMethod("GetItem", rule)))
+	want := `items.proto: ItemService.GetItem: binding GET /v1/items;version={v}: segment "items;version={v}": ` +
+		"wildcards must be a whole path segment; route patterns follow the http.ServeMux grammar " +
+		"(https://pkg.go.dev/net/http#hdr-Patterns); set raw_patterns=true to pass them through unchecked"
+	if resp.GetError() != want {
+		t.Fatalf("Generate() error = %q, want %q", resp.GetError(), want)
+	}
+
+	resp = New().Generate(itemsRequest(t, "raw_patterns=true", itemMethod("GetItem", rule)))
+	if resp.Error != nil {
+		t.Fatalf("Generate() with raw_patterns error = %s", resp.GetError())
+	}
+	if code := resp.File[0].GetContent(); !strings.Contains(code, `r.HandleFunc(http.MethodGet, "/v1/items;version={v}", `) {
+		t.Errorf("generated code doesn't register the raw pattern")
+	}
+}
+
+func TestGeneratePathPrefix(t *testing.T) {
+	t.Parallel()
+
+	resp := New().Generate(graphqlRequest(t, "binding=true,auto_options=true,doc=true,path_prefix=/api/"))
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	files := map[string]string{}
+	for _, f := range resp.File {
+		files[f.GetName()] = f.GetContent()
+	}
+	for name, expected := range map[string][]string{
+		"items_http.pb.go": {
+			`r.HandleFunc(http.MethodGet, "/api/v1/items/{item_id}", handler.HandleGetItem)`,
+			`handleOptions(r, "/api/v1/items/{item_id}", []string{`,
+		},
+		"doc.go": {"//	GET  /api/v1/items/{item_id}  HandleGetItem\n"},
+	} {
+		for _, e := range expected {
+			if !strings.Contains(files[name], e) {
+				t.Errorf("%s doesn't contain %q", name, e)
+			}
+		}
+	}
+	if strings.Contains(files["items_http.pb.go"], `"/v1/items`) {
+		t.Errorf("generated code registers a route without the prefix")
+	}
+}
+
+func TestGeneratePathTemplates(t *testing.T) {
+	t.Parallel()
+
+	rule := &options.HttpRule{
+		Pattern:            &options.HttpRule_Get{Get: "/v1/{name=projects/*/items/*}"},
+		AdditionalBindings: []*options.HttpRule{{Pattern: &options.HttpRule_Get{Get: "/v1/files/{name=**}"}}},
+	}
+
+	cache := withExtension(httpserver.E_Cache, &httpserver.Cache{TtlSeconds: 30})
+	resp := New().Generate(itemsRequest(t, "", itemMethod("GetItem", rule, cache)))
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	code := resp.File[0].GetContent()
+	for _, expected := range []string{
+		"func pathTemplateValues(h http.HandlerFunc, variables map[string]string) http.HandlerFunc {",
+		`r.HandleFunc(http.MethodGet, "/v1/projects/{name_1}/items/{name_2}", pathTemplateValues(` +
+			`cacheResponse("/v1/projects/{name_1}/items/{name_2}", 30, handler.HandleGetItem), map[string]string{"name": "projects/{name_1}/items/{name_2}"}))`,
+		`r.HandleFunc(http.MethodGet, "/v1/files/{name...}", cacheResponse("/v1/files/{name...}", 30, handler.HandleGetItem))`,
+		// HandlerFor serves other frameworks, which set "name" whole.
+		"func HandlerForGetItem(handler ItemServiceHandler, middlewares ...Middleware) http.Handler {\n" +
+			"\treturn applyMiddlewares(cacheResponse(\"/v1/projects/{name_1}/items/{name_2}\", 30, handler.HandleGetItem), middlewares).ServeHTTP\n}",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("generated code doesn't contain %q", expected)
+		}
+	}
+
+	resp = New().Generate(itemsRequest(t, "raw_patterns=true", itemMethod("GetItem", rule, cache)))
+	if resp.Error != nil {
+		t.Fatalf("Generate() with raw_patterns error = %s", resp.GetError())
+	}
+	code = resp.File[0].GetContent()
+	if !strings.Contains(code, `r.HandleFunc(http.MethodGet, "/v1/{name=projects/*/items/*}", `) {
+		t.Errorf("generated code doesn't register the raw pattern")
+	}
+	if strings.Contains(code, "pathTemplateValues") {
+		t.Errorf("generated code expands the raw pattern's variables")
+	}
+}
+
+func TestGenerateRejectsCustomVerbs(t *testing.T) {
+	t.Parallel()
+
+	resp := New().Generate(itemsRequest(t, "", itemMethod("GetItem", getRule("/v1/{name=operations/*}:cancel"))))
+	want := `items.proto: ItemService.GetItem: binding GET /v1/{name=operations/*}:cancel: segment "{name_1}:cancel": ` +
+		"wildcards must be a whole path segment"
+	if !strings.HasPrefix(resp.GetError(), want) {
+		t.Fatalf("Generate() error = %q, want prefix %q", resp.GetError(), want)
+	}
+}
@@ -0,0 +1,80 @@
+package httpinterface
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+// permissions is the <file>_http.permissions.json matrix listing every route
+// generated for a proto file with the scopes it requires, so security
+// reviews can audit the API surface from the build artifacts.
+type permissions struct {
+	Source string            `json:"source"`
+	Routes []permissionRoute `json:"routes"`
+}
+
+type permissionRoute struct {
+	RPC     string `json:"rpc"`
+	Method  string `json:"method"`
+	Pattern string `json:"pattern"`
+	// Internal marks the routes of (http_server.visibility) = INTERNAL
+	// methods, registered only by Register<Service>InternalRoutes.
+	Internal bool `json:"internal,omitempty"`
+	// Scopes is empty, rather than omitted, for routes requiring no scope,
+	// so they stand out in the review.
+	Scopes []string `json:"scopes"`
+}
+
+// HasScopes reports whether any method in d has scopes from the
+// (http_server.service_scopes) or (http_server.scopes) options, so a
+// permission matrix is written for the file.
+func (d *ServiceData) HasScopes() bool {
+	for _, service := range d.Services {
+		for _, method := range service.Methods {
+			if len(method.Scopes) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// generatePermissionsFile returns the permission matrix of file, with one
+// entry per HTTP binding in declaration order.
+func (g *Generator) generatePermissionsFile(file *descriptor.FileDescriptorProto, data *ServiceData) (*plugin.CodeGeneratorResponse_File, error) {
+	doc := permissions{Source: file.GetName(), Routes: []permissionRoute{}}
+	for _, service := range data.Services {
+		for _, method := range service.Methods {
+			scopes := method.Scopes
+			if scopes == nil {
+				scopes = []string{}
+			}
+			for _, rule := range method.HTTPRules {
+				doc.Routes = append(doc.Routes, permissionRoute{
+					RPC:      service.RPCName(method),
+					Method:   rule.Method,
+					Pattern:  rule.Pattern,
+					Internal: method.Internal,
+					Scopes:   scopes,
+				})
+			}
+		}
+	}
+
+	name := strings.TrimSuffix(g.getOutputFilename(file.GetName()), ".pb.go") + ".permissions.json"
+	content, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error generating %s: %v", name, err)
+	}
+	outputFile := &plugin.CodeGeneratorResponse_File{
+		Name:    proto.String(name),
+		Content: proto.String(string(content) + "\n"),
+	}
+	g.applySourceRelativePath(outputFile, file.GetName())
+	return outputFile, nil
+}
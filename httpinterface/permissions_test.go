@@ -0,0 +1,78 @@
+package httpinterface
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpserver"
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestGeneratePermissions(t *testing.T) {
+	t.Parallel()
+
+	req := graphqlRequest(t, "binding=true")
+	service := req.ProtoFile[0].Service[0]
+	service.Options = &descriptor.ServiceOptions{}
+	proto.SetExtension(service.Options, httpserver.E_ServiceScopes, []string{"items.read"})
+	proto.SetExtension(service.Method[1].Options, httpserver.E_Scopes, []string{"items.write", "items.read"})
+	resp := New().Generate(req)
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+
+	var content string
+	for _, f := range resp.File {
+		if strings.HasSuffix(f.GetName(), ".permissions.json") {
+			content = f.GetContent()
+		}
+	}
+	var doc permissions
+	if err := json.Unmarshal([]byte(content), &doc); err != nil {
+		t.Fatalf("permission matrix %q: %v", content, err)
+	}
+	want := []permissionRoute{
+		{RPC: "/items.v1.ItemService/GetItem", Method: "GET", Pattern: "/v1/items/{item_id}", Scopes: []string{"items.read"}},
+		{RPC: "/items.v1.ItemService/SaveItem", Method: "PUT", Pattern: "/v1/items/{item_id}", Scopes: []string{"items.read", "items.write"}},
+		{RPC: "/items.v1.ItemService/ExportItems", Method: "POST", Pattern: "/v1/items:export", Scopes: []string{"items.read"}},
+	}
+	if doc.Source != req.ProtoFile[0].GetName() || !slices.EqualFunc(doc.Routes, want, func(a, b permissionRoute) bool {
+		return a.RPC == b.RPC && a.Method == b.Method && a.Pattern == b.Pattern && a.Internal == b.Internal && slices.Equal(a.Scopes, b.Scopes)
+	}) {
+		t.Errorf("permission matrix =\n%s\nwant routes %+v", content, want)
+	}
+}
+
+func TestGeneratePermissionsWithoutScopes(t *testing.T) {
+	t.Parallel()
+
+	resp := New().Generate(graphqlRequest(t, "binding=true"))
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	for _, f := range resp.File {
+		if strings.HasSuffix(f.GetName(), ".permissions.json") {
+			t.Errorf("%s written without scopes", f.GetName())
+		}
+	}
+}
+
+func TestMethodScopes(t *testing.T) {
+	t.Parallel()
+
+	serviceOpts := &descriptor.ServiceOptions{}
+	proto.SetExtension(serviceOpts, httpserver.E_ServiceScopes, []string{"tasks.read"})
+	methodOpts := &descriptor.MethodOptions{}
+	proto.SetExtension(methodOpts, httpserver.E_Scopes, []string{"tasks.write", "tasks.read"})
+
+	got := methodScopes(&descriptor.ServiceDescriptorProto{Options: serviceOpts}, &descriptor.MethodDescriptorProto{Options: methodOpts})
+	if want := []string{"tasks.read", "tasks.write"}; !slices.Equal(got, want) {
+		t.Errorf("methodScopes() = %q, want %q", got, want)
+	}
+	if got := methodScopes(&descriptor.ServiceDescriptorProto{}, &descriptor.MethodDescriptorProto{}); got != nil {
+		t.Errorf("methodScopes() without options = %q, want nil", got)
+	}
+}
@@ -0,0 +1,37 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateCodeRateLimitHeaders(t *testing.T) {
+	t.Parallel()
+	g := New()
+
+	code, err := g.GenerateCode(itemServiceData(Options{Binding: true, Client: true, RateLimitHeaders: true}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	for _, expected := range []string{
+		"type RateLimit struct {",
+		"func SetRateLimit(w http.ResponseWriter, limit RateLimit) {",
+		"h.Set(\"RateLimit-Remaining\", strconv.FormatInt(max(limit.Remaining, 0), 10))",
+		"func WriteRateLimitExceeded(w http.ResponseWriter, limit RateLimit) {",
+		"func ParseRateLimit(h http.Header) (RateLimit, bool) {",
+		"\tRateLimit   RateLimit\n\tRateLimited bool\n",
+		"rateLimit, rateLimited := ParseRateLimit(httpResp.Header)",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+
+	code, err = g.GenerateCode(itemServiceData(Options{Binding: true, Client: true}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	if strings.Contains(code, "RateLimit") {
+		t.Error("Generated code has rate limit helpers without rate_limit_headers=true")
+	}
+}
@@ -0,0 +1,44 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateRecording(t *testing.T) {
+	t.Parallel()
+
+	code, err := New().GenerateCode(itemServiceData(Options{Recording: true}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+
+	for _, expected := range []string{
+		"var recordingRoutes = []struct{ route, name string }{\n" +
+			"\t{\"GET /v1/items/{id}\", \"ItemService_GetItem\"},\n" +
+			"\t{\"GET /v1/things/{id}\", \"ItemService_GetItem_1\"},\n" +
+			"\t{\"PATCH /v1/items/{item.id}\", \"ItemService_UpdateItem\"},\n" +
+			"\t{\"GET /v1/items\", \"ItemService_ListItems\"},\n}",
+		"type RecordingTransport struct",
+		"func (t *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {",
+		`"path/filepath"`,
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+}
+
+func TestGenerateWithoutRecording(t *testing.T) {
+	t.Parallel()
+
+	code, err := New().GenerateCode(itemServiceData(Options{}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	for _, unexpected := range []string{"RecordingTransport", "recordingRoutes", `"path/filepath"`} {
+		if strings.Contains(code, unexpected) {
+			t.Errorf("Generated code contains %q without recording=true", unexpected)
+		}
+	}
+}
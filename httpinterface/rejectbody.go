@@ -0,0 +1,26 @@
+package httpinterface
+
+// RejectsBody reports whether the route of d answers requests with a body
+// with 400 Bad Request through rejectBody: GET and DELETE routes without a
+// body rule, when unexpected_body=reject is set. rejectBody runs before the
+// 415 Unsupported Media Type check of strict_content_type.
+func (d routeHandlerData) RejectsBody() bool {
+	o := d.Options
+	return o.UnexpectedBody == UnexpectedBodyReject && !d.Method.StreamBody &&
+		(d.Rule.Method == "GET" || d.Rule.Method == "DELETE") && d.Rule.Body == ""
+}
+
+// RejectsBodies reports whether any route in d rejects request bodies, so
+// the generated file needs the rejectBody helper.
+func (d *ServiceData) RejectsBodies() bool {
+	for _, service := range d.Services {
+		for _, method := range service.Methods {
+			for _, rule := range method.HTTPRules {
+				if newRouteHandlerData(d.Options, method, rule, "", "").RejectsBody() {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
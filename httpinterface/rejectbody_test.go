@@ -0,0 +1,92 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateRejectBody(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		parameter      string
+		wantContain    []string
+		wantNotContain []string
+	}{
+		{
+			name:      "default",
+			parameter: "",
+			wantContain: []string{
+				`r.HandleFunc(http.MethodGet, "/items/{id}", handler.HandleGetItem)`,
+				`r.HandleFunc(http.MethodDelete, "/items/{id}", handler.HandleDeleteItem)`,
+			},
+			wantNotContain: []string{"rejectBody", `"io"`},
+		},
+		{
+			name:      "reject",
+			parameter: "unexpected_body=reject",
+			wantContain: []string{
+				`"io"`,
+				"func rejectBody(h http.HandlerFunc) http.HandlerFunc {",
+				"http.StatusBadRequest",
+				`r.HandleFunc(http.MethodGet, "/items/{id}", rejectBody(handler.HandleGetItem))`,
+				`r.HandleFunc(http.MethodGet, "/items/{id}", rejectBody(h.ServeHTTP))`,
+				`r.HandleFunc(http.MethodDelete, "/items/{id}", rejectBody(handler.HandleDeleteItem))`,
+				`r.HandleFunc(http.MethodDelete, "/items", handler.HandlePurgeItems)`,
+				`r.HandleFunc(http.MethodPost, "/items", handler.HandleCreateItem)`,
+			},
+		},
+		{
+			name:      "ignore",
+			parameter: "unexpected_body=ignore",
+			wantContain: []string{
+				`r.HandleFunc(http.MethodGet, "/items/{id}", handler.HandleGetItem)`,
+				`r.HandleFunc(http.MethodDelete, "/items/{id}", handler.HandleDeleteItem)`,
+			},
+			wantNotContain: []string{"rejectBody", `"io"`},
+		},
+		{
+			name:      "before strict content type",
+			parameter: "unexpected_body=reject,strict_content_type=true,binding=true",
+			wantContain: []string{
+				`r.HandleFunc(http.MethodGet, "/items/{id}", rejectBody(requireContentType(handler.HandleGetItem, false)))`,
+				`r.HandleFunc(http.MethodDelete, "/items", requireContentType(handler.HandlePurgeItems, true))`,
+			},
+		},
+		{
+			name:      "strict content type only",
+			parameter: "strict_content_type=true,binding=true",
+			wantContain: []string{
+				`r.HandleFunc(http.MethodGet, "/items/{id}", requireContentType(handler.HandleGetItem, false))`,
+			},
+			wantNotContain: []string{"rejectBody"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp := New().Generate(itemsRequest(t, tt.parameter,
+				itemMethod("GetItem", getRule("/items/{id}")),
+				itemMethod("DeleteItem", deleteRule("/items/{id}", "")),
+				itemMethod("PurgeItems", deleteRule("/items", "*")),
+				itemMethod("CreateItem", postRule("/items", "*")),
+			))
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+			code := resp.File[0].GetContent()
+			for _, expected := range tt.wantContain {
+				if !strings.Contains(code, expected) {
+					t.Errorf("Generated code doesn't contain %q", expected)
+				}
+			}
+			for _, unexpected := range tt.wantNotContain {
+				if strings.Contains(code, unexpected) {
+					t.Errorf("Generated code contains %q", unexpected)
+				}
+			}
+		})
+	}
+}
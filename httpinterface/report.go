@@ -0,0 +1,73 @@
+package httpinterface
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+// report is the <file>_http.report.json document describing the size of the
+// code generated for a proto file, so teams can estimate the cost of a large
+// API before adopting the plugin.
+type report struct {
+	Source string `json:"source"`
+	// Routes counts the HTTP bindings of all services, each registered as
+	// one ServeMux pattern.
+	Routes   int             `json:"routes"`
+	Services []reportService `json:"services"`
+	Files    []reportFile    `json:"files"`
+	// GoBytes and GoLines total the generated Go files, the part of the
+	// output compiled into the binary.
+	GoBytes int `json:"go_bytes"`
+	GoLines int `json:"go_lines"`
+}
+
+type reportService struct {
+	Name    string `json:"name"`
+	Methods int    `json:"methods"`
+	Routes  int    `json:"routes"`
+}
+
+type reportFile struct {
+	Name  string `json:"name"`
+	Bytes int    `json:"bytes"`
+	Lines int    `json:"lines"`
+}
+
+// generateReportFile returns the report of outputFiles, the files generated
+// for file, listed under their output names.
+func (g *Generator) generateReportFile(file *descriptor.FileDescriptorProto, data *ServiceData, outputFiles []*plugin.CodeGeneratorResponse_File) (*plugin.CodeGeneratorResponse_File, error) {
+	doc := report{Source: file.GetName()}
+	for _, service := range data.Services {
+		entry := reportService{Name: service.FullName, Methods: len(service.Methods)}
+		for _, method := range service.Methods {
+			entry.Routes += len(method.HTTPRules)
+		}
+		doc.Routes += entry.Routes
+		doc.Services = append(doc.Services, entry)
+	}
+	for _, f := range outputFiles {
+		entry := reportFile{Name: f.GetName(), Bytes: len(f.GetContent()), Lines: strings.Count(f.GetContent(), "\n")}
+		if strings.HasSuffix(entry.Name, ".go") {
+			doc.GoBytes += entry.Bytes
+			doc.GoLines += entry.Lines
+		}
+		doc.Files = append(doc.Files, entry)
+	}
+
+	name := strings.TrimSuffix(g.getOutputFilename(file.GetName()), ".pb.go") + ".report.json"
+	content, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error generating %s: %v", name, err)
+	}
+	outputFile := &plugin.CodeGeneratorResponse_File{
+		Name:    proto.String(name),
+		Content: proto.String(string(content) + "\n"),
+	}
+	g.applySourceRelativePath(outputFile, file.GetName())
+	return outputFile, nil
+}
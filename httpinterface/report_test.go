@@ -0,0 +1,47 @@
+package httpinterface
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestGenerateReport(t *testing.T) {
+	t.Parallel()
+
+	resp := New().Generate(graphqlRequest(t, "binding=true,report=true,ts_client=true"))
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	files := map[string]string{}
+	for _, f := range resp.File {
+		files[f.GetName()] = f.GetContent()
+	}
+
+	content, ok := files["items_http.report.json"]
+	if !ok {
+		t.Fatalf("missing items_http.report.json in %d generated files", len(resp.File))
+	}
+	var got report
+	if err := json.Unmarshal([]byte(content), &got); err != nil {
+		t.Fatalf("invalid report: %v\n%s", err, content)
+	}
+	if got.Source != "items.proto" || got.Routes != 3 {
+		t.Errorf("report source = %q, routes = %d, want items.proto and 3", got.Source, got.Routes)
+	}
+	if len(got.Services) != 1 || got.Services[0] != (reportService{Name: "items.v1.ItemService", Methods: 3, Routes: 3}) {
+		t.Errorf("report services = %+v", got.Services)
+	}
+
+	goCode := files["items_http.pb.go"]
+	want := []reportFile{
+		{Name: "items_http.pb.go", Bytes: len(goCode), Lines: strings.Count(goCode, "\n")},
+		{Name: "items_http.ts", Bytes: len(files["items_http.ts"]), Lines: strings.Count(files["items_http.ts"], "\n")},
+	}
+	if len(got.Files) != len(want) || got.Files[0] != want[0] || got.Files[1] != want[1] {
+		t.Errorf("report files = %+v, want %+v", got.Files, want)
+	}
+	if got.GoBytes != len(goCode) || got.GoLines != want[0].Lines {
+		t.Errorf("report go_bytes = %d, go_lines = %d, want %d and %d", got.GoBytes, got.GoLines, len(goCode), want[0].Lines)
+	}
+}
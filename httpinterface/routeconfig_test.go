@@ -0,0 +1,84 @@
+package httpinterface
+
+import (
+	"go/format"
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpserver"
+)
+
+func TestGenerateRouteConfig(t *testing.T) {
+	t.Parallel()
+
+	resp := New().Generate(itemsRequest(t, "route_config=true",
+		itemMethod("ExportItems", postRule("/items:export", "*"), withExtension(httpserver.E_Timeouts, &httpserver.Timeouts{WriteMs: 300000})),
+		itemMethod("ListItems", getRule("/items")),
+	))
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	code := resp.File[0].GetContent()
+	if formatted, err := format.Source([]byte(code)); err != nil || string(formatted) != code {
+		t.Errorf("generated code is not gofmt'ed (err = %v)", err)
+	}
+
+	for _, expected := range []string{
+		"type RouteConfig struct {",
+		"func (c RouteConfig) handler(readMs, writeMs int, h http.HandlerFunc) http.HandlerFunc {",
+		"type ItemServiceRouteConfig struct {\n\t// ExportItems configures the routes of ExportItems.\n\tExportItems RouteConfig\n",
+		"func RegisterItemServiceRoutesWithConfig(r Routes, handler ItemServiceHandler, config ItemServiceRouteConfig) error {",
+		"\tif c := config.ListItems; !c.Disabled {\n\t\th := applyMiddlewares(http.HandlerFunc(handler.HandleListItems), c.Middlewares)\n",
+		// The config replaces the deadlines of (http_server.timeouts) rather
+		// than nesting in routeTimeouts
+		"\t\tr.HandleFunc(http.MethodPost, \"/items:export\", c.handler(0, 300000, h.ServeHTTP))\n",
+		"\t\"time\"\n",
+		"type ItemServiceRoute string\n",
+		"\tRouteListItems ItemServiceRoute = \"/items.v1.ItemService/ListItems\"\n",
+		"\t\tcase RouteExportItems:\n\t\t\tc.ExportItems.Disabled = true\n",
+		"func RegisterItemServiceRoutesExcept(r Routes, handler ItemServiceHandler, methods ...ItemServiceRoute) error {",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("generated code doesn't contain %q", expected)
+		}
+	}
+}
+
+func TestGenerateRouteConfigInternalMethods(t *testing.T) {
+	t.Parallel()
+
+	internal := withExtension(httpserver.E_Visibility, httpserver.Visibility_INTERNAL)
+	resp := New().Generate(itemsRequest(t, "route_config=true",
+		itemMethod("ExportItems", postRule("/items:export", "*"), internal),
+		itemMethod("ListItems", getRule("/items"), internal),
+	))
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	code := resp.File[0].GetContent()
+	if formatted, err := format.Source([]byte(code)); err != nil || string(formatted) != code {
+		t.Errorf("generated code is not gofmt'ed (err = %v)", err)
+	}
+	// Register<Service>Routes registers the public methods only, so the
+	// config of a service without any has no fields
+	if expected := "type ItemServiceRouteConfig struct {\n}\n"; !strings.Contains(code, expected) {
+		t.Errorf("generated code doesn't contain %q", expected)
+	}
+}
+
+func TestGenerateRouteConfigDisabled(t *testing.T) {
+	t.Parallel()
+
+	resp := New().Generate(itemsRequest(t, "",
+		itemMethod("ExportItems", postRule("/items:export", "*")),
+		itemMethod("ListItems", getRule("/items")),
+	))
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	for _, unexpected := range []string{"RouteConfig", "WithConfig", "routeTimeouts"} {
+		if strings.Contains(resp.File[0].GetContent(), unexpected) {
+			t.Errorf("generated code contains %q without route_config", unexpected)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package httpinterface
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// RouteTable returns the "METHOD pattern" keys of the routes the
+// Register<Service>Routes functions of d register, service by service in
+// registration order, including the OPTIONS routes of auto_options. Methods
+// with INTERNAL visibility are left out, like Register<Service>Routes leaves
+// them to Register<Service>InternalRoutes.
+func (d *ServiceData) RouteTable() []string {
+	var routes []string
+	for _, service := range d.Services {
+		public := service.Methods[:0:0]
+		for _, method := range service.Methods {
+			if !method.Internal {
+				public = append(public, method)
+			}
+		}
+		service.Methods = public
+		for _, method := range service.Methods {
+			for _, rule := range method.HTTPRules {
+				routes = append(routes, rule.Method+" "+rule.Pattern)
+			}
+		}
+		if d.Options.AutoOptions {
+			for _, route := range service.OptionsRoutes() {
+				routes = append(routes, "OPTIONS "+route.Pattern)
+			}
+		}
+	}
+	return routes
+}
+
+// RouteTableChecksum returns the checksum the generated RouteTableChecksum
+// function returns: the hex SHA-256 of the RouteTable keys, each followed by a
+// newline, as the generated ChecksumRoutes computes it.
+func (d *ServiceData) RouteTableChecksum() string {
+	h := sha256.New()
+	for _, route := range d.RouteTable() {
+		h.Write([]byte(route + "\n"))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,89 @@
+package httpinterface
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpinterface/parser"
+)
+
+func TestGenerateRouteChecksum(t *testing.T) {
+	t.Parallel()
+
+	checksum := sha256.Sum256([]byte("POST /api/items:export\nGET /api/items\nOPTIONS /api/items:export\nOPTIONS /api/items\n"))
+	tests := []struct {
+		name           string
+		parameter      string
+		wantContain    []string
+		wantNotContain []string
+	}{
+		{
+			name:      "enabled",
+			parameter: "route_checksum=true,auto_options=true,path_prefix=/api",
+			wantContain: []string{
+				"\t\"crypto/sha256\"\n\t\"encoding/hex\"\n",
+				"var routeTable = []string{\n" +
+					"\t\"POST /api/items:export\",\n" +
+					"\t\"GET /api/items\",\n" +
+					"\t\"OPTIONS /api/items:export\",\n" +
+					"\t\"OPTIONS /api/items\",\n}",
+				"func RouteTable() []string {",
+				"func RouteTableChecksum() string {\n\treturn \"" + hex.EncodeToString(checksum[:]) + "\"\n}",
+				"func ChecksumRoutes(routes []string) string {",
+			},
+		},
+		{
+			name:           "disabled",
+			parameter:      "auto_options=true",
+			wantNotContain: []string{"routeTable", "RouteTable", "ChecksumRoutes", "crypto/sha256"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp := New().Generate(itemsRequest(t, tt.parameter,
+				itemMethod("ExportItems", postRule("/items:export", "*")),
+				itemMethod("ListItems", getRule("/items")),
+			))
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+			code := resp.File[0].GetContent()
+			for _, expected := range tt.wantContain {
+				if !strings.Contains(code, expected) {
+					t.Errorf("Generated code doesn't contain %q", expected)
+				}
+			}
+			for _, unexpected := range tt.wantNotContain {
+				if strings.Contains(code, unexpected) {
+					t.Errorf("Generated code contains %q", unexpected)
+				}
+			}
+		})
+	}
+}
+
+func TestRouteTable(t *testing.T) {
+	t.Parallel()
+
+	data := &ServiceData{Services: []ServiceInfo{
+		{Methods: []MethodInfo{
+			{Name: "UpdateItem", HTTPRules: []parser.HTTPRule{
+				{Method: "PUT", Pattern: "/v1/items/{id}"},
+				{Method: "PATCH", Pattern: "/v1/items/{id}"},
+			}},
+			{Name: "PurgeItems", Internal: true, HTTPRules: []parser.HTTPRule{{Method: "POST", Pattern: "/v1/items:purge"}}},
+		}},
+		{Methods: []MethodInfo{
+			{Name: "GetUser", HTTPRules: []parser.HTTPRule{{Method: "GET", Pattern: "/v1/users/{id}"}}},
+		}},
+	}}
+	want := []string{"PUT /v1/items/{id}", "PATCH /v1/items/{id}", "GET /v1/users/{id}"}
+	if got := data.RouteTable(); !reflect.DeepEqual(got, want) {
+		t.Errorf("RouteTable() = %v, want %v", got, want)
+	}
+}
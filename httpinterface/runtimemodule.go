@@ -0,0 +1,158 @@
+package httpinterface
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+// runtimeModuleGoVersion is the go directive of the runtime module's go.mod,
+// the first release whose http.ServeMux matches methods and wildcards.
+const runtimeModuleGoVersion = "1.22"
+
+// RuntimeImportPath is the import path of the runtime package of this
+// repository, which the packages generated with runtime_import=true alias
+// their router types to.
+const RuntimeImportPath = "github.com/farhaan/protoc-gen-go-http-server-interface/runtime"
+
+// generateRuntimeModule returns the files of the runtime_module: its go.mod,
+// the router types the generated packages alias and their tests, under the
+// directory named by the last element of its import path. They are the same
+// for every run with the same module, so the plugin can write them each time.
+func (g *Generator) generateRuntimeModule() ([]*plugin.CodeGeneratorResponse_File, error) {
+	dir := g.Options.RuntimePackage()
+	files, err := g.runtimeFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	goMod := &plugin.CodeGeneratorResponse_File{
+		Name:    proto.String(path.Join(dir, "go.mod")),
+		Content: proto.String(fmt.Sprintf("module %s\n\ngo %s\n", g.Options.RuntimeModule, runtimeModuleGoVersion)),
+	}
+	return append([]*plugin.CodeGeneratorResponse_File{goMod}, files...), nil
+}
+
+// RuntimePackageFiles returns the files of the runtime package at
+// RuntimeImportPath, runtime.go and runtime_test.go, as the runtime module
+// templates render them. go generate writes them to the runtime directory of
+// this repository.
+func RuntimePackageFiles() ([]*plugin.CodeGeneratorResponse_File, error) {
+	g := New()
+	if err := g.applyOptions("runtime_import=true"); err != nil {
+		return nil, err
+	}
+	return g.runtimeFiles("")
+}
+
+// runtimeFiles returns the router types the generated packages alias and
+// their tests, named after the runtime package, under dir.
+func (g *Generator) runtimeFiles(dir string) ([]*plugin.CodeGeneratorResponse_File, error) {
+	name := g.Options.RuntimePackage()
+	data := &ServiceData{PackageName: name, Options: *g.Options}
+	var files []*plugin.CodeGeneratorResponse_File
+	for _, file := range []struct{ template, name string }{
+		{"runtime-module", name + ".go"},
+		{"runtime-module-test", name + "_test.go"},
+	} {
+		var buf bytes.Buffer
+		if err := g.ParsedTemplates.ExecuteTemplate(&buf, file.template, data); err != nil {
+			return nil, fmt.Errorf("failed to execute %s template: %v", file.template, err)
+		}
+		files = append(files, &plugin.CodeGeneratorResponse_File{
+			Name:    proto.String(path.Join(dir, file.name)),
+			Content: proto.String(buf.String()),
+		})
+	}
+	return files, nil
+}
+
+// checkRuntimeModule reports services with route tags when the router types
+// come from the runtime_module, whose RouteGroup has no UseForTags.
+func checkRuntimeModule(data *ServiceData) error {
+	if data.Options.RuntimeModule == "" {
+		return nil
+	}
+	for _, service := range data.Services {
+		for _, method := range service.Methods {
+			if len(method.Tags) > 0 {
+				return fmt.Errorf("%s cannot serve the tags of %s.%s: RouteGroup.UseForTags is generated per package",
+					data.Options.runtimeOptionName(), service.Name, method.Name)
+			}
+			if method.CacheTTLSeconds > 0 {
+				return fmt.Errorf("%s cannot serve the cache of %s.%s: WithResponseCache is generated per package",
+					data.Options.runtimeOptionName(), service.Name, method.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// routerImports are the imports of the router that the rest of a generated
+// file may not use once the router comes from the runtime_module.
+var routerImports = []string{"errors", "strings"}
+
+// pruneRouterImports removes the routerImports the Go files among files do
+// not use.
+func pruneRouterImports(files []*plugin.CodeGeneratorResponse_File) error {
+	for _, f := range files {
+		if path.Ext(f.GetName()) != ".go" {
+			continue
+		}
+		content, err := pruneImports(f.GetContent(), func(importPath string) bool {
+			return slices.Contains(routerImports, importPath)
+		})
+		if err != nil {
+			return fmt.Errorf("pruning imports of %s: %v", f.GetName(), err)
+		}
+		f.Content = proto.String(content)
+	}
+	return nil
+}
+
+// pruneImports removes the imports of the Go source content that prunable
+// reports and content does not refer to, by the name they are imported under
+// or the last element of their path.
+func pruneImports(content string, prunable func(importPath string) bool) (string, error) {
+	fset := token.NewFileSet()
+	file, err := goparser.ParseFile(fset, "", content, goparser.SkipObjectResolution)
+	if err != nil {
+		return "", err
+	}
+	used := make(map[string]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if x, ok := sel.X.(*ast.Ident); ok {
+				used[x.Name] = true
+			}
+		}
+		return true
+	})
+	// Remove the unused specs last to first, so earlier offsets hold
+	for i := len(file.Imports) - 1; i >= 0; i-- {
+		spec := file.Imports[i]
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		name := path.Base(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if name == "_" || name == "." || used[name] || !prunable(importPath) {
+			continue
+		}
+		start, end := fset.Position(spec.Pos()).Offset, fset.Position(spec.End()).Offset
+		start = strings.LastIndexByte(content[:start], '\n') + 1
+		if j := strings.IndexByte(content[end:], '\n'); j >= 0 {
+			end += j + 1
+		}
+		content = content[:start] + content[end:]
+	}
+	return content, nil
+}
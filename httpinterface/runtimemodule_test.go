@@ -0,0 +1,170 @@
+package httpinterface
+
+import (
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpserver"
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+func TestGenerateRuntimeModule(t *testing.T) {
+	t.Parallel()
+
+	for _, parameter := range []string{
+		"paths=source_relative,runtime_module=example.com/shop/httpserverts",
+		"paths=source_relative,layout=split,binding=true,runtime_module=example.com/shop/httpserverts/",
+	} {
+		t.Run(parameter, func(t *testing.T) {
+			t.Parallel()
+			file := layoutTestFile()
+			file.Service = file.Service[:1]
+			resp := New().Generate(&plugin.CodeGeneratorRequest{
+				Parameter:      proto.String(parameter),
+				FileToGenerate: []string{"shop/v1/shop.proto"},
+				ProtoFile:      []*descriptor.FileDescriptorProto{file},
+			})
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+
+			files := make(map[string]string)
+			var generated strings.Builder
+			for _, f := range resp.File {
+				files[f.GetName()] = f.GetContent()
+				if strings.HasPrefix(f.GetName(), "shop/") {
+					generated.WriteString(f.GetContent())
+				}
+				if strings.HasSuffix(f.GetName(), ".go") {
+					if formatted, err := format.Source([]byte(f.GetContent())); err != nil || string(formatted) != f.GetContent() {
+						t.Errorf("%s is not gofmt'ed (err = %v)", f.GetName(), err)
+					}
+				}
+			}
+
+			if got, want := files["httpserverts/go.mod"], "module example.com/shop/httpserverts\n\ngo 1.22\n"; got != want {
+				t.Errorf("httpserverts/go.mod = %q, want %q", got, want)
+			}
+			for name, expected := range map[string]string{
+				"httpserverts/httpserverts.go":      "package httpserverts\n",
+				"httpserverts/httpserverts_test.go": "func TestConflictPolicies(t *testing.T) {",
+			} {
+				if !strings.Contains(files[name], expected) {
+					t.Errorf("%s doesn't contain %q", name, expected)
+				}
+			}
+			if !strings.Contains(files["httpserverts/httpserverts.go"], "func (g *RouteGroup) HandleFunc(method, pattern string, handler http.HandlerFunc) {") {
+				t.Error("runtime module doesn't declare RouteGroup")
+			}
+
+			code := generated.String()
+			for _, expected := range []string{
+				"\t\"example.com/shop/httpserverts\"\n",
+				"\tRouteGroup = httpserverts.RouteGroup\n",
+				"\treturn httpserverts.NewRouter(mux)\n",
+			} {
+				if !strings.Contains(code, expected) {
+					t.Errorf("generated code doesn't contain %q", expected)
+				}
+			}
+			for _, unexpected := range []string{"func (g *RouteGroup)", "type RouteGroup struct", "\t\"sync/atomic\"\n"} {
+				if strings.Contains(code, unexpected) {
+					t.Errorf("generated code contains %q", unexpected)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateRuntimeModuleTags(t *testing.T) {
+	t.Parallel()
+
+	file := layoutTestFile()
+	proto.SetExtension(file.Service[1].Method[0].Options, httpserver.E_Tags, []string{"admin"})
+	resp := New().Generate(&plugin.CodeGeneratorRequest{
+		Parameter:      proto.String("runtime_module=example.com/shop/httpserverts"),
+		FileToGenerate: []string{"shop/v1/shop.proto"},
+		ProtoFile:      []*descriptor.FileDescriptorProto{file},
+	})
+	want := "shop/v1/shop.proto: runtime_module cannot serve the tags of OrderService.GetOrder"
+	if !strings.HasPrefix(resp.GetError(), want) {
+		t.Errorf("Generate() error = %q, want prefix %q", resp.GetError(), want)
+	}
+}
+
+func TestGenerateRuntimeImport(t *testing.T) {
+	t.Parallel()
+
+	file := layoutTestFile()
+	file.Service = file.Service[:1]
+	resp := New().Generate(&plugin.CodeGeneratorRequest{
+		Parameter:      proto.String("paths=source_relative,binding=true,runtime_import=true"),
+		FileToGenerate: []string{"shop/v1/shop.proto"},
+		ProtoFile:      []*descriptor.FileDescriptorProto{file},
+	})
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	if len(resp.File) != 1 {
+		t.Fatalf("Generate() returned %d files, want the generated package only", len(resp.File))
+	}
+	code := resp.File[0].GetContent()
+	for _, expected := range []string{
+		"\t\"github.com/farhaan/protoc-gen-go-http-server-interface/runtime\"\n",
+		"// The router types are those of the runtime package " + RuntimeImportPath + ",\n",
+		"// runtime_import=true.\n",
+		"\tRouteGroup = runtime.RouteGroup\n",
+		"\treturn runtime.NewRouter(mux)\n",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("generated code doesn't contain %q", expected)
+		}
+	}
+	if strings.Contains(code, "type RouteGroup struct") {
+		t.Error("generated code declares RouteGroup")
+	}
+}
+
+func TestRuntimePackageUpToDate(t *testing.T) {
+	t.Parallel()
+
+	files, err := RuntimePackageFiles()
+	if err != nil {
+		t.Fatalf("RuntimePackageFiles() error = %v", err)
+	}
+	for _, f := range files {
+		got, err := os.ReadFile(filepath.Join("..", "runtime", f.GetName()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != f.GetContent() {
+			t.Errorf("runtime/%s is out of date; run go generate ./runtime", f.GetName())
+		}
+	}
+}
+
+func TestPruneRouterImports(t *testing.T) {
+	t.Parallel()
+
+	src := "package api\n\nimport (\n\t\"errors\"\n\t\"net/http\"\n\t\"strings\"\n)\n\n" +
+		"var errClosed = errors.New(\"closed\")\n\nvar _ http.Handler\n"
+	files := []*plugin.CodeGeneratorResponse_File{
+		{Name: proto.String("api_http.pb.go"), Content: proto.String(src)},
+		{Name: proto.String("api_http.ts"), Content: proto.String("import \"strings\"\n")},
+	}
+	if err := pruneRouterImports(files); err != nil {
+		t.Fatalf("pruneRouterImports() error = %v", err)
+	}
+	want := strings.Replace(src, "\t\"strings\"\n", "", 1)
+	if got := files[0].GetContent(); got != want {
+		t.Errorf("pruned file =\n%s\nwant\n%s", got, want)
+	}
+	if got := files[1].GetContent(); got != "import \"strings\"\n" {
+		t.Errorf("non-Go file changed to %q", got)
+	}
+}
@@ -0,0 +1,257 @@
+package httpinterface
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+
+	"google.golang.org/protobuf/proto"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+// scaffoldTemplateData is the data passed to the scaffold templates.
+type scaffoldTemplateData struct {
+	// Package is the Go package of the scaffolded file.
+	Package string
+	// Import is the Go package of the generated handler interfaces.
+	Import GoImport
+	// Service is the service of a per-service file.
+	Service ServiceInfo
+	// Services are all scaffolded services, for main.go.
+	Services []ServiceInfo
+	// HandlerImport and ServiceImport are the packages of the scaffolded
+	// handlers and services with scaffold=project; the handlers of
+	// scaffold=handler have no service package.
+	HandlerImport GoImport
+	ServiceImport GoImport
+	// Binary is the name of the built server with scaffold=deploy.
+	Binary string
+	// GeneratedDir is the directory of the generated package relative to the
+	// scaffold directory, and ProtoFiles the proto files it is generated from.
+	GeneratedDir string
+	ProtoFiles   []string
+	// PathValue makes scaffolded handlers read path values with the generated
+	// PathValue accessor of pathvalue_source rather than r.PathValue.
+	PathValue bool
+}
+
+// Handler returns the name of the handler type scaffolded for service.
+func (d scaffoldTemplateData) Handler(service ServiceInfo) string {
+	return scaffoldTypeName(service.Name)
+}
+
+// scaffoldFile is a file to scaffold, relative to the scaffold directory.
+type scaffoldFile struct {
+	name     string
+	template string
+	data     scaffoldTemplateData
+}
+
+// generateScaffoldFiles returns the starter files selected by the scaffold
+// option for the services in req, in place of the generated code. Files that
+// already exist in the scaffold directory are left out so protoc does not
+// overwrite them.
+func (g *Generator) generateScaffoldFiles(req *plugin.CodeGeneratorRequest) ([]*plugin.CodeGeneratorResponse_File, error) {
+	services, imp, err := g.scaffoldServices(req)
+	if err != nil || len(services) == 0 {
+		return nil, err
+	}
+
+	var planned []scaffoldFile
+	switch g.Options.Scaffold {
+	case ScaffoldHandler:
+		for _, service := range services {
+			planned = append(planned, scaffoldFile{
+				name:     scaffoldFileName(service.Name),
+				template: "scaffold-handler",
+				data:     scaffoldTemplateData{Package: g.Options.scaffoldPackage(), Import: imp, Service: service, PathValue: g.Options.PathValueSource != ""},
+			})
+		}
+	case ScaffoldProject:
+		root := g.scaffoldImportPath(imp)
+		data := scaffoldTemplateData{
+			Import:        imp,
+			Services:      services,
+			HandlerImport: GoImport{Path: root + "/handler", Name: "handler"},
+			ServiceImport: GoImport{Path: root + "/service", Name: "service"},
+			PathValue:     g.Options.PathValueSource != "",
+		}
+		data.Package = "main"
+		planned = append(planned, scaffoldFile{name: "main.go", template: "scaffold-main", data: data})
+		for _, service := range services {
+			data.Service = service
+			data.Package = "service"
+			planned = append(planned, scaffoldFile{
+				name:     path.Join("service", snakeCase(service.Name)+".go"),
+				template: "scaffold-service",
+				data:     data,
+			})
+			data.Package = "handler"
+			planned = append(planned, scaffoldFile{
+				name:     path.Join("handler", scaffoldFileName(service.Name)),
+				template: "scaffold-handler",
+				data:     data,
+			})
+		}
+	case ScaffoldDeploy:
+		root := g.scaffoldImportPath(imp)
+		data := scaffoldTemplateData{
+			Import:       imp,
+			Services:     services,
+			Binary:       path.Base(root),
+			GeneratedDir: path.Base(imp.Path),
+			ProtoFiles:   req.FileToGenerate,
+		}
+		if dir, ok := strings.CutPrefix(imp.Path, root+"/"); ok {
+			data.GeneratedDir = dir
+		}
+		planned = append(planned,
+			scaffoldFile{name: "Dockerfile", template: "scaffold-dockerfile", data: data},
+			scaffoldFile{name: "Makefile", template: "scaffold-makefile", data: data},
+		)
+	}
+
+	var files []*plugin.CodeGeneratorResponse_File
+	for _, file := range planned {
+		exists, err := fileExists(filepath.Join(g.Options.ScaffoldDir, filepath.FromSlash(file.name)))
+		if err != nil {
+			return nil, fmt.Errorf("scaffold: %v", err)
+		}
+		if exists {
+			continue
+		}
+
+		content, err := g.renderScaffold(file.name, file.template, file.data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scaffold %s: %v", file.name, err)
+		}
+		files = append(files, &plugin.CodeGeneratorResponse_File{
+			Name:    proto.String(file.name),
+			Content: proto.String(content),
+		})
+	}
+	return files, nil
+}
+
+// scaffoldServices returns the services of the files to generate and the Go
+// package of their generated code, which must be the same for all of them.
+func (g *Generator) scaffoldServices(req *plugin.CodeGeneratorRequest) ([]ServiceInfo, GoImport, error) {
+	var (
+		services []ServiceInfo
+		imp      GoImport
+	)
+	for _, file := range req.ProtoFile {
+		if !g.shouldGenerate(file.GetName(), req.FileToGenerate) || !g.hasHTTPRules(file) {
+			continue
+		}
+		data := g.buildServiceData(file)
+		if len(data.Services) == 0 {
+			continue
+		}
+		if data.GoImport.Path == "" {
+			return nil, GoImport{}, fmt.Errorf("%s: scaffold needs the Go import path of the generated code; set go_package or import_alias", file.GetName())
+		}
+		if imp.Path != "" && data.GoImport.Path != imp.Path {
+			return nil, GoImport{}, fmt.Errorf("%s: scaffold needs all services in one Go package, found %s and %s",
+				file.GetName(), imp.Path, data.GoImport.Path)
+		}
+		imp = data.GoImport
+		services = append(services, data.Services...)
+	}
+	return services, imp, nil
+}
+
+// scaffoldImportPath returns the Go import path of the scaffold directory:
+// the scaffold_import_path option, or the parent of the generated package.
+func (g *Generator) scaffoldImportPath(imp GoImport) string {
+	if g.Options.ScaffoldImportPath != "" {
+		return g.Options.ScaffoldImportPath
+	}
+	return path.Dir(imp.Path)
+}
+
+// renderScaffold executes the named scaffold template for the file name,
+// formatting the result if it is Go source.
+func (g *Generator) renderScaffold(name, tmpl string, data any) (string, error) {
+	var buf bytes.Buffer
+	if err := g.ParsedTemplates.ExecuteTemplate(&buf, tmpl, data); err != nil {
+		return "", err
+	}
+	if path.Ext(name) != ".go" {
+		return buf.String(), nil
+	}
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return "", err
+	}
+	return string(formatted), nil
+}
+
+// scaffoldTypeName returns the name of the handler type scaffolded for a
+// service, e.g. "TaskHandler" for TaskService.
+func scaffoldTypeName(service string) string {
+	if trimmed := strings.TrimSuffix(service, "Service"); trimmed != "" {
+		service = trimmed
+	}
+	return service + "Handler"
+}
+
+// scaffoldFileName returns the handler file scaffolded for a service, e.g.
+// "task_handler.go" for TaskService.
+func scaffoldFileName(service string) string {
+	return snakeCase(scaffoldTypeName(service)) + ".go"
+}
+
+// snakeCase converts a Go identifier to snake case, e.g. "TaskService" to
+// "task_service" and "HTTPProxy" to "http_proxy".
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		// A word starts at an upper-case letter after a lower-case one, or at the
+		// last letter of an initialism followed by a lower-case letter.
+		if i > 0 && unicode.IsUpper(r) && (unicode.IsLower(runes[i-1]) ||
+			(i+1 < len(runes) && unicode.IsUpper(runes[i-1]) && unicode.IsLower(runes[i+1]))) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// scaffoldRoutes describes the bindings of a method for its scaffolded doc
+// comment, grouping methods that share a pattern, e.g.
+// "PUT/PATCH /api/v1/tasks/{task_id}".
+func scaffoldRoutes(method MethodInfo) string {
+	var patterns []string
+	methods := map[string][]string{}
+	for _, rule := range method.HTTPRules {
+		if !slices.Contains(patterns, rule.Pattern) {
+			patterns = append(patterns, rule.Pattern)
+		}
+		methods[rule.Pattern] = append(methods[rule.Pattern], rule.Method)
+	}
+
+	routes := make([]string, len(patterns))
+	for i, pattern := range patterns {
+		routes[i] = strings.Join(methods[pattern], "/") + " " + pattern
+	}
+	return strings.Join(routes, ", ")
+}
+
+// fileExists reports whether a file exists at name.
+func fileExists(name string) (bool, error) {
+	_, err := os.Stat(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
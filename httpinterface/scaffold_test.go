@@ -0,0 +1,255 @@
+package httpinterface
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpinterface/parser"
+	options "google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+func TestScaffoldNames(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		service  string
+		wantType string
+		wantFile string
+	}{
+		{service: "TaskService", wantType: "TaskHandler", wantFile: "task_handler.go"},
+		{service: "UserProfileService", wantType: "UserProfileHandler", wantFile: "user_profile_handler.go"},
+		{service: "Billing", wantType: "BillingHandler", wantFile: "billing_handler.go"},
+		{service: "Service", wantType: "ServiceHandler", wantFile: "service_handler.go"},
+		{service: "HTTPProxyService", wantType: "HTTPProxyHandler", wantFile: "http_proxy_handler.go"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.service, func(t *testing.T) {
+			t.Parallel()
+			if got := scaffoldTypeName(tt.service); got != tt.wantType {
+				t.Errorf("scaffoldTypeName() = %q, want %q", got, tt.wantType)
+			}
+			if got := scaffoldFileName(tt.service); got != tt.wantFile {
+				t.Errorf("scaffoldFileName() = %q, want %q", got, tt.wantFile)
+			}
+		})
+	}
+}
+
+func TestScaffoldRoutes(t *testing.T) {
+	t.Parallel()
+
+	method := MethodInfo{HTTPRules: []parser.HTTPRule{
+		{Method: "PUT", Pattern: "/tasks/{id}"},
+		{Method: "PATCH", Pattern: "/tasks/{id}"},
+		{Method: "POST", Pattern: "/tasks/{id}:update"},
+	}}
+	if got, want := scaffoldRoutes(method), "PUT/PATCH /tasks/{id}, POST /tasks/{id}:update"; got != want {
+		t.Errorf("scaffoldRoutes() = %q, want %q", got, want)
+	}
+}
+
+// scaffoldRequest returns a request for tasks.proto with a TaskService bound to
+// GET /tasks/{id}, using goPackage as its go_package option.
+func scaffoldRequest(parameter, goPackage string) *plugin.CodeGeneratorRequest {
+	opts := &descriptor.MethodOptions{}
+	proto.SetExtension(opts, options.E_Http, &options.HttpRule{Pattern: &options.HttpRule_Get{Get: "/tasks/{id}"}})
+
+	file := &descriptor.FileDescriptorProto{
+		Name:        proto.String("tasks.proto"),
+		Package:     proto.String("tasks.v1"),
+		Syntax:      proto.String("proto3"),
+		MessageType: []*descriptor.DescriptorProto{{Name: proto.String("GetTaskRequest")}, {Name: proto.String("Task")}},
+		Service: []*descriptor.ServiceDescriptorProto{{
+			Name: proto.String("TaskService"),
+			Method: []*descriptor.MethodDescriptorProto{
+				{Name: proto.String("GetTask"), InputType: proto.String(".tasks.v1.GetTaskRequest"), OutputType: proto.String(".tasks.v1.Task"), Options: opts},
+			},
+		}},
+	}
+	if goPackage != "" {
+		file.Options = &descriptor.FileOptions{GoPackage: proto.String(goPackage)}
+	}
+	return &plugin.CodeGeneratorRequest{
+		Parameter:      proto.String(parameter),
+		FileToGenerate: []string{"tasks.proto"},
+		ProtoFile:      []*descriptor.FileDescriptorProto{file},
+	}
+}
+
+func TestGenerateScaffoldHandler(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	resp := New().Generate(scaffoldRequest("scaffold=handler,scaffold_dir="+dir, "example.com/tasks/pb"))
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	if len(resp.File) != 1 || resp.File[0].GetName() != "task_handler.go" {
+		t.Fatalf("Generate() files = %v, want only task_handler.go", resp.File)
+	}
+
+	code := resp.File[0].GetContent()
+	for _, expected := range []string{
+		"package handler\n",
+		`pb "example.com/tasks/pb"`,
+		"type TaskHandler struct",
+		"func NewTaskHandler() *TaskHandler",
+		"var _ pb.TaskServiceHandler = (*TaskHandler)(nil)",
+		"// HandleGetTask handles GET /tasks/{id}\nfunc (h *TaskHandler) HandleGetTask(w http.ResponseWriter, r *http.Request) {",
+		"// TODO: decode a pb.GetTaskRequest and respond with a pb.Task.\n\t// Path values: r.PathValue(\"id\").",
+		"http.StatusNotImplemented",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Scaffolded code doesn't contain %q", expected)
+		}
+	}
+	if strings.Contains(code, "DO NOT EDIT") {
+		t.Error("Scaffolded code is marked as generated")
+	}
+}
+
+func TestGenerateScaffoldHandlerPathValue(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	resp := New().Generate(scaffoldRequest("scaffold=handler,scaffold_dir="+dir+",pathvalue_source=chi", "example.com/tasks/pb"))
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	if code := resp.File[0].GetContent(); !strings.Contains(code, "\t// Path values: pb.PathValue(r, \"id\").") {
+		t.Errorf("Scaffolded handler doesn't read path values with pb.PathValue:\n%s", code)
+	}
+}
+
+func TestGenerateScaffoldProject(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		parameter  string
+		wantImport string
+	}{
+		{name: "import path from generated package", parameter: "", wantImport: "example.com/tasks"},
+		{name: "explicit import path", parameter: ",scaffold_import_path=example.com/svc", wantImport: "example.com/svc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp := New().Generate(scaffoldRequest("scaffold=project,scaffold_dir="+t.TempDir()+tt.parameter, "example.com/tasks/pb"))
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+
+			files := map[string]string{}
+			for _, file := range resp.File {
+				files[file.GetName()] = file.GetContent()
+			}
+			want := map[string][]string{
+				"main.go": {
+					"package main\n",
+					`"` + tt.wantImport + `/handler"`,
+					`"` + tt.wantImport + `/service"`,
+					"pb.RegisterTaskServiceRoutes(router, handler.NewTaskHandler(service.NewTaskService()))",
+					`http.ListenAndServe(":8080", router)`,
+				},
+				"service/task_service.go": {
+					"package service\n",
+					"type TaskService struct",
+					"func NewTaskService() *TaskService",
+				},
+				"handler/task_handler.go": {
+					"package handler\n",
+					"svc *service.TaskService",
+					"func NewTaskHandler(svc *service.TaskService) *TaskHandler",
+					"func (h *TaskHandler) HandleGetTask(w http.ResponseWriter, r *http.Request)",
+				},
+			}
+			if len(files) != len(want) {
+				t.Errorf("Generate() files = %d, want %d", len(files), len(want))
+			}
+			for name, expected := range want {
+				code, ok := files[name]
+				if !ok {
+					t.Errorf("Generate() did not write %s", name)
+					continue
+				}
+				for _, e := range expected {
+					if !strings.Contains(code, e) {
+						t.Errorf("%s doesn't contain %q", name, e)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateScaffoldDeploy(t *testing.T) {
+	t.Parallel()
+
+	resp := New().Generate(scaffoldRequest("scaffold=deploy,scaffold_dir="+t.TempDir(), "example.com/tasks/api/pb"))
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+
+	files := map[string]string{}
+	for _, file := range resp.File {
+		files[file.GetName()] = file.GetContent()
+	}
+	want := map[string][]string{
+		"Dockerfile": {
+			"FROM golang:1.24 AS build",
+			"-o /out/api .",
+			`ENTRYPOINT ["/api"]`,
+		},
+		"Makefile": {
+			".PHONY: generate build run test docker",
+			"BINARY ?= api\n",
+			"PROTO_FILES ?= tasks.proto\n",
+			"GENERATED_DIR ?= pb\n",
+			"\tgo test ./...\n",
+		},
+	}
+	if len(files) != len(want) {
+		t.Errorf("Generate() files = %d, want %d", len(files), len(want))
+	}
+	for name, expected := range want {
+		for _, e := range expected {
+			if !strings.Contains(files[name], e) {
+				t.Errorf("%s doesn't contain %q", name, e)
+			}
+		}
+	}
+}
+
+func TestGenerateScaffoldSkipsExistingFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "task_handler.go"), []byte("package handler\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	resp := New().Generate(scaffoldRequest("scaffold=handler,scaffold_dir="+dir, "example.com/tasks/pb"))
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	if len(resp.File) != 0 {
+		t.Errorf("Generate() returned %d files, want none for an existing handler", len(resp.File))
+	}
+}
+
+func TestGenerateScaffoldWithoutGoPackage(t *testing.T) {
+	t.Parallel()
+
+	resp := New().Generate(scaffoldRequest("scaffold=handler,scaffold_dir="+t.TempDir(), ""))
+	if !strings.Contains(resp.GetError(), "tasks.proto: scaffold needs the Go import path") {
+		t.Errorf("Generate() error = %q, want missing import path error", resp.GetError())
+	}
+}
@@ -0,0 +1,61 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateCodeScope(t *testing.T) {
+	t.Parallel()
+	g := New()
+
+	data := func(options Options) *ServiceData {
+		return newServiceData("api", options, newService("ItemService",
+			newMethod("GetItem", "Item", "Item", newRule("GET", "/items/{id}", "")),
+		))
+	}
+
+	code, err := g.GenerateCode(data(Options{Scope: true}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	for _, expected := range []string{
+		"type ScopeFactory interface {\n\tNew(r *http.Request) (context.Context, func())\n}",
+		"type ScopeFunc func(r *http.Request) (context.Context, func())",
+		"type RouterOption func(*RouteGroup)",
+		"// Options such as WithScope apply to the router and all its groups.\n" +
+			"func NewRouter(mux *http.ServeMux, opts ...RouterOption) *RouteGroup",
+		"func WithScope(factory ScopeFactory) RouterOption",
+		"\tif g.scope != nil {\n\t\thandler = withScope(g.scope, handler)\n\t}\n",
+		"scope:       g.scope,",
+		"\t\"context\"\n",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+	if strings.Contains(code, "UnitOfWork") {
+		t.Error("Generated code contains UnitOfWork without unit_of_work")
+	}
+
+	// The scope wraps the unit of work, so Begin receives the scope's context.
+	code, err = g.GenerateCode(data(Options{Scope: true, UnitOfWork: true}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	if strings.Count(code, "type RouterOption func(*RouteGroup)") != 1 {
+		t.Error("Generated code doesn't declare RouterOption once")
+	}
+	uow, scope := strings.Index(code, "handler = withUnitOfWork("), strings.Index(code, "handler = withScope(")
+	if uow < 0 || scope < uow {
+		t.Error("Generated code doesn't wrap the unit of work in the scope")
+	}
+
+	code, err = g.GenerateCode(data(Options{}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	if strings.Contains(code, "Scope") || strings.Contains(code, "RouterOption") {
+		t.Error("Generated code contains Scope without scope")
+	}
+}
@@ -0,0 +1,48 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateCodeServerTiming(t *testing.T) {
+	t.Parallel()
+	g := New()
+
+	code, err := g.GenerateCode(itemServiceData(Options{Binding: true, ServerTiming: true}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	for _, expected := range []string{
+		"func ServerTiming(allow func(*http.Request) bool) Middleware {",
+		"func AddServerTiming(ctx context.Context, name string, d time.Duration) {",
+		"func StartServerTiming(ctx context.Context, name string) func() {",
+		"w.Header().Set(\"Server-Timing\", w.timings.header())",
+		"\thandler = serverTimingRoute(handler)\n",
+		"\tstopTiming := StartServerTiming(r.Context(), \"decode\")\n",
+		"\tstopTiming()\n\tstopTiming = StartServerTiming(r.Context(), \"handler\")\n\n\tresp, err := interceptor(r.Context(), rpc, req, handler)\n\tstopTiming()\n",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+
+	// The middleware alone needs none of the binding imports
+	code, err = g.GenerateCode(newServiceData("api", Options{ServerTiming: true}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	for _, expected := range []string{"\t\"context\"\n", "\t\"strconv\"\n", "\t\"sync\"\n", "\t\"time\"\n", "func ServerTiming("} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code without binding doesn't contain %q", expected)
+		}
+	}
+
+	code, err = g.GenerateCode(itemServiceData(Options{Binding: true}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	if strings.Contains(code, "ServerTiming") || strings.Contains(code, "stopTiming") {
+		t.Error("Generated code records server timing without server_timing=true")
+	}
+}
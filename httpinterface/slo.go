@@ -0,0 +1,203 @@
+package httpinterface
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpserver"
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+	"sigs.k8s.io/yaml"
+)
+
+// sloMetric is the request duration histogram the Prometheus rules read, as
+// named by the OpenTelemetry HTTP semantic conventions.
+const sloMetric = "http_server_request_duration_seconds"
+
+// Names of the series recorded by the Prometheus rules, one per route.
+const (
+	sloLatencyRecord = "route:http_server_request_duration_seconds:p99_5m"
+	sloErrorsRecord  = "route:http_server_request_errors:ratio_rate5m"
+)
+
+// sloManifest is the <file>_http.slo.yaml document listing the objectives of
+// the methods that set (http_server.slo).
+type sloManifest struct {
+	Source   string       `json:"source"`
+	Services []sloService `json:"services"`
+}
+
+type sloService struct {
+	Name    string      `json:"name"`
+	Methods []sloMethod `json:"methods"`
+}
+
+type sloMethod struct {
+	Name   string   `json:"name"`
+	Routes []string `json:"routes"`
+	// LatencyP99Ms and AvailabilityPercent mirror the http_server.Slo fields.
+	LatencyP99Ms        uint32  `json:"latency_p99_ms,omitempty"`
+	AvailabilityPercent float64 `json:"availability_percent,omitempty"`
+}
+
+// prometheusRules is a Prometheus rule file.
+type prometheusRules struct {
+	Groups []prometheusRuleGroup `json:"groups"`
+}
+
+type prometheusRuleGroup struct {
+	Name  string           `json:"name"`
+	Rules []prometheusRule `json:"rules"`
+}
+
+type prometheusRule struct {
+	Record      string            `json:"record,omitempty"`
+	Alert       string            `json:"alert,omitempty"`
+	Expr        string            `json:"expr"`
+	For         string            `json:"for,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// generateSLOFiles returns the <file>_http.slo.yaml manifest of file and, with
+// slo_prometheus, its <file>_http.slo.rules.yaml Prometheus rules. Files
+// without methods setting (http_server.slo) get neither.
+func (g *Generator) generateSLOFiles(file *descriptor.FileDescriptorProto, data *ServiceData) ([]*plugin.CodeGeneratorResponse_File, error) {
+	manifest := sloManifest{Source: file.GetName()}
+	var rules prometheusRules
+	for _, service := range data.Services {
+		fullName := service.Name
+		if file.GetPackage() != "" {
+			fullName = file.GetPackage() + "." + service.Name
+		}
+		entry := sloService{Name: fullName}
+		group := prometheusRuleGroup{Name: fullName + ".slo"}
+		for _, method := range service.Methods {
+			slo := methodSLO(findMethod(file, service.Name, method.Name))
+			if slo == nil {
+				continue
+			}
+			if slo.GetLatencyP99Ms() == 0 && slo.GetAvailabilityPercent() == 0 {
+				return nil, fmt.Errorf("%s: %s.%s sets (http_server.slo) without latency_p99_ms or availability_percent",
+					file.GetName(), service.Name, method.Name)
+			}
+			if p := slo.GetAvailabilityPercent(); p < 0 || p >= 100 {
+				return nil, fmt.Errorf("%s: %s.%s: (http_server.slo) availability_percent must be at least 0 and below 100, got %v",
+					file.GetName(), service.Name, method.Name, p)
+			}
+
+			m := sloMethod{
+				Name:                method.Name,
+				LatencyP99Ms:        slo.GetLatencyP99Ms(),
+				AvailabilityPercent: slo.GetAvailabilityPercent(),
+			}
+			for _, rule := range method.HTTPRules {
+				m.Routes = append(m.Routes, rule.Method+" "+rule.Pattern)
+			}
+			entry.Methods = append(entry.Methods, m)
+			group.Rules = append(group.Rules, sloPrometheusRules(fullName, service.Name, method, slo)...)
+		}
+		if len(entry.Methods) > 0 {
+			manifest.Services = append(manifest.Services, entry)
+			rules.Groups = append(rules.Groups, group)
+		}
+	}
+	if len(manifest.Services) == 0 {
+		return nil, nil
+	}
+
+	base := strings.TrimSuffix(g.getOutputFilename(file.GetName()), ".pb.go")
+	manifestFile, err := g.sloFile(file, base+".slo.yaml", manifest)
+	if err != nil {
+		return nil, err
+	}
+	files := []*plugin.CodeGeneratorResponse_File{manifestFile}
+	if g.Options.SLOPrometheus {
+		rulesFile, err := g.sloFile(file, base+".slo.rules.yaml", rules)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, rulesFile)
+	}
+	return files, nil
+}
+
+// sloFile returns doc as the YAML file name generated from file.
+func (g *Generator) sloFile(file *descriptor.FileDescriptorProto, name string, doc any) (*plugin.CodeGeneratorResponse_File, error) {
+	content, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("error generating %s: %v", name, err)
+	}
+	header := "# Code generated by protoc-gen-go-http-server-interface. DO NOT EDIT.\n# source: " + file.GetName() + "\n\n"
+	outputFile := &plugin.CodeGeneratorResponse_File{
+		Name:    proto.String(name),
+		Content: proto.String(header + string(content)),
+	}
+	g.applySourceRelativePath(outputFile, file.GetName())
+	return outputFile, nil
+}
+
+// sloPrometheusRules returns the recording rules of each route of method, of
+// the service named fullName, and an alert per objective of slo, firing when
+// a route misses it for 10 minutes.
+func sloPrometheusRules(fullName, service string, method MethodInfo, slo *httpserver.Slo) []prometheusRule {
+	latencyMs, availability := slo.GetLatencyP99Ms(), slo.GetAvailabilityPercent()
+
+	var rules []prometheusRule
+	for _, rule := range method.HTTPRules {
+		selector := fmt.Sprintf("http_request_method=%q,http_route=%q", rule.Method, rule.Pattern)
+		labels := map[string]string{"service": fullName, "rpc": method.Name, "route": rule.Method + " " + rule.Pattern}
+		if len(method.Tags) > 0 {
+			labels["tags"] = strings.Join(method.Tags, ",")
+		}
+		if latencyMs > 0 {
+			rules = append(rules, prometheusRule{
+				Record: sloLatencyRecord,
+				Expr:   fmt.Sprintf("histogram_quantile(0.99, sum by (le) (rate(%s_bucket{%s}[5m])))", sloMetric, selector),
+				Labels: labels,
+			})
+		}
+		if availability > 0 {
+			rules = append(rules, prometheusRule{
+				Record: sloErrorsRecord,
+				Expr: fmt.Sprintf("sum(rate(%s_count{%s,http_response_status_code=~\"5..\"}[5m])) / sum(rate(%s_count{%s}[5m]))",
+					sloMetric, selector, sloMetric, selector),
+				Labels: labels,
+			})
+		}
+	}
+
+	series := fmt.Sprintf("{service=%q,rpc=%q}", fullName, method.Name)
+	if latencyMs > 0 {
+		rules = append(rules, prometheusRule{
+			Alert:  service + method.Name + "LatencySLO",
+			Expr:   sloLatencyRecord + series + " > " + sloNumber(float64(latencyMs)/1000),
+			For:    "10m",
+			Labels: map[string]string{"severity": "warning"},
+			Annotations: map[string]string{
+				"summary": fmt.Sprintf("%s.%s p99 latency is above its %dms objective on {{ $labels.route }}", service, method.Name, latencyMs),
+			},
+		})
+	}
+	if availability > 0 {
+		rules = append(rules, prometheusRule{
+			Alert:  service + method.Name + "AvailabilitySLO",
+			Expr:   sloErrorsRecord + series + " > " + sloNumber((100-availability)/100),
+			For:    "10m",
+			Labels: map[string]string{"severity": "warning"},
+			Annotations: map[string]string{
+				"summary": fmt.Sprintf("%s.%s availability is below its %s%% objective on {{ $labels.route }}", service, method.Name, sloNumber(availability)),
+			},
+		})
+	}
+	return rules
+}
+
+// sloNumber formats v for a PromQL threshold, dropping the floating-point
+// noise of computing it, so a 99.9% objective allows an error ratio of 0.001.
+func sloNumber(v float64) string {
+	return strconv.FormatFloat(math.Round(v*1e9)/1e9, 'g', -1, 64)
+}
@@ -0,0 +1,125 @@
+package httpinterface
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpserver"
+)
+
+func TestGenerateSLO(t *testing.T) {
+	t.Parallel()
+
+	req := graphqlRequest(t, "binding=true,slo=true,slo_prometheus=true")
+	setMethodExtension(req.ProtoFile[0], "GetItem", httpserver.E_Slo, &httpserver.Slo{LatencyP99Ms: 200, AvailabilityPercent: 99.9})
+	resp := New().Generate(req)
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	files := map[string]string{}
+	for _, f := range resp.File {
+		files[f.GetName()] = f.GetContent()
+	}
+
+	manifest, ok := files["items_http.slo.yaml"]
+	if !ok {
+		t.Fatalf("missing items_http.slo.yaml in %d generated files", len(resp.File))
+	}
+	for _, expected := range []string{
+		"# Code generated by protoc-gen-go-http-server-interface. DO NOT EDIT.\n# source: items.proto\n",
+		"- methods:\n  - availability_percent: 99.9\n    latency_p99_ms: 200\n    name: GetItem\n    routes:\n    - GET /v1/items/{item_id}\n  name: items.v1.ItemService\n",
+	} {
+		if !strings.Contains(manifest, expected) {
+			t.Errorf("manifest doesn't contain %q:\n%s", expected, manifest)
+		}
+	}
+	if strings.Contains(manifest, "SaveItem") {
+		t.Error("manifest lists a method without (http_server.slo)")
+	}
+
+	rules := files["items_http.slo.rules.yaml"]
+	for _, expected := range []string{
+		"- name: items.v1.ItemService.slo\n",
+		"histogram_quantile(0.99, sum by (le) (rate(http_server_request_duration_seconds_bucket{http_request_method=\"GET\",http_route=\"/v1/items/{item_id}\"}[5m])))",
+		"record: route:http_server_request_duration_seconds:p99_5m\n",
+		"record: route:http_server_request_errors:ratio_rate5m\n",
+		"      route: GET /v1/items/{item_id}\n      rpc: GetItem\n      service: items.v1.ItemService\n",
+		"- alert: ItemServiceGetItemLatencySLO\n",
+		"expr: route:http_server_request_duration_seconds:p99_5m{service=\"items.v1.ItemService\",rpc=\"GetItem\"}\n      > 0.2\n",
+		"- alert: ItemServiceGetItemAvailabilitySLO\n",
+		"expr: route:http_server_request_errors:ratio_rate5m{service=\"items.v1.ItemService\",rpc=\"GetItem\"}\n      > 0.001\n",
+	} {
+		if !strings.Contains(rules, expected) {
+			t.Errorf("rules don't contain %q:\n%s", expected, rules)
+		}
+	}
+}
+
+func TestGenerateSLOLatencyOnly(t *testing.T) {
+	t.Parallel()
+
+	req := graphqlRequest(t, "binding=true,slo=true")
+	setMethodExtension(req.ProtoFile[0], "GetItem", httpserver.E_Slo, &httpserver.Slo{LatencyP99Ms: 250})
+	resp := New().Generate(req)
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	var names []string
+	for _, f := range resp.File {
+		names = append(names, f.GetName())
+		if f.GetName() == "items_http.slo.yaml" && strings.Contains(f.GetContent(), "availability_percent") {
+			t.Errorf("manifest has an availability objective:\n%s", f.GetContent())
+		}
+	}
+	if !slices.Contains(names, "items_http.slo.yaml") || slices.Contains(names, "items_http.slo.rules.yaml") {
+		t.Errorf("Generate() wrote %v, want the manifest without rules", names)
+	}
+}
+
+func TestGenerateSLOInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		slo     *httpserver.Slo
+		wantErr string
+	}{
+		{
+			name:    "no objective",
+			slo:     &httpserver.Slo{},
+			wantErr: "items.proto: ItemService.GetItem sets (http_server.slo) without latency_p99_ms or availability_percent",
+		},
+		{
+			name:    "availability of 100 percent",
+			slo:     &httpserver.Slo{AvailabilityPercent: 100},
+			wantErr: "availability_percent must be at least 0 and below 100, got 100",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := graphqlRequest(t, "binding=true,slo=true")
+			setMethodExtension(req.ProtoFile[0], "GetItem", httpserver.E_Slo, tt.slo)
+			resp := New().Generate(req)
+			if !strings.Contains(resp.GetError(), tt.wantErr) {
+				t.Errorf("Generate() error = %q, want %q", resp.GetError(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerateSLOWithoutObjectives(t *testing.T) {
+	t.Parallel()
+
+	resp := New().Generate(graphqlRequest(t, "binding=true,slo=true,slo_prometheus=true"))
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	for _, f := range resp.File {
+		if strings.Contains(f.GetName(), ".slo.") {
+			t.Errorf("Generate() wrote %s for a file without (http_server.slo)", f.GetName())
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateCodeStaticErrors(t *testing.T) {
+	t.Parallel()
+	g := New()
+
+	code, err := g.GenerateCode(newServiceData("api", Options{StaticErrors: true}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	for _, expected := range []string{
+		"\t\"bytes\"\n", "\t\"compress/gzip\"\n", "\t\"strconv\"\n",
+		"var staticErrors = map[int]*staticError{",
+		"http.StatusNotFound:            newStaticError(`{\"error\":{\"code\":404,\"message\":\"Not Found\",\"status\":\"NOT_FOUND\"}}`),",
+		"func WriteStaticError(w http.ResponseWriter, r *http.Request, status int) {",
+		"func acceptsGzip(r *http.Request) bool {",
+		"\tif _, pattern := g.mux.Handler(r); pattern == \"\" {\n",
+		"g.mux.ServeHTTP(&staticErrorResponseWriter{ResponseWriter: w, r: r}, r)",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+
+	code, err = g.GenerateCode(newServiceData("api", Options{}))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	if strings.Contains(code, "staticError") || strings.Contains(code, "g.mux.Handler(r)") {
+		t.Error("Generated code writes static errors without static_errors=true")
+	}
+}
@@ -0,0 +1,44 @@
+package httpinterface
+
+import "fmt"
+
+// HasStreamArray reports whether any method in d sets the
+// (http_server.stream_array) option, so the generated file needs the
+// StreamJSONArray helpers.
+func (d *ServiceData) HasStreamArray() bool {
+	for _, service := range d.Services {
+		if service.HasStreamArrayMethods() {
+			return true
+		}
+	}
+	return false
+}
+
+// HasStreamArrayMethods reports whether any method of s sets the
+// (http_server.stream_array) option.
+func (s ServiceInfo) HasStreamArrayMethods() bool {
+	for _, method := range s.Methods {
+		if method.StreamArray {
+			return true
+		}
+	}
+	return false
+}
+
+// checkStreamArray reports an error if a method in data sets the
+// (http_server.stream_array) option without binding=true, which provides the
+// encoder the streaming helpers are built on.
+func checkStreamArray(data *ServiceData) error {
+	if data.Options.Binding {
+		return nil
+	}
+	for _, service := range data.Services {
+		for _, method := range service.Methods {
+			if method.StreamArray {
+				return fmt.Errorf("%s.%s sets (http_server.stream_array), which requires binding=true",
+					service.Name, method.Name)
+			}
+		}
+	}
+	return nil
+}
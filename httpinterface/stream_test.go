@@ -0,0 +1,76 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpserver"
+)
+
+func TestGenerateStreamArray(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		parameter   string
+		wantErr     string
+		wantContain []string
+	}{
+		{
+			name:      "with binding",
+			parameter: "binding=true",
+			wantContain: []string{
+				"\t\"time\"\n",
+				"type JSONArrayStreamer struct",
+				"var DefaultJSONArrayStreamer = JSONArrayStreamer{",
+				"func StreamJSONArray(w http.ResponseWriter, iter func() (proto.Message, bool, error)) error",
+				"func (s JSONArrayStreamer) Stream(w http.ResponseWriter, iter func() (proto.Message, bool, error)) error",
+			},
+		},
+		{
+			name:      "without binding",
+			parameter: "",
+			wantErr:   "items.proto: ItemService.ListItems sets (http_server.stream_array), which requires binding=true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp := New().Generate(itemsRequest(t, tt.parameter,
+				itemMethod("ListItems", getRule("/items"), withExtension(httpserver.E_StreamArray, true)),
+				itemMethod("GetItem", getRule("/items/{id}")),
+			))
+
+			if tt.wantErr != "" {
+				if !strings.Contains(resp.GetError(), tt.wantErr) {
+					t.Fatalf("Generate() error = %q, want error containing %q", resp.GetError(), tt.wantErr)
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+			code := resp.File[0].GetContent()
+			for _, expected := range tt.wantContain {
+				if !strings.Contains(code, expected) {
+					t.Errorf("Generated code doesn't contain %q", expected)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateCodeWithoutStreamArray(t *testing.T) {
+	t.Parallel()
+
+	code, err := New().GenerateCode(newServiceData("api", Options{Binding: true}, newService("ItemService",
+		newMethod("ListItems", "Item", "Item"),
+	)))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	if strings.Contains(code, "StreamJSONArray") {
+		t.Error("Generated code contains StreamJSONArray without a stream_array method")
+	}
+}
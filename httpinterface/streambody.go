@@ -0,0 +1,41 @@
+package httpinterface
+
+import "fmt"
+
+// HasStreamBodyMethods reports whether any method of s sets the
+// (http_server.stream_body) option, so its typed handler reads a request body.
+func (s ServiceInfo) HasStreamBodyMethods() bool {
+	for _, method := range s.Methods {
+		if method.StreamBody {
+			return true
+		}
+	}
+	return false
+}
+
+// checkStreamBody reports an error if a method in data sets the
+// (http_server.stream_body) option without binding=true, which generates the
+// typed handler receiving the body, or together with an option that needs
+// the decoded or buffered request: async, which hands the request message to
+// an Enqueuer, and dedupe, which hashes the whole body.
+func checkStreamBody(data *ServiceData) error {
+	for _, service := range data.Services {
+		for _, method := range service.Methods {
+			if !method.StreamBody {
+				continue
+			}
+			switch {
+			case !data.Options.Binding:
+				return fmt.Errorf("%s.%s sets (http_server.stream_body), which requires binding=true",
+					service.Name, method.Name)
+			case method.Async:
+				return fmt.Errorf("%s.%s sets both (http_server.stream_body) and (http_server.async), "+
+					"which hands the decoded request to an Enqueuer", service.Name, method.Name)
+			case method.DedupeWindowSeconds > 0:
+				return fmt.Errorf("%s.%s sets both (http_server.stream_body) and (http_server.dedupe), "+
+					"which buffers the request body to hash it", service.Name, method.Name)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpserver"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestGenerateStreamBody(t *testing.T) {
+	t.Parallel()
+
+	// Each case generates UploadItem with the options in upload next to the
+	// unary GetItem.
+	streamBody := withExtension(httpserver.E_StreamBody, true)
+	tests := []struct {
+		name           string
+		parameter      string
+		upload         []func(*descriptor.MethodOptions)
+		wantErr        string
+		wantContain    []string
+		wantNotContain []string
+	}{
+		{
+			name:      "with binding",
+			parameter: "binding=true,strict_content_type=true,mock=true",
+			upload:    []func(*descriptor.MethodOptions){streamBody},
+			wantContain: []string{
+				"UploadItem(ctx context.Context, req *Item, body io.Reader) (*Item, error)",
+				`serveUnary(w, r, "/items.v1.ItemService/UploadItem", &Item{}, "", []string{"id"}, a.interceptor,`,
+				"return a.srv.UploadItem(ctx, req.(*Item), r.Body)",
+				`r.HandleFunc(http.MethodPut, "/items/{id}/content", handler.HandleUploadItem)`,
+				`r.HandleFunc(http.MethodGet, "/items/{id}", requireContentType(handler.HandleGetItem, false))`,
+				"func (m *itemServiceMock) UploadItem(ctx context.Context, req *Item, body io.Reader) (*Item, error)",
+			},
+			wantNotContain: []string{"requireContentType(handler.HandleUploadItem"},
+		},
+		{
+			name:           "client leaves the method out",
+			parameter:      "binding=true,client=true",
+			upload:         []func(*descriptor.MethodOptions){streamBody},
+			wantContain:    []string{"func (c *ItemServiceClient) GetItem(", "Methods marked (http_server.stream_body)"},
+			wantNotContain: []string{"func (c *ItemServiceClient) UploadItem("},
+		},
+		{
+			name:      "without binding",
+			parameter: "",
+			upload:    []func(*descriptor.MethodOptions){streamBody},
+			wantErr:   "items.proto: ItemService.UploadItem sets (http_server.stream_body), which requires binding=true",
+		},
+		{
+			name:      "with async",
+			parameter: "binding=true",
+			upload:    []func(*descriptor.MethodOptions){streamBody, withExtension(httpserver.E_Async, true)},
+			wantErr:   "ItemService.UploadItem sets both (http_server.stream_body) and (http_server.async)",
+		},
+		{
+			name:      "with dedupe",
+			parameter: "binding=true",
+			upload:    []func(*descriptor.MethodOptions){streamBody, withExtension(httpserver.E_Dedupe, &httpserver.Dedupe{WindowSeconds: 5})},
+			wantErr:   "ItemService.UploadItem sets both (http_server.stream_body) and (http_server.dedupe)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp := New().Generate(itemsRequest(t, tt.parameter,
+				itemMethod("UploadItem", putRule("/items/{id}/content", "*"), tt.upload...),
+				itemMethod("GetItem", getRule("/items/{id}")),
+			))
+
+			if tt.wantErr != "" {
+				if !strings.Contains(resp.GetError(), tt.wantErr) {
+					t.Fatalf("Generate() error = %q, want error containing %q", resp.GetError(), tt.wantErr)
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+			code := resp.File[0].GetContent()
+			for _, expected := range tt.wantContain {
+				if !strings.Contains(code, expected) {
+					t.Errorf("Generated code doesn't contain %q", expected)
+				}
+			}
+			for _, unexpected := range tt.wantNotContain {
+				if strings.Contains(code, unexpected) {
+					t.Errorf("Generated code contains %q", unexpected)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package httpinterface
+
+import (
+	"strconv"
+	"strings"
+)
+
+// HasTags reports whether any method in d has tags from the
+// (http_server.service_tags) or (http_server.tags) options, so the generated
+// file needs RouteTags.
+func (d *ServiceData) HasTags() bool {
+	for _, service := range d.Services {
+		for _, method := range service.Methods {
+			if len(method.Tags) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// goStringSlice returns values as a Go []string literal, such as
+// []string{"tasks", "admin"}.
+func goStringSlice(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = strconv.Quote(v)
+	}
+	return "[]string{" + strings.Join(quoted, ", ") + "}"
+}
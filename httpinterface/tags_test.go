@@ -0,0 +1,134 @@
+package httpinterface
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpserver"
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestGenerateTags(t *testing.T) {
+	t.Parallel()
+
+	req := graphqlRequest(t, "binding=true,auto_options=true,self_description=true,doc=true,"+
+		"slo=true,slo_prometheus=true,gateway_openapi=aws,gateway_backend=https://items.example.com")
+	file := setServiceExtension(req.ProtoFile[0], httpserver.E_ServiceTags, []string{"items"})
+	setMethodExtension(file, "SaveItem", httpserver.E_Tags, []string{"admin", "items"})
+	setMethodExtension(file, "SaveItem", httpserver.E_Slo, &httpserver.Slo{AvailabilityPercent: 99.9})
+	resp := New().Generate(req)
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	files := map[string]string{}
+	for _, f := range resp.File {
+		files[f.GetName()] = f.GetContent()
+	}
+
+	for name, expected := range map[string][]string{
+		"items_http.pb.go": {
+			"var routeTags = []struct {\n\tmethod, pattern string\n\ttags            []string\n}{\n" +
+				"\t{\"GET\", \"/v1/items/{item_id}\", []string{\"items\"}},\n" +
+				"\t{\"PUT\", \"/v1/items/{item_id}\", []string{\"items\", \"admin\"}},\n" +
+				"\t{\"POST\", \"/v1/items:export\", []string{\"items\"}},\n}",
+			"func RouteTags(r *http.Request) []string {",
+			"\tTags []string `json:\"tags,omitempty\"`\n",
+			`{Method: "PUT", RPC: "/items.v1.ItemService/SaveItem", Body: true, Tags: []string{"items", "admin"}},`,
+		},
+		"doc.go": {
+			"//	GET  /v1/items/{item_id}  HandleGetItem [items]\n",
+			"//	PUT  /v1/items/{item_id}  HandleSaveItem [items, admin]\n",
+		},
+		"items_http_gateway.swagger.yaml": {
+			"      tags:\n      - items\n      - admin\n      x-amazon-apigateway-integration:\n        httpMethod: PUT\n",
+		},
+		"items_http.slo.rules.yaml": {
+			"      route: PUT /v1/items/{item_id}\n      rpc: SaveItem\n      service: items.v1.ItemService\n      tags: items,admin\n",
+		},
+	} {
+		content, ok := files[name]
+		if !ok {
+			t.Errorf("missing %s in %d generated files", name, len(resp.File))
+			continue
+		}
+		for _, e := range expected {
+			if !strings.Contains(content, e) {
+				t.Errorf("%s doesn't contain %q", name, e)
+			}
+		}
+	}
+}
+
+func TestGenerateUseForTags(t *testing.T) {
+	t.Parallel()
+
+	req := graphqlRequest(t, "binding=true")
+	file := setServiceExtension(req.ProtoFile[0], httpserver.E_ServiceTags, []string{"items"})
+	setMethodExtension(file, "SaveItem", httpserver.E_Tags, []string{"admin", "items"})
+	setMethodExtension(file, "SaveItem", httpserver.E_Slo, &httpserver.Slo{AvailabilityPercent: 99.9})
+	resp := New().Generate(req)
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	code := resp.File[0].GetContent()
+	for _, expected := range []string{
+		"\ttagged      []taggedMiddlewares\n",
+		"\t\ttagged:      slices.Clip(g.tagged),\n",
+		"func (g *RouteGroup) UseForTags(tag string, middlewares ...Middleware) Router {",
+		"\t\tif routeHasTag(method, pattern, t.tag) {\n\t\t\tmiddlewares = appendMiddlewares(middlewares, t.middlewares)\n",
+		"\tfinalHandler := applyMiddlewares(handler, middlewares)\n",
+		"func routeHasTag(method, pattern, tag string) bool {",
+		"\t\"slices\"\n",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+}
+
+func TestGenerateWithoutTags(t *testing.T) {
+	t.Parallel()
+
+	resp := New().Generate(graphqlRequest(t, "binding=true,auto_options=true,self_description=true,doc=true"))
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	for _, f := range resp.File {
+		for _, unexpected := range []string{"RouteTags", "routeTags", "UseForTags", "tagged", "Tags: []string", "HandleGetItem ["} {
+			if strings.Contains(f.GetContent(), unexpected) {
+				t.Errorf("%s contains %q without tags", f.GetName(), unexpected)
+			}
+		}
+	}
+}
+
+func TestMethodTags(t *testing.T) {
+	t.Parallel()
+
+	serviceOpts := &descriptor.ServiceOptions{}
+	proto.SetExtension(serviceOpts, httpserver.E_ServiceTags, []string{"tasks", "public"})
+	methodOpts := &descriptor.MethodOptions{}
+	proto.SetExtension(methodOpts, httpserver.E_Tags, []string{"admin", "tasks"})
+
+	tests := []struct {
+		name    string
+		service *descriptor.ServiceDescriptorProto
+		method  *descriptor.MethodDescriptorProto
+		want    []string
+	}{
+		{"none", &descriptor.ServiceDescriptorProto{}, &descriptor.MethodDescriptorProto{}, nil},
+		{"service", &descriptor.ServiceDescriptorProto{Options: serviceOpts}, &descriptor.MethodDescriptorProto{}, []string{"tasks", "public"}},
+		{"method", &descriptor.ServiceDescriptorProto{}, &descriptor.MethodDescriptorProto{Options: methodOpts}, []string{"admin", "tasks"}},
+		{"both", &descriptor.ServiceDescriptorProto{Options: serviceOpts}, &descriptor.MethodDescriptorProto{Options: methodOpts}, []string{"tasks", "public", "admin"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := methodTags(tt.service, tt.method); !slices.Equal(got, tt.want) {
+				t.Errorf("methodTags() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
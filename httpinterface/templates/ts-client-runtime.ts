@@ -0,0 +1,94 @@
+/** Options of the generated clients. */
+export interface ClientOptions {
+  /** Base URL of the server, such as "https://api.example.com". */
+  baseUrl: string;
+  /** Function sending the requests, the global fetch when unset. */
+  fetch?: typeof fetch;
+  /** Headers sent with every request. */
+  headers?: HeadersInit;
+}
+
+/** Thrown by the generated clients when the server responds with a status other than 2xx. */
+export class ClientError extends Error {
+  constructor(
+    /** HTTP status code of the response. */
+    readonly status: number,
+    /** Response body. */
+    readonly body: string,
+  ) {
+    super(`http status ${status}: ${body.trim() || "request failed"}`);
+    this.name = "ClientError";
+  }
+}
+
+/**
+ * pathValue returns the escaped value of the path parameter name. The value of
+ * a {name...} wildcard keeps its slashes.
+ */
+function pathValue(value: unknown, name: string, wildcard: boolean): string {
+  if (value === undefined || value === null || value === "") {
+    throw new Error(`path parameter {${name}} is not set`);
+  }
+  const s = String(value);
+  return wildcard ? s.split("/").map(encodeURIComponent).join("/") : encodeURIComponent(s);
+}
+
+/**
+ * encodeQuery adds the set fields of value, other than those at or under a
+ * path in skip, to params in the form the server binds them: nested fields
+ * with dotted keys, the map fields in maps with brackets and repeated fields
+ * as repeated keys.
+ */
+function encodeQuery(value: object, prefix: string, skip: string[], maps: string[], params: URLSearchParams): URLSearchParams {
+  for (const [name, v] of Object.entries(value)) {
+    const key = prefix + name;
+    if (v === undefined || v === null || skip.includes(key)) {
+      continue;
+    }
+    if (Array.isArray(v)) {
+      for (const item of v) {
+        params.append(key, String(item));
+      }
+    } else if (typeof v === "object" && maps.includes(key)) {
+      for (const [k, item] of Object.entries(v)) {
+        params.append(`${key}[${k}]`, String(item));
+      }
+    } else if (typeof v === "object") {
+      encodeQuery(v, key + ".", skip, maps, params);
+    } else {
+      params.append(key, String(v));
+    }
+  }
+  return params;
+}
+
+/**
+ * invoke sends a request to path on the server of options, with body as its
+ * JSON body unless it is undefined, and returns the decoded JSON response.
+ * Non-2xx responses throw ClientError.
+ */
+async function invoke<T>(options: ClientOptions, method: string, path: string, query: URLSearchParams | undefined, body: unknown, init?: RequestInit): Promise<T> {
+  let url = options.baseUrl.replace(/\/+$/, "") + path;
+  const qs = query?.toString();
+  if (qs) {
+    url += "?" + qs;
+  }
+  const headers = new Headers(options.headers);
+  new Headers(init?.headers).forEach((value, name) => headers.set(name, value));
+  headers.set("Accept", "application/json");
+  if (body !== undefined) {
+    headers.set("Content-Type", "application/json");
+  }
+
+  const resp = await (options.fetch ?? fetch)(url, {
+    ...init,
+    method,
+    headers,
+    body: body === undefined ? undefined : JSON.stringify(body),
+  });
+  const text = await resp.text();
+  if (!resp.ok) {
+    throw new ClientError(resp.status, text);
+  }
+  return (text.trim() === "" ? {} : JSON.parse(text)) as T;
+}
@@ -0,0 +1,27 @@
+package httpinterface
+
+// HasTimeouts reports whether m sets the (http_server.timeouts) option, so its
+// routes are served through routeTimeouts.
+func (m MethodInfo) HasTimeouts() bool {
+	return m.ReadTimeoutMs > 0 || m.WriteTimeoutMs > 0
+}
+
+// WithoutTimeouts returns m without its (http_server.timeouts) deadlines, for
+// routes whose RouteConfig applies them in place of routeTimeouts.
+func (m MethodInfo) WithoutTimeouts() MethodInfo {
+	m.ReadTimeoutMs, m.WriteTimeoutMs = 0, 0
+	return m
+}
+
+// HasTimeouts reports whether any method in d sets the (http_server.timeouts)
+// option, so the generated file needs the routeTimeouts helper.
+func (d *ServiceData) HasTimeouts() bool {
+	for _, service := range d.Services {
+		for _, method := range service.Methods {
+			if method.HasTimeouts() {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpserver"
+)
+
+func TestGenerateTimeouts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		parameter      string
+		timeouts       *httpserver.Timeouts
+		wantContain    []string
+		wantNotContain []string
+	}{
+		{
+			name:      "read and write",
+			parameter: "",
+			timeouts:  &httpserver.Timeouts{ReadMs: 5000, WriteMs: 300000},
+			wantContain: []string{
+				`"time"`,
+				"func routeTimeouts(readMs, writeMs int, h http.HandlerFunc) http.HandlerFunc {",
+				"rc := http.NewResponseController(w)",
+				`r.HandleFunc(http.MethodPost, "/items:export", routeTimeouts(5000, 300000, handler.HandleExportItems))`,
+				`r.HandleFunc(http.MethodPost, "/items:export", routeTimeouts(5000, 300000, h.ServeHTTP))`,
+				`r.HandleFunc(http.MethodGet, "/items", handler.HandleListItems)`,
+			},
+		},
+		{
+			name:      "outside the other wrappers",
+			parameter: "strict_content_type=true,decompress=true,binding=true",
+			timeouts:  &httpserver.Timeouts{WriteMs: 60000},
+			wantContain: []string{
+				`routeTimeouts(0, 60000, requireContentType(decompressBody(handler.HandleExportItems), true))`,
+				`requireContentType(handler.HandleListItems, false)`,
+			},
+		},
+		{
+			name:           "empty option",
+			parameter:      "",
+			timeouts:       &httpserver.Timeouts{},
+			wantNotContain: []string{"routeTimeouts", `"time"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp := New().Generate(itemsRequest(t, tt.parameter,
+				itemMethod("ExportItems", postRule("/items:export", "*"), withExtension(httpserver.E_Timeouts, tt.timeouts)),
+				itemMethod("ListItems", getRule("/items")),
+			))
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+			code := resp.File[0].GetContent()
+			for _, expected := range tt.wantContain {
+				if !strings.Contains(code, expected) {
+					t.Errorf("Generated code doesn't contain %q", expected)
+				}
+			}
+			for _, unexpected := range tt.wantNotContain {
+				if strings.Contains(code, unexpected) {
+					t.Errorf("Generated code contains %q", unexpected)
+				}
+			}
+		})
+	}
+}
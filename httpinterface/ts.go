@@ -0,0 +1,331 @@
+package httpinterface
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+)
+
+// tsPathParamRegex matches a path parameter such as {task_id} or {name...}.
+var tsPathParamRegex = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// tsWellKnownTypes maps google.protobuf messages to the TypeScript type of
+// their JSON form.
+var tsWellKnownTypes = map[string]string{
+	".google.protobuf.Timestamp":   "string",
+	".google.protobuf.Duration":    "string",
+	".google.protobuf.FieldMask":   "string",
+	".google.protobuf.DoubleValue": "number",
+	".google.protobuf.FloatValue":  "number",
+	".google.protobuf.Int32Value":  "number",
+	".google.protobuf.UInt32Value": "number",
+	".google.protobuf.Int64Value":  "string",
+	".google.protobuf.UInt64Value": "string",
+	".google.protobuf.BoolValue":   "boolean",
+	".google.protobuf.StringValue": "string",
+	".google.protobuf.BytesValue":  "string",
+	".google.protobuf.Struct":      "{ [key: string]: unknown }",
+	".google.protobuf.Value":       "unknown",
+	".google.protobuf.ListValue":   "unknown[]",
+	".google.protobuf.Any":         `{ "@type": string; [key: string]: unknown }`,
+	".google.protobuf.Empty":       "Record<string, never>",
+}
+
+// tsScalars maps proto scalar types to TypeScript types. 64-bit integers are
+// strings in the proto JSON mapping.
+var tsScalars = map[descriptor.FieldDescriptorProto_Type]string{
+	descriptor.FieldDescriptorProto_TYPE_DOUBLE:   "number",
+	descriptor.FieldDescriptorProto_TYPE_FLOAT:    "number",
+	descriptor.FieldDescriptorProto_TYPE_INT32:    "number",
+	descriptor.FieldDescriptorProto_TYPE_SINT32:   "number",
+	descriptor.FieldDescriptorProto_TYPE_SFIXED32: "number",
+	descriptor.FieldDescriptorProto_TYPE_UINT32:   "number",
+	descriptor.FieldDescriptorProto_TYPE_FIXED32:  "number",
+	descriptor.FieldDescriptorProto_TYPE_INT64:    "string",
+	descriptor.FieldDescriptorProto_TYPE_SINT64:   "string",
+	descriptor.FieldDescriptorProto_TYPE_SFIXED64: "string",
+	descriptor.FieldDescriptorProto_TYPE_UINT64:   "string",
+	descriptor.FieldDescriptorProto_TYPE_FIXED64:  "string",
+	descriptor.FieldDescriptorProto_TYPE_BOOL:     "boolean",
+	descriptor.FieldDescriptorProto_TYPE_STRING:   "string",
+	descriptor.FieldDescriptorProto_TYPE_BYTES:    "string",
+}
+
+// tsTemplateLiteral escapes s for a TypeScript template literal.
+var tsTemplateLiteral = strings.NewReplacer("\\", "\\\\", "`", "\\`", "${", "\\${")
+
+// tsTypes accumulates the interfaces and enum types of a TypeScript file in
+// the order they are first referenced.
+type tsTypes struct {
+	g       *Generator
+	defs    []string
+	defined map[string]bool
+	// usesOperationRef records that an async method returns OperationRef.
+	usesOperationRef bool
+}
+
+// generateTSFile returns the <file>_http.ts client of file: an interface per
+// message the methods use, in its proto JSON form, and a client class per
+// service with a fetch-based method per RPC that calls the method's primary
+// binding, the TypeScript counterpart of the generated Go client. Methods
+// marked (http_server.stream_body) are left out.
+func (g *Generator) generateTSFile(file *descriptor.FileDescriptorProto, data *ServiceData) (*plugin.CodeGeneratorResponse_File, error) {
+	types := &tsTypes{g: g, defined: map[string]bool{}}
+
+	var clients strings.Builder
+	for _, service := range data.Services {
+		clients.WriteString("\n/** " + service.Name + "Client calls " + service.Name + " over HTTP. */\n")
+		clients.WriteString("export class " + service.Name + "Client {\n")
+		clients.WriteString("  constructor(private readonly options: ClientOptions) {}\n")
+		for _, method := range service.Methods {
+			if method.StreamBody {
+				continue
+			}
+			code, err := types.method(file, service, method)
+			if err != nil {
+				return nil, err
+			}
+			clients.WriteString("\n" + code)
+		}
+		clients.WriteString("}\n")
+	}
+
+	var b strings.Builder
+	b.WriteString("// Code generated by protoc-gen-go-http-server-interface. DO NOT EDIT.\n")
+	b.WriteString("// source: " + file.GetName() + "\n")
+	b.WriteString("/* eslint-disable */\n\n")
+	b.WriteString(tsClientRuntime)
+	if types.usesOperationRef {
+		b.WriteString("\n/** Returned by methods that accept requests for asynchronous processing. */\n")
+		b.WriteString("export interface OperationRef {\n  id: string;\n  method: string;\n}\n")
+	}
+	for _, def := range types.defs {
+		b.WriteString("\n" + def)
+	}
+	b.WriteString(clients.String())
+
+	name := strings.TrimSuffix(g.getOutputFilename(file.GetName()), ".pb.go") + ".ts"
+	outputFile := &plugin.CodeGeneratorResponse_File{
+		Name:    proto.String(name),
+		Content: proto.String(b.String()),
+	}
+	g.applySourceRelativePath(outputFile, file.GetName())
+	return outputFile, nil
+}
+
+// method returns the client method calling method's primary binding. Path
+// parameters are read from the request, the field selected by body is sent as
+// JSON, and the remaining fields are sent as query parameters unless body is
+// "*".
+func (t *tsTypes) method(file *descriptor.FileDescriptorProto, service ServiceInfo, method MethodInfo) (string, error) {
+	rpc := findMethod(file, service.Name, method.Name)
+	inputType := rpc.GetInputType()
+	rule := method.PrimaryRule()
+
+	input := t.messageType(inputType)
+	var output string
+	switch {
+	case method.Async:
+		t.usesOperationRef = true
+		output = "OperationRef"
+	case method.StreamArray:
+		output = t.messageType(rpc.GetOutputType()) + "[]"
+	default:
+		output = t.messageType(rpc.GetOutputType())
+	}
+
+	// Build the path as a template literal, collecting the fields it uses
+	var (
+		path strings.Builder
+		skip []string
+		last int
+	)
+	pattern := rule.PathTemplate()
+	for _, loc := range tsPathParamRegex.FindAllStringSubmatchIndex(pattern, -1) {
+		path.WriteString(tsTemplateLiteral.Replace(pattern[last:loc[0]]))
+		last = loc[1]
+
+		param, wildcard := strings.CutSuffix(pattern[loc[2]:loc[3]], "...")
+		param, segments, templated := strings.Cut(param, "=")
+		wildcard = wildcard || templated && segments != "*"
+		jsonPath, ok := t.jsonPath(inputType, param)
+		if !ok {
+			return "", fmt.Errorf("%s: path parameter {%s} of %s.%s does not name a field of %s",
+				file.GetName(), param, service.Name, method.Name, strings.TrimPrefix(inputType, "."))
+		}
+		skip = append(skip, jsonPath)
+		fmt.Fprintf(&path, "${pathValue(req.%s, %q, %t)}", strings.ReplaceAll(jsonPath, ".", "?."), param, wildcard)
+	}
+	path.WriteString(tsTemplateLiteral.Replace(pattern[last:]))
+
+	body := "undefined"
+	switch rule.Body {
+	case "":
+	case "*":
+		body = "req"
+	default:
+		jsonPath, ok := t.jsonPath(inputType, rule.Body)
+		if !ok {
+			return "", fmt.Errorf("%s: body %q of %s.%s does not name a field of %s",
+				file.GetName(), rule.Body, service.Name, method.Name, strings.TrimPrefix(inputType, "."))
+		}
+		skip = append(skip, jsonPath)
+		body = "req." + jsonPath
+	}
+
+	query := "undefined"
+	if rule.Body != "*" {
+		query = fmt.Sprintf("encodeQuery(req, \"\", %s, %s, new URLSearchParams())",
+			tsStringArray(skip), tsStringArray(t.mapPaths(inputType, "", map[string]bool{})))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "  /** %s calls %s %s. */\n", method.Name, rule.Method, pattern)
+	fmt.Fprintf(&b, "  %s(req: %s, init?: RequestInit): Promise<%s> {\n", strings.ToLower(method.Name[:1])+method.Name[1:], input, output)
+	fmt.Fprintf(&b, "    const path = `%s`;\n", path.String())
+	fmt.Fprintf(&b, "    return invoke(this.options, %q, path, %s, %s, init);\n", rule.Method, query, body)
+	b.WriteString("  }\n")
+	return b.String(), nil
+}
+
+// jsonPath returns the dotted JSON name path of the dotted proto field path
+// in the message typeName.
+func (t *tsTypes) jsonPath(typeName, fieldPath string) (string, bool) {
+	var names []string
+	for _, name := range strings.Split(fieldPath, ".") {
+		msg := t.g.protoTypes.messages[typeName]
+		if msg == nil {
+			return "", false
+		}
+		var found *descriptor.FieldDescriptorProto
+		for _, field := range msg.GetField() {
+			if field.GetName() == name {
+				found = field
+				break
+			}
+		}
+		if found == nil {
+			return "", false
+		}
+		names = append(names, jsonFieldName(found))
+		typeName = found.GetTypeName()
+	}
+	return strings.Join(names, "."), true
+}
+
+// mapPaths returns the dotted JSON paths of the map fields of the message
+// typeName and of its singular message fields not already on the path in
+// visiting, which encodeQuery sends with bracketed keys.
+func (t *tsTypes) mapPaths(typeName, prefix string, visiting map[string]bool) []string {
+	msg := t.g.protoTypes.messages[typeName]
+	if msg == nil || visiting[typeName] {
+		return nil
+	}
+	visiting[typeName] = true
+	defer delete(visiting, typeName)
+
+	var paths []string
+	for _, field := range msg.GetField() {
+		if field.GetType() != descriptor.FieldDescriptorProto_TYPE_MESSAGE {
+			continue
+		}
+		path := prefix + jsonFieldName(field)
+		switch {
+		case t.g.protoTypes.messages[field.GetTypeName()].GetOptions().GetMapEntry():
+			paths = append(paths, path)
+		case field.GetLabel() != descriptor.FieldDescriptorProto_LABEL_REPEATED:
+			paths = append(paths, t.mapPaths(field.GetTypeName(), path+".", visiting)...)
+		}
+	}
+	return paths
+}
+
+// messageType returns the TypeScript type of the message typeName, defining
+// an interface for it and the types it refers to on first use.
+func (t *tsTypes) messageType(typeName string) string {
+	if wkt, ok := tsWellKnownTypes[typeName]; ok {
+		return wkt
+	}
+	msg := t.g.protoTypes.messages[typeName]
+	if msg == nil {
+		return "unknown"
+	}
+	name := t.g.protoTypes.names[typeName]
+	if t.defined[name] {
+		return name
+	}
+	t.defined[name] = true
+
+	// Reserve the definition's position before defining the field types
+	i := len(t.defs)
+	t.defs = append(t.defs, "")
+
+	var b strings.Builder
+	b.WriteString("export interface " + name + " {\n")
+	for _, field := range msg.GetField() {
+		b.WriteString("  " + jsonFieldName(field) + "?: " + t.fieldType(field) + ";\n")
+	}
+	b.WriteString("}\n")
+	t.defs[i] = b.String()
+	return name
+}
+
+// fieldType returns the TypeScript type of field.
+func (t *tsTypes) fieldType(field *descriptor.FieldDescriptorProto) string {
+	var elem string
+	switch field.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_MESSAGE, descriptor.FieldDescriptorProto_TYPE_GROUP:
+		if entry := t.g.protoTypes.messages[field.GetTypeName()]; entry.GetOptions().GetMapEntry() {
+			return "{ [key: string]: " + t.fieldType(entry.GetField()[1]) + " }"
+		}
+		elem = t.messageType(field.GetTypeName())
+	case descriptor.FieldDescriptorProto_TYPE_ENUM:
+		elem = t.enumType(field.GetTypeName())
+	default:
+		elem = tsScalars[field.GetType()]
+	}
+	if field.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED {
+		if strings.ContainsAny(elem, " |") {
+			return "(" + elem + ")[]"
+		}
+		return elem + "[]"
+	}
+	return elem
+}
+
+// enumType returns the TypeScript union of the value names of the enum
+// typeName, defining it on first use.
+func (t *tsTypes) enumType(typeName string) string {
+	enum := t.g.protoTypes.enums[typeName]
+	if enum == nil {
+		return "string"
+	}
+	name := t.g.protoTypes.names[typeName]
+	if t.defined[name] {
+		return name
+	}
+	t.defined[name] = true
+
+	var b strings.Builder
+	b.WriteString("export type " + name + " =")
+	for _, value := range enum.GetValue() {
+		b.WriteString("\n  | " + strconv.Quote(value.GetName()))
+	}
+	b.WriteString(";\n")
+	t.defs = append(t.defs, b.String())
+	return name
+}
+
+// tsStringArray returns a TypeScript array literal of values.
+func tsStringArray(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = strconv.Quote(v)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
@@ -0,0 +1,78 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+
+	options "google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestGenerateTSClient(t *testing.T) {
+	t.Parallel()
+
+	resp := New().Generate(graphqlRequest(t, "binding=true,ts_client=true"))
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	files := map[string]string{}
+	for _, f := range resp.File {
+		files[f.GetName()] = f.GetContent()
+	}
+
+	ts, ok := files["items_http.ts"]
+	if !ok {
+		t.Fatalf("missing items_http.ts in %d generated files", len(resp.File))
+	}
+	for _, expected := range []string{
+		"// Code generated by protoc-gen-go-http-server-interface. DO NOT EDIT.\n// source: items.proto\n",
+		"export class ClientError extends Error {",
+		"export interface OperationRef {\n  id: string;\n  method: string;\n}\n",
+		"export interface GetItemRequest {\n  itemId?: string;\n}\n",
+		"export interface Item {\n  itemId?: string;\n  size?: string;\n  state?: State;\n  tags?: string[];\n  labels?: { [key: string]: string };\n  owner?: Item_Owner;\n}\n",
+		"export type State =\n  | \"STATE_UNSPECIFIED\"\n  | \"STATE_ACTIVE\";\n",
+		"export interface ExportRequest {\n}\n",
+		"export class ItemServiceClient {\n  constructor(private readonly options: ClientOptions) {}\n",
+		"  /** GetItem calls GET /v1/items/{item_id}. */\n  getItem(req: GetItemRequest, init?: RequestInit): Promise<Item> {\n" +
+			"    const path = `/v1/items/${pathValue(req.itemId, \"item_id\", false)}`;\n" +
+			"    return invoke(this.options, \"GET\", path, encodeQuery(req, \"\", [\"itemId\"], [], new URLSearchParams()), undefined, init);\n",
+		"    return invoke(this.options, \"PUT\", path, undefined, req, init);\n",
+		"  exportItems(req: ExportRequest, init?: RequestInit): Promise<OperationRef> {\n",
+	} {
+		if !strings.Contains(ts, expected) {
+			t.Errorf("Generated TypeScript doesn't contain %q", expected)
+		}
+	}
+	if strings.Contains(ts, "LabelsEntry") {
+		t.Error("Generated TypeScript has an interface for a map entry")
+	}
+}
+
+func TestGenerateTSClientUnknownPathField(t *testing.T) {
+	t.Parallel()
+
+	req := graphqlRequest(t, "binding=true,ts_client=true")
+	opts := &descriptor.MethodOptions{}
+	proto.SetExtension(opts, options.E_Http, &options.HttpRule{Pattern: &options.HttpRule_Get{Get: "/v1/items/{id}"}})
+	req.ProtoFile[0].Service[0].Method[0].Options = opts
+
+	resp := New().Generate(req)
+	if !strings.Contains(resp.GetError(), "path parameter {id} of ItemService.GetItem does not name a field of items.v1.GetItemRequest") {
+		t.Errorf("Generate() error = %q, want unknown path field", resp.GetError())
+	}
+}
+
+func TestGenerateWithoutTSClient(t *testing.T) {
+	t.Parallel()
+
+	resp := New().Generate(graphqlRequest(t, "binding=true"))
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	for _, f := range resp.File {
+		if strings.HasSuffix(f.GetName(), ".ts") {
+			t.Errorf("Generated %s without ts_client=true", f.GetName())
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package httpinterface
+
+import (
+	"slices"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpinterface/parser"
+)
+
+// RPCName returns the gRPC-style full method name passed to unary
+// interceptors, e.g. "/tasks.v1.TaskService/GetTask".
+func (s ServiceInfo) RPCName(method MethodInfo) string {
+	service := s.FullName
+	if service == "" {
+		service = s.Name
+	}
+	return "/" + service + "/" + method.Name
+}
+
+// BindPathParams returns the path parameters the typed adapter binds: those of
+// every binding, in order of first use, except names that are aliases of a
+// primary binding parameter.
+func (m MethodInfo) BindPathParams() []string {
+	params := []string{}
+	for _, rule := range m.HTTPRules {
+		for _, name := range rule.PathParams {
+			if _, alias := m.PathParamAliases[name]; !alias && !slices.Contains(params, name) {
+				params = append(params, name)
+			}
+		}
+	}
+	return params
+}
+
+// PrimaryBody returns the body selector of m's primary binding.
+func (m MethodInfo) PrimaryBody() string {
+	if len(m.HTTPRules) == 0 {
+		return ""
+	}
+	return m.HTTPRules[0].Body
+}
+
+// UniformBody reports whether every binding of m uses the same body selector.
+func (m MethodInfo) UniformBody() bool {
+	for _, rule := range m.HTTPRules {
+		if rule.Body != m.PrimaryBody() {
+			return false
+		}
+	}
+	return true
+}
+
+// BodyRules returns the first binding for each HTTP method of m whose body
+// selector is not empty, for choosing the selector by request method when the
+// bindings disagree.
+func (m MethodInfo) BodyRules() []parser.HTTPRule {
+	var rules []parser.HTTPRule
+	for _, rule := range m.HTTPRules {
+		if !slices.ContainsFunc(rules, func(r parser.HTTPRule) bool { return r.Method == rule.Method }) {
+			rules = append(rules, rule)
+		}
+	}
+	return slices.DeleteFunc(rules, func(r parser.HTTPRule) bool { return r.Body == "" })
+}
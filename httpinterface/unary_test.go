@@ -0,0 +1,147 @@
+package httpinterface
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpinterface/parser"
+)
+
+func TestMethodInfoUnaryBinding(t *testing.T) {
+	t.Parallel()
+
+	method := MethodInfo{
+		Name: "UpdateUser",
+		HTTPRules: []parser.HTTPRule{
+			{Method: "PATCH", Pattern: "/users/{user_id}", Body: "user", PathParams: []string{"user_id"}},
+			{Method: "PUT", Pattern: "/v2/users/{id}", Body: "*", PathParams: []string{"id"}},
+			{Method: "PUT", Pattern: "/orgs/{org_id}/users/{user_id}", Body: "user", PathParams: []string{"org_id", "user_id"}},
+			{Method: "POST", Pattern: "/users/{user_id}:touch", PathParams: []string{"user_id"}},
+		},
+		PathParamAliases: map[string]string{"id": "user_id"},
+	}
+
+	if got, want := method.BindPathParams(), []string{"user_id", "org_id"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("BindPathParams() = %v, want %v", got, want)
+	}
+	if method.UniformBody() {
+		t.Error("UniformBody() = true, want false")
+	}
+	wantRules := []parser.HTTPRule{method.HTTPRules[0], method.HTTPRules[1]}
+	if got := method.BodyRules(); !reflect.DeepEqual(got, wantRules) {
+		t.Errorf("BodyRules() = %v, want %v", got, wantRules)
+	}
+
+	service := ServiceInfo{Name: "UserService", FullName: "users.v1.UserService"}
+	if got, want := service.RPCName(method), "/users.v1.UserService/UpdateUser"; got != want {
+		t.Errorf("RPCName() = %q, want %q", got, want)
+	}
+}
+
+func TestGenerateCodeTypedHandlers(t *testing.T) {
+	t.Parallel()
+	g := New()
+
+	list := newMethod("ListUsers", "commonv1.PageRequest", "ListUsersResponse", newRule("GET", "/users", ""), newRule("POST", "/users:search", "*"))
+	list.InputImport = GoImport{Path: "example.com/common/v1", Name: "commonv1"}
+	data := newServiceData("usersv1", Options{Binding: true}, newService("users.v1.UserService",
+		newMethod("GetUser", "GetUserRequest", "User", newRule("GET", "/users/{user_id}", "")),
+		list,
+	))
+	data.GoImport = GoImport{Path: "example.com/users/v1", Name: "usersv1"}
+
+	code, err := g.GenerateCode(data)
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	for _, expected := range []string{
+		"\t\"context\"\n",
+		"\tcommonv1 \"example.com/common/v1\"\n",
+		"type UnaryInterceptor func(ctx context.Context, rpc string, req proto.Message, next UnaryHandler) (proto.Message, error)",
+		"func ChainUnaryInterceptors(interceptors ...UnaryInterceptor) UnaryInterceptor",
+		"type UserServiceTypedHandler interface {\n" +
+			"\tGetUser(ctx context.Context, req *GetUserRequest) (*User, error)\n" +
+			"\tListUsers(ctx context.Context, req *commonv1.PageRequest) (*ListUsersResponse, error)\n}",
+		"func NewUserServiceHandler(srv UserServiceTypedHandler, interceptors ...UnaryInterceptor) UserServiceHandler",
+		`serveUnary(w, r, "/users.v1.UserService/GetUser", &GetUserRequest{}, "", []string{"user_id"}, a.interceptor,`,
+		"\tswitch r.Method {\n\tcase http.MethodPost:\n\t\tbody = \"*\"\n\t}\n",
+		`serveUnary(w, r, "/users.v1.UserService/ListUsers", &commonv1.PageRequest{}, body, []string{}, a.interceptor,`,
+		"\tsetRetryAfter(w, err)\n",
+		"func setRetryAfter(w http.ResponseWriter, err error) time.Duration {",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+
+	data.Options.Binding = false
+	if code, err = g.GenerateCode(data); err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	if strings.Contains(code, "UnaryInterceptor") || strings.Contains(code, "commonv1") {
+		t.Error("Generated code contains typed handlers without binding")
+	}
+}
+
+func TestGenerateSplitCodeTypedHandlers(t *testing.T) {
+	t.Parallel()
+
+	files, err := New().GenerateSplitCode(newServiceData("api", Options{Binding: true, Layout: LayoutSplit}, newService("PingService",
+		newMethod("Ping", "PingRequest", "PingResponse", newRule("GET", "/ping", "")),
+	)))
+	if err != nil {
+		t.Fatalf("GenerateSplitCode() error = %v", err)
+	}
+
+	register := files[2].Content
+	for _, expected := range []string{
+		"import (\n\t\"context\"\n\t\"net/http\"\n\n\t\"google.golang.org/protobuf/proto\"\n)",
+		"type PingServiceTypedHandler interface",
+		"func RegisterPingServiceRoutes(",
+	} {
+		if !strings.Contains(register, expected) {
+			t.Errorf("register file doesn't contain %q", expected)
+		}
+	}
+	if !strings.Contains(files[1].Content, "func serveUnary(") {
+		t.Error("router file doesn't contain serveUnary")
+	}
+}
+
+func TestGenerateCodeDecodeHelpers(t *testing.T) {
+	t.Parallel()
+	g := New()
+
+	data := newServiceData("usersv1", Options{Binding: true, DecodeHelpers: true}, newService("users.v1.UserService",
+		newMethod("UpdateUser", "UpdateUserRequest", "User", newRule("PATCH", "/users/{user_id}", "user")),
+		newMethod("ListUsers", "ListUsersRequest", "ListUsersResponse", newRule("GET", "/users", ""), newRule("POST", "/users:search", "*")),
+	))
+
+	code, err := g.GenerateCode(data)
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	for _, expected := range []string{
+		"func DecodeUpdateUserRequest(r *http.Request) (*UpdateUserRequest, error) {\n" +
+			"\treq := &UpdateUserRequest{}\n" +
+			"\tif err := BindRequest(r, req, \"user\", \"user_id\"); err != nil {\n" +
+			"\t\treturn nil, err\n\t}\n\treturn req, nil\n}",
+		"func DecodeListUsersRequest(r *http.Request) (*ListUsersRequest, error) {\n" +
+			"\tbody := \"\"\n\tswitch r.Method {\n\tcase http.MethodPost:\n\t\tbody = \"*\"\n\t}\n" +
+			"\treq := &ListUsersRequest{}\n" +
+			"\tif err := BindRequest(r, req, body); err != nil {",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+
+	data.Options.DecodeHelpers = false
+	if code, err = g.GenerateCode(data); err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	if strings.Contains(code, "func Decode") {
+		t.Error("Generated code contains decode helpers without decode_helpers=true")
+	}
+}
@@ -0,0 +1,82 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpserver"
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestMethodSkipUnitOfWork(t *testing.T) {
+	t.Parallel()
+
+	withOption := func(v bool) *descriptor.MethodDescriptorProto {
+		opts := &descriptor.MethodOptions{}
+		proto.SetExtension(opts, httpserver.E_UnitOfWork, v)
+		return &descriptor.MethodDescriptorProto{Options: opts}
+	}
+
+	tests := []struct {
+		name   string
+		method *descriptor.MethodDescriptorProto
+		want   bool
+	}{
+		{name: "no options", method: &descriptor.MethodDescriptorProto{}},
+		{name: "option unset", method: &descriptor.MethodDescriptorProto{Options: &descriptor.MethodOptions{}}},
+		{name: "option true", method: withOption(true)},
+		{name: "option false", method: withOption(false), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := methodSkipUnitOfWork(tt.method); got != tt.want {
+				t.Errorf("methodSkipUnitOfWork() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateCodeUnitOfWork(t *testing.T) {
+	t.Parallel()
+	g := New()
+
+	data := func(unitOfWork bool) *ServiceData {
+		touch := newMethod("TouchItem", "Item", "Item",
+			newRule("POST", "/items/{id}:touch", ""),
+			newRule("GET", "/items/{id}:touch", ""),
+		)
+		touch.SkipUnitOfWork = true
+		return newServiceData("api", Options{UnitOfWork: unitOfWork}, newService("ItemService",
+			newMethod("CreateItem", "Item", "Item", newRule("POST", "/items", "*")),
+			touch,
+		))
+	}
+
+	code, err := g.GenerateCode(data(true))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	for _, expected := range []string{
+		"type UnitOfWork interface",
+		"func NewRouter(mux *http.ServeMux, opts ...RouterOption) *RouteGroup",
+		"func WithUnitOfWork(uow UnitOfWork) RouterOption",
+		"var unitOfWorkExempt = map[string]bool{\n\t\"POST /items/{id}:touch\": true,\n}",
+		"handler = withUnitOfWork(g.unitOfWork, handler)",
+		"unitOfWork:  g.unitOfWork,",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Generated code doesn't contain %q", expected)
+		}
+	}
+
+	code, err = g.GenerateCode(data(false))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	if strings.Contains(code, "UnitOfWork") || !strings.Contains(code, "func NewRouter(mux *http.ServeMux) *RouteGroup") {
+		t.Error("Generated code changed NewRouter or contains UnitOfWork without unit_of_work")
+	}
+}
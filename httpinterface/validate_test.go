@@ -0,0 +1,89 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		parameter      string
+		wantContain    []string
+		wantNotContain []string
+	}{
+		{
+			name:      "typed handlers",
+			parameter: "binding=true,validate=true",
+			wantContain: []string{
+				"var RequestValidator Validator\n",
+				"func validateRequest(r *http.Request, msg proto.Message) *ValidationError {",
+				"func WriteValidationError(w http.ResponseWriter, err *ValidationError) {",
+				"\tif err := BindRequest(r, req, body, pathParams...); err != nil {\n" +
+					"\t\thttp.Error(w, err.Error(), http.StatusBadRequest)\n" +
+					"\t\treturn\n" +
+					"\t}\n" +
+					"\tif err := validateRequest(r, req); err != nil {\n" +
+					"\t\tWriteValidationError(w, err)\n" +
+					"\t\treturn\n" +
+					"\t}\n",
+			},
+			wantNotContain: []string{"func DecodeGetItemRequest"},
+		},
+		{
+			name:      "router validator",
+			parameter: "binding=true,validate=true",
+			wantContain: []string{
+				"func NewRouter(mux *http.ServeMux, opts ...RouterOption) *RouteGroup {",
+				"func WithValidator(v Validator) RouterOption {",
+				"\tif g.validator != nil {\n" +
+					"\t\thandler = withValidator(g.validator, handler)\n" +
+					"\t}\n",
+				"\tvalidator, _ := r.Context().Value(validatorKey{}).(Validator)\n" +
+					"\tif validator == nil {\n" +
+					"\t\tvalidator = RequestValidator\n" +
+					"\t}\n",
+			},
+		},
+		{
+			name:      "decode helpers",
+			parameter: "binding=true,validate=true,decode_helpers=true",
+			wantContain: []string{
+				"// reported with 400 Bad Request. The bound message is validated too, failing\n" +
+					"// with a *ValidationError for WriteValidationError to report.\n",
+				"\tif err := validateRequest(r, req); err != nil {\n" +
+					"\t\treturn nil, err\n" +
+					"\t}\n" +
+					"\treturn req, nil\n",
+			},
+		},
+		{
+			name:           "disabled",
+			parameter:      "binding=true,decode_helpers=true",
+			wantNotContain: []string{"validateRequest", "RequestValidator", "ValidationError", "WithValidator", "RouterOption"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp := New().Generate(graphqlRequest(t, tt.parameter))
+			if resp.Error != nil {
+				t.Fatalf("Generate() error = %s", resp.GetError())
+			}
+			code := resp.File[0].GetContent()
+			for _, expected := range tt.wantContain {
+				if !strings.Contains(code, expected) {
+					t.Errorf("Generated code doesn't contain %q", expected)
+				}
+			}
+			for _, unexpected := range tt.wantNotContain {
+				if strings.Contains(code, unexpected) {
+					t.Errorf("Generated code contains %q", unexpected)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package httpinterface
+
+// HasInternalMethods reports whether any method of s sets
+// (http_server.visibility) = INTERNAL, so its routes are split between
+// Register<Service>PublicRoutes and Register<Service>InternalRoutes.
+func (s ServiceInfo) HasInternalMethods() bool {
+	for _, method := range s.Methods {
+		if method.Internal {
+			return true
+		}
+	}
+	return false
+}
+
+// Public returns d restricted to the methods without INTERNAL visibility.
+func (d serviceTemplateData) Public() serviceTemplateData {
+	return d.withMethods(false)
+}
+
+// Internal returns d restricted to the methods with INTERNAL visibility.
+func (d serviceTemplateData) Internal() serviceTemplateData {
+	return d.withMethods(true)
+}
+
+// withMethods returns a copy of d holding only the methods whose Internal
+// field equals internal.
+func (d serviceTemplateData) withMethods(internal bool) serviceTemplateData {
+	methods := make([]MethodInfo, 0, len(d.Methods))
+	for _, method := range d.Methods {
+		if method.Internal == internal {
+			methods = append(methods, method)
+		}
+	}
+	d.ServiceInfo.Methods = methods
+	return d
+}
@@ -0,0 +1,97 @@
+package httpinterface
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpserver"
+	options "google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestGenerateInternalVisibility(t *testing.T) {
+	t.Parallel()
+
+	methodOpts := func(rule *options.HttpRule, visibility httpserver.Visibility) *descriptor.MethodOptions {
+		opts := &descriptor.MethodOptions{}
+		proto.SetExtension(opts, options.E_Http, rule)
+		if visibility != httpserver.Visibility_VISIBILITY_UNSPECIFIED {
+			proto.SetExtension(opts, httpserver.E_Visibility, visibility)
+		}
+		return opts
+	}
+
+	file := &descriptor.FileDescriptorProto{
+		Name:        proto.String("items.proto"),
+		Package:     proto.String("items.v1"),
+		Syntax:      proto.String("proto3"),
+		MessageType: []*descriptor.DescriptorProto{{Name: proto.String("Item")}},
+		Service: []*descriptor.ServiceDescriptorProto{
+			{
+				Name: proto.String("ItemService"),
+				Method: []*descriptor.MethodDescriptorProto{
+					{
+						Name: proto.String("GetItem"), InputType: proto.String(".items.v1.Item"), OutputType: proto.String(".items.v1.Item"),
+						Options: methodOpts(&options.HttpRule{Pattern: &options.HttpRule_Get{Get: "/items/{id}"}}, httpserver.Visibility_PUBLIC),
+					},
+					{
+						Name: proto.String("PurgeItems"), InputType: proto.String(".items.v1.Item"), OutputType: proto.String(".items.v1.Item"),
+						Options: methodOpts(&options.HttpRule{Pattern: &options.HttpRule_Post{Post: "/admin/items:purge"}}, httpserver.Visibility_INTERNAL),
+					},
+				},
+			},
+			{
+				Name: proto.String("HealthService"),
+				Method: []*descriptor.MethodDescriptorProto{
+					{
+						Name: proto.String("Check"), InputType: proto.String(".items.v1.Item"), OutputType: proto.String(".items.v1.Item"),
+						Options: methodOpts(&options.HttpRule{Pattern: &options.HttpRule_Get{Get: "/healthz"}}, httpserver.Visibility_VISIBILITY_UNSPECIFIED),
+					},
+				},
+			},
+		},
+	}
+
+	resp := New().Generate(pluginRequest(t, "doc=true", file))
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	if len(resp.File) != 2 {
+		t.Fatalf("len(resp.File) = %d, want 2", len(resp.File))
+	}
+	code, doc := resp.File[0].GetContent(), resp.File[1].GetContent()
+
+	section := func(start string) string {
+		i := strings.Index(code, start)
+		if i < 0 {
+			t.Fatalf("Generated code doesn't contain %q", start)
+		}
+		end := strings.Index(code[i:], "\n}\n")
+		return code[i : i+end]
+	}
+
+	public := section("func RegisterItemServicePublicRoutes(")
+	if !strings.Contains(public, "handler.HandleGetItem") || strings.Contains(public, "handler.HandlePurgeItems") {
+		t.Errorf("RegisterItemServicePublicRoutes registers the wrong methods:\n%s", public)
+	}
+	internal := section("func RegisterItemServiceInternalRoutes(")
+	if !strings.Contains(internal, "handler.HandlePurgeItems") || strings.Contains(internal, "handler.HandleGetItem") {
+		t.Errorf("RegisterItemServiceInternalRoutes registers the wrong methods:\n%s", internal)
+	}
+	if routes := section("func RegisterItemServiceRoutes("); !strings.Contains(routes, "return RegisterItemServicePublicRoutes(r, handler)") {
+		t.Errorf("RegisterItemServiceRoutes does not delegate to the public routes:\n%s", routes)
+	}
+
+	// Services without internal methods keep a single registration function
+	if strings.Contains(code, "RegisterHealthServicePublicRoutes") || strings.Contains(code, "RegisterHealthServiceInternalRoutes") {
+		t.Error("Generated code splits HealthService, which has no internal methods")
+	}
+	if !strings.Contains(section("func RegisterHealthServiceRoutes("), "handler.HandleCheck") {
+		t.Error("RegisterHealthServiceRoutes does not register Check")
+	}
+
+	if !strings.Contains(doc, "HandlePurgeItems (internal)") || strings.Contains(doc, "HandleGetItem (internal)") {
+		t.Errorf("doc.go does not mark exactly the internal routes:\n%s", doc)
+	}
+}
@@ -0,0 +1,768 @@
+// Custom options read by protoc-gen-go-http-server-interface.
+//
+// Import this file and set the options on methods alongside google.api.http:
+//
+//   import "http_server/options.proto";
+//
+//   rpc ListTasks(ListTasksRequest) returns (ListTasksResponse) {
+//     option (google.api.http) = { get: "/v1/tasks" };
+//     option (http_server.stream_array) = true;
+//   }
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: http_server/options.proto
+
+package httpserver
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Visibility controls which registration function serves a method's routes.
+type Visibility int32
+
+const (
+	// Same as PUBLIC.
+	Visibility_VISIBILITY_UNSPECIFIED Visibility = 0
+	// Registered by Register<Service>Routes and Register<Service>PublicRoutes.
+	Visibility_PUBLIC Visibility = 1
+	// Registered only by Register<Service>InternalRoutes, for admin or
+	// operational RPCs served on a separate listener.
+	Visibility_INTERNAL Visibility = 2
+)
+
+// Enum value maps for Visibility.
+var (
+	Visibility_name = map[int32]string{
+		0: "VISIBILITY_UNSPECIFIED",
+		1: "PUBLIC",
+		2: "INTERNAL",
+	}
+	Visibility_value = map[string]int32{
+		"VISIBILITY_UNSPECIFIED": 0,
+		"PUBLIC":                 1,
+		"INTERNAL":               2,
+	}
+)
+
+func (x Visibility) Enum() *Visibility {
+	p := new(Visibility)
+	*p = x
+	return p
+}
+
+func (x Visibility) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Visibility) Descriptor() protoreflect.EnumDescriptor {
+	return file_http_server_options_proto_enumTypes[0].Descriptor()
+}
+
+func (Visibility) Type() protoreflect.EnumType {
+	return &file_http_server_options_proto_enumTypes[0]
+}
+
+func (x Visibility) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Visibility.Descriptor instead.
+func (Visibility) EnumDescriptor() ([]byte, []int) {
+	return file_http_server_options_proto_rawDescGZIP(), []int{0}
+}
+
+// Normalization is a canonicalization applied to a string field's value when
+// a request is bound.
+type Normalization int32
+
+const (
+	// Leaves the value unchanged.
+	Normalization_NORMALIZATION_UNSPECIFIED Normalization = 0
+	// Removes leading and trailing white space.
+	Normalization_TRIM Normalization = 1
+	// Maps letters to lower case, as for email addresses and slugs.
+	Normalization_LOWER Normalization = 2
+	// Maps letters to upper case, as for country or currency codes.
+	Normalization_UPPER Normalization = 3
+	// Replaces each run of white space with a single space and removes leading
+	// and trailing white space.
+	Normalization_COLLAPSE_SPACES Normalization = 4
+)
+
+// Enum value maps for Normalization.
+var (
+	Normalization_name = map[int32]string{
+		0: "NORMALIZATION_UNSPECIFIED",
+		1: "TRIM",
+		2: "LOWER",
+		3: "UPPER",
+		4: "COLLAPSE_SPACES",
+	}
+	Normalization_value = map[string]int32{
+		"NORMALIZATION_UNSPECIFIED": 0,
+		"TRIM":                      1,
+		"LOWER":                     2,
+		"UPPER":                     3,
+		"COLLAPSE_SPACES":           4,
+	}
+)
+
+func (x Normalization) Enum() *Normalization {
+	p := new(Normalization)
+	*p = x
+	return p
+}
+
+func (x Normalization) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Normalization) Descriptor() protoreflect.EnumDescriptor {
+	return file_http_server_options_proto_enumTypes[1].Descriptor()
+}
+
+func (Normalization) Type() protoreflect.EnumType {
+	return &file_http_server_options_proto_enumTypes[1]
+}
+
+func (x Normalization) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Normalization.Descriptor instead.
+func (Normalization) EnumDescriptor() ([]byte, []int) {
+	return file_http_server_options_proto_rawDescGZIP(), []int{1}
+}
+
+// Cache configures caching of a method's GET responses by the generated
+// ResponseCache.
+type Cache struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// How long a response stays cached, in seconds. Zero disables caching.
+	TtlSeconds    uint32 `protobuf:"varint,1,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Cache) Reset() {
+	*x = Cache{}
+	mi := &file_http_server_options_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cache) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cache) ProtoMessage() {}
+
+func (x *Cache) ProtoReflect() protoreflect.Message {
+	mi := &file_http_server_options_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cache.ProtoReflect.Descriptor instead.
+func (*Cache) Descriptor() ([]byte, []int) {
+	return file_http_server_options_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Cache) GetTtlSeconds() uint32 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+// Dedupe configures the absorption of duplicate requests to a method by the
+// generated RequestDeduper.
+type Dedupe struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// How long after a request completes a duplicate is answered with its
+	// response, in seconds. Zero disables deduplication.
+	WindowSeconds uint32 `protobuf:"varint,1,opt,name=window_seconds,json=windowSeconds,proto3" json:"window_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Dedupe) Reset() {
+	*x = Dedupe{}
+	mi := &file_http_server_options_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Dedupe) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dedupe) ProtoMessage() {}
+
+func (x *Dedupe) ProtoReflect() protoreflect.Message {
+	mi := &file_http_server_options_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dedupe.ProtoReflect.Descriptor instead.
+func (*Dedupe) Descriptor() ([]byte, []int) {
+	return file_http_server_options_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Dedupe) GetWindowSeconds() uint32 {
+	if x != nil {
+		return x.WindowSeconds
+	}
+	return 0
+}
+
+// Slo declares a method's service level objectives, written to the SLO
+// manifest and Prometheus rules of the slo plugin option.
+type Slo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Objective for the 99th percentile latency of the method's routes, in
+	// milliseconds. Zero sets no latency objective.
+	LatencyP99Ms uint32 `protobuf:"varint,1,opt,name=latency_p99_ms,json=latencyP99Ms,proto3" json:"latency_p99_ms,omitempty"`
+	// Objective for the percentage of requests answered without a 5xx status,
+	// such as 99.9. Zero sets no availability objective.
+	AvailabilityPercent float64 `protobuf:"fixed64,2,opt,name=availability_percent,json=availabilityPercent,proto3" json:"availability_percent,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Slo) Reset() {
+	*x = Slo{}
+	mi := &file_http_server_options_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Slo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Slo) ProtoMessage() {}
+
+func (x *Slo) ProtoReflect() protoreflect.Message {
+	mi := &file_http_server_options_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Slo.ProtoReflect.Descriptor instead.
+func (*Slo) Descriptor() ([]byte, []int) {
+	return file_http_server_options_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Slo) GetLatencyP99Ms() uint32 {
+	if x != nil {
+		return x.LatencyP99Ms
+	}
+	return 0
+}
+
+func (x *Slo) GetAvailabilityPercent() float64 {
+	if x != nil {
+		return x.AvailabilityPercent
+	}
+	return 0
+}
+
+// Timeouts overrides the server's ReadTimeout and WriteTimeout for a
+// method's routes, through the deadlines of http.ResponseController.
+type Timeouts struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Time allowed to read the request body, in milliseconds, counted from
+	// when the route starts serving the request. Zero keeps the server's.
+	ReadMs uint32 `protobuf:"varint,1,opt,name=read_ms,json=readMs,proto3" json:"read_ms,omitempty"`
+	// Time allowed to write the response, in milliseconds, counted from when
+	// the route starts serving the request. Zero keeps the server's.
+	WriteMs       uint32 `protobuf:"varint,2,opt,name=write_ms,json=writeMs,proto3" json:"write_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Timeouts) Reset() {
+	*x = Timeouts{}
+	mi := &file_http_server_options_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Timeouts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Timeouts) ProtoMessage() {}
+
+func (x *Timeouts) ProtoReflect() protoreflect.Message {
+	mi := &file_http_server_options_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Timeouts.ProtoReflect.Descriptor instead.
+func (*Timeouts) Descriptor() ([]byte, []int) {
+	return file_http_server_options_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Timeouts) GetReadMs() uint32 {
+	if x != nil {
+		return x.ReadMs
+	}
+	return 0
+}
+
+func (x *Timeouts) GetWriteMs() uint32 {
+	if x != nil {
+		return x.WriteMs
+	}
+	return 0
+}
+
+var file_http_server_options_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         51001,
+		Name:          "http_server.stream_array",
+		Tag:           "varint,51001,opt,name=stream_array",
+		Filename:      "http_server/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*Visibility)(nil),
+		Field:         51002,
+		Name:          "http_server.visibility",
+		Tag:           "varint,51002,opt,name=visibility,enum=http_server.Visibility",
+		Filename:      "http_server/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*Cache)(nil),
+		Field:         51003,
+		Name:          "http_server.cache",
+		Tag:           "bytes,51003,opt,name=cache",
+		Filename:      "http_server/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         51004,
+		Name:          "http_server.async",
+		Tag:           "varint,51004,opt,name=async",
+		Filename:      "http_server/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         51005,
+		Name:          "http_server.unit_of_work",
+		Tag:           "varint,51005,opt,name=unit_of_work",
+		Filename:      "http_server/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*Slo)(nil),
+		Field:         51006,
+		Name:          "http_server.slo",
+		Tag:           "bytes,51006,opt,name=slo",
+		Filename:      "http_server/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*Dedupe)(nil),
+		Field:         51008,
+		Name:          "http_server.dedupe",
+		Tag:           "bytes,51008,opt,name=dedupe",
+		Filename:      "http_server/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: ([]string)(nil),
+		Field:         51009,
+		Name:          "http_server.tags",
+		Tag:           "bytes,51009,rep,name=tags",
+		Filename:      "http_server/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         51011,
+		Name:          "http_server.stream_body",
+		Tag:           "varint,51011,opt,name=stream_body",
+		Filename:      "http_server/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*Timeouts)(nil),
+		Field:         51012,
+		Name:          "http_server.timeouts",
+		Tag:           "bytes,51012,opt,name=timeouts",
+		Filename:      "http_server/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: ([]string)(nil),
+		Field:         51015,
+		Name:          "http_server.scopes",
+		Tag:           "bytes,51015,rep,name=scopes",
+		Filename:      "http_server/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*uint32)(nil),
+		Field:         51018,
+		Name:          "http_server.long_poll_ms",
+		Tag:           "varint,51018,opt,name=long_poll_ms",
+		Filename:      "http_server/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.ServiceOptions)(nil),
+		ExtensionType: ([]string)(nil),
+		Field:         51010,
+		Name:          "http_server.service_tags",
+		Tag:           "bytes,51010,rep,name=service_tags",
+		Filename:      "http_server/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.ServiceOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         51014,
+		Name:          "http_server.base_path",
+		Tag:           "bytes,51014,opt,name=base_path",
+		Filename:      "http_server/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.ServiceOptions)(nil),
+		ExtensionType: ([]string)(nil),
+		Field:         51016,
+		Name:          "http_server.service_scopes",
+		Tag:           "bytes,51016,rep,name=service_scopes",
+		Filename:      "http_server/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.ServiceOptions)(nil),
+		ExtensionType: ([]string)(nil),
+		Field:         51017,
+		Name:          "http_server.forward_headers",
+		Tag:           "bytes,51017,rep,name=forward_headers",
+		Filename:      "http_server/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: ([]Normalization)(nil),
+		Field:         51013,
+		Name:          "http_server.normalize",
+		Tag:           "varint,51013,rep,packed,name=normalize,enum=http_server.Normalization",
+		Filename:      "http_server/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.EnumValueOptions)(nil),
+		ExtensionType: (*int32)(nil),
+		Field:         51007,
+		Name:          "http_server.http_status",
+		Tag:           "varint,51007,opt,name=http_status",
+		Filename:      "http_server/options.proto",
+	},
+}
+
+// Extension fields to descriptorpb.MethodOptions.
+var (
+	// Marks a method whose response is a large list that handlers may stream
+	// as a JSON array with StreamJSONArray instead of buffering it. Requires
+	// the binding=true plugin option.
+	//
+	// optional bool stream_array = 51001;
+	E_StreamArray = &file_http_server_options_proto_extTypes[0]
+	// Selects the registration function for the method's routes, keeping
+	// INTERNAL methods off the listener used for public routes.
+	//
+	// optional http_server.Visibility visibility = 51002;
+	E_Visibility = &file_http_server_options_proto_extTypes[1]
+	// Caches the method's successful GET responses, keyed by path, query and
+	// principal, on routers given a ResponseCache with WithResponseCache.
+	//
+	// optional http_server.Cache cache = 51003;
+	E_Cache = &file_http_server_options_proto_extTypes[2]
+	// Accepts the method's requests with 202 Accepted and hands them to the
+	// generated Enqueuer instead of a handler. Requires binding=true.
+	//
+	// optional bool async = 51004;
+	E_Async = &file_http_server_options_proto_extTypes[3]
+	// Set to false to run the method's routes outside the unit of work of a
+	// router created with WithUnitOfWork. Requires unit_of_work=true to matter.
+	//
+	// optional bool unit_of_work = 51005;
+	E_UnitOfWork = &file_http_server_options_proto_extTypes[4]
+	// Declares the method's service level objectives, such as
+	// { latency_p99_ms: 200 }, compiled into monitoring configuration by the
+	// slo plugin option.
+	//
+	// optional http_server.Slo slo = 51006;
+	E_Slo = &file_http_server_options_proto_extTypes[5]
+	// Answers duplicates of a request, with the same method, path, query, body
+	// and principal, with the response to the first one, absorbing the double
+	// submits of flaky clients.
+	//
+	// optional http_server.Dedupe dedupe = 51008;
+	E_Dedupe = &file_http_server_options_proto_extTypes[6]
+	// Tags the method's routes, such as ["tasks", "admin"], for grouping in the
+	// route table, OpenAPI document, metrics labels and discovery endpoint. They
+	// follow the tags of the service.
+	//
+	// repeated string tags = 51009;
+	E_Tags = &file_http_server_options_proto_extTypes[7]
+	// Passes the request body of the method's routes to its typed handler
+	// unread, as an io.Reader, binding only the path parameters and query
+	// string into the request message, so large uploads are never buffered.
+	// Requires binding=true.
+	//
+	// optional bool stream_body = 51011;
+	E_StreamBody = &file_http_server_options_proto_extTypes[8]
+	// Sets the read and write deadlines of the method's routes, such as
+	// { write_ms: 300000 } for a slow export next to fast CRUD methods whose
+	// requests keep the server-wide timeouts.
+	//
+	// optional http_server.Timeouts timeouts = 51012;
+	E_Timeouts = &file_http_server_options_proto_extTypes[9]
+	// Lists the authorization scopes or roles callers need for the method's
+	// routes, such as ["tasks.write"], after those of the service. They are
+	// exported to the <file>_http.permissions.json matrix for security review;
+	// enforcing them is left to the application's middlewares.
+	//
+	// repeated string scopes = 51015;
+	E_Scopes = &file_http_server_options_proto_extTypes[10]
+	// Serves the method as a long poll through the generated AsyncAdapter: its
+	// typed handler returns a channel its result is sent on, and requests still
+	// waiting for it after this many milliseconds are answered with 504 Gateway
+	// Timeout. Requires binding=true.
+	//
+	// optional uint32 long_poll_ms = 51018;
+	E_LongPollMs = &file_http_server_options_proto_extTypes[11]
+)
+
+// Extension fields to descriptorpb.ServiceOptions.
+var (
+	// Tags the routes of all the service's methods. Extensions of a package
+	// share one namespace, so the service option cannot also be named tags.
+	//
+	// repeated string service_tags = 51010;
+	E_ServiceTags = &file_http_server_options_proto_extTypes[12]
+	// Overrides the base path of the service's routes, such as "/api/tasks",
+	// generated as <Service>BasePath for Register<Service>RoutesAt. Defaults to
+	// "/" followed by the proto package, such as "/tasks.v1".
+	//
+	// optional string base_path = 51014;
+	E_BasePath = &file_http_server_options_proto_extTypes[13]
+	// Lists the authorization scopes or roles callers need for all the
+	// service's methods, such as ["tasks.read"].
+	//
+	// repeated string service_scopes = 51016;
+	E_ServiceScopes = &file_http_server_options_proto_extTypes[14]
+	// Lists the request headers the service forwards to the calls it makes,
+	// such as ["x-request-id", "authorization"], generated as
+	// <Service>ForwardedHeaders and the Propagate<Service>Headers middleware.
+	//
+	// repeated string forward_headers = 51017;
+	E_ForwardHeaders = &file_http_server_options_proto_extTypes[15]
+)
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// Normalizes the value of a string field of a request message when it is
+	// bound, in the order listed, such as
+	// [(http_server.normalize) = TRIM, (http_server.normalize) = LOWER] for an
+	// email address. Requires the binding=true plugin option to apply.
+	//
+	// repeated http_server.Normalization normalize = 51013;
+	E_Normalize = &file_http_server_options_proto_extTypes[16]
+)
+
+// Extension fields to descriptorpb.EnumValueOptions.
+var (
+	// Sets the HTTP status code of errors with a reason of the package's
+	// ErrorReason enum, such as 404 for TASK_NOT_FOUND. Reasons without it are
+	// answered with 500 Internal Server Error.
+	//
+	// optional int32 http_status = 51007;
+	E_HttpStatus = &file_http_server_options_proto_extTypes[17]
+)
+
+var File_http_server_options_proto protoreflect.FileDescriptor
+
+const file_http_server_options_proto_rawDesc = "" +
+	"\n" +
+	"\x19http_server/options.proto\x12\vhttp_server\x1a google/protobuf/descriptor.proto\"(\n" +
+	"\x05Cache\x12\x1f\n" +
+	"\vttl_seconds\x18\x01 \x01(\rR\n" +
+	"ttlSeconds\"/\n" +
+	"\x06Dedupe\x12%\n" +
+	"\x0ewindow_seconds\x18\x01 \x01(\rR\rwindowSeconds\"^\n" +
+	"\x03Slo\x12$\n" +
+	"\x0elatency_p99_ms\x18\x01 \x01(\rR\flatencyP99Ms\x121\n" +
+	"\x14availability_percent\x18\x02 \x01(\x01R\x13availabilityPercent\">\n" +
+	"\bTimeouts\x12\x17\n" +
+	"\aread_ms\x18\x01 \x01(\rR\x06readMs\x12\x19\n" +
+	"\bwrite_ms\x18\x02 \x01(\rR\awriteMs*B\n" +
+	"\n" +
+	"Visibility\x12\x1a\n" +
+	"\x16VISIBILITY_UNSPECIFIED\x10\x00\x12\n" +
+	"\n" +
+	"\x06PUBLIC\x10\x01\x12\f\n" +
+	"\bINTERNAL\x10\x02*c\n" +
+	"\rNormalization\x12\x1d\n" +
+	"\x19NORMALIZATION_UNSPECIFIED\x10\x00\x12\b\n" +
+	"\x04TRIM\x10\x01\x12\t\n" +
+	"\x05LOWER\x10\x02\x12\t\n" +
+	"\x05UPPER\x10\x03\x12\x13\n" +
+	"\x0fCOLLAPSE_SPACES\x10\x04:C\n" +
+	"\fstream_array\x12\x1e.google.protobuf.MethodOptions\x18\xb9\x8e\x03 \x01(\bR\vstreamArray:Y\n" +
+	"\n" +
+	"visibility\x12\x1e.google.protobuf.MethodOptions\x18\xba\x8e\x03 \x01(\x0e2\x17.http_server.VisibilityR\n" +
+	"visibility:J\n" +
+	"\x05cache\x12\x1e.google.protobuf.MethodOptions\x18\xbb\x8e\x03 \x01(\v2\x12.http_server.CacheR\x05cache:6\n" +
+	"\x05async\x12\x1e.google.protobuf.MethodOptions\x18\xbc\x8e\x03 \x01(\bR\x05async:B\n" +
+	"\funit_of_work\x12\x1e.google.protobuf.MethodOptions\x18\xbd\x8e\x03 \x01(\bR\n" +
+	"unitOfWork:D\n" +
+	"\x03slo\x12\x1e.google.protobuf.MethodOptions\x18\xbe\x8e\x03 \x01(\v2\x10.http_server.SloR\x03slo:M\n" +
+	"\x06dedupe\x12\x1e.google.protobuf.MethodOptions\x18\xc0\x8e\x03 \x01(\v2\x13.http_server.DedupeR\x06dedupe:4\n" +
+	"\x04tags\x12\x1e.google.protobuf.MethodOptions\x18\xc1\x8e\x03 \x03(\tR\x04tags:A\n" +
+	"\vstream_body\x12\x1e.google.protobuf.MethodOptions\x18Î\x03 \x01(\bR\n" +
+	"streamBody:S\n" +
+	"\btimeouts\x12\x1e.google.protobuf.MethodOptions\x18Ď\x03 \x01(\v2\x15.http_server.TimeoutsR\btimeouts:8\n" +
+	"\x06scopes\x12\x1e.google.protobuf.MethodOptions\x18ǎ\x03 \x03(\tR\x06scopes:B\n" +
+	"\flong_poll_ms\x12\x1e.google.protobuf.MethodOptions\x18ʎ\x03 \x01(\rR\n" +
+	"longPollMs:D\n" +
+	"\fservice_tags\x12\x1f.google.protobuf.ServiceOptions\x18\u008e\x03 \x03(\tR\vserviceTags:>\n" +
+	"\tbase_path\x12\x1f.google.protobuf.ServiceOptions\x18Ǝ\x03 \x01(\tR\bbasePath:H\n" +
+	"\x0eservice_scopes\x12\x1f.google.protobuf.ServiceOptions\x18Ȏ\x03 \x03(\tR\rserviceScopes:J\n" +
+	"\x0fforward_headers\x12\x1f.google.protobuf.ServiceOptions\x18Ɏ\x03 \x03(\tR\x0eforwardHeaders:Y\n" +
+	"\tnormalize\x12\x1d.google.protobuf.FieldOptions\x18Ŏ\x03 \x03(\x0e2\x1a.http_server.NormalizationR\tnormalize:D\n" +
+	"\vhttp_status\x12!.google.protobuf.EnumValueOptions\x18\xbf\x8e\x03 \x01(\x05R\n" +
+	"httpStatusBNZLgithub.com/farhaan/protoc-gen-go-http-server-interface/httpserver;httpserverb\x06proto3"
+
+var (
+	file_http_server_options_proto_rawDescOnce sync.Once
+	file_http_server_options_proto_rawDescData []byte
+)
+
+func file_http_server_options_proto_rawDescGZIP() []byte {
+	file_http_server_options_proto_rawDescOnce.Do(func() {
+		file_http_server_options_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_http_server_options_proto_rawDesc), len(file_http_server_options_proto_rawDesc)))
+	})
+	return file_http_server_options_proto_rawDescData
+}
+
+var file_http_server_options_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_http_server_options_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_http_server_options_proto_goTypes = []any{
+	(Visibility)(0),                       // 0: http_server.Visibility
+	(Normalization)(0),                    // 1: http_server.Normalization
+	(*Cache)(nil),                         // 2: http_server.Cache
+	(*Dedupe)(nil),                        // 3: http_server.Dedupe
+	(*Slo)(nil),                           // 4: http_server.Slo
+	(*Timeouts)(nil),                      // 5: http_server.Timeouts
+	(*descriptorpb.MethodOptions)(nil),    // 6: google.protobuf.MethodOptions
+	(*descriptorpb.ServiceOptions)(nil),   // 7: google.protobuf.ServiceOptions
+	(*descriptorpb.FieldOptions)(nil),     // 8: google.protobuf.FieldOptions
+	(*descriptorpb.EnumValueOptions)(nil), // 9: google.protobuf.EnumValueOptions
+}
+var file_http_server_options_proto_depIdxs = []int32{
+	6,  // 0: http_server.stream_array:extendee -> google.protobuf.MethodOptions
+	6,  // 1: http_server.visibility:extendee -> google.protobuf.MethodOptions
+	6,  // 2: http_server.cache:extendee -> google.protobuf.MethodOptions
+	6,  // 3: http_server.async:extendee -> google.protobuf.MethodOptions
+	6,  // 4: http_server.unit_of_work:extendee -> google.protobuf.MethodOptions
+	6,  // 5: http_server.slo:extendee -> google.protobuf.MethodOptions
+	6,  // 6: http_server.dedupe:extendee -> google.protobuf.MethodOptions
+	6,  // 7: http_server.tags:extendee -> google.protobuf.MethodOptions
+	6,  // 8: http_server.stream_body:extendee -> google.protobuf.MethodOptions
+	6,  // 9: http_server.timeouts:extendee -> google.protobuf.MethodOptions
+	6,  // 10: http_server.scopes:extendee -> google.protobuf.MethodOptions
+	6,  // 11: http_server.long_poll_ms:extendee -> google.protobuf.MethodOptions
+	7,  // 12: http_server.service_tags:extendee -> google.protobuf.ServiceOptions
+	7,  // 13: http_server.base_path:extendee -> google.protobuf.ServiceOptions
+	7,  // 14: http_server.service_scopes:extendee -> google.protobuf.ServiceOptions
+	7,  // 15: http_server.forward_headers:extendee -> google.protobuf.ServiceOptions
+	8,  // 16: http_server.normalize:extendee -> google.protobuf.FieldOptions
+	9,  // 17: http_server.http_status:extendee -> google.protobuf.EnumValueOptions
+	0,  // 18: http_server.visibility:type_name -> http_server.Visibility
+	2,  // 19: http_server.cache:type_name -> http_server.Cache
+	4,  // 20: http_server.slo:type_name -> http_server.Slo
+	3,  // 21: http_server.dedupe:type_name -> http_server.Dedupe
+	5,  // 22: http_server.timeouts:type_name -> http_server.Timeouts
+	1,  // 23: http_server.normalize:type_name -> http_server.Normalization
+	24, // [24:24] is the sub-list for method output_type
+	24, // [24:24] is the sub-list for method input_type
+	18, // [18:24] is the sub-list for extension type_name
+	0,  // [0:18] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_http_server_options_proto_init() }
+func file_http_server_options_proto_init() {
+	if File_http_server_options_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_http_server_options_proto_rawDesc), len(file_http_server_options_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   4,
+			NumExtensions: 18,
+			NumServices:   0,
+		},
+		GoTypes:           file_http_server_options_proto_goTypes,
+		DependencyIndexes: file_http_server_options_proto_depIdxs,
+		EnumInfos:         file_http_server_options_proto_enumTypes,
+		MessageInfos:      file_http_server_options_proto_msgTypes,
+		ExtensionInfos:    file_http_server_options_proto_extTypes,
+	}.Build()
+	File_http_server_options_proto = out.File
+	file_http_server_options_proto_goTypes = nil
+	file_http_server_options_proto_depIdxs = nil
+}
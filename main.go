@@ -4,6 +4,11 @@
 // Usage:
 //
 //	protoc --go_http_server_interface_out=paths=source_relative:. path/to/file.proto
+//
+// Run with --doctor to diagnose the protoc, googleapis, Go module and proto
+// setup of the working directory, with --selftest to check that the code it
+// generates for bundled proto fixtures builds, and with --update to install
+// the latest release with go install.
 package main
 
 import (
@@ -11,24 +16,68 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
+	"strings"
 
 	"google.golang.org/protobuf/proto"
 	plugin "google.golang.org/protobuf/types/pluginpb"
 
+	"github.com/farhaan/protoc-gen-go-http-server-interface/doctor"
 	"github.com/farhaan/protoc-gen-go-http-server-interface/httpinterface"
+	"github.com/farhaan/protoc-gen-go-http-server-interface/selftest"
 	"github.com/farhaan/protoc-gen-go-http-server-interface/version"
 )
 
+// modulePath is the path --update installs the plugin from.
+const modulePath = "github.com/farhaan/protoc-gen-go-http-server-interface"
+
+// importPaths collects repeated -I flags.
+type importPaths []string
+
+func (p *importPaths) String() string     { return strings.Join(*p, ",") }
+func (p *importPaths) Set(v string) error { *p = append(*p, v); return nil }
+
 func main() {
 	// Flags for debugging
-	var showVersion bool
+	var showVersion, runDoctor, runSelftest, update bool
+	var includes importPaths
+	var selftestDir string
 	flag.BoolVar(&showVersion, "version", false, "print the version and exit")
+	flag.BoolVar(&runDoctor, "doctor", false, "diagnose the setup of the working directory, or of the .proto files given as arguments, and exit")
+	flag.Var(&includes, "I", "proto import path searched by --doctor for google/api/annotations.proto (repeatable)")
+	flag.BoolVar(&runSelftest, "selftest", false, "generate code for bundled proto fixtures, build it with the go command, and exit")
+	flag.StringVar(&selftestDir, "selftest_dir", "", "directory --selftest writes the generated module to and keeps; a temporary directory by default")
+	flag.BoolVar(&update, "update", false, "install the latest release with go install and exit")
 	flag.Parse()
 
 	if showVersion {
 		fmt.Fprintf(os.Stderr, "protoc-gen-go-http-server-interface %s\n", version.GetVersion())
 		os.Exit(0)
 	}
+	if runDoctor {
+		d := &doctor.Doctor{Dir: ".", ImportPaths: includes, Files: flag.Args()}
+		fmt.Fprintf(os.Stderr, "protoc-gen-go-http-server-interface %s\n", version.GetVersion())
+		if !doctor.Report(os.Stderr, d.Run()) {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	if runSelftest {
+		s := &selftest.SelfTest{Dir: selftestDir}
+		fmt.Fprintf(os.Stderr, "protoc-gen-go-http-server-interface %s\n", version.GetVersion())
+		if !doctor.Report(os.Stderr, s.Run()) {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	if update {
+		cmd := exec.Command("go", "install", modulePath+"@latest")
+		cmd.Stdout, cmd.Stderr = os.Stderr, os.Stderr
+		if err := cmd.Run(); err != nil {
+			logFatal(err, "go install "+modulePath+"@latest failed")
+		}
+		os.Exit(0)
+	}
 
 	// Read input from stdin (protoc pipes input here)
 	data, err := io.ReadAll(os.Stdin)
@@ -44,6 +93,7 @@ func main() {
 
 	// Create a new httpinterface generator
 	g := httpinterface.New()
+	g.Warnings = os.Stderr
 
 	// Generate the code
 	response := g.Generate(&request)
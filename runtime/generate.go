@@ -0,0 +1,27 @@
+//go:build ignore
+
+// generate writes the runtime package, runtime.go and runtime_test.go, from
+// the runtime module templates of the generator, so the router types packages
+// generated with runtime_import=true alias are those runtime_module writes.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpinterface"
+)
+
+func main() {
+	files, err := httpinterface.RuntimePackageFiles()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "generate: %v\n", err)
+		os.Exit(1)
+	}
+	for _, f := range files {
+		if err := os.WriteFile(f.GetName(), []byte(f.GetContent()), 0o644); err != nil {
+			fmt.Fprintf(os.Stderr, "generate: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
@@ -0,0 +1,532 @@
+// Code generated by protoc-gen-go-http-server-interface. DO NOT EDIT.
+
+// Package runtime holds the router types shared by the packages
+// generated with runtime_import=true. Each of them
+// aliases Routes, Router, RouteGroup and Middleware to this package, so a
+// router created by one registers the routes of all of them, and the package
+// depends on nothing but the standard library.
+package runtime
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// Middleware represents a middleware function that wraps an http.Handler.
+type Middleware func(http.Handler) http.Handler
+
+// Routes defines the minimal interface for route registration.
+// This interface is intentionally minimal to maximize compatibility with
+// standard library and third-party routers (chi, gorilla/mux, etc.).
+type Routes interface {
+	// HandleFunc registers a handler function for the given method and pattern.
+	HandleFunc(method, pattern string, handler http.HandlerFunc)
+}
+
+// Router extends Routes with grouping and middleware support.
+type Router interface {
+	Routes
+	// Group creates a sub-router with the given prefix.
+	Group(prefix string, middlewares ...Middleware) Router
+	// Use appends middlewares to the chain.
+	Use(middlewares ...Middleware) Router
+}
+
+// RouteGroup implements Router using http.ServeMux.
+type RouteGroup struct {
+	mux         *http.ServeMux
+	prefix      string
+	middlewares []Middleware
+	routes      []string
+	registry    *routeRegistry
+	onConflict  ConflictPolicy
+}
+
+// routeRegistry records the routes and mounts of a router and all its groups.
+type routeRegistry struct {
+	routes  []string
+	mounts  []string
+	options map[string]*atomic.Pointer[sharedOptions]
+}
+
+// NewRouter creates a new router with an optional mux.
+// If mux is nil, a new http.ServeMux will be created.
+func NewRouter(mux *http.ServeMux) *RouteGroup {
+	if mux == nil {
+		mux = http.NewServeMux()
+	}
+	return &RouteGroup{
+		mux:         mux,
+		prefix:      "",
+		middlewares: nil,
+		routes:      []string{},
+		registry:    &routeRegistry{},
+	}
+}
+
+// Mux returns the underlying http.ServeMux.
+func (g *RouteGroup) Mux() *http.ServeMux {
+	return g.mux
+}
+
+// joinPath safely joins URL path segments.
+func joinPath(base, path string) string {
+	if path == "" || path == "/" {
+		return base
+	}
+	if base == "" || base == "/" {
+		return path
+	}
+	return strings.TrimSuffix(base, "/") + "/" + strings.TrimPrefix(path, "/")
+}
+
+// Group creates a new RouteGroup with the given prefix and optional middlewares.
+func (g *RouteGroup) Group(prefix string, middlewares ...Middleware) Router {
+	// Ensure prefix starts with /
+	if prefix != "" && !strings.HasPrefix(prefix, "/") {
+		prefix = "/" + prefix
+	}
+
+	return &RouteGroup{
+		mux:         g.mux,
+		prefix:      joinPath(g.prefix, prefix),
+		middlewares: appendMiddlewares(g.middlewares, middlewares),
+		routes:      []string{},
+		registry:    g.registry,
+		onConflict:  g.onConflict,
+	}
+}
+
+// Use appends middlewares to all routes registered after this call.
+func (g *RouteGroup) Use(middlewares ...Middleware) Router {
+	g.middlewares = appendMiddlewares(g.middlewares, middlewares)
+	return g
+}
+
+// HandleFunc registers a handler function for the given method and pattern.
+// Group middlewares are automatically applied to the handler.
+// It panics with *MountConflictError if the route falls under a mounted prefix.
+func (g *RouteGroup) HandleFunc(method, pattern string, handler http.HandlerFunc) {
+	fullPattern := joinPath(g.prefix, pattern)
+	finalHandler := applyMiddlewares(handler, g.middlewares)
+	routeKey := method + " " + fullPattern
+	if g.registry != nil {
+		for _, mount := range g.registry.mounts {
+			if underMount(fullPattern, mount) {
+				panic(&MountConflictError{Prefix: mount, Route: routeKey})
+			}
+		}
+		g.registry.routes = append(g.registry.routes, routeKey)
+	}
+	if g.handle(routeKey, finalHandler) {
+		g.routes = append(g.routes, routeKey)
+	}
+}
+
+// sharedOptions is what the OPTIONS route of a path answers with: the methods
+// the path's routes accept and the details of the HandleOptions calls for it.
+type sharedOptions struct {
+	allow   []string
+	details []any
+}
+
+// HandleOptions registers respond as the OPTIONS route of pattern, whose other
+// routes accept the methods in allow, sorted, and are described by detail.
+// The services with routes on a path share its OPTIONS route: a call for a
+// path the router or any of its groups already has one for adds allow and
+// detail to those respond is called with, instead of registering the route
+// again, which the ServeMux would panic on.
+func (g *RouteGroup) HandleOptions(pattern string, allow []string, detail any, respond func(w http.ResponseWriter, r *http.Request, allow []string, details []any)) {
+	key := patternShape(http.MethodOptions + " " + joinPath(g.prefix, pattern))
+	if g.registry != nil {
+		if shared, ok := g.registry.options[key]; ok {
+			current := shared.Load()
+			details := append(make([]any, 0, len(current.details)+1), current.details...)
+			shared.Store(&sharedOptions{allow: mergeMethods(current.allow, allow), details: append(details, detail)})
+			return
+		}
+	}
+	shared := &atomic.Pointer[sharedOptions]{}
+	shared.Store(&sharedOptions{allow: allow, details: []any{detail}})
+	g.HandleFunc(http.MethodOptions, pattern, func(w http.ResponseWriter, r *http.Request) {
+		current := shared.Load()
+		respond(w, r, current.allow, current.details)
+	})
+	if g.registry != nil {
+		if g.registry.options == nil {
+			g.registry.options = map[string]*atomic.Pointer[sharedOptions]{}
+		}
+		g.registry.options[key] = shared
+	}
+}
+
+// mergeMethods returns the sorted union of the sorted method lists a and b.
+func mergeMethods(a, b []string) []string {
+	merged := make([]string, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		switch {
+		case a[0] < b[0]:
+			merged, a = append(merged, a[0]), a[1:]
+		case a[0] > b[0]:
+			merged, b = append(merged, b[0]), b[1:]
+		default:
+			merged, a, b = append(merged, a[0]), a[1:], b[1:]
+		}
+	}
+	return append(append(merged, a...), b...)
+}
+
+// ConflictPolicy selects what a router does when a route is registered for a
+// method and pattern that its ServeMux already serves, such as a /liveness
+// route of two services sharing the mux.
+type ConflictPolicy int
+
+const (
+	// ConflictError panics with *RouteConflictError.
+	ConflictError ConflictPolicy = iota
+	// ConflictSkip keeps the route registered first.
+	ConflictSkip
+	// ConflictReplace serves the route registered last. Only routes registered
+	// under ConflictReplace by a generated router can be replaced; others
+	// panic as with ConflictError.
+	ConflictReplace
+)
+
+// OnConflict sets the ConflictPolicy of the routes registered after this
+// call, on the group and groups created from it afterwards. It is
+// ConflictError by default.
+func (g *RouteGroup) OnConflict(policy ConflictPolicy) Router {
+	g.onConflict = policy
+	return g
+}
+
+// RouteConflictError reports a route registered for a method and pattern that
+// the ServeMux already serves.
+type RouteConflictError struct {
+	// Route is the conflicting route, as "METHOD /path".
+	Route string
+	// Existing is the route already registered, which differs from Route only
+	// in the names of its wildcards.
+	Existing string
+}
+
+func (e *RouteConflictError) Error() string {
+	return "protogen: route " + e.Route + " conflicts with registered route " + e.Existing
+}
+
+// handle registers h for routeKey on the mux, resolving a conflict with a
+// route already registered for the same method and pattern by the group's
+// ConflictPolicy. It reports whether h serves the route. Routes that only
+// overlap routeKey are left for the mux to reject. Under ConflictError, the
+// default, the mux is only searched for the existing route once registering
+// h panics.
+func (g *RouteGroup) handle(routeKey string, h http.Handler) bool {
+	if g.onConflict == ConflictError {
+		defer func() {
+			if p := recover(); p != nil {
+				if _, pattern := registeredRoute(g.mux, routeKey); pattern != "" && patternShape(pattern) == patternShape(routeKey) {
+					panic(&RouteConflictError{Route: routeKey, Existing: pattern})
+				}
+				panic(p)
+			}
+		}()
+		g.mux.Handle(routeKey, h)
+		return true
+	}
+	if existing, pattern := registeredRoute(g.mux, routeKey); pattern != "" && patternShape(pattern) == patternShape(routeKey) {
+		if g.onConflict == ConflictSkip {
+			return false
+		}
+		if r, ok := existing.(interface{ ReplaceHandler(http.Handler) }); ok {
+			r.ReplaceHandler(h)
+			return true
+		}
+		panic(&RouteConflictError{Route: routeKey, Existing: pattern})
+	}
+	if g.onConflict == ConflictReplace {
+		rh := &replaceableHandler{}
+		rh.ReplaceHandler(h)
+		h = rh
+	}
+	g.mux.Handle(routeKey, h)
+	return true
+}
+
+// replaceableHandler is the handler of a route registered under
+// ConflictReplace, which a router of any generated package can replace under
+// ConflictReplace too.
+type replaceableHandler struct {
+	handler atomic.Pointer[http.Handler]
+}
+
+func (h *replaceableHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	(*h.handler.Load()).ServeHTTP(w, r)
+}
+
+// ReplaceHandler serves the route with handler from now on.
+func (h *replaceableHandler) ReplaceHandler(handler http.Handler) {
+	h.handler.Store(&handler)
+}
+
+// registeredRoute returns the handler and pattern of the route of mux that
+// serves the route pattern routeKey, "METHOD /path", with a placeholder value
+// for each wildcard, or a pattern of "" if no route serves it.
+func registeredRoute(mux *http.ServeMux, routeKey string) (http.Handler, string) {
+	method, path, _ := strings.Cut(routeKey, " ")
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if segment == "{$}" {
+			segments[i] = ""
+		} else if strings.HasPrefix(segment, "{") {
+			segments[i] = "_"
+		}
+	}
+	r, err := http.NewRequest(method, strings.Join(segments, "/"), nil)
+	if err != nil {
+		return nil, ""
+	}
+	return mux.Handler(r)
+}
+
+// patternShape returns the route pattern "METHOD /path" with its wildcards
+// unnamed, so patterns differing only in wildcard names compare equal.
+func patternShape(routeKey string) string {
+	segments := strings.Split(routeKey, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") && segment != "{$}" {
+			if strings.HasSuffix(segment, "...}") {
+				segments[i] = "{...}"
+			} else {
+				segments[i] = "{}"
+			}
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// Mount serves h for every request under prefix, relative to the group, with
+// the prefix stripped from the request path, so third-party handlers such as a
+// GraphQL endpoint or websocket hub can live under the generated router. Group
+// middlewares are applied to h. Mounting at the root serves h for requests no
+// other route matches.
+//
+// Mount returns *MountConflictError if a route registered on the router or any
+// of its groups falls under prefix; routes registered under prefix afterwards
+// panic with the same error.
+func (g *RouteGroup) Mount(prefix string, h http.Handler) error {
+	if h == nil {
+		return ErrNilHandler
+	}
+	if prefix != "" && !strings.HasPrefix(prefix, "/") {
+		prefix = "/" + prefix
+	}
+	mount := strings.TrimSuffix(joinPath(g.prefix, prefix), "/")
+
+	if g.registry != nil {
+		for _, route := range g.registry.routes {
+			_, path, _ := strings.Cut(route, " ")
+			if underMount(path, mount) {
+				return &MountConflictError{Prefix: mount, Route: route}
+			}
+		}
+		g.registry.mounts = append(g.registry.mounts, mount)
+	}
+
+	handler := applyMiddlewares(stripMountPrefix(mount, h), g.middlewares)
+	if mount != "" {
+		g.mux.Handle(mount, handler)
+	}
+	g.mux.Handle(mount+"/", handler)
+	return nil
+}
+
+// MountConflictError reports a mounted prefix that overlaps a registered route.
+type MountConflictError struct {
+	// Prefix is the mounted path prefix.
+	Prefix string
+	// Route is the conflicting route, as "METHOD /path".
+	Route string
+}
+
+// Error returns the mounted prefix and the route it overlaps.
+func (e *MountConflictError) Error() string {
+	return "protogen: mount " + e.Prefix + "/ overlaps route " + e.Route
+}
+
+// underMount reports whether requests to the route pattern path can fall
+// under the mounted prefix mount, comparing them segment by segment: a
+// wildcard such as {id} matches any segment of mount, and one such as
+// {path...} all the rest. Nothing conflicts with a mount at the root, which
+// only receives unmatched requests.
+func underMount(path, mount string) bool {
+	if mount == "" {
+		return false
+	}
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for i, want := range strings.Split(strings.TrimPrefix(mount, "/"), "/") {
+		if i == len(segments) {
+			return false
+		}
+		segment := segments[i]
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "...}") {
+			return true
+		}
+		if segment != want && (!strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") || segment == "{$}") {
+			return false
+		}
+	}
+	return true
+}
+
+// stripMountPrefix serves h with prefix removed from the request path; a
+// request for the prefix itself is served as "/".
+func stripMountPrefix(prefix string, h http.Handler) http.Handler {
+	if prefix == "" {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r2 := r.Clone(r.Context())
+		r2.URL.Path = strings.TrimPrefix(r.URL.Path, prefix)
+		if r2.URL.Path == "" {
+			r2.URL.Path = "/"
+		}
+		if r.URL.RawPath != "" {
+			r2.URL.RawPath = strings.TrimPrefix(r.URL.RawPath, prefix)
+			if r2.URL.RawPath == "" {
+				r2.URL.RawPath = "/"
+			}
+		}
+		h.ServeHTTP(w, r2)
+	})
+}
+
+// GetRoutes returns all registered routes for this group.
+func (g *RouteGroup) GetRoutes() []string {
+	return g.routes
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (g *RouteGroup) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mux.ServeHTTP(w, r)
+}
+
+// appendMiddlewares combines parent and new middlewares, filtering out nils.
+func appendMiddlewares(parent, additional []Middleware) []Middleware {
+	result := make([]Middleware, 0, len(parent)+len(additional))
+	for _, mw := range parent {
+		if mw != nil {
+			result = append(result, mw)
+		}
+	}
+	for _, mw := range additional {
+		if mw != nil {
+			result = append(result, mw)
+		}
+	}
+	return result
+}
+
+// applyMiddlewares wraps handler with the given middlewares (outermost first).
+func applyMiddlewares(handler http.Handler, middlewares []Middleware) http.Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		if middlewares[i] != nil {
+			handler = middlewares[i](handler)
+		}
+	}
+	return handler
+}
+
+// ErrNilRouter is returned when a nil router is passed to a register function.
+var ErrNilRouter = errors.New("protogen: router is nil")
+
+// ErrNilHandler is returned when a nil handler is passed to a register function.
+var ErrNilHandler = errors.New("protogen: handler is nil")
+
+// DefaultRouter creates a new router with a new ServeMux.
+//
+// Deprecated: Use NewRouter(nil) instead.
+func DefaultRouter() *RouteGroup {
+	return NewRouter(nil)
+}
+
+// RouteDef is a route listed by a RouteProvider: the handler of a method and
+// pattern, relative to the prefix MergeRouters serves it under.
+type RouteDef struct {
+	Method  string
+	Pattern string
+	Handler http.HandlerFunc
+}
+
+// RouteProvider lists the routes of a service, for MergeRouters to serve them
+// on one router along with the routes of services of other proto packages.
+type RouteProvider interface {
+	Routes() []RouteDef
+}
+
+// RouteList is a RouteProvider of a fixed list of routes. *RouteList
+// implements Routes, so a register function lists the routes it registers in
+// one:
+//
+//	var routes RouteList
+//	err := RegisterTaskServiceRoutes(&routes, handler)
+type RouteList []RouteDef
+
+// HandleFunc appends the route of handler for method and pattern to l.
+func (l *RouteList) HandleFunc(method, pattern string, handler http.HandlerFunc) {
+	*l = append(*l, RouteDef{Method: method, Pattern: pattern, Handler: handler})
+}
+
+// Routes returns the routes of l.
+func (l RouteList) Routes() []RouteDef {
+	return l
+}
+
+// MergeRouters returns a router serving the routes of all providers under
+// prefix, so a binary implementing the services of many proto packages serves
+// them on a single handler:
+//
+//	router, err := runtime.MergeRouters("/api",
+//		tasksv1.TaskServiceRouteProvider{Handler: tasks},
+//		usersv1.UserServiceRouteProvider{Handler: users},
+//	)
+//
+// The routes are registered in the order of providers. MergeRouters returns
+// *RouteConflictError if two providers list routes for the same method and
+// pattern, the error of the ServeMux for routes it cannot tell apart, and
+// ErrNilHandler for a nil provider or route handler.
+func MergeRouters(prefix string, providers ...RouteProvider) (*RouteGroup, error) {
+	router := NewRouter(nil)
+	group := router.Group(prefix)
+	for _, provider := range providers {
+		if provider == nil {
+			return nil, ErrNilHandler
+		}
+		if err := mergeRoutes(group, provider); err != nil {
+			return nil, err
+		}
+	}
+	return router, nil
+}
+
+// mergeRoutes registers the routes of provider on r, returning the error
+// listing or registering them panics with.
+func mergeRoutes(r Routes, provider RouteProvider) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			e, ok := p.(error)
+			if !ok {
+				panic(p)
+			}
+			err = e
+		}
+	}()
+	for _, route := range provider.Routes() {
+		if route.Handler == nil {
+			return ErrNilHandler
+		}
+		r.HandleFunc(route.Method, route.Pattern, route.Handler)
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+// Code generated by protoc-gen-go-http-server-interface. DO NOT EDIT.
+
+package runtime
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func serve(t *testing.T, h http.Handler, method, target string) (int, string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+	body, err := io.ReadAll(rec.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rec.Code, string(body)
+}
+
+func write(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, body+r.PathValue("id"))
+	}
+}
+
+func TestGroupMiddlewares(t *testing.T) {
+	var order []string
+	mark := func(name string) Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				order = append(order, name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	router := NewRouter(nil)
+	router.Use(mark("router"))
+	api := router.Group("api", mark("group"), nil)
+	api.HandleFunc(http.MethodGet, "/tasks/{id}", write("task "))
+
+	code, body := serve(t, router, http.MethodGet, "/api/tasks/7")
+	if code != http.StatusOK || body != "task 7" {
+		t.Fatalf("GET /api/tasks/7 = %d %q, want 200 %q", code, body, "task 7")
+	}
+	if want := []string{"router", "group"}; !slices.Equal(order, want) {
+		t.Errorf("middlewares ran in order %v, want %v", order, want)
+	}
+	if want := []string{"GET /api/tasks/{id}"}; !slices.Equal(api.(*RouteGroup).GetRoutes(), want) {
+		t.Errorf("GetRoutes() = %v, want %v", api.(*RouteGroup).GetRoutes(), want)
+	}
+}
+
+func TestConflictPolicies(t *testing.T) {
+	mux := http.NewServeMux()
+	NewRouter(mux).OnConflict(ConflictReplace).HandleFunc(http.MethodGet, "/tasks/{id}", write("first "))
+	NewRouter(mux).HandleFunc(http.MethodGet, "/users/{id}", write("user "))
+
+	for _, r := range []Router{NewRouter(mux), NewRouter(mux).OnConflict(ConflictReplace)} {
+		func() {
+			defer func() {
+				var conflict *RouteConflictError
+				if err, _ := recover().(error); !errors.As(err, &conflict) {
+					t.Errorf("registering a route twice panicked with %v, want *RouteConflictError", err)
+				}
+			}()
+			// Only routes registered under ConflictReplace can be replaced
+			r.HandleFunc(http.MethodGet, "/users/{user_id}", write("second "))
+		}()
+	}
+	if _, body := serve(t, mux, http.MethodGet, "/users/1"); body != "user 1" {
+		t.Errorf("after a conflict, GET /users/1 = %q, want %q", body, "user 1")
+	}
+
+	NewRouter(mux).OnConflict(ConflictSkip).HandleFunc(http.MethodGet, "/tasks/{id}", write("skipped "))
+	if _, body := serve(t, mux, http.MethodGet, "/tasks/1"); body != "first 1" {
+		t.Errorf("after ConflictSkip, GET /tasks/1 = %q, want %q", body, "first 1")
+	}
+
+	NewRouter(mux).OnConflict(ConflictReplace).HandleFunc(http.MethodGet, "/tasks/{id}", write("replaced "))
+	if _, body := serve(t, mux, http.MethodGet, "/tasks/1"); body != "replaced 1" {
+		t.Errorf("after ConflictReplace, GET /tasks/1 = %q, want %q", body, "replaced 1")
+	}
+}
+
+// TestDefaultRegistration checks that a router without mounts or a conflict
+// policy registers each route on its ServeMux as a plain http.Handle call
+// would: under the route's pattern and served by the route's own handler.
+func TestDefaultRegistration(t *testing.T) {
+	mux := http.NewServeMux()
+	NewRouter(mux).HandleFunc(http.MethodGet, "/tasks/{id}", write("task "))
+
+	h, pattern := mux.Handler(httptest.NewRequest(http.MethodGet, "/tasks/1", nil))
+	if pattern != "GET /tasks/{id}" {
+		t.Errorf("route pattern = %q, want %q", pattern, "GET /tasks/{id}")
+	}
+	if _, ok := h.(http.HandlerFunc); !ok {
+		t.Errorf("route handler = %T, want the http.HandlerFunc registered", h)
+	}
+	if _, body := serve(t, mux, http.MethodGet, "/tasks/1"); body != "task 1" {
+		t.Errorf("GET /tasks/1 = %q, want %q", body, "task 1")
+	}
+}
+
+func TestMount(t *testing.T) {
+	router := NewRouter(nil)
+	if err := router.Mount("/files", nil); !errors.Is(err, ErrNilHandler) {
+		t.Errorf("Mount(nil) = %v, want ErrNilHandler", err)
+	}
+	err := router.Mount("/files", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, r.URL.Path)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, body := serve(t, router, http.MethodGet, "/files/a/b"); body != "/a/b" {
+		t.Errorf("GET /files/a/b served path %q, want %q", body, "/a/b")
+	}
+
+	router.HandleFunc(http.MethodGet, "/tasks", write("tasks"))
+	var conflict *MountConflictError
+	if err := router.Mount("/tasks", http.NotFoundHandler()); !errors.As(err, &conflict) {
+		t.Errorf("Mount over a route = %v, want *MountConflictError", err)
+	}
+}
+
+func TestMountWildcardConflicts(t *testing.T) {
+	tests := []struct {
+		route    string
+		mount    string
+		conflict bool
+	}{
+		{route: "/{id}", mount: "/graphql", conflict: true},
+		{route: "/v1/{name...}", mount: "/v1/files/static", conflict: true},
+		{route: "/v1/{id}/avatar", mount: "/v1/users", conflict: true},
+		{route: "/v1/{id}", mount: "/v1/users/static", conflict: false},
+		{route: "/{id}", mount: "/graphql/ws", conflict: false},
+		{route: "/v1/{$}", mount: "/v1/users", conflict: false},
+	}
+	for _, tt := range tests {
+		router := NewRouter(nil)
+		router.HandleFunc(http.MethodGet, tt.route, write("route"))
+		var conflict *MountConflictError
+		if err := router.Mount(tt.mount, http.NotFoundHandler()); errors.As(err, &conflict) != tt.conflict {
+			t.Errorf("Mount(%q) after GET %s = %v, want conflict %v", tt.mount, tt.route, err, tt.conflict)
+		}
+
+		router = NewRouter(nil)
+		if err := router.Mount(tt.mount, http.NotFoundHandler()); err != nil {
+			t.Fatal(err)
+		}
+		func() {
+			defer func() {
+				err, _ := recover().(error)
+				if errors.As(err, &conflict) != tt.conflict {
+					t.Errorf("GET %s after Mount(%q) panicked with %v, want conflict %v", tt.route, tt.mount, err, tt.conflict)
+				}
+			}()
+			router.HandleFunc(http.MethodGet, tt.route, write("route"))
+		}()
+	}
+}
+
+func TestHandleOptions(t *testing.T) {
+	respond := func(w http.ResponseWriter, r *http.Request, allow []string, details []any) {
+		names := make([]string, len(details))
+		for i, detail := range details {
+			names[i], _ = detail.(string)
+		}
+		w.Header().Set("Allow", strings.Join(allow, ", "))
+		_, _ = io.WriteString(w, strings.Join(names, " "))
+	}
+	router := NewRouter(nil)
+	router.HandleOptions("/tasks/{id}", []string{"GET", "HEAD", "OPTIONS"}, "tasks", respond)
+	router.Group("/").(*RouteGroup).HandleOptions("/tasks/{task_id}", []string{"DELETE", "OPTIONS"}, "admin", respond)
+	router.HandleOptions("/tasks/{path...}", []string{"OPTIONS", "PUT"}, "files", respond)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodOptions, "/tasks/1", nil))
+	if got, want := rec.Header().Get("Allow"), "DELETE, GET, HEAD, OPTIONS"; got != want {
+		t.Errorf("OPTIONS /tasks/1 Allow = %q, want %q", got, want)
+	}
+	if got, want := rec.Body.String(), "tasks admin"; got != want {
+		t.Errorf("OPTIONS /tasks/1 details = %q, want %q", got, want)
+	}
+	if _, body := serve(t, router, http.MethodOptions, "/tasks/a/b"); body != "files" {
+		t.Errorf("OPTIONS /tasks/a/b details = %q, want %q", body, "files")
+	}
+}
+
+func TestMergeRouters(t *testing.T) {
+	var tasks, users, duplicate RouteList
+	tasks.HandleFunc(http.MethodGet, "/tasks/{id}", write("task "))
+	users.HandleFunc(http.MethodGet, "/users/{id}", write("user "))
+
+	router, err := MergeRouters("/api", tasks, users)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for target, want := range map[string]string{"/api/tasks/1": "task 1", "/api/users/2": "user 2"} {
+		if _, body := serve(t, router, http.MethodGet, target); body != want {
+			t.Errorf("GET %s = %q, want %q", target, body, want)
+		}
+	}
+
+	duplicate.HandleFunc(http.MethodGet, "/tasks/{task_id}", write("other "))
+	var conflict *RouteConflictError
+	if _, err := MergeRouters("/api", tasks, duplicate); !errors.As(err, &conflict) {
+		t.Errorf("MergeRouters with a duplicate route = %v, want *RouteConflictError", err)
+	}
+	if _, err := MergeRouters("", tasks, nil); !errors.Is(err, ErrNilHandler) {
+		t.Errorf("MergeRouters with a nil provider = %v, want ErrNilHandler", err)
+	}
+}
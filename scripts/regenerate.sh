@@ -87,6 +87,9 @@ while IFS= read -r dir; do
     regenerate_example "$dir" "$name"
 done < <(find "$ROOT_DIR/examples" -name "buf.gen.yaml" -exec dirname {} \;)
 
+log_info "=== Regenerating Conformance Suite ==="
+regenerate_example "$ROOT_DIR/conformance" "conformance"
+
 # Step 4: Run tests
 if [[ "$SKIP_TEST" == false ]]; then
     log_info "=== Running tests ==="
@@ -0,0 +1,321 @@
+// Package selftest checks an installation of protoc-gen-go-http-server-interface
+// end to end: it generates code for bundled proto fixtures, covering proto2,
+// proto3 and Edition 2023 files with path templates, additional bindings,
+// custom verbs and custom methods, under a battery of plugin options, and
+// builds the output with the go command in a temporary module. It needs
+// neither protoc nor the googleapis protos, only a Go toolchain and access to
+// the module proxy or a module cache holding the dependencies.
+package selftest
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"runtime/debug"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	descriptor "google.golang.org/protobuf/types/descriptorpb"
+	plugin "google.golang.org/protobuf/types/pluginpb"
+
+	// The fixtures import google/protobuf/empty.proto, whose descriptor
+	// loadFixtures takes from the binary
+	_ "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/doctor"
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpinterface"
+)
+
+// fixtures is the FileDescriptorSet of the .proto files in testdata, without
+// their imports, which are taken from the descriptors linked into the binary.
+// Regenerate it with make generate-selftest after editing them.
+//
+//go:embed testdata/fixtures.binpb
+var fixtures []byte
+
+// messages holds the protoc-gen-go output of the fixtures, such as
+// testdata/proto3.pb.go, which is the same in the package of every case.
+// make generate-selftest regenerates it along with fixtures.
+//
+//go:embed testdata/*.pb.go
+var messages embed.FS
+
+// Case is a set of plugin options the fixtures are generated with.
+type Case struct {
+	// Name names the directory the case is generated into, such as "binding".
+	Name string
+	// Parameter is the plugin parameter, as passed with
+	// --go_http_server_interface_opt.
+	Parameter string
+}
+
+// Cases are the option sets checked when SelfTest.Cases is nil.
+var Cases = []Case{
+	{Name: "default"},
+	{Name: "binding", Parameter: "binding=true,decode_helpers=true,strict_content_type=true,validate=true"},
+	{Name: "client", Parameter: "binding=true,client=true,mock=true,error_details=true"},
+	{Name: "split", Parameter: "layout=split,binding=true,error_handler=true"},
+	{Name: "split-client", Parameter: "layout=split,binding=true,client=true"},
+	// The CLIs import the generated code, so its import paths must be those
+	// of the case's packages
+	{Name: "cli", Parameter: "binding=true,client=true,cli=true," +
+		"import_alias=editions.proto=" + modulePath + "/cli/editions;editionspb," +
+		"import_alias=proto2.proto=" + modulePath + "/cli/proto2;proto2pb," +
+		"import_alias=proto3.proto=" + modulePath + "/cli/proto3;proto3pb"},
+	{Name: "routing", Parameter: "path_prefix=/api,auto_options=true,conditional_get=true,unexpected_body=reject"},
+}
+
+// modulePath is the path of the module the generated code is built in.
+const modulePath = "example.com/selftest"
+
+// goDirective is the go version of that module, the first release whose
+// http.ServeMux routes the generated method and wildcard patterns.
+const goDirective = "1.22"
+
+// requiredModules are the modules the generated code imports, required at
+// the versions the plugin was built with.
+var requiredModules = []string{
+	"google.golang.org/protobuf",
+	"google.golang.org/genproto/googleapis/api",
+}
+
+// SelfTest generates and builds the fixtures.
+type SelfTest struct {
+	// Dir is the directory the module is written to. When empty, a temporary
+	// directory is created and removed once the checks complete.
+	Dir string
+	// Cases are the option sets checked; nil means Cases.
+	Cases []Case
+	// Command runs the go command with args in dir and returns its combined
+	// output; nil means exec.Command("go", args...).CombinedOutput.
+	Command func(dir string, args ...string) ([]byte, error)
+}
+
+// Run generates the fixtures for every case and builds the output, and
+// returns the results in order, in the form doctor.Report prints. Once a
+// check fails, the checks depending on it are not run.
+func (s *SelfTest) Run() []doctor.Result {
+	files, result := loadFixtures()
+	results := []doctor.Result{result}
+	if result.Status == doctor.Fail {
+		return results
+	}
+
+	dir := s.Dir
+	if dir == "" {
+		tmp, err := os.MkdirTemp("", "http-server-interface-selftest-")
+		if err != nil {
+			return append(results, doctor.Result{Check: "module", Status: doctor.Fail, Message: err.Error()})
+		}
+		defer os.RemoveAll(tmp)
+		dir = tmp
+	}
+
+	cases := s.Cases
+	if cases == nil {
+		cases = Cases
+	}
+	generated := true
+	for _, c := range cases {
+		result := generateCase(dir, c, files)
+		results = append(results, result)
+		generated = generated && result.Status != doctor.Fail
+	}
+	if !generated {
+		return results
+	}
+
+	result = writeModule(dir)
+	results = append(results, result)
+	if result.Status == doctor.Fail {
+		return results
+	}
+	return append(results, s.build(dir))
+}
+
+// loadFixtures returns the fixtures, preceded by the files they import, as a
+// protoc request lists them.
+func loadFixtures() ([]*descriptor.FileDescriptorProto, doctor.Result) {
+	result := doctor.Result{Check: "fixtures"}
+	set := &descriptor.FileDescriptorSet{}
+	if err := proto.Unmarshal(fixtures, set); err != nil {
+		result.Status = doctor.Fail
+		result.Message = "cannot decode the bundled fixtures: " + err.Error()
+		return nil, result
+	}
+
+	var files []*descriptor.FileDescriptorProto
+	seen := map[string]bool{}
+	var addImports func(name string) error
+	addImports = func(name string) error {
+		if seen[name] {
+			return nil
+		}
+		seen[name] = true
+		fd, err := protoregistry.GlobalFiles.FindFileByPath(name)
+		if err != nil {
+			return err
+		}
+		file := protodesc.ToFileDescriptorProto(fd)
+		for _, dep := range file.GetDependency() {
+			if err := addImports(dep); err != nil {
+				return err
+			}
+		}
+		files = append(files, file)
+		return nil
+	}
+	var names []string
+	for _, file := range set.GetFile() {
+		for _, dep := range file.GetDependency() {
+			if err := addImports(dep); err != nil {
+				result.Status = doctor.Fail
+				result.Message = fmt.Sprintf("%s imports %s: %v", file.GetName(), dep, err)
+				return nil, result
+			}
+		}
+		names = append(names, file.GetName())
+	}
+	result.Message = strings.Join(names, ", ")
+	return append(files, set.GetFile()...), result
+}
+
+// generateCase writes the bundled message code of each fixture and its HTTP
+// code generated with c to its own package, such as dir/binding/proto3.
+func generateCase(dir string, c Case, files []*descriptor.FileDescriptorProto) doctor.Result {
+	result := doctor.Result{Check: "generate " + c.Name}
+	count := 0
+	for _, file := range files {
+		if strings.HasPrefix(file.GetName(), "google/") {
+			continue
+		}
+		generated, err := generateFixture(file.GetName(), c.Parameter, files)
+		if err != nil {
+			result.Status = doctor.Fail
+			result.Message = err.Error()
+			result.Fix = "report the failure at https://github.com/farhaan/protoc-gen-go-http-server-interface/issues"
+			return result
+		}
+		base := strings.TrimSuffix(path.Base(file.GetName()), ".proto")
+		pkgDir := filepath.Join(dir, c.Name, base)
+		if err := os.MkdirAll(pkgDir, 0o755); err != nil {
+			result.Status = doctor.Fail
+			result.Message = err.Error()
+			return result
+		}
+		code, err := messages.ReadFile("testdata/" + base + ".pb.go")
+		if err == nil {
+			err = os.WriteFile(filepath.Join(pkgDir, base+".pb.go"), code, 0o644)
+		}
+		if err != nil {
+			result.Status = doctor.Fail
+			result.Message = err.Error()
+			return result
+		}
+		count++
+		for _, f := range generated {
+			name := filepath.Join(pkgDir, path.Base(f.GetName()))
+			if strings.HasPrefix(f.GetName(), "cmd/") {
+				// The commands of cli=true are packages under the generated code
+				name = filepath.Join(pkgDir, filepath.FromSlash(f.GetName()))
+			}
+			if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+				result.Status = doctor.Fail
+				result.Message = err.Error()
+				return result
+			}
+			if err := os.WriteFile(name, []byte(f.GetContent()), 0o644); err != nil {
+				result.Status = doctor.Fail
+				result.Message = err.Error()
+				return result
+			}
+			count++
+		}
+	}
+	result.Message = fmt.Sprintf("%d files", count)
+	if c.Parameter != "" {
+		result.Message += " with " + c.Parameter
+	}
+	return result
+}
+
+// generateFixture runs the plugin, with parameter, on the fixture named name
+// and returns the files it generates.
+func generateFixture(name, parameter string, files []*descriptor.FileDescriptorProto) ([]*plugin.CodeGeneratorResponse_File, error) {
+	req := &plugin.CodeGeneratorRequest{FileToGenerate: []string{name}, ProtoFile: files}
+	if parameter != "" {
+		req.Parameter = proto.String(parameter)
+	}
+	resp := httpinterface.New().Generate(req)
+	if resp.Error != nil {
+		return nil, fmt.Errorf("%s", resp.GetError())
+	}
+	return resp.File, nil
+}
+
+// writeModule writes the go.mod of the module in dir, requiring the modules
+// the generated code imports at the versions the plugin was built with.
+func writeModule(dir string) doctor.Result {
+	result := doctor.Result{Check: "go.mod"}
+	goMod := "module " + modulePath + "\n\ngo " + goDirective + "\n"
+	if info, ok := debug.ReadBuildInfo(); ok {
+		var requires []string
+		for _, dep := range info.Deps {
+			if slices.Contains(requiredModules, dep.Path) && dep.Version != "" && dep.Version != "(devel)" {
+				requires = append(requires, "\t"+dep.Path+" "+dep.Version+"\n")
+			}
+		}
+		if len(requires) > 0 {
+			goMod += "\nrequire (\n" + strings.Join(requires, "") + ")\n"
+		}
+	}
+	path := filepath.Join(dir, "go.mod")
+	if err := os.WriteFile(path, []byte(goMod), 0o644); err != nil {
+		result.Status = doctor.Fail
+		result.Message = err.Error()
+		return result
+	}
+	result.Message = path
+	return result
+}
+
+// build builds every package of the module in dir, adding the requirements
+// and go.sum entries it is missing.
+func (s *SelfTest) build(dir string) doctor.Result {
+	result := doctor.Result{Check: "go build"}
+	out, err := s.command(dir, "build", "-mod=mod", "./...")
+	if err != nil {
+		result.Status = doctor.Fail
+		result.Message = commandError(err, out)
+		result.Fix = "if modules failed to download, check GOPROXY or access to the module proxy; " +
+			"otherwise run with --selftest_dir to keep the generated code, and report the errors at " +
+			"https://github.com/farhaan/protoc-gen-go-http-server-interface/issues"
+		return result
+	}
+	result.Message = "the generated code builds"
+	return result
+}
+
+func (s *SelfTest) command(dir string, args ...string) ([]byte, error) {
+	if s.Command != nil {
+		return s.Command(dir, args...)
+	}
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	return cmd.CombinedOutput()
+}
+
+// commandError describes a failed command by its output, or err when it
+// printed nothing.
+func commandError(err error, out []byte) string {
+	if msg := strings.TrimSpace(string(out)); msg != "" {
+		return msg
+	}
+	return err.Error()
+}
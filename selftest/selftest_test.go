@@ -0,0 +1,111 @@
+package selftest
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/doctor"
+)
+
+// fakeCommand records the go commands it is asked to run and answers them
+// with out and err.
+type fakeCommand struct {
+	calls []string
+	out   string
+	err   error
+}
+
+func (f *fakeCommand) run(dir string, args ...string) ([]byte, error) {
+	f.calls = append(f.calls, dir+": go "+strings.Join(args, " "))
+	return []byte(f.out), f.err
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cmd := &fakeCommand{}
+	results := (&SelfTest{Dir: dir, Command: cmd.run}).Run()
+
+	var report bytes.Buffer
+	if !doctor.Report(&report, results) {
+		t.Fatalf("Run() failed:\n%s", report.String())
+	}
+	if want := len(Cases) + 3; len(results) != want {
+		t.Errorf("Run() returned %d results, want %d:\n%s", len(results), want, report.String())
+	}
+	if results[0].Message != "editions.proto, proto2.proto, proto3.proto" {
+		t.Errorf("fixtures = %q", results[0].Message)
+	}
+	if want := []string{dir + ": go build -mod=mod ./..."}; strings.Join(cmd.calls, "\n") != strings.Join(want, "\n") {
+		t.Errorf("commands = %q, want %q", cmd.calls, want)
+	}
+
+	for _, name := range []string{
+		"default/proto2/proto2.pb.go",
+		"default/proto2/proto2_http.pb.go",
+		"binding/editions/editions_http.pb.go",
+		"split/proto3/proto3_http_router.pb.go",
+		"cli/proto3/cmd/taskservicecli/main.go",
+	} {
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(name))); err != nil {
+			t.Errorf("%s was not generated: %v", name, err)
+		}
+	}
+	goMod, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(goMod), "module example.com/selftest\n\ngo 1.22\n") {
+		t.Errorf("go.mod = %q", goMod)
+	}
+}
+
+func TestRunFailures(t *testing.T) {
+	t.Parallel()
+
+	t.Run("generation", func(t *testing.T) {
+		t.Parallel()
+		cmd := &fakeCommand{}
+		results := (&SelfTest{Dir: t.TempDir(), Cases: []Case{{Name: "bad", Parameter: "validate=true"}}, Command: cmd.run}).Run()
+		last := results[len(results)-1]
+		if last.Check != "generate bad" || last.Status != doctor.Fail || !strings.Contains(last.Message, "validate requires binding=true") {
+			t.Errorf("last result = %+v", last)
+		}
+		if len(cmd.calls) != 0 {
+			t.Errorf("go was run after generation failed: %q", cmd.calls)
+		}
+	})
+
+	t.Run("build", func(t *testing.T) {
+		t.Parallel()
+		cmd := &fakeCommand{out: "default/proto2/proto2_http.pb.go:1:1: expected 'package'\n", err: errors.New("exit status 1")}
+		results := (&SelfTest{Dir: t.TempDir(), Cases: []Case{{Name: "default"}}, Command: cmd.run}).Run()
+		last := results[len(results)-1]
+		if last.Check != "go build" || last.Status != doctor.Fail || last.Message != "default/proto2/proto2_http.pb.go:1:1: expected 'package'" {
+			t.Errorf("last result = %+v", last)
+		}
+	})
+}
+
+// TestRunBuilds builds the fixtures with the installed go command, as
+// --selftest does.
+func TestRunBuilds(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the generated code with the go command")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go is not installed")
+	}
+	t.Parallel()
+
+	var report bytes.Buffer
+	if !doctor.Report(&report, (&SelfTest{}).Run()) {
+		t.Fatalf("Run() failed:\n%s", report.String())
+	}
+}
@@ -0,0 +1,403 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: editions.proto
+
+package editionspb
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Note struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *string                `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text" json:"text,omitempty"`
+	Revision      *int32                 `protobuf:"varint,3,opt,name=revision,def=1" json:"revision,omitempty"`
+	Metadata      *Metadata              `protobuf:"group,4,opt,name=Metadata,json=metadata" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+// Default values for Note fields.
+const (
+	Default_Note_Revision = int32(1)
+)
+
+func (x *Note) Reset() {
+	*x = Note{}
+	mi := &file_editions_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Note) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Note) ProtoMessage() {}
+
+func (x *Note) ProtoReflect() protoreflect.Message {
+	mi := &file_editions_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Note.ProtoReflect.Descriptor instead.
+func (*Note) Descriptor() ([]byte, []int) {
+	return file_editions_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Note) GetId() string {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return ""
+}
+
+func (x *Note) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Note) GetRevision() int32 {
+	if x != nil && x.Revision != nil {
+		return *x.Revision
+	}
+	return Default_Note_Revision
+}
+
+func (x *Note) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type Metadata struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Labels        []string               `protobuf:"bytes,1,rep,name=labels" json:"labels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Metadata) Reset() {
+	*x = Metadata{}
+	mi := &file_editions_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Metadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Metadata) ProtoMessage() {}
+
+func (x *Metadata) ProtoReflect() protoreflect.Message {
+	mi := &file_editions_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Metadata.ProtoReflect.Descriptor instead.
+func (*Metadata) Descriptor() ([]byte, []int) {
+	return file_editions_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Metadata) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type GetNoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *string                `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNoteRequest) Reset() {
+	*x = GetNoteRequest{}
+	mi := &file_editions_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNoteRequest) ProtoMessage() {}
+
+func (x *GetNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_editions_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNoteRequest.ProtoReflect.Descriptor instead.
+func (*GetNoteRequest) Descriptor() ([]byte, []int) {
+	return file_editions_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetNoteRequest) GetId() string {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return ""
+}
+
+type SaveNoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *string                `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Note          *Note                  `protobuf:"bytes,2,opt,name=note" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveNoteRequest) Reset() {
+	*x = SaveNoteRequest{}
+	mi := &file_editions_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveNoteRequest) ProtoMessage() {}
+
+func (x *SaveNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_editions_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveNoteRequest.ProtoReflect.Descriptor instead.
+func (*SaveNoteRequest) Descriptor() ([]byte, []int) {
+	return file_editions_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SaveNoteRequest) GetId() string {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return ""
+}
+
+func (x *SaveNoteRequest) GetNote() *Note {
+	if x != nil {
+		return x.Note
+	}
+	return nil
+}
+
+type DeleteNoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *string                `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteNoteRequest) Reset() {
+	*x = DeleteNoteRequest{}
+	mi := &file_editions_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNoteRequest) ProtoMessage() {}
+
+func (x *DeleteNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_editions_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNoteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteNoteRequest) Descriptor() ([]byte, []int) {
+	return file_editions_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteNoteRequest) GetId() string {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return ""
+}
+
+type DeleteNoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteNoteResponse) Reset() {
+	*x = DeleteNoteResponse{}
+	mi := &file_editions_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNoteResponse) ProtoMessage() {}
+
+func (x *DeleteNoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_editions_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNoteResponse.ProtoReflect.Descriptor instead.
+func (*DeleteNoteResponse) Descriptor() ([]byte, []int) {
+	return file_editions_proto_rawDescGZIP(), []int{5}
+}
+
+var File_editions_proto protoreflect.FileDescriptor
+
+const file_editions_proto_rawDesc = "" +
+	"\n" +
+	"\x0eeditions.proto\x12\x11selftest.editions\x1a\x1cgoogle/api/annotations.proto\"\x90\x01\n" +
+	"\x04Note\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\x04text\x18\x02 \x01(\tB\x05\xaa\x01\x02\b\x02R\x04text\x12\x1d\n" +
+	"\brevision\x18\x03 \x01(\x05:\x011R\brevision\x12>\n" +
+	"\bmetadata\x18\x04 \x01(\v2\x1b.selftest.editions.MetadataB\x05\xaa\x01\x02(\x02R\bmetadata\"\"\n" +
+	"\bMetadata\x12\x16\n" +
+	"\x06labels\x18\x01 \x03(\tR\x06labels\" \n" +
+	"\x0eGetNoteRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"N\n" +
+	"\x0fSaveNoteRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12+\n" +
+	"\x04note\x18\x02 \x01(\v2\x17.selftest.editions.NoteR\x04note\"#\n" +
+	"\x11DeleteNoteRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x14\n" +
+	"\x12DeleteNoteResponse2\xc6\x02\n" +
+	"\vNoteService\x12]\n" +
+	"\aGetNote\x12!.selftest.editions.GetNoteRequest\x1a\x17.selftest.editions.Note\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/v1/notes/{id}\x12e\n" +
+	"\bSaveNote\x12\".selftest.editions.SaveNoteRequest\x1a\x17.selftest.editions.Note\"\x1c\x82\xd3\xe4\x93\x02\x16:\x04note\x1a\x0e/v1/notes/{id}\x12q\n" +
+	"\n" +
+	"DeleteNote\x12$.selftest.editions.DeleteNoteRequest\x1a%.selftest.editions.DeleteNoteResponse\"\x16\x82\xd3\xe4\x93\x02\x10*\x0e/v1/notes/{id}B!Z\x1fexample.com/selftest/editionspbb\beditionsp\xe8\a"
+
+var (
+	file_editions_proto_rawDescOnce sync.Once
+	file_editions_proto_rawDescData []byte
+)
+
+func file_editions_proto_rawDescGZIP() []byte {
+	file_editions_proto_rawDescOnce.Do(func() {
+		file_editions_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_editions_proto_rawDesc), len(file_editions_proto_rawDesc)))
+	})
+	return file_editions_proto_rawDescData
+}
+
+var file_editions_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_editions_proto_goTypes = []any{
+	(*Note)(nil),               // 0: selftest.editions.Note
+	(*Metadata)(nil),           // 1: selftest.editions.Metadata
+	(*GetNoteRequest)(nil),     // 2: selftest.editions.GetNoteRequest
+	(*SaveNoteRequest)(nil),    // 3: selftest.editions.SaveNoteRequest
+	(*DeleteNoteRequest)(nil),  // 4: selftest.editions.DeleteNoteRequest
+	(*DeleteNoteResponse)(nil), // 5: selftest.editions.DeleteNoteResponse
+}
+var file_editions_proto_depIdxs = []int32{
+	1, // 0: selftest.editions.Note.metadata:type_name -> selftest.editions.Metadata
+	0, // 1: selftest.editions.SaveNoteRequest.note:type_name -> selftest.editions.Note
+	2, // 2: selftest.editions.NoteService.GetNote:input_type -> selftest.editions.GetNoteRequest
+	3, // 3: selftest.editions.NoteService.SaveNote:input_type -> selftest.editions.SaveNoteRequest
+	4, // 4: selftest.editions.NoteService.DeleteNote:input_type -> selftest.editions.DeleteNoteRequest
+	0, // 5: selftest.editions.NoteService.GetNote:output_type -> selftest.editions.Note
+	0, // 6: selftest.editions.NoteService.SaveNote:output_type -> selftest.editions.Note
+	5, // 7: selftest.editions.NoteService.DeleteNote:output_type -> selftest.editions.DeleteNoteResponse
+	5, // [5:8] is the sub-list for method output_type
+	2, // [2:5] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_editions_proto_init() }
+func file_editions_proto_init() {
+	if File_editions_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_editions_proto_rawDesc), len(file_editions_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_editions_proto_goTypes,
+		DependencyIndexes: file_editions_proto_depIdxs,
+		MessageInfos:      file_editions_proto_msgTypes,
+	}.Build()
+	File_editions_proto = out.File
+	file_editions_proto_goTypes = nil
+	file_editions_proto_depIdxs = nil
+}
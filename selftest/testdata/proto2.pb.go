@@ -0,0 +1,316 @@
+// Fixture for the proto2 syntax: required fields and explicit defaults.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: proto2.proto
+
+package proto2pb
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Item struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *string                `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Name          *string                `protobuf:"bytes,2,opt,name=name,def=unnamed" json:"name,omitempty"`
+	Quantity      *int32                 `protobuf:"varint,3,opt,name=quantity,def=1" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+// Default values for Item fields.
+const (
+	Default_Item_Name     = string("unnamed")
+	Default_Item_Quantity = int32(1)
+)
+
+func (x *Item) Reset() {
+	*x = Item{}
+	mi := &file_proto2_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Item) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Item) ProtoMessage() {}
+
+func (x *Item) ProtoReflect() protoreflect.Message {
+	mi := &file_proto2_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Item.ProtoReflect.Descriptor instead.
+func (*Item) Descriptor() ([]byte, []int) {
+	return file_proto2_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Item) GetId() string {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return ""
+}
+
+func (x *Item) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return Default_Item_Name
+}
+
+func (x *Item) GetQuantity() int32 {
+	if x != nil && x.Quantity != nil {
+		return *x.Quantity
+	}
+	return Default_Item_Quantity
+}
+
+type GetItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *string                `protobuf:"bytes,1,req,name=id" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetItemRequest) Reset() {
+	*x = GetItemRequest{}
+	mi := &file_proto2_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemRequest) ProtoMessage() {}
+
+func (x *GetItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto2_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemRequest.ProtoReflect.Descriptor instead.
+func (*GetItemRequest) Descriptor() ([]byte, []int) {
+	return file_proto2_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetItemRequest) GetId() string {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return ""
+}
+
+type ListItemsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      *int32                 `protobuf:"varint,1,opt,name=page_size,json=pageSize,def=10" json:"page_size,omitempty"`
+	Filter        *string                `protobuf:"bytes,2,opt,name=filter" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+// Default values for ListItemsRequest fields.
+const (
+	Default_ListItemsRequest_PageSize = int32(10)
+)
+
+func (x *ListItemsRequest) Reset() {
+	*x = ListItemsRequest{}
+	mi := &file_proto2_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListItemsRequest) ProtoMessage() {}
+
+func (x *ListItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto2_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListItemsRequest.ProtoReflect.Descriptor instead.
+func (*ListItemsRequest) Descriptor() ([]byte, []int) {
+	return file_proto2_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListItemsRequest) GetPageSize() int32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return Default_ListItemsRequest_PageSize
+}
+
+func (x *ListItemsRequest) GetFilter() string {
+	if x != nil && x.Filter != nil {
+		return *x.Filter
+	}
+	return ""
+}
+
+type ListItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Item                `protobuf:"bytes,1,rep,name=items" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListItemsResponse) Reset() {
+	*x = ListItemsResponse{}
+	mi := &file_proto2_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListItemsResponse) ProtoMessage() {}
+
+func (x *ListItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto2_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListItemsResponse.ProtoReflect.Descriptor instead.
+func (*ListItemsResponse) Descriptor() ([]byte, []int) {
+	return file_proto2_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListItemsResponse) GetItems() []*Item {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+var File_proto2_proto protoreflect.FileDescriptor
+
+const file_proto2_proto_rawDesc = "" +
+	"\n" +
+	"\fproto2.proto\x12\x0fselftest.proto2\x1a\x1cgoogle/api/annotations.proto\"R\n" +
+	"\x04Item\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\x04name\x18\x02 \x01(\t:\aunnamedR\x04name\x12\x1d\n" +
+	"\bquantity\x18\x03 \x01(\x05:\x011R\bquantity\" \n" +
+	"\x0eGetItemRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x02(\tR\x02id\"K\n" +
+	"\x10ListItemsRequest\x12\x1f\n" +
+	"\tpage_size\x18\x01 \x01(\x05:\x0210R\bpageSize\x12\x16\n" +
+	"\x06filter\x18\x02 \x01(\tR\x06filter\"@\n" +
+	"\x11ListItemsResponse\x12+\n" +
+	"\x05items\x18\x01 \x03(\v2\x15.selftest.proto2.ItemR\x05items2\xa1\x02\n" +
+	"\vItemService\x12Y\n" +
+	"\aGetItem\x12\x1f.selftest.proto2.GetItemRequest\x1a\x15.selftest.proto2.Item\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/v1/items/{id}\x12e\n" +
+	"\tListItems\x12!.selftest.proto2.ListItemsRequest\x1a\".selftest.proto2.ListItemsResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/items\x12P\n" +
+	"\n" +
+	"CreateItem\x12\x15.selftest.proto2.Item\x1a\x15.selftest.proto2.Item\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/itemsB\x1fZ\x1dexample.com/selftest/proto2pb"
+
+var (
+	file_proto2_proto_rawDescOnce sync.Once
+	file_proto2_proto_rawDescData []byte
+)
+
+func file_proto2_proto_rawDescGZIP() []byte {
+	file_proto2_proto_rawDescOnce.Do(func() {
+		file_proto2_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto2_proto_rawDesc), len(file_proto2_proto_rawDesc)))
+	})
+	return file_proto2_proto_rawDescData
+}
+
+var file_proto2_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_proto2_proto_goTypes = []any{
+	(*Item)(nil),              // 0: selftest.proto2.Item
+	(*GetItemRequest)(nil),    // 1: selftest.proto2.GetItemRequest
+	(*ListItemsRequest)(nil),  // 2: selftest.proto2.ListItemsRequest
+	(*ListItemsResponse)(nil), // 3: selftest.proto2.ListItemsResponse
+}
+var file_proto2_proto_depIdxs = []int32{
+	0, // 0: selftest.proto2.ListItemsResponse.items:type_name -> selftest.proto2.Item
+	1, // 1: selftest.proto2.ItemService.GetItem:input_type -> selftest.proto2.GetItemRequest
+	2, // 2: selftest.proto2.ItemService.ListItems:input_type -> selftest.proto2.ListItemsRequest
+	0, // 3: selftest.proto2.ItemService.CreateItem:input_type -> selftest.proto2.Item
+	0, // 4: selftest.proto2.ItemService.GetItem:output_type -> selftest.proto2.Item
+	3, // 5: selftest.proto2.ItemService.ListItems:output_type -> selftest.proto2.ListItemsResponse
+	0, // 6: selftest.proto2.ItemService.CreateItem:output_type -> selftest.proto2.Item
+	4, // [4:7] is the sub-list for method output_type
+	1, // [1:4] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_proto2_proto_init() }
+func file_proto2_proto_init() {
+	if File_proto2_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto2_proto_rawDesc), len(file_proto2_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto2_proto_goTypes,
+		DependencyIndexes: file_proto2_proto_depIdxs,
+		MessageInfos:      file_proto2_proto_msgTypes,
+	}.Build()
+	File_proto2_proto = out.File
+	file_proto2_proto_goTypes = nil
+	file_proto2_proto_depIdxs = nil
+}
@@ -0,0 +1,481 @@
+// Fixture for the proto3 syntax: path templates, additional bindings, body
+// fields, custom verbs, custom methods and a well-known response type.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: proto3.proto
+
+package proto3pb
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Task struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Done          bool                   `protobuf:"varint,3,opt,name=done,proto3" json:"done,omitempty"`
+	Priority      *int32                 `protobuf:"varint,4,opt,name=priority,proto3,oneof" json:"priority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Task) Reset() {
+	*x = Task{}
+	mi := &file_proto3_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Task) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task) ProtoMessage() {}
+
+func (x *Task) ProtoReflect() protoreflect.Message {
+	mi := &file_proto3_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Task.ProtoReflect.Descriptor instead.
+func (*Task) Descriptor() ([]byte, []int) {
+	return file_proto3_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Task) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Task) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Task) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+func (x *Task) GetPriority() int32 {
+	if x != nil && x.Priority != nil {
+		return *x.Priority
+	}
+	return 0
+}
+
+type GetTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskRequest) Reset() {
+	*x = GetTaskRequest{}
+	mi := &file_proto3_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskRequest) ProtoMessage() {}
+
+func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto3_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto3_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetTaskRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type UpdateTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Task          *Task                  `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskRequest) Reset() {
+	*x = UpdateTaskRequest{}
+	mi := &file_proto3_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskRequest) ProtoMessage() {}
+
+func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto3_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto3_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UpdateTaskRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type BatchGetTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Names         []string               `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetTasksRequest) Reset() {
+	*x = BatchGetTasksRequest{}
+	mi := &file_proto3_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetTasksRequest) ProtoMessage() {}
+
+func (x *BatchGetTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto3_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetTasksRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetTasksRequest) Descriptor() ([]byte, []int) {
+	return file_proto3_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BatchGetTasksRequest) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+type BatchGetTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetTasksResponse) Reset() {
+	*x = BatchGetTasksResponse{}
+	mi := &file_proto3_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetTasksResponse) ProtoMessage() {}
+
+func (x *BatchGetTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto3_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetTasksResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetTasksResponse) Descriptor() ([]byte, []int) {
+	return file_proto3_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BatchGetTasksResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+type ArchiveTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       string                 `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	DoneOnly      bool                   `protobuf:"varint,2,opt,name=done_only,json=doneOnly,proto3" json:"done_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArchiveTasksRequest) Reset() {
+	*x = ArchiveTasksRequest{}
+	mi := &file_proto3_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchiveTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveTasksRequest) ProtoMessage() {}
+
+func (x *ArchiveTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto3_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveTasksRequest.ProtoReflect.Descriptor instead.
+func (*ArchiveTasksRequest) Descriptor() ([]byte, []int) {
+	return file_proto3_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ArchiveTasksRequest) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *ArchiveTasksRequest) GetDoneOnly() bool {
+	if x != nil {
+		return x.DoneOnly
+	}
+	return false
+}
+
+type ArchiveTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Archived      int32                  `protobuf:"varint,1,opt,name=archived,proto3" json:"archived,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArchiveTasksResponse) Reset() {
+	*x = ArchiveTasksResponse{}
+	mi := &file_proto3_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchiveTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveTasksResponse) ProtoMessage() {}
+
+func (x *ArchiveTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto3_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveTasksResponse.ProtoReflect.Descriptor instead.
+func (*ArchiveTasksResponse) Descriptor() ([]byte, []int) {
+	return file_proto3_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ArchiveTasksResponse) GetArchived() int32 {
+	if x != nil {
+		return x.Archived
+	}
+	return 0
+}
+
+var File_proto3_proto protoreflect.FileDescriptor
+
+const file_proto3_proto_rawDesc = "" +
+	"\n" +
+	"\fproto3.proto\x12\x0fselftest.proto3\x1a\x1cgoogle/api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\"r\n" +
+	"\x04Task\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x12\n" +
+	"\x04done\x18\x03 \x01(\bR\x04done\x12\x1f\n" +
+	"\bpriority\x18\x04 \x01(\x05H\x00R\bpriority\x88\x01\x01B\v\n" +
+	"\t_priority\"$\n" +
+	"\x0eGetTaskRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"R\n" +
+	"\x11UpdateTaskRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12)\n" +
+	"\x04task\x18\x02 \x01(\v2\x15.selftest.proto3.TaskR\x04task\",\n" +
+	"\x14BatchGetTasksRequest\x12\x14\n" +
+	"\x05names\x18\x01 \x03(\tR\x05names\"D\n" +
+	"\x15BatchGetTasksResponse\x12+\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x15.selftest.proto3.TaskR\x05tasks\"L\n" +
+	"\x13ArchiveTasksRequest\x12\x18\n" +
+	"\aproject\x18\x01 \x01(\tR\aproject\x12\x1b\n" +
+	"\tdone_only\x18\x02 \x01(\bR\bdoneOnly\"2\n" +
+	"\x14ArchiveTasksResponse\x12\x1a\n" +
+	"\barchived\x18\x01 \x01(\x05R\barchived2\xe2\x05\n" +
+	"\vTaskService\x12|\n" +
+	"\aGetTask\x12\x1f.selftest.proto3.GetTaskRequest\x1a\x15.selftest.proto3.Task\"9\x82\xd3\xe4\x93\x023Z\x12\x12\x10/v1/tasks/{name}\x12\x1d/v1/{name=projects/*/tasks/*}\x12e\n" +
+	"\tCheckTask\x12\x1f.selftest.proto3.GetTaskRequest\x1a\x15.selftest.proto3.Task\" \x82\xd3\xe4\x93\x02\x1aB\x18\n" +
+	"\x04HEAD\x12\x10/v1/tasks/{name}\x12\x81\x01\n" +
+	"\n" +
+	"UpdateTask\x12\".selftest.proto3.UpdateTaskRequest\x1a\x15.selftest.proto3.Task\"8\x82\xd3\xe4\x93\x022:\x04taskZ\x18:\x04task\x1a\x10/v1/tasks/{name}2\x10/v1/tasks/{name}\x12_\n" +
+	"\n" +
+	"DeleteTask\x12\x1f.selftest.proto3.GetTaskRequest\x1a\x16.google.protobuf.Empty\"\x18\x82\xd3\xe4\x93\x02\x12*\x10/v1/tasks/{name}\x12z\n" +
+	"\rBatchGetTasks\x12%.selftest.proto3.BatchGetTasksRequest\x1a&.selftest.proto3.BatchGetTasksResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/tasks:batchGet\x12\x8c\x01\n" +
+	"\fArchiveTasks\x12$.selftest.proto3.ArchiveTasksRequest\x1a%.selftest.proto3.ArchiveTasksResponse\"/\x82\xd3\xe4\x93\x02):\x01*\"$/v1/projects/{project}/tasks:archiveB\x1fZ\x1dexample.com/selftest/proto3pbb\x06proto3"
+
+var (
+	file_proto3_proto_rawDescOnce sync.Once
+	file_proto3_proto_rawDescData []byte
+)
+
+func file_proto3_proto_rawDescGZIP() []byte {
+	file_proto3_proto_rawDescOnce.Do(func() {
+		file_proto3_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto3_proto_rawDesc), len(file_proto3_proto_rawDesc)))
+	})
+	return file_proto3_proto_rawDescData
+}
+
+var file_proto3_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_proto3_proto_goTypes = []any{
+	(*Task)(nil),                  // 0: selftest.proto3.Task
+	(*GetTaskRequest)(nil),        // 1: selftest.proto3.GetTaskRequest
+	(*UpdateTaskRequest)(nil),     // 2: selftest.proto3.UpdateTaskRequest
+	(*BatchGetTasksRequest)(nil),  // 3: selftest.proto3.BatchGetTasksRequest
+	(*BatchGetTasksResponse)(nil), // 4: selftest.proto3.BatchGetTasksResponse
+	(*ArchiveTasksRequest)(nil),   // 5: selftest.proto3.ArchiveTasksRequest
+	(*ArchiveTasksResponse)(nil),  // 6: selftest.proto3.ArchiveTasksResponse
+	(*emptypb.Empty)(nil),         // 7: google.protobuf.Empty
+}
+var file_proto3_proto_depIdxs = []int32{
+	0, // 0: selftest.proto3.UpdateTaskRequest.task:type_name -> selftest.proto3.Task
+	0, // 1: selftest.proto3.BatchGetTasksResponse.tasks:type_name -> selftest.proto3.Task
+	1, // 2: selftest.proto3.TaskService.GetTask:input_type -> selftest.proto3.GetTaskRequest
+	1, // 3: selftest.proto3.TaskService.CheckTask:input_type -> selftest.proto3.GetTaskRequest
+	2, // 4: selftest.proto3.TaskService.UpdateTask:input_type -> selftest.proto3.UpdateTaskRequest
+	1, // 5: selftest.proto3.TaskService.DeleteTask:input_type -> selftest.proto3.GetTaskRequest
+	3, // 6: selftest.proto3.TaskService.BatchGetTasks:input_type -> selftest.proto3.BatchGetTasksRequest
+	5, // 7: selftest.proto3.TaskService.ArchiveTasks:input_type -> selftest.proto3.ArchiveTasksRequest
+	0, // 8: selftest.proto3.TaskService.GetTask:output_type -> selftest.proto3.Task
+	0, // 9: selftest.proto3.TaskService.CheckTask:output_type -> selftest.proto3.Task
+	0, // 10: selftest.proto3.TaskService.UpdateTask:output_type -> selftest.proto3.Task
+	7, // 11: selftest.proto3.TaskService.DeleteTask:output_type -> google.protobuf.Empty
+	4, // 12: selftest.proto3.TaskService.BatchGetTasks:output_type -> selftest.proto3.BatchGetTasksResponse
+	6, // 13: selftest.proto3.TaskService.ArchiveTasks:output_type -> selftest.proto3.ArchiveTasksResponse
+	8, // [8:14] is the sub-list for method output_type
+	2, // [2:8] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_proto3_proto_init() }
+func file_proto3_proto_init() {
+	if File_proto3_proto != nil {
+		return
+	}
+	file_proto3_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto3_proto_rawDesc), len(file_proto3_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto3_proto_goTypes,
+		DependencyIndexes: file_proto3_proto_depIdxs,
+		MessageInfos:      file_proto3_proto_msgTypes,
+	}.Build()
+	File_proto3_proto = out.File
+	file_proto3_proto_goTypes = nil
+	file_proto3_proto_depIdxs = nil
+}
@@ -7,13 +7,15 @@ toolchain go1.24.5
 require (
 	github.com/farhaan/protoc-gen-go-http-server-interface v0.0.0
 	golang.org/x/tools v0.36.0
+	google.golang.org/genproto/googleapis/api v0.0.0-20250324211829-b45e905df463
 	google.golang.org/protobuf v1.36.8
 )
 
 require (
+	go.yaml.in/yaml/v2 v2.4.2 // indirect
 	golang.org/x/mod v0.27.0 // indirect
 	golang.org/x/sync v0.16.0 // indirect
-	google.golang.org/genproto/googleapis/api v0.0.0-20250324211829-b45e905df463 // indirect
+	sigs.k8s.io/yaml v1.6.0 // indirect
 )
 
 replace github.com/farhaan/protoc-gen-go-http-server-interface => ../
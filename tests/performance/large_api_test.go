@@ -0,0 +1,129 @@
+package performance_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/farhaan/protoc-gen-go-http-server-interface/httpinterface"
+	options "google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// largeAPIRoutes is the route count of the largest API the plugin is tested
+// against.
+const largeAPIRoutes = 5000
+
+// largeAPIBudget bounds the generation of the large API, leaving headroom for
+// the race detector and slow CI machines.
+const largeAPIBudget = 30 * time.Second
+
+// largeAPIRequest returns a request for a file with services of 100 methods
+// each, every method bound to one route with a path parameter, until the
+// file has routes routes.
+func largeAPIRequest(routes int, parameter string) *pluginpb.CodeGeneratorRequest {
+	file := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("large.proto"),
+		Package: proto.String("large.v1"),
+		Syntax:  proto.String("proto3"),
+		Options: &descriptorpb.FileOptions{GoPackage: proto.String("example.com/large/v1;largev1")},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Resource"),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("id"),
+				JsonName: proto.String("id"),
+				Number:   proto.Int32(1),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			}},
+		}},
+	}
+
+	var service *descriptorpb.ServiceDescriptorProto
+	for i := range routes {
+		if i%100 == 0 {
+			service = &descriptorpb.ServiceDescriptorProto{Name: proto.String(fmt.Sprintf("Service%03d", i/100))}
+			file.Service = append(file.Service, service)
+		}
+		opts := &descriptorpb.MethodOptions{}
+		proto.SetExtension(opts, options.E_Http, &options.HttpRule{
+			Pattern: &options.HttpRule_Get{Get: fmt.Sprintf("/v1/service%03d/resource%02d/{id}", i/100, i%100)},
+		})
+		service.Method = append(service.Method, &descriptorpb.MethodDescriptorProto{
+			Name:       proto.String(fmt.Sprintf("GetResource%02d", i%100)),
+			InputType:  proto.String(".large.v1.Resource"),
+			OutputType: proto.String(".large.v1.Resource"),
+			Options:    opts,
+		})
+	}
+
+	return &pluginpb.CodeGeneratorRequest{
+		Parameter:      proto.String(parameter),
+		FileToGenerate: []string{"large.proto"},
+		ProtoFile:      []*descriptorpb.FileDescriptorProto{file},
+	}
+}
+
+// TestPerformance_LargeAPIGeneration generates a package of largeAPIRoutes
+// routes within largeAPIBudget and checks the size report.
+func TestPerformance_LargeAPIGeneration(t *testing.T) {
+	t.Parallel()
+	if testing.Short() {
+		t.Skip("Skipping performance test in short mode")
+	}
+
+	req := largeAPIRequest(largeAPIRoutes, "binding=true,report=true")
+	start := time.Now()
+	resp := httpinterface.New().Generate(req)
+	elapsed := time.Since(start)
+	if resp.Error != nil {
+		t.Fatalf("Generate() error = %s", resp.GetError())
+	}
+	if elapsed > largeAPIBudget {
+		t.Errorf("generating %d routes took %v, over the %v budget", largeAPIRoutes, elapsed, largeAPIBudget)
+	}
+
+	var report struct {
+		Routes  int `json:"routes"`
+		GoBytes int `json:"go_bytes"`
+		GoLines int `json:"go_lines"`
+	}
+	var code string
+	for _, f := range resp.File {
+		switch {
+		case strings.HasSuffix(f.GetName(), ".report.json"):
+			if err := json.Unmarshal([]byte(f.GetContent()), &report); err != nil {
+				t.Fatalf("invalid report: %v", err)
+			}
+		case strings.HasSuffix(f.GetName(), ".pb.go"):
+			code = f.GetContent()
+		}
+	}
+	if report.Routes != largeAPIRoutes {
+		t.Errorf("report routes = %d, want %d", report.Routes, largeAPIRoutes)
+	}
+	if report.GoBytes != len(code) {
+		t.Errorf("report go_bytes = %d, want %d", report.GoBytes, len(code))
+	}
+	if got := strings.Count(code, "r.HandleFunc("); got < largeAPIRoutes {
+		t.Errorf("generated code registers %d routes, want at least %d", got, largeAPIRoutes)
+	}
+	t.Logf("generated %d routes in %v: %d bytes, %d lines of Go (%d bytes per route)",
+		report.Routes, elapsed, report.GoBytes, report.GoLines, report.GoBytes/max(report.Routes, 1))
+}
+
+// BenchmarkGenerate_LargeAPI measures generating a package of largeAPIRoutes
+// routes.
+func BenchmarkGenerate_LargeAPI(b *testing.B) {
+	req := largeAPIRequest(largeAPIRoutes, "binding=true")
+	b.ResetTimer()
+	for range b.N {
+		if resp := httpinterface.New().Generate(req); resp.Error != nil {
+			b.Fatal(resp.GetError())
+		}
+	}
+}